@@ -0,0 +1,291 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/build"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+
+	"cmd/compile/internal/importer"
+	"cmd/compile/internal/syntax"
+	"cmd/compile/internal/types2"
+	"internal/types/errors"
+)
+
+// A checked package is the result of type-checking an open document
+// together with the other files of its package.
+type checked struct {
+	doc   *document
+	file  *syntax.File // the syntax tree of doc
+	files []*syntax.File
+	srcs  map[*syntax.File]*document
+	pkg   *types2.Package
+	info  *types2.Info
+	diags []diagnostic // for doc
+}
+
+// typecheck parses and type-checks d.
+// A .goo file is checked on its own, as 'go run file.goo' builds it;
+// a .go file is checked with the other files of its package.
+func (s *server) typecheck(d *document) *checked {
+	c := &checked{doc: d, srcs: make(map[*syntax.File]*document)}
+	docs := []*document{d}
+	if strings.HasSuffix(d.path, ".go") {
+		docs = append(docs, s.siblings(d)...)
+	}
+
+	var pkgName string
+	for _, src := range docs {
+		errh := func(err error) {
+			if src == d {
+				c.addError(err.(syntax.Error).Pos, err.(syntax.Error).Msg, severityError)
+			}
+		}
+		f, _ := syntax.Parse(syntax.NewFileBase(src.path), strings.NewReader(src.text), errh, nil, syntax.CheckBranches)
+		if f == nil {
+			continue
+		}
+		if src == d {
+			pkgName = f.PkgName.Value
+			c.file = f
+		} else if f.PkgName.Value != pkgName {
+			continue
+		}
+		c.files = append(c.files, f)
+		c.srcs[f] = src
+	}
+	if c.file == nil {
+		return c
+	}
+	c.lintPut()
+
+	conf := types2.Config{
+		Importer:           s.importer(filepath.Dir(d.path)),
+		IgnoreBranchErrors: true, // parser already checked via syntax.CheckBranches mode
+		Sizes:              types2.SizesFor("gc", runtime.GOARCH),
+		EnableAlias:        true,
+		AutoImports:        s.stdPackages(),
+		Error: func(err error) {
+			terr := err.(types2.Error)
+			if terr.Pos.FileBase() != c.file.Pos().FileBase() {
+				return
+			}
+			// Unused imports are warnings in goo, as in the compiler.
+			severity := severityError
+			if terr.Code == errors.UnusedImport {
+				severity = severityWarning
+			}
+			c.addError(terr.Pos, terr.Msg, severity)
+		},
+	}
+	c.info = &types2.Info{
+		Types:      make(map[syntax.Expr]types2.TypeAndValue),
+		Defs:       make(map[*syntax.Name]types2.Object),
+		Uses:       make(map[*syntax.Name]types2.Object),
+		Implicits:  make(map[syntax.Node]types2.Object),
+		Selections: make(map[*syntax.SelectorExpr]*types2.Selection),
+		Scopes:     make(map[syntax.Node]*types2.Scope),
+	}
+	func() {
+		defer func() {
+			// The type checker is not used to syntax trees with
+			// errors; keep serving if it trips over one.
+			if err := recover(); err != nil {
+				s.logf("type-checking %s: %v", d.path, err)
+			}
+		}()
+		c.pkg, _ = conf.Check(pkgName, c.files, c.info)
+	}()
+	if c.pkg != nil {
+		c.lintCheck()
+	}
+	return c
+}
+
+// siblings returns the other .go files of the package of d,
+// open or on disk, that match the build context.
+func (s *server) siblings(d *document) []*document {
+	dir := filepath.Dir(d.path)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var docs []*document
+	for _, e := range entries {
+		name := e.Name()
+		path := filepath.Join(dir, name)
+		if path == d.path || e.IsDir() || !strings.HasSuffix(name, ".go") {
+			continue
+		}
+		if strings.HasSuffix(name, "_test.go") && !strings.HasSuffix(d.path, "_test.go") {
+			continue
+		}
+		if ok, err := build.Default.MatchFile(dir, name); err != nil || !ok {
+			continue
+		}
+		if src := s.docs[pathToURI(path)]; src != nil {
+			docs = append(docs, src)
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		docs = append(docs, newDocument(pathToURI(path), path, string(data), 0))
+	}
+	return docs
+}
+
+// addError adds a diagnostic at pos to c.
+func (c *checked) addError(pos syntax.Pos, msg string, severity int) {
+	source := "compile"
+	if severity == severityWarning {
+		source = "goo"
+	}
+	c.diags = append(c.diags, diagnostic{
+		Range:    c.doc.wordRange(pos.Line(), pos.Col()),
+		Severity: severity,
+		Source:   source,
+		Message:  msg,
+	})
+}
+
+// verbRx matches a fmt verb.
+var verbRx = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]*)?[vTtbcdoOqxXUeEfFgGsp]`)
+
+// lintPut warns about put calls with a format string, which put does
+// not interpret. It runs before type-checking, which rewrites put calls.
+func (c *checked) lintPut() {
+	for _, f := range c.files {
+		for _, decl := range f.DeclList {
+			if fn, ok := decl.(*syntax.FuncDecl); ok && fn.Recv == nil && fn.Name.Value == "put" {
+				return // put is not the builtin
+			}
+		}
+	}
+	syntax.Inspect(c.file, func(n syntax.Node) bool {
+		call, ok := n.(*syntax.CallExpr)
+		if !ok || len(call.ArgList) < 2 {
+			return true
+		}
+		if name, ok := call.Fun.(*syntax.Name); !ok || name.Value != "put" {
+			return true
+		}
+		if lit, ok := call.ArgList[0].(*syntax.BasicLit); ok && lit.Kind == syntax.StringLit && verbRx.MatchString(lit.Value) {
+			c.addError(call.Fun.Pos(), "put does not interpret format verbs; use printf", severityWarning)
+		}
+		return true
+	})
+}
+
+// lintCheck warns about check statements whose condition is constant.
+func (c *checked) lintCheck() {
+	syntax.Inspect(c.file, func(n syntax.Node) bool {
+		s, ok := n.(*syntax.CheckStmt)
+		if !ok {
+			return true
+		}
+		if tv, ok := c.info.Types[s.Cond]; ok && tv.Value != nil {
+			c.addError(s.Pos(), fmt.Sprintf("check condition is always %s", tv.Value), severityWarning)
+		}
+		return true
+	})
+}
+
+// importer returns the importer for the packages imported by files in dir.
+func (s *server) importer(dir string) types2.Importer {
+	imp := s.imports[dir]
+	if imp == nil {
+		imp = &exportImporter{
+			dir:      dir,
+			exports:  make(map[string]string),
+			packages: make(map[string]*types2.Package),
+		}
+		s.imports[dir] = imp
+	}
+	return imp
+}
+
+// stdPackages returns the import paths of the importable standard
+// library packages by package name. A .goo file imports the packages
+// named like the qualifiers it uses but does not declare; the go command,
+// which lists them here, resolves those names the same way when it
+// builds the file.
+func (s *server) stdPackages() map[string][]string {
+	if s.std != nil {
+		return s.std
+	}
+	s.std = make(map[string][]string)
+	cmd := exec.Command(filepath.Join(build.Default.GOROOT, "bin", "go"), "list", "-e", "-f", "{{.Name}}\t{{.ImportPath}}", "std")
+	out, err := cmd.Output()
+	if err != nil {
+		s.logf("listing the standard library: %v", err)
+		return s.std
+	}
+	for line := range strings.Lines(string(out)) {
+		name, path, _ := strings.Cut(strings.TrimSpace(line), "\t")
+		if name == "" || name == "main" || strings.HasPrefix(path, "vendor/") ||
+			slices.Contains(strings.Split(path, "/"), "internal") {
+			continue
+		}
+		s.std[name] = append(s.std[name], path)
+	}
+	return s.std
+}
+
+// An exportImporter imports packages from the export data that
+// 'go list -export' builds, as seen from dir.
+type exportImporter struct {
+	dir      string
+	exports  map[string]string // import path to export data file
+	packages map[string]*types2.Package
+}
+
+func (imp *exportImporter) Import(path string) (*types2.Package, error) {
+	if path == "unsafe" {
+		return types2.Unsafe, nil
+	}
+	if _, ok := imp.exports[path]; !ok {
+		if err := imp.list(path); err != nil {
+			return nil, err
+		}
+	}
+	file := imp.exports[path]
+	if file == "" {
+		return nil, fmt.Errorf("could not import %s (no export data)", path)
+	}
+	return importer.Import(imp.packages, path, imp.dir, func(string) (io.ReadCloser, error) {
+		return os.Open(file)
+	})
+}
+
+// list records the export data files of path and its dependencies.
+func (imp *exportImporter) list(path string) error {
+	cmd := exec.Command(filepath.Join(build.Default.GOROOT, "bin", "go"), "list", "-e", "-export", "-deps", "-f", "{{.ImportPath}}\t{{.Export}}", "--", path)
+	cmd.Dir = imp.dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("could not import %s: %v\n%s", path, err, stderr.Bytes())
+	}
+	for line := range strings.Lines(string(out)) {
+		pkg, file, _ := strings.Cut(strings.TrimSpace(line), "\t")
+		imp.exports[pkg] = file
+	}
+	if _, ok := imp.exports[path]; !ok {
+		imp.exports[path] = ""
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// A document is the text of a source file, open in the editor or read
+// from disk. It converts between byte offsets, the 1-based line and byte
+// column of syntax.Pos, and the UTF-16 positions of the protocol.
+type document struct {
+	uri     string
+	path    string
+	version int
+	text    string
+	lines   []int // offsets of the line starts
+}
+
+func newDocument(uri, path, text string, version int) *document {
+	d := &document{uri: uri, path: path, version: version}
+	d.setText(text)
+	return d
+}
+
+func (d *document) setText(text string) {
+	d.text = text
+	d.lines = d.lines[:0]
+	d.lines = append(d.lines, 0)
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			d.lines = append(d.lines, i+1)
+		}
+	}
+}
+
+// line returns the text of the zero-based line n, without its newline.
+func (d *document) line(n int) string {
+	if n < 0 || n >= len(d.lines) {
+		return ""
+	}
+	end := len(d.text)
+	if n+1 < len(d.lines) {
+		end = d.lines[n+1] - 1
+	}
+	return strings.TrimSuffix(d.text[d.lines[n]:end], "\r")
+}
+
+// offset returns the byte offset of p, clamped to the document.
+func (d *document) offset(p position) int {
+	if p.Line < 0 {
+		return 0
+	}
+	if p.Line >= len(d.lines) {
+		return len(d.text)
+	}
+	line := d.line(p.Line)
+	col := 0
+	for n := 0; col < len(line) && n < p.Character; {
+		r, size := utf8.DecodeRuneInString(line[col:])
+		n += utf16.RuneLen(r)
+		col += size
+	}
+	return d.lines[p.Line] + col
+}
+
+// position returns the protocol position of the byte offset off.
+func (d *document) position(off int) position {
+	off = min(max(off, 0), len(d.text))
+	n := 0
+	for n+1 < len(d.lines) && d.lines[n+1] <= off {
+		n++
+	}
+	return position{Line: n, Character: utf16Len(d.text[d.lines[n]:off])}
+}
+
+// syntaxPos returns the 1-based line and byte column of p.
+func (d *document) syntaxPos(p position) (line, col uint) {
+	off := d.offset(p)
+	q := d.position(off)
+	return uint(q.Line + 1), uint(off-d.lines[q.Line]) + 1
+}
+
+// lspPos returns the protocol position of a 1-based line and byte column.
+func (d *document) lspPos(line, col uint) position {
+	if line == 0 {
+		return position{}
+	}
+	n := int(line) - 1
+	if n >= len(d.lines) {
+		return d.position(len(d.text))
+	}
+	text := d.line(n)
+	c := min(int(col)-1, len(text))
+	return position{Line: n, Character: utf16Len(text[:max(c, 0)])}
+}
+
+// wordRange returns the range of the identifier at the 1-based line and
+// byte column, or of the single character there if there is none.
+func (d *document) wordRange(line, col uint) lspRange {
+	start := d.lspPos(line, col)
+	text := d.line(int(line) - 1)
+	i := int(col) - 1
+	if i < 0 || i >= len(text) {
+		return lspRange{start, start}
+	}
+	j := i
+	for j < len(text) {
+		r, size := utf8.DecodeRuneInString(text[j:])
+		if !isIdentRune(r) {
+			break
+		}
+		j += size
+	}
+	if j == i {
+		_, size := utf8.DecodeRuneInString(text[i:])
+		j = i + size
+	}
+	return lspRange{start, d.lspPos(line, uint(j)+1)}
+}
+
+// textAt reports whether the identifier name is written at the 1-based
+// line and byte column. Names synthesized by the parser or the type
+// checker, like the fmt.Printf a put call turns into, are not.
+func (d *document) textAt(line, col uint, name string) bool {
+	text := d.line(int(line) - 1)
+	i := int(col) - 1
+	return i >= 0 && strings.HasPrefix(text[min(i, len(text)):], name)
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// uriToPath returns the file name of a file URI.
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+	path := u.Path
+	if len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		// file:///C:/dir on Windows
+		path = path[1:]
+	}
+	return filepath.FromSlash(path)
+}
+
+// pathToURI returns the file URI of the file name path.
+func pathToURI(path string) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
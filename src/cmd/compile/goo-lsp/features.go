@@ -0,0 +1,443 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"go/build"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"cmd/compile/internal/syntax"
+	"cmd/compile/internal/types2"
+)
+
+// gooBuiltins documents the goo builtins that are not objects of the
+// universe scope: put and printf are rewritten into fmt calls by the
+// type checker, and check and with are statements.
+var gooBuiltins = map[string]string{
+	"put":    "func put(a ...any)\n```\nput prints its arguments separated by spaces and a newline; the fmt import is implicit in .goo files.",
+	"printf": "func printf(format string, a ...any)\n```\nprintf is fmt.Printf; the fmt import is implicit in .goo files.",
+	"check":  "check cond\n```\ncheck panics with the source text of cond unless cond is truthy.",
+	"dbg":    "func dbg[T any](x T) T\n```\ndbg prints the position of the call, the source text, value and type of x to standard error and returns x.",
+	"old":    "func old[T any](x T) T\n```\nold(x) in an ensures clause is the value of x on entry to the function.",
+	"typeof": "func typeof(x any) string\n```\ntypeof(x) is the type of x, as a string constant.",
+	"with":   "with x := f()? { ... }\n```\nwith closes x by its Close or Stop method at the end of the block; ? returns the error of f.",
+}
+
+// keywords are the Go and goo keywords offered by completion.
+var keywords = []string{
+	"and", "break", "case", "chan", "check", "const", "continue", "def",
+	"default", "defer", "else", "enum", "fallthrough", "for", "func", "go",
+	"goto", "if", "import", "in", "interface", "map", "not", "or",
+	"package", "range", "return", "select", "struct", "switch", "type", "var",
+	"with",
+}
+
+// nameAt returns the name written at p in the document, if any,
+// and the object it denotes.
+func (c *checked) nameAt(p position) (*syntax.Name, types2.Object) {
+	if c.file == nil || c.info == nil {
+		return nil, nil
+	}
+	line, col := c.doc.syntaxPos(p)
+	var found *syntax.Name
+	syntax.Inspect(c.file, func(n syntax.Node) bool {
+		name, ok := n.(*syntax.Name)
+		if found != nil || !ok {
+			return found == nil
+		}
+		pos := name.Pos()
+		if pos.Line() == line && pos.Col() <= col && col <= pos.Col()+uint(len(name.Value)) && c.doc.textAt(line, pos.Col(), name.Value) {
+			found = name
+		}
+		return true
+	})
+	if found == nil {
+		return nil, nil
+	}
+	obj := c.info.Defs[found]
+	if obj == nil {
+		obj = c.info.Uses[found]
+	}
+	return found, obj
+}
+
+// wordAt returns the identifier at or just before p and its range.
+func (c *checked) wordAt(p position) (string, lspRange) {
+	line, col := c.doc.syntaxPos(p)
+	text := c.doc.line(int(line) - 1)
+	i, j := int(col)-1, int(col)-1
+	for i > 0 && isIdentByte(text[i-1]) {
+		i--
+	}
+	for j < len(text) && isIdentByte(text[j]) {
+		j++
+	}
+	return text[i:j], lspRange{c.doc.lspPos(line, uint(i)+1), c.doc.lspPos(line, uint(j)+1)}
+}
+
+func isIdentByte(b byte) bool {
+	return b == '_' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9'
+}
+
+// hover describes the name at p: its declaration, with the contract
+// clauses of functions, and, for values, what typeof reports for it.
+func (c *checked) hover(p position) *hover {
+	name, obj := c.nameAt(p)
+	if obj == nil {
+		word, r := c.wordAt(p)
+		if doc, ok := gooBuiltins[word]; ok {
+			return &hover{markupContent{"markdown", "```go\n" + doc}, r}
+		}
+		return nil
+	}
+	if doc, ok := gooBuiltins[obj.Name()]; ok && obj.Parent() == types2.Universe {
+		return &hover{markupContent{"markdown", "```go\n" + doc}, c.nameRange(name)}
+	}
+	text := "```go\n" + types2.ObjectString(obj, types2.RelativeTo(c.pkg))
+	if fn, ok := obj.(*types2.Func); ok {
+		requires, ensures := types2.Contracts(fn)
+		for _, cond := range requires {
+			text += "\n\trequires " + cond
+		}
+		for _, cond := range ensures {
+			text += "\n\tensures " + cond
+		}
+	}
+	text += "\n```"
+	switch obj.(type) {
+	case *types2.Var, *types2.Const, *types2.Func:
+		text += fmt.Sprintf("\n\ntypeof(%s) == %q", name.Value, obj.Type().String())
+	}
+	return &hover{markupContent{"markdown", text}, c.nameRange(name)}
+}
+
+func (c *checked) nameRange(name *syntax.Name) lspRange {
+	pos := name.Pos()
+	return lspRange{c.doc.lspPos(pos.Line(), pos.Col()), c.doc.lspPos(pos.Line(), pos.Col()+uint(len(name.Value)))}
+}
+
+// definition returns the location of the declaration of the name at p.
+func (s *server) definition(c *checked, p position) *location {
+	_, obj := c.nameAt(p)
+	if obj == nil || !obj.Pos().IsKnown() {
+		return nil
+	}
+	return s.location(c, obj.Pos(), obj.Name())
+}
+
+// location returns the location of the name at pos, which may be in
+// another file of the package or in an imported package.
+func (s *server) location(c *checked, pos syntax.Pos, name string) *location {
+	filename := pos.FileBase().Filename()
+	if rest, ok := strings.CutPrefix(filename, "$GOROOT"); ok {
+		filename = filepath.Join(build.Default.GOROOT, filepath.FromSlash(rest))
+	}
+	var d *document
+	for _, src := range c.srcs {
+		if src.path == filename {
+			d = src
+		}
+	}
+	if d == nil {
+		d = s.docs[pathToURI(filename)]
+	}
+	if d == nil {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return nil
+		}
+		d = newDocument(pathToURI(filename), filename, string(data), 0)
+	}
+	start := d.lspPos(pos.Line(), pos.Col())
+	end := d.lspPos(pos.Line(), pos.Col()+uint(len(name)))
+	return &location{d.uri, lspRange{start, end}}
+}
+
+// completion returns the completions at p: the fields and methods of
+// the operand before a dot, or the members of an imported package, or
+// else the names in scope, the keywords and the goo builtins.
+func (s *server) completion(c *checked, p position) *completionList {
+	list := &completionList{Items: []completionItem{}}
+	text := c.doc.text
+	off := c.doc.offset(p)
+	start := off
+	for start > 0 && isIdentByte(text[start-1]) {
+		start--
+	}
+	prefix := text[start:off]
+
+	var items []completionItem
+	if start > 0 && text[start-1] == '.' {
+		items = s.selectorCompletion(c, start-1)
+	} else if c.pkg != nil {
+		items = c.scopeCompletion(p)
+	}
+	for _, item := range items {
+		if strings.HasPrefix(item.Label, prefix) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	slices.SortFunc(list.Items, func(a, b completionItem) int { return strings.Compare(a.Label, b.Label) })
+	return list
+}
+
+// selectorCompletion returns the members of the operand before the dot
+// at offset dot. A selector without a name does not parse, so the
+// document is checked again with a placeholder name after the dot.
+func (s *server) selectorCompletion(c *checked, dot int) []completionItem {
+	text := c.doc.text
+	if dot+1 >= len(text) || !isIdentByte(text[dot+1]) {
+		d := newDocument(c.doc.uri, c.doc.path, text[:dot+1]+"_"+text[dot+1:], c.doc.version)
+		c = s.typecheck(d)
+	}
+	if c.pkg == nil {
+		return nil
+	}
+	n := c.doc.position(dot).Line
+	line, col := uint(n+1), uint(dot-c.doc.lines[n]+1)
+	var x syntax.Expr
+	syntax.Inspect(c.file, func(n syntax.Node) bool {
+		if sel, ok := n.(*syntax.SelectorExpr); ok && sel.Pos().Line() == line && sel.Pos().Col() == col {
+			x = sel.X
+		}
+		return x == nil
+	})
+	if x == nil {
+		return nil
+	}
+
+	var items []completionItem
+	if name, ok := x.(*syntax.Name); ok {
+		if pkgName, ok := c.info.Uses[name].(*types2.PkgName); ok {
+			scope := pkgName.Imported().Scope()
+			for _, n := range scope.Names() {
+				if obj := scope.Lookup(n); obj.Exported() {
+					items = append(items, c.item(obj))
+				}
+			}
+			return items
+		}
+	}
+	tv, ok := c.info.Types[x]
+	if !ok || tv.Type == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	for _, n := range memberNames(tv.Type) {
+		if seen[n] {
+			continue
+		}
+		seen[n] = true
+		if obj, _, _ := types2.LookupFieldOrMethod(tv.Type, true, c.pkg, n); obj != nil {
+			items = append(items, c.item(obj))
+		}
+	}
+	return items
+}
+
+// memberNames returns the names of the fields and methods of t,
+// including promoted ones, possibly with duplicates.
+func memberNames(t types2.Type) []string {
+	var names []string
+	seen := make(map[types2.Type]bool)
+	var visit func(t types2.Type)
+	visit = func(t types2.Type) {
+		if p, ok := types2.Unalias(t).(*types2.Pointer); ok {
+			t = p.Elem()
+		}
+		if seen[t] {
+			return
+		}
+		seen[t] = true
+		if n, ok := types2.Unalias(t).(*types2.Named); ok {
+			for i := range n.NumMethods() {
+				names = append(names, n.Method(i).Name())
+			}
+		}
+		switch u := t.Underlying().(type) {
+		case *types2.Struct:
+			for i := range u.NumFields() {
+				f := u.Field(i)
+				names = append(names, f.Name())
+				if f.Embedded() {
+					visit(f.Type())
+				}
+			}
+		case *types2.Interface:
+			for i := range u.NumMethods() {
+				names = append(names, u.Method(i).Name())
+			}
+		}
+	}
+	visit(t)
+	return names
+}
+
+// scopeCompletion returns the names in scope at p, the keywords and
+// the goo builtins.
+func (c *checked) scopeCompletion(p position) []completionItem {
+	line, col := c.doc.syntaxPos(p)
+	before := func(pos syntax.Pos) bool {
+		return pos.Line() < line || pos.Line() == line && pos.Col() < col
+	}
+
+	// Find the innermost scope at p. Function scopes are recorded for
+	// the signature but extend over the body.
+	scope := c.info.Scopes[c.file]
+	syntax.Inspect(c.file, func(n syntax.Node) bool {
+		if n == nil {
+			return false
+		}
+		var s *types2.Scope
+		end := syntax.EndPos(n)
+		switch n := n.(type) {
+		case *syntax.FuncDecl:
+			s = c.info.Scopes[n.Type]
+		case *syntax.FuncLit:
+			s = c.info.Scopes[n.Type]
+		default:
+			s = c.info.Scopes[n]
+		}
+		if !before(syntax.StartPos(n)) || end.IsKnown() && before(end) {
+			// The implicit main of a goo script has no end position.
+			return false
+		}
+		if s != nil {
+			scope = s
+		}
+		return true
+	})
+
+	var items []completionItem
+	seen := make(map[string]bool)
+	for s := scope; s != nil; s = s.Parent() {
+		local := s != c.pkg.Scope() && s != types2.Universe && s.Parent() != c.pkg.Scope()
+		for _, n := range s.Names() {
+			obj := s.Lookup(n)
+			if seen[n] || local && !before(obj.Pos()) || c.synthetic(obj) {
+				continue
+			}
+			seen[n] = true
+			items = append(items, c.item(obj))
+		}
+	}
+	for n := range gooBuiltins {
+		if !seen[n] {
+			seen[n] = true
+			items = append(items, completionItem{Label: n, Kind: kindFunction, Detail: "goo builtin"})
+		}
+	}
+	for _, k := range keywords {
+		if !seen[k] {
+			items = append(items, completionItem{Label: k, Kind: kindKeyword})
+		}
+	}
+	return items
+}
+
+// synthetic reports whether obj was declared by the parser rather than
+// in the document, like the implicit main of a goo script.
+func (c *checked) synthetic(obj types2.Object) bool {
+	pos := obj.Pos()
+	return pos.FileBase() == c.file.Pos().FileBase() && !c.doc.textAt(pos.Line(), pos.Col(), obj.Name())
+}
+
+// item returns the completion item for obj.
+func (c *checked) item(obj types2.Object) completionItem {
+	qf := types2.RelativeTo(c.pkg)
+	item := completionItem{Label: obj.Name(), Detail: types2.TypeString(obj.Type(), qf)}
+	switch obj := obj.(type) {
+	case *types2.Var:
+		item.Kind = kindVariable
+		if obj.IsField() {
+			item.Kind = kindField
+		}
+	case *types2.Const:
+		item.Kind = kindConstant
+	case *types2.Func:
+		item.Kind = kindFunction
+		if obj.Signature().Recv() != nil {
+			item.Kind = kindMethod
+		}
+	case *types2.TypeName:
+		item.Kind = kindClass
+		if types2.IsInterface(obj.Type()) {
+			item.Kind = kindInterface
+		}
+		item.Detail = types2.TypeString(obj.Type().Underlying(), qf)
+	case *types2.PkgName:
+		item.Kind = kindModule
+		item.Detail = fmt.Sprintf("%q", obj.Imported().Path())
+	case *types2.Builtin:
+		item.Kind = kindFunction
+		item.Detail = "builtin"
+	case *types2.Nil:
+		item.Kind = kindConstant
+		item.Detail = "untyped nil"
+	}
+	return item
+}
+
+// rename renames the object denoted by the name at p in all files of
+// its package.
+func (c *checked) rename(p position, newName string) (*workspaceEdit, *rpcError) {
+	name, obj := c.nameAt(p)
+	if obj == nil {
+		return nil, &rpcError{codeRequestFailed, "no identifier to rename here"}
+	}
+	if obj.Pkg() != c.pkg {
+		return nil, &rpcError{codeRequestFailed, fmt.Sprintf("cannot rename %s: declared outside the package", obj.Name())}
+	}
+	if _, ok := obj.(*types2.PkgName); ok {
+		return nil, &rpcError{codeRequestFailed, "cannot rename imports"}
+	}
+	if !isIdentifier(newName) {
+		return nil, &rpcError{codeRequestFailed, fmt.Sprintf("%q is not a valid identifier", newName)}
+	}
+	if s := obj.Parent(); s != nil && s.Lookup(newName) != nil {
+		return nil, &rpcError{codeRequestFailed, fmt.Sprintf("cannot rename %s: %s is already declared in its scope", name.Value, newName)}
+	}
+
+	edit := &workspaceEdit{Changes: make(map[string][]textEdit)}
+	for _, f := range c.files {
+		d := c.srcs[f]
+		syntax.Inspect(f, func(n syntax.Node) bool {
+			n1, ok := n.(*syntax.Name)
+			if !ok || c.info.Defs[n1] != obj && c.info.Uses[n1] != obj {
+				return true
+			}
+			pos := n1.Pos()
+			if !d.textAt(pos.Line(), pos.Col(), n1.Value) {
+				return true
+			}
+			r := lspRange{d.lspPos(pos.Line(), pos.Col()), d.lspPos(pos.Line(), pos.Col()+uint(len(n1.Value)))}
+			for _, e := range edit.Changes[d.uri] {
+				if e.Range == r {
+					return true // shared node, as in a, b T
+				}
+			}
+			edit.Changes[d.uri] = append(edit.Changes[d.uri], textEdit{r, newName})
+			return true
+		})
+	}
+	return edit, nil
+}
+
+// isIdentifier reports whether s is an identifier and not a keyword.
+func isIdentifier(s string) bool {
+	if s == "" || '0' <= s[0] && s[0] <= '9' || slices.Contains(keywords, s) {
+		return false
+	}
+	for _, r := range s {
+		if !isIdentRune(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"go/format"
+	"strings"
+)
+
+// formatDocument returns the edits formatting d: gofmt for .go files,
+// and reindentation for .goo files, which go/parser cannot parse.
+// A file that does not parse is left alone.
+func formatDocument(d *document) []textEdit {
+	var text string
+	if strings.HasSuffix(d.path, ".goo") {
+		text = reindent(d.text)
+	} else {
+		src, err := format.Source([]byte(d.text))
+		if err != nil {
+			return []textEdit{}
+		}
+		text = string(src)
+	}
+	if text == d.text {
+		return []textEdit{}
+	}
+	return []textEdit{{lspRange{position{}, d.position(len(d.text))}, text}}
+}
+
+// reindent indents the lines of a goo source file with one tab per
+// enclosing bracket, outdenting case clauses as gofmt does. It removes
+// trailing white space and runs of blank lines, and leaves the content
+// of raw strings and block comments as it is.
+func reindent(src string) string {
+	var b strings.Builder
+	depth := 0
+	var raw, comment, blank bool // in a raw string, a block comment, after a blank line
+	for line := range strings.Lines(src) {
+		line = strings.TrimRight(line, "\r\n")
+		if raw || comment {
+			// Keep continuation lines of raw strings as they are.
+			depth, raw, comment = scanLine(line, depth, raw, comment)
+			if !raw && !comment {
+				line = strings.TrimRight(line, " \t")
+			}
+			b.WriteString(line + "\n")
+			blank = false
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			// A # comment must stay in the first column.
+			b.WriteString(strings.TrimRight(line, " \t") + "\n")
+			blank = false
+			continue
+		}
+		text := strings.TrimSpace(line)
+		if text == "" {
+			if !blank && b.Len() > 0 {
+				b.WriteString("\n")
+			}
+			blank = true
+			continue
+		}
+		blank = false
+
+		indent := depth
+		for i := 0; i < len(text) && strings.IndexByte(")]}", text[i]) >= 0; i++ {
+			indent--
+		}
+		if strings.HasPrefix(text, "case ") || strings.HasPrefix(text, "default:") {
+			indent--
+		}
+		depth, raw, comment = scanLine(text, depth, false, false)
+		if !raw {
+			text = strings.TrimRight(text, " \t")
+		}
+		b.WriteString(strings.Repeat("\t", max(indent, 0)) + text + "\n")
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// scanLine returns the bracket depth after line and whether it ends
+// in a raw string or block comment, given the state at its start.
+func scanLine(line string, depth int, raw, comment bool) (int, bool, bool) {
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case raw:
+			raw = c != '`'
+		case comment:
+			if c == '*' && i+1 < len(line) && line[i+1] == '/' {
+				comment = false
+				i++
+			}
+		case c == '`':
+			raw = true
+		case c == '"' || c == '\'':
+			for i++; i < len(line) && line[i] != c; i++ {
+				if line[i] == '\\' {
+					i++
+				}
+			}
+		case c == '/' && i+1 < len(line) && line[i+1] == '/':
+			return depth, false, false
+		case c == '/' && i+1 < len(line) && line[i+1] == '*':
+			comment = true
+			i++
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth = max(depth-1, 0)
+		}
+	}
+	return depth, raw, comment
+}
@@ -0,0 +1,318 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"internal/testenv"
+	"internal/txtar"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "record the server messages of the sessions in testdata")
+
+// TestSessions replays the recorded sessions in testdata.
+//
+// A session is a txtar archive of source files and a "session" file,
+// whose lines are
+//
+//	-> message      a message sent by the client
+//	<- message      the next message expected from the server
+//	open file       sugar for a didOpen notification with the file's text
+//	change file new sugar for a didChange notification replacing the text of
+//	                file with that of the archive file new
+//
+// $ROOT in messages stands for the URI of the directory holding the files.
+// Unless the session starts with an initialize request, the server is
+// initialized first. With -update, the <- lines are recorded anew.
+func TestSessions(t *testing.T) {
+	testenv.MustHaveGoBuild(t) // for go list -export
+
+	files, err := filepath.Glob("testdata/*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		t.Run(strings.TrimSuffix(filepath.Base(file), ".txt"), func(t *testing.T) {
+			runSession(t, file)
+		})
+	}
+}
+
+func runSession(t *testing.T, file string) {
+	ar, err := txtar.ParseFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	root := pathToURI(dir)
+	var session *txtar.File
+	texts := make(map[string]string)
+	for i, f := range ar.Files {
+		if f.Name == "session" {
+			session = &ar.Files[i]
+			continue
+		}
+		texts[f.Name] = string(f.Data)
+		name := filepath.Join(dir, f.Name)
+		if err := os.MkdirAll(filepath.Dir(name), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, f.Data, 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if session == nil {
+		t.Fatalf("%s: no session", file)
+	}
+
+	c := startClient(t)
+	lines := strings.Split(strings.TrimSuffix(string(session.Data), "\n"), "\n")
+	if len(lines) == 0 || !strings.Contains(lines[0], `"initialize"`) {
+		c.send(t, `{"jsonrpc":"2.0","id":0,"method":"initialize","params":{"rootUri":"`+root+`"}}`)
+		c.receive(t)
+		c.send(t, `{"jsonrpc":"2.0","method":"initialized","params":{}}`)
+	}
+
+	var record []string
+	versions := make(map[string]int)
+	for n, line := range lines {
+		where := fmt.Sprintf("%s:%d", file, n+1)
+		var msg string
+		switch verb, arg, _ := strings.Cut(line, " "); verb {
+		case "", "#":
+			record = append(record, line)
+			continue
+		case "->":
+			msg = strings.ReplaceAll(arg, "$ROOT", root)
+		case "<-":
+			if *update {
+				continue
+			}
+			got := canonical(t, strings.ReplaceAll(c.receive(t), root, "$ROOT"))
+			if want := canonical(t, arg); got != want {
+				t.Errorf("%s: unexpected message\ngot:  %s\nwant: %s", where, got, want)
+			}
+			continue
+		case "open":
+			versions[arg] = 1
+			msg = notification("textDocument/didOpen", map[string]any{
+				"textDocument": map[string]any{"uri": root + "/" + arg, "languageId": "goo", "version": 1, "text": texts[arg]},
+			})
+		case "change":
+			name, text, _ := strings.Cut(arg, " ")
+			versions[name]++
+			msg = notification("textDocument/didChange", map[string]any{
+				"textDocument":   map[string]any{"uri": root + "/" + name, "version": versions[name]},
+				"contentChanges": []any{map[string]any{"text": texts[text]}},
+			})
+		default:
+			t.Fatalf("%s: unknown line %q", where, line)
+		}
+		record = append(record, line)
+		c.send(t, msg)
+		if *update {
+			for range replies(msg) {
+				record = append(record, "<- "+strings.ReplaceAll(c.receive(t), root, "$ROOT"))
+			}
+		}
+	}
+	c.close(t)
+
+	if *update {
+		session.Data = []byte(strings.Join(record, "\n") + "\n")
+		if err := os.WriteFile(file, txtar.Format(ar), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// replies returns the number of messages the server sends for msg.
+func replies(msg string) int {
+	var m message
+	json.Unmarshal([]byte(msg), &m)
+	switch {
+	case m.ID != nil:
+		return 1
+	case m.Method == "textDocument/didOpen", m.Method == "textDocument/didChange", m.Method == "textDocument/didClose":
+		return 1 // diagnostics
+	}
+	return 0
+}
+
+func notification(method string, params any) string {
+	data, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "method": method, "params": params})
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
+
+// canonical returns msg with sorted keys and without space.
+func canonical(t *testing.T, msg string) string {
+	var v any
+	if err := json.Unmarshal([]byte(msg), &v); err != nil {
+		t.Fatalf("bad message %s: %v", msg, err)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// A client talks to a server running in the test process.
+type client struct {
+	w    *io.PipeWriter
+	msgs chan string
+	done chan error
+}
+
+func startClient(t *testing.T) *client {
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	c := &client{w: inW, msgs: make(chan string, 100), done: make(chan error, 1)}
+	s := newServer(inR, outW)
+	go func() {
+		c.done <- s.serve()
+		outW.Close()
+	}()
+	go func() {
+		r := bufio.NewReader(outR)
+		for {
+			data, err := readMessage(r)
+			if err != nil {
+				close(c.msgs)
+				return
+			}
+			c.msgs <- string(data)
+		}
+	}()
+	return c
+}
+
+func (c *client) send(t *testing.T, msg string) {
+	t.Helper()
+	if err := writeMessage(c.w, []byte(msg)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (c *client) receive(t *testing.T) string {
+	t.Helper()
+	select {
+	case msg, ok := <-c.msgs:
+		if !ok {
+			t.Fatal("server stopped")
+		}
+		return msg
+	case <-time.After(2 * time.Minute):
+		t.Fatal("timeout waiting for the server")
+	}
+	panic("unreachable")
+}
+
+// close ends the session and checks that the server sent nothing else.
+func (c *client) close(t *testing.T) {
+	t.Helper()
+	c.w.Close()
+	if err := <-c.done; err != nil {
+		t.Fatal(err)
+	}
+	for msg := range c.msgs {
+		t.Errorf("unexpected message %s", msg)
+	}
+}
+
+func TestFraming(t *testing.T) {
+	var buf bytes.Buffer
+	for _, msg := range []string{`{"a":1}`, `{"b":"ü"}`} {
+		if err := writeMessage(&buf, []byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	buf.WriteString("Content-Type: application/vscode-jsonrpc; charset=utf-8\r\nContent-Length: 2\r\n\r\n{}")
+	r := bufio.NewReader(&buf)
+	for _, want := range []string{`{"a":1}`, `{"b":"ü"}`, `{}`} {
+		data, err := readMessage(r)
+		if err != nil || string(data) != want {
+			t.Fatalf("readMessage = %q, %v; want %q", data, err, want)
+		}
+	}
+	if _, err := readMessage(r); err != io.EOF {
+		t.Fatalf("readMessage at end = %v, want EOF", err)
+	}
+}
+
+func TestDocumentPositions(t *testing.T) {
+	d := newDocument("file:///a.goo", "/a.goo", "x := \"π😀\"\nput(x)\n", 1)
+	for _, tt := range []struct {
+		p         position
+		line, col uint
+	}{
+		{position{0, 0}, 1, 1},
+		{position{0, 6}, 1, 7},  // π is 2 bytes, 1 UTF-16 unit
+		{position{0, 7}, 1, 9},  // 😀 is 4 bytes, 2 UTF-16 units
+		{position{0, 9}, 1, 13}, // closing quote
+		{position{1, 4}, 2, 5},
+	} {
+		line, col := d.syntaxPos(tt.p)
+		if line != tt.line || col != tt.col {
+			t.Errorf("syntaxPos(%v) = %d:%d, want %d:%d", tt.p, line, col, tt.line, tt.col)
+		}
+		if p := d.lspPos(line, col); p != tt.p {
+			t.Errorf("lspPos(%d, %d) = %v, want %v", line, col, p, tt.p)
+		}
+	}
+}
+
+func TestReindent(t *testing.T) {
+	src := "#!/usr/bin/env goo\n" +
+		"def f(x int) int {\n" +
+		"  if x > 0 {   \n" +
+		"return x\n" +
+		"      }\n" +
+		"\n" +
+		"\n" +
+		"    switch x {\n" +
+		"    case 1:\n" +
+		"    s := `a\n" +
+		"  {raw`\n" +
+		"  default:\n" +
+		"put(\"}\") // }\n" +
+		"}\n" +
+		"return -x }\n" +
+		"# comment\n"
+	want := "#!/usr/bin/env goo\n" +
+		"def f(x int) int {\n" +
+		"\tif x > 0 {\n" +
+		"\t\treturn x\n" +
+		"\t}\n" +
+		"\n" +
+		"\tswitch x {\n" +
+		"\tcase 1:\n" +
+		"\t\ts := `a\n" +
+		"  {raw`\n" +
+		"\tdefault:\n" +
+		"\t\tput(\"}\") // }\n" +
+		"\t}\n" +
+		"\treturn -x }\n" +
+		"# comment\n"
+	if got := reindent(src); got != want {
+		t.Errorf("reindent:\n%s\nwant:\n%s", got, want)
+	}
+	if got := reindent(want); got != want {
+		t.Errorf("reindent is not idempotent:\n%s", got)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Goo-lsp is a language server for goo and Go source files.
+//
+// Usage:
+//
+//	go tool goo-lsp [-log file]
+//
+// Goo-lsp speaks the Language Server Protocol over standard input and
+// output, so that any editor with an LSP client can use it. Unlike gopls,
+// it parses and type-checks with the compiler's own syntax and types2
+// packages, which understand the goo syntax: implicit main, put and
+// printf, check, lambdas, if expressions and the rest.
+//
+// It provides
+//
+//   - diagnostics: syntax and type errors, and warnings for unused imports
+//     and suspicious uses of put and check;
+//   - hover, showing the declaration and typeof of the name under the cursor;
+//   - go to definition;
+//   - completion of names in scope, fields and methods, package members,
+//     keywords and the goo builtins put, printf, typeof and check;
+//   - rename of local and package-level names;
+//   - formatting: gofmt for .go files, reindentation for .goo files.
+//
+// A .goo file is checked on its own, as 'go run file.goo' builds it.
+// A .go file is checked with the other .go files of its package.
+// Imported packages are loaded from export data built by 'go list -export'.
+//
+// The -log flag names a file receiving a trace of all messages.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"cmd/internal/telemetry/counter"
+)
+
+var logFile = flag.String("log", "", "write a trace of all messages to `file`")
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: go tool goo-lsp [-log file]\n")
+	os.Exit(2)
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("goo-lsp: ")
+	counter.Open()
+
+	flag.Usage = usage
+	flag.Parse()
+	counter.Inc("goo-lsp/invocations")
+	counter.CountFlags("goo-lsp/flag:", *flag.CommandLine)
+	if flag.NArg() != 0 {
+		usage()
+	}
+
+	s := newServer(os.Stdin, os.Stdout)
+	if *logFile != "" {
+		f, err := os.Create(*logFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		s.trace = f
+	}
+	if err := s.serve(); err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(s.exitCode)
+}
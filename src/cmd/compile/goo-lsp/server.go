@@ -0,0 +1,275 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// A server is a language server talking to one client.
+// It handles one message at a time, in the order they arrive.
+type server struct {
+	input    *bufio.Reader
+	out      io.Writer
+	trace    io.Writer // if not nil, receives all messages
+	docs     map[string]*document
+	imports  map[string]*exportImporter // by directory
+	std      map[string][]string        // standard library packages by name; see stdPackages
+	shutdown bool
+	exitCode int
+}
+
+func newServer(in io.Reader, out io.Writer) *server {
+	return &server{
+		input:   bufio.NewReader(in),
+		out:     out,
+		docs:    make(map[string]*document),
+		imports: make(map[string]*exportImporter),
+	}
+}
+
+// serve handles messages until the client sends exit or
+// closes the connection.
+func (s *server) serve() error {
+	for {
+		data, err := readMessage(s.input)
+		if err == io.EOF {
+			if !s.shutdown {
+				s.exitCode = 1
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.logf("<- %s", data)
+
+		var msg message
+		if err := json.Unmarshal(data, &msg); err != nil {
+			s.reply(nil, nil, &rpcError{codeParseError, err.Error()})
+			continue
+		}
+		if msg.Method == "" {
+			continue // a response; goo-lsp sends no requests
+		}
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				s.exitCode = 1
+			}
+			return nil
+		}
+		result, rerr := s.handle(msg.Method, msg.Params, msg.ID != nil)
+		if msg.ID != nil {
+			s.reply(msg.ID, result, rerr)
+		} else if rerr != nil {
+			s.logf("%s: %v", msg.Method, rerr)
+		}
+	}
+}
+
+// handle handles a request or notification and returns its result.
+func (s *server) handle(method string, params json.RawMessage, request bool) (any, *rpcError) {
+	if s.shutdown && request {
+		return nil, &rpcError{codeInvalidRequest, "server is shut down"}
+	}
+	switch method {
+	case "initialize":
+		return map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync":           1, // full
+				"hoverProvider":              true,
+				"definitionProvider":         true,
+				"completionProvider":         map[string]any{"triggerCharacters": []string{"."}},
+				"renameProvider":             true,
+				"documentFormattingProvider": true,
+			},
+			"serverInfo": map[string]any{"name": "goo-lsp"},
+		}, nil
+
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var p didOpenParams
+		if err := unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		path := uriToPath(p.TextDocument.URI)
+		if path == "" {
+			return nil, &rpcError{codeInvalidParams, "not a file URI: " + p.TextDocument.URI}
+		}
+		d := newDocument(p.TextDocument.URI, path, p.TextDocument.Text, p.TextDocument.Version)
+		s.docs[d.uri] = d
+		s.publish(d)
+		return nil, nil
+
+	case "textDocument/didChange":
+		var p didChangeParams
+		if err := unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		d := s.docs[p.TextDocument.URI]
+		if d == nil {
+			return nil, &rpcError{codeInvalidParams, "document not open: " + p.TextDocument.URI}
+		}
+		for _, change := range p.ContentChanges {
+			if change.Range == nil {
+				d.setText(change.Text)
+				continue
+			}
+			start, end := d.offset(change.Range.Start), d.offset(change.Range.End)
+			d.setText(d.text[:start] + change.Text + d.text[max(start, end):])
+		}
+		d.version = p.TextDocument.Version
+		s.publish(d)
+		return nil, nil
+
+	case "textDocument/didSave":
+		// Saved files may be imported by others; reload export data.
+		clear(s.imports)
+		return nil, nil
+
+	case "textDocument/didClose":
+		var p didCloseParams
+		if err := unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		delete(s.docs, p.TextDocument.URI)
+		s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: p.TextDocument.URI, Diagnostics: []diagnostic{}})
+		return nil, nil
+
+	case "textDocument/hover":
+		var p textDocumentPositionParams
+		c, err := s.checkParams(params, &p, &p.TextDocument)
+		if err != nil {
+			return nil, err
+		}
+		return c.hover(p.Position), nil
+
+	case "textDocument/definition":
+		var p textDocumentPositionParams
+		c, err := s.checkParams(params, &p, &p.TextDocument)
+		if err != nil {
+			return nil, err
+		}
+		return s.definition(c, p.Position), nil
+
+	case "textDocument/completion":
+		var p textDocumentPositionParams
+		c, err := s.checkParams(params, &p, &p.TextDocument)
+		if err != nil {
+			return nil, err
+		}
+		return s.completion(c, p.Position), nil
+
+	case "textDocument/rename":
+		var p renameParams
+		c, err := s.checkParams(params, &p, &p.TextDocument)
+		if err != nil {
+			return nil, err
+		}
+		return c.rename(p.Position, p.NewName)
+
+	case "textDocument/formatting":
+		var p formattingParams
+		if err := unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		d := s.docs[p.TextDocument.URI]
+		if d == nil {
+			return nil, &rpcError{codeInvalidParams, "document not open: " + p.TextDocument.URI}
+		}
+		return formatDocument(d), nil
+	}
+
+	if strings.HasPrefix(method, "$/") || !request {
+		return nil, nil // notifications may be ignored
+	}
+	return nil, &rpcError{codeMethodNotFound, "method not supported: " + method}
+}
+
+// checkParams decodes the parameters of a request about an open document
+// into p and type-checks the document, named by doc after decoding.
+func (s *server) checkParams(params json.RawMessage, p any, doc *textDocumentIdentifier) (*checked, *rpcError) {
+	if err := unmarshal(params, p); err != nil {
+		return nil, err
+	}
+	d := s.docs[doc.URI]
+	if d == nil {
+		return nil, &rpcError{codeInvalidParams, "document not open: " + doc.URI}
+	}
+	return s.typecheck(d), nil
+}
+
+func unmarshal(params json.RawMessage, p any) *rpcError {
+	if err := json.Unmarshal(params, p); err != nil {
+		return &rpcError{codeInvalidParams, err.Error()}
+	}
+	return nil
+}
+
+// publish sends the diagnostics of d to the client.
+func (s *server) publish(d *document) {
+	c := s.typecheck(d)
+	diags := append([]diagnostic{}, c.diags...)
+	slices.SortStableFunc(diags, func(a, b diagnostic) int {
+		return cmp.Or(cmp.Compare(a.Range.Start.Line, b.Range.Start.Line), cmp.Compare(a.Range.Start.Character, b.Range.Start.Character))
+	})
+	s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: d.uri, Version: d.version, Diagnostics: diags})
+}
+
+// reply sends the response to the request id.
+func (s *server) reply(id json.RawMessage, result any, rerr *rpcError) {
+	msg := message{JSONRPC: "2.0", ID: id}
+	if id == nil {
+		msg.ID = json.RawMessage("null")
+	}
+	if rerr != nil {
+		msg.Error = rerr
+	} else {
+		data, err := json.Marshal(result)
+		if err != nil {
+			msg.Error = &rpcError{codeInternalError, err.Error()}
+		} else {
+			msg.Result = data
+		}
+	}
+	s.send(msg)
+}
+
+// notify sends a notification to the client.
+func (s *server) notify(method string, params any) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		s.logf("%s: %v", method, err)
+		return
+	}
+	s.send(message{JSONRPC: "2.0", Method: method, Params: data})
+}
+
+func (s *server) send(msg message) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		s.logf("%v", err)
+		return
+	}
+	s.logf("-> %s", data)
+	if err := writeMessage(s.out, data); err != nil {
+		s.logf("%v", err)
+	}
+}
+
+func (s *server) logf(format string, args ...any) {
+	if s.trace != nil {
+		fmt.Fprintf(s.trace, format+"\n", args...)
+	}
+}
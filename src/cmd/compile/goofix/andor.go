@@ -0,0 +1,84 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+
+	"cmd/compile/internal/syntax"
+)
+
+func init() {
+	registerGoo(gooAndOrFix)
+}
+
+var gooAndOrFix = gooFix{
+	name: "andor",
+	desc: `Spell the logical operators &&, || and ! as and, or and not.
+Reversed, and, or, not and ¬ become &&, || and !, and ≠ becomes !=.`,
+	disabled: true,
+	fix:      gooAndOr,
+	reverse:  gooUnandOr,
+}
+
+var gooOperators = []struct{ goo, golang string }{
+	{"and", "&&"},
+	{"or", "||"},
+	{"not", "!"},
+	{"¬", "!"},
+	{"≠", "!="},
+}
+
+func gooAndOr(f *gooFile) bool {
+	fixed := false
+	syntax.Inspect(f.file, func(n syntax.Node) bool {
+		x, ok := n.(*syntax.Operation)
+		if !ok || !x.Pos().IsKnown() {
+			return true
+		}
+		off := f.offset(x.Pos())
+		switch {
+		case x.Op == syntax.AndAnd && bytes.HasPrefix(f.src[off:], []byte("&&")):
+			f.replace(off, off+2, "and")
+		case x.Op == syntax.OrOr && bytes.HasPrefix(f.src[off:], []byte("||")):
+			f.replace(off, off+2, "or")
+		case x.Op == syntax.Not && x.Y == nil && bytes.HasPrefix(f.src[off:], []byte("!")):
+			f.replace(off, off+1, "not ")
+		default:
+			return true
+		}
+		fixed = true
+		return true
+	})
+	return fixed
+}
+
+func gooUnandOr(f *gooFile) bool {
+	fixed := false
+	syntax.Inspect(f.file, func(n syntax.Node) bool {
+		x, ok := n.(*syntax.Operation)
+		if !ok || !x.Pos().IsKnown() {
+			return true
+		}
+		off := f.offset(x.Pos())
+		for _, op := range gooOperators {
+			if !bytes.HasPrefix(f.src[off:], []byte(op.goo)) {
+				continue
+			}
+			end := off + len(op.goo)
+			if op.golang == "!" {
+				// not x becomes !x.
+				for end < len(f.src) && isSpace(f.src[end]) {
+					end++
+				}
+			}
+			f.replace(off, end, op.golang)
+			fixed = true
+			break
+		}
+		return true
+	})
+	return fixed
+}
@@ -0,0 +1,265 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"cmd/compile/internal/syntax"
+)
+
+func init() {
+	registerGoo(gooEnumFix)
+}
+
+var gooEnumFix = gooFix{
+	name: "enum",
+	desc: `Rewrite an int type with a group of iota constants and a String
+method returning their names to an enum declaration.
+Reversed, an enum declaration becomes the type, constants and method.`,
+	fix:     gooEnum,
+	reverse: gooUnenum,
+}
+
+// A gooEnumDecl is a Go declaration of an enum:
+//
+//	type T int
+//
+//	const (
+//		A T = iota
+//		B
+//	)
+//
+//	func (t T) String() string { ... }
+//
+// where String returns the name of each constant.
+type gooEnumDecl struct {
+	typ    *syntax.TypeDecl
+	consts []*syntax.ConstDecl
+	str    *syntax.FuncDecl
+}
+
+func gooEnum(f *gooFile) bool {
+	enums := make(map[string]*gooEnumDecl)
+	var order []string
+	var group *syntax.Group
+	for _, decl := range f.file.DeclList {
+		switch d := decl.(type) {
+		case *syntax.TypeDecl:
+			if d.Group == nil && !d.Alias && d.TParamList == nil && isNamed(d.Type, "int") && d.Type.Pos().IsKnown() {
+				enums[d.Name.Value] = &gooEnumDecl{typ: d}
+				order = append(order, d.Name.Value)
+			}
+		case *syntax.ConstDecl:
+			if d.Group == nil || d.Group == group {
+				continue
+			}
+			group = d.Group
+			if e := enums[constType(d)]; e != nil && e.consts == nil {
+				e.consts = groupConsts(f.file.DeclList, d)
+			}
+		case *syntax.FuncDecl:
+			if e := enums[stringReceiver(d)]; e != nil {
+				e.str = d
+			}
+		}
+	}
+
+	fixed := false
+	for _, name := range order {
+		e := enums[name]
+		if e.consts == nil || e.str == nil || !namesConsts(e.str, e.consts) {
+			continue
+		}
+		// Find the keywords the syntax tree has no positions of.
+		typ := f.keywordBefore(f.offset(e.typ.Pos()), "type", "")
+		cnst := f.keywordBefore(f.offset(e.consts[0].Pos()), "const", "(")
+		fn := f.keywordBefore(f.offset(e.str.Pos()), "func", "")
+		if typ < 0 || cnst < 0 || fn < 0 {
+			continue
+		}
+		closing := strings.IndexByte(string(f.src[f.offset(syntax.EndPos(e.consts[len(e.consts)-1])):]), ')')
+		if closing < 0 {
+			continue
+		}
+		closing += f.offset(syntax.EndPos(e.consts[len(e.consts)-1]))
+
+		var names []string
+		for _, c := range e.consts {
+			names = append(names, c.NameList[0].Value)
+		}
+		f.replace(typ, f.offset(syntax.EndPos(e.typ.Type)), fmt.Sprintf("enum %s { %s }", name, strings.Join(names, ", ")))
+		f.deleteLines(cnst, closing+1)
+		f.deleteLines(fn, f.offset(e.str.Body.Rbrace)+1)
+		fixed = true
+	}
+	return fixed
+}
+
+// constType returns the type name of the first constant of a const
+// group if it is declared as T = iota, or "".
+func constType(d *syntax.ConstDecl) string {
+	if len(d.NameList) != 1 || !isNamed(d.Values, "iota") {
+		return ""
+	}
+	if t, ok := d.Type.(*syntax.Name); ok {
+		return t.Value
+	}
+	return ""
+}
+
+// groupConsts returns the constants of the group of first, which must
+// all be single names repeating the first declaration, or nil.
+func groupConsts(decls []syntax.Decl, first *syntax.ConstDecl) []*syntax.ConstDecl {
+	var consts []*syntax.ConstDecl
+	for _, decl := range decls {
+		d, ok := decl.(*syntax.ConstDecl)
+		if !ok || d.Group != first.Group {
+			continue
+		}
+		if d != first && (len(d.NameList) != 1 || d.Type != nil || d.Values != nil) || d.NameList[0].Value == "_" {
+			return nil
+		}
+		consts = append(consts, d)
+	}
+	return consts
+}
+
+// stringReceiver returns the receiver type name of a String() string
+// method with a value receiver, or "".
+func stringReceiver(d *syntax.FuncDecl) string {
+	if d.Recv == nil || d.Name.Value != "String" || d.Body == nil || len(d.Type.ParamList) != 0 {
+		return ""
+	}
+	if len(d.Type.ResultList) != 1 || !isNamed(d.Type.ResultList[0].Type, "string") {
+		return ""
+	}
+	if t, ok := d.Recv.Type.(*syntax.Name); ok && d.Recv.Name != nil {
+		return t.Value
+	}
+	return ""
+}
+
+// namesConsts reports whether the String method fn returns the names
+// of the constants, as
+//
+//	return [...]string{"A", "B"}[t]
+//
+// or as a switch with one case per constant returning its name and an
+// optional default.
+func namesConsts(fn *syntax.FuncDecl, consts []*syntax.ConstDecl) bool {
+	recv := fn.Recv.Name.Value
+	body := fn.Body.List
+	if len(body) == 1 {
+		if ret, ok := body[0].(*syntax.ReturnStmt); ok {
+			x, ok := ret.Results.(*syntax.IndexExpr)
+			if !ok || !isNamed(x.Index, recv) {
+				return false
+			}
+			lit, ok := x.X.(*syntax.CompositeLit)
+			if !ok || len(lit.ElemList) != len(consts) {
+				return false
+			}
+			for i, elem := range lit.ElemList {
+				if !isString(elem, consts[i].NameList[0].Value) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+
+	if len(body) == 0 || len(body) > 2 {
+		return false
+	}
+	s, ok := body[0].(*syntax.SwitchStmt)
+	if !ok || s.Init != nil || !isNamed(s.Tag, recv) {
+		return false
+	}
+	seen := make(map[string]bool)
+	for _, c := range s.Body {
+		if c.Cases == nil {
+			continue // default
+		}
+		name, ok := c.Cases.(*syntax.Name)
+		if !ok || len(c.Body) != 1 {
+			return false
+		}
+		ret, ok := c.Body[0].(*syntax.ReturnStmt)
+		if !ok || !isString(ret.Results, name.Value) {
+			return false
+		}
+		seen[name.Value] = true
+	}
+	for _, c := range consts {
+		if !seen[c.NameList[0].Value] {
+			return false
+		}
+	}
+	return len(seen) == len(consts)
+}
+
+// isString reports whether x is a string literal of s.
+func isString(x syntax.Expr, s string) bool {
+	lit, ok := x.(*syntax.BasicLit)
+	if !ok || lit.Kind != syntax.StringLit {
+		return false
+	}
+	v, err := strconv.Unquote(lit.Value)
+	return err == nil && v == s
+}
+
+func gooUnenum(f *gooFile) bool {
+	fixed := false
+	decls := f.file.DeclList
+	for i, decl := range decls {
+		// The type of an enum has a name without a position.
+		d, ok := decl.(*syntax.TypeDecl)
+		if !ok || d.Type.Pos().IsKnown() {
+			continue
+		}
+		var names []string
+		for _, decl := range decls[i+1:] {
+			c, ok := decl.(*syntax.ConstDecl)
+			if !ok || c.Type != d.Name {
+				break
+			}
+			names = append(names, c.NameList[0].Value)
+		}
+		start := f.keywordBefore(f.offset(d.Pos()), "enum", "")
+		end := strings.IndexByte(string(f.src[f.offset(d.Pos()):]), '}')
+		if start < 0 || end < 0 || len(names) == 0 {
+			continue
+		}
+		end += f.offset(d.Pos()) + 1
+
+		recv, _ := utf8.DecodeRuneInString(d.Name.Value)
+		r := string(unicode.ToLower(recv))
+		var b strings.Builder
+		fmt.Fprintf(&b, "type %s int\n\nconst (\n", d.Name.Value)
+		for j, name := range names {
+			if j == 0 {
+				fmt.Fprintf(&b, "\t%s %s = iota\n", name, d.Name.Value)
+			} else {
+				fmt.Fprintf(&b, "\t%s\n", name)
+			}
+		}
+		fmt.Fprintf(&b, ")\n\nfunc (%s %s) String() string {\n\treturn [...]string{", r, d.Name.Value)
+		for j, name := range names {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(strconv.Quote(name))
+		}
+		fmt.Fprintf(&b, "}[%s]\n}", r)
+		f.replace(start, end, b.String())
+		fixed = true
+	}
+	return fixed
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"cmd/compile/internal/syntax"
+	"cmd/compile/internal/types2"
+)
+
+func init() {
+	registerGoo(gooForInFix)
+}
+
+var gooForInFix = gooFix{
+	name: "forin",
+	desc: `Rewrite range loops declaring their variables to for-in loops:
+for _, v := range xs becomes for v in xs for slices, arrays and strings,
+for k, v := range m becomes for k, v in m, and for k := range m becomes
+for k in m for the other types. Reversed, for-in loops become range loops.`,
+	fix:     gooForIn,
+	reverse: gooUnforIn,
+}
+
+// rangesOverElems reports whether the single variable of a for-in
+// loop over a value of type t is the element rather than the index.
+// It must match types2.
+func rangesOverElems(t types2.Type) bool {
+	if p, ok := types2.Unalias(t).Underlying().(*types2.Pointer); ok {
+		if a, ok := p.Elem().Underlying().(*types2.Array); ok {
+			t = a
+		}
+	}
+	switch t := t.Underlying().(type) {
+	case *types2.Slice, *types2.Array:
+		return true
+	case *types2.Basic:
+		return t.Info()&types2.IsString != 0
+	}
+	return false
+}
+
+func gooForIn(f *gooFile) bool {
+	fixed := false
+	syntax.Inspect(f.file, func(n syntax.Node) bool {
+		s, ok := n.(*syntax.ForStmt)
+		if !ok {
+			return true
+		}
+		r, ok := s.Init.(*syntax.RangeClause)
+		if !ok || r.In || !r.Def || r.Lhs == nil {
+			return true
+		}
+		typ := f.typeOf(r.X)
+		if typ == nil {
+			return true
+		}
+		var vars string
+		switch lhs := r.Lhs.(type) {
+		case *syntax.Name:
+			if rangesOverElems(typ) {
+				return true // for v in xs would range over the elements
+			}
+			vars = lhs.Value
+		case *syntax.ListExpr:
+			if len(lhs.ElemList) != 2 {
+				return true
+			}
+			key, ok1 := lhs.ElemList[0].(*syntax.Name)
+			value, ok2 := lhs.ElemList[1].(*syntax.Name)
+			switch {
+			case !ok1 || !ok2 || value.Value == "_":
+				return true
+			case key.Value == "_" && rangesOverElems(typ):
+				vars = value.Value
+			default:
+				vars = key.Value + ", " + value.Value
+			}
+		default:
+			return true
+		}
+		// r.Pos() is the position of range.
+		f.replace(f.offset(syntax.StartPos(r.Lhs)), f.offset(r.Pos())+len("range"), vars+" in")
+		fixed = true
+		return true
+	})
+	return fixed
+}
+
+func gooUnforIn(f *gooFile) bool {
+	fixed := false
+	syntax.Inspect(f.file, func(n syntax.Node) bool {
+		s, ok := n.(*syntax.ForStmt)
+		if !ok {
+			return true
+		}
+		r, ok := s.Init.(*syntax.RangeClause)
+		if !ok || !r.In {
+			return true
+		}
+		if f.typeOf(r.X) == nil {
+			return true // cannot tell the index from the element
+		}
+		// r.Pos() is the position of in or ∈.
+		start, op := f.offset(syntax.StartPos(r.Lhs)), f.offset(r.Pos())
+		vars := f.text(r.Lhs, op)
+		if list, ok := r.Lhs.(*syntax.ListExpr); ok && list.ElemList[0].Pos() == list.ElemList[1].Pos() {
+			// The type checker added the _ of for _, v := range xs.
+			vars = "_, " + vars
+		}
+		end := op + len("in")
+		if f.src[op] != 'i' {
+			end = op + len("∈")
+		}
+		f.replace(start, end, vars+" := range")
+		fixed = true
+		return true
+	})
+	return fixed
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Goofix rewrites Go code into idiomatic goo, and goo code back into Go.
+//
+// Usage:
+//
+//	go tool goofix [-reverse] [-diff] [-r name,...] [path ...]
+//
+// The rewrites turn fmt.Println(x) into put(x), range loops into for-in
+// loops, comparisons with nil and zero into truthy conditions, and so
+// on; with -reverse, they turn goo code back into Go. The -r flag
+// selects among them; some run only when named with -r. Goofix prints
+// the full list of rewrites in its help output.
+//
+// Without an explicit path, goofix reads standard input and writes the
+// result to standard output. A directory is processed recursively.
+//
+// Unlike the fixes of cmd/fix, which rewrite go/ast trees, the goo
+// rewrites parse and type-check with the compiler's syntax and types2
+// packages, which know the goo syntax, and edit the source text, since
+// go/printer cannot print goo. They keep the formatting of the files.
+//
+// 'go fix -goo' runs goofix on the files of packages.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/scanner"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cmd/internal/telemetry/counter"
+)
+
+var (
+	reverse         = flag.Bool("reverse", false, "undo the goo rewrites, producing Go")
+	doDiff          = flag.Bool("diff", false, "display diffs instead of rewriting files")
+	allowedRewrites = flag.String("r", "", "restrict the rewrites to this comma-separated list")
+)
+
+var allowed map[string]bool
+
+var exitCode = 0
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: go tool goofix [-reverse] [-diff] [-r fixname,...] [path ...]\n")
+	flag.PrintDefaults()
+	fmt.Fprintf(os.Stderr, "\nAvailable rewrites are:\n")
+	for _, f := range gooFixes {
+		if f.disabled {
+			fmt.Fprintf(os.Stderr, "\n%s (disabled)\n", f.name)
+		} else {
+			fmt.Fprintf(os.Stderr, "\n%s\n", f.name)
+		}
+		desc := strings.TrimSpace(f.desc)
+		desc = strings.ReplaceAll(desc, "\n", "\n\t")
+		fmt.Fprintf(os.Stderr, "\t%s\n", desc)
+	}
+	os.Exit(2)
+}
+
+func main() {
+	counter.Open()
+	flag.Usage = usage
+	flag.Parse()
+	counter.Inc("goofix/invocations")
+	counter.CountFlags("goofix/flag:", *flag.CommandLine)
+
+	if *allowedRewrites != "" {
+		allowed = make(map[string]bool)
+		for _, f := range strings.Split(*allowedRewrites, ",") {
+			allowed[f] = true
+		}
+	}
+
+	if flag.NArg() == 0 {
+		if err := processFile("standard input", true); err != nil {
+			report(err)
+		}
+		os.Exit(exitCode)
+	}
+
+	for i := 0; i < flag.NArg(); i++ {
+		path := flag.Arg(i)
+		switch dir, err := os.Stat(path); {
+		case err != nil:
+			report(err)
+		case dir.IsDir():
+			filepath.WalkDir(path, visitFile)
+		default:
+			if err := processFile(path, false); err != nil {
+				report(err)
+			}
+		}
+	}
+
+	os.Exit(exitCode)
+}
+
+func report(err error) {
+	scanner.PrintError(os.Stderr, err)
+	exitCode = 2
+}
+
+func visitFile(path string, f fs.DirEntry, err error) error {
+	if err == nil && isGoFile(f) {
+		err = processFile(path, false)
+	}
+	if err != nil {
+		report(err)
+	}
+	return nil
+}
+
+func isGoFile(f fs.DirEntry) bool {
+	// ignore non-Go files
+	name := f.Name()
+	return !f.IsDir() && !strings.HasPrefix(name, ".") &&
+		(strings.HasSuffix(name, ".go") || strings.HasSuffix(name, ".goo"))
+}
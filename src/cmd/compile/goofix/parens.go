@@ -0,0 +1,79 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"go/scanner"
+	"go/token"
+
+	"cmd/compile/internal/syntax"
+)
+
+func init() {
+	registerGoo(gooParensFix)
+}
+
+var gooParensFix = gooFix{
+	name: "parens",
+	desc: `Reversed, add the parentheses to goo call statements without them:
+f x, y becomes f(x, y). Calls keep their parentheses in goo.`,
+	fix:     func(*gooFile) bool { return false },
+	reverse: gooParens,
+}
+
+func gooParens(f *gooFile) bool {
+	// The type checker rewrites put calls, so look at the calls as written.
+	file, err := syntax.Parse(syntax.NewFileBase(f.name), bytes.NewReader(f.src), nil, nil, 0)
+	if err != nil {
+		return false
+	}
+	fixed := false
+	syntax.Inspect(file, func(n syntax.Node) bool {
+		call, ok := n.(*syntax.CallExpr)
+		if !ok || !call.NoParens {
+			return true
+		}
+		arg := f.offset(syntax.StartPos(call.ArgList[0]))
+		f.replace(f.offset(syntax.EndPos(call.Fun)), arg, "(")
+		end := f.stmtEnd(arg)
+		f.replace(end, end, ")")
+		fixed = true
+		return true
+	})
+	return fixed
+}
+
+// stmtEnd returns the offset of the end of the simple statement
+// continuing at offset off.
+func (f *gooFile) stmtEnd(off int) int {
+	src := f.src[off:]
+	file := token.NewFileSet().AddFile("", -1, len(src))
+	var s scanner.Scanner
+	s.Init(file, src, nil, 0)
+	depth, end := 0, 0
+	for {
+		pos, tok, lit := s.Scan()
+		switch tok {
+		case token.EOF:
+			return off + end
+		case token.SEMICOLON:
+			if depth == 0 {
+				return off + end
+			}
+		case token.LPAREN, token.LBRACK, token.LBRACE:
+			depth++
+		case token.RPAREN, token.RBRACK, token.RBRACE:
+			if depth == 0 {
+				return off + end
+			}
+			depth--
+		}
+		if lit == "" {
+			lit = tok.String()
+		}
+		end = file.Offset(pos) + len(lit)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"strconv"
+
+	"cmd/compile/internal/syntax"
+	"cmd/compile/internal/types2"
+)
+
+func init() {
+	registerGoo(gooPutFix)
+}
+
+var gooPutFix = gooFix{
+	name: "put",
+	desc: `Rewrite fmt.Println(x) statements to put(x).
+Reversed, put calls become fmt.Println calls, importing fmt if needed.`,
+	fix:     gooPut,
+	reverse: gooUnput,
+}
+
+func gooPut(f *gooFile) bool {
+	if f.pkg == nil {
+		return false
+	}
+	// put must not be declared by the program.
+	for name := range f.info.Defs {
+		if name.Value == "put" {
+			return false
+		}
+	}
+	fixed := false
+	syntax.Inspect(f.file, func(n syntax.Node) bool {
+		s, ok := n.(*syntax.ExprStmt)
+		if !ok {
+			return true
+		}
+		call, ok := s.X.(*syntax.CallExpr)
+		if !ok || len(call.ArgList) != 1 || call.HasDots {
+			return true
+		}
+		sel, ok := call.Fun.(*syntax.SelectorExpr)
+		if !ok || sel.Sel.Value != "Println" {
+			return true
+		}
+		pkg, ok := sel.X.(*syntax.Name)
+		if !ok {
+			return true
+		}
+		if obj, ok := f.info.Uses[pkg].(*types2.PkgName); !ok || obj.Imported().Path() != "fmt" {
+			return true
+		}
+		f.replace(f.offset(pkg.Pos()), f.offset(sel.Sel.Pos())+len("Println"), "put")
+		fixed = true
+		return true
+	})
+	return fixed
+}
+
+func gooUnput(f *gooFile) bool {
+	if len(f.puts) == 0 {
+		return false
+	}
+
+	// Find or add the import of fmt.
+	name := ""
+	for _, decl := range f.file.DeclList {
+		if imp, ok := decl.(*syntax.ImportDecl); ok && imp.Path != nil {
+			if path, _ := strconv.Unquote(imp.Path.Value); path == "fmt" {
+				switch {
+				case imp.LocalPkgName == nil:
+					name = "fmt"
+				case imp.LocalPkgName.Value == ".":
+					name = "."
+				case imp.LocalPkgName.Value != "_":
+					name = imp.LocalPkgName.Value
+				}
+			}
+		}
+	}
+	if name == "" {
+		name = "fmt"
+		f.addImport("fmt")
+	}
+	fun := name + ".Println"
+	if name == "." {
+		fun = "Println"
+	}
+
+	for _, put := range f.puts {
+		start := f.offset(put.Pos())
+		f.replace(start, start+len("put"), fun)
+	}
+	return true
+}
+
+// addImport adds an import of path after the package clause of f,
+// or at the top of a file without one.
+func (f *gooFile) addImport(path string) {
+	// The package clause of a goo script is implicit.
+	if pkg := f.offset(f.file.PkgName.Pos()); f.keywordBefore(pkg, "package", "") >= 0 {
+		end := f.lineEnd(pkg)
+		f.replace(end, end, "\nimport "+strconv.Quote(path)+"\n")
+		return
+	}
+	// Skip the #! line and # comments of a goo script.
+	off := 0
+	for off < len(f.src) && f.src[off] == '#' {
+		off = f.lineEnd(off)
+	}
+	f.replace(off, off, "import "+strconv.Quote(path)+"\n\n")
+}
@@ -0,0 +1,405 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file runs the rewrites on a file and implements the editing of
+// the source text they share.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"internal/diff"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"cmd/compile/internal/importer"
+	"cmd/compile/internal/syntax"
+	"cmd/compile/internal/types2"
+)
+
+// A gooFix is a rewrite between a Go idiom and its goo form.
+type gooFix struct {
+	name     string
+	desc     string
+	disabled bool // whether the rewrite runs only when named with -r
+	fix      func(*gooFile) bool
+	reverse  func(*gooFile) bool
+}
+
+var gooFixes []gooFix
+
+func registerGoo(f gooFix) {
+	gooFixes = append(gooFixes, f)
+}
+
+// A gooFile is a parsed and type-checked file being rewritten.
+// Rewrites record edits of its source, applied all at once.
+type gooFile struct {
+	name  string
+	src   []byte
+	lines []int // offsets of the line starts
+	file  *syntax.File
+	pkg   *types2.Package // nil if the file was not type-checked
+	info  *types2.Info
+	puts  []*syntax.Name // the names of put in calls of the put builtin
+	edits []gooEdit
+}
+
+// A gooEdit replaces src[start:end] by text.
+type gooEdit struct {
+	start, end int
+	text       string
+}
+
+func processFile(filename string, useStdin bool) error {
+	var src []byte
+	var err error
+	if useStdin {
+		src, err = io.ReadAll(os.Stdin)
+	} else {
+		src, err = os.ReadFile(filename)
+	}
+	if err != nil {
+		return err
+	}
+
+	newSrc, applied, err := gooRewrite(filename, src, *reverse)
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "%s: fixed %s\n", filename, strings.Join(applied, " "))
+
+	if *doDiff {
+		os.Stdout.Write(diff.Diff(filename, src, "fixed/"+filename, newSrc))
+		return nil
+	}
+	if useStdin {
+		os.Stdout.Write(newSrc)
+		return nil
+	}
+	return os.WriteFile(filename, newSrc, 0)
+}
+
+// gooRewrite applies the selected goo rewrites, or their reverse, to
+// src and returns the new source and the names of the rewrites applied.
+// Each rewrite sees the result of the previous ones. Reversed output
+// that is valid Go is gofmt-formatted.
+func gooRewrite(filename string, src []byte, reverse bool) ([]byte, []string, error) {
+	var applied []string
+	for _, fix := range gooFixes {
+		if allowed != nil && !allowed[fix.name] || allowed == nil && fix.disabled {
+			continue
+		}
+		f, err := parseGoo(filename, src)
+		if err != nil {
+			return nil, nil, err
+		}
+		rewrite := fix.fix
+		if reverse {
+			rewrite = fix.reverse
+		}
+		if !rewrite(f) {
+			continue
+		}
+		src = f.apply()
+		applied = append(applied, fix.name)
+	}
+	if reverse && len(applied) > 0 {
+		if _, err := parser.ParseFile(token.NewFileSet(), filename, src, parser.ParseComments); err == nil {
+			if out, err := format.Source(src); err == nil {
+				src = out
+			}
+		}
+	}
+	return src, applied, nil
+}
+
+// parseGoo parses and type-checks src. A .go file is checked with the
+// other .go files of its package in the same directory, a .goo file on
+// its own, as 'go run' builds it. Type errors are ignored: rewrites
+// that need a type skip expressions without one.
+func parseGoo(filename string, src []byte) (*gooFile, error) {
+	f := &gooFile{name: filename, src: src, lines: []int{0}}
+	for i, b := range src {
+		if b == '\n' {
+			f.lines = append(f.lines, i+1)
+		}
+	}
+	file, err := syntax.Parse(syntax.NewFileBase(filename), bytes.NewReader(src), nil, nil, syntax.CheckBranches)
+	if err != nil {
+		return nil, err
+	}
+	f.file = file
+
+	// The type checker rewrites put calls; note them first.
+	syntax.Inspect(file, func(n syntax.Node) bool {
+		if call, ok := n.(*syntax.CallExpr); ok {
+			if name, ok := call.Fun.(*syntax.Name); ok && name.Value == "put" {
+				f.puts = append(f.puts, name)
+			}
+		}
+		return true
+	})
+
+	files := []*syntax.File{file}
+	dir := filepath.Dir(filename)
+	if strings.HasSuffix(filename, ".go") {
+		files = append(files, gooSiblings(filename, file.PkgName.Value)...)
+	}
+	f.info = &types2.Info{
+		Types:  make(map[syntax.Expr]types2.TypeAndValue),
+		Defs:   make(map[*syntax.Name]types2.Object),
+		Uses:   make(map[*syntax.Name]types2.Object),
+		Scopes: make(map[syntax.Node]*types2.Scope),
+	}
+	conf := types2.Config{
+		Importer:           gooImporter(dir),
+		IgnoreBranchErrors: true, // parser already checked via syntax.CheckBranches mode
+		Sizes:              types2.SizesFor("gc", runtime.GOARCH),
+		EnableAlias:        true,
+		Error:              func(error) {},
+	}
+	func() {
+		defer func() {
+			// The type checker is not used to syntax trees with
+			// errors; rewrite what can be rewritten without types.
+			if recover() != nil {
+				f.pkg = nil
+			}
+		}()
+		f.pkg, _ = conf.Check(file.PkgName.Value, files, f.info)
+	}()
+
+	// Keep the put calls unless the program declares put.
+	for name := range f.info.Defs {
+		if name.Value == "put" || f.pkg == nil {
+			f.puts = nil
+		}
+	}
+	return f, nil
+}
+
+// gooSiblings parses the other .go files of package pkg in the
+// directory of filename that match the build context.
+func gooSiblings(filename, pkg string) []*syntax.File {
+	dir := filepath.Dir(filename)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var files []*syntax.File
+	for _, e := range entries {
+		name := e.Name()
+		path := filepath.Join(dir, name)
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || path == filepath.Clean(filename) {
+			continue
+		}
+		if ok, err := build.Default.MatchFile(dir, name); err != nil || !ok {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		file, err := syntax.Parse(syntax.NewFileBase(path), bytes.NewReader(data), nil, nil, 0)
+		if err == nil && file.PkgName.Value == pkg {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// offset returns the offset in f.src of pos.
+func (f *gooFile) offset(pos syntax.Pos) int {
+	return f.lines[pos.Line()-1] + int(pos.Col()) - 1
+}
+
+// text returns the source text from the start of x up to end.
+func (f *gooFile) text(x syntax.Node, end int) string {
+	return strings.TrimSpace(string(f.src[f.offset(syntax.StartPos(x)):end]))
+}
+
+// condEnd returns the offset of the end of the condition of an if or
+// for statement whose body starts at body.
+func (f *gooFile) condEnd(body *syntax.BlockStmt) int {
+	end := f.offset(body.Pos())
+	for end > 0 && isSpace(f.src[end-1]) {
+		end--
+	}
+	return end
+}
+
+// typeOf returns the type of x, or nil.
+func (f *gooFile) typeOf(x syntax.Expr) types2.Type {
+	if tv, ok := f.info.Types[x]; ok && tv.Type != nil && tv.Type != types2.Typ[types2.Invalid] {
+		return tv.Type
+	}
+	return nil
+}
+
+func (f *gooFile) replace(start, end int, text string) {
+	f.edits = append(f.edits, gooEdit{start, end, text})
+}
+
+// apply returns the source with the edits of f applied.
+// Overlapping deletions are merged; other edits overlapping
+// an earlier one are dropped.
+func (f *gooFile) apply() []byte {
+	slices.SortStableFunc(f.edits, func(a, b gooEdit) int { return a.start - b.start })
+	var buf bytes.Buffer
+	last := 0
+	for _, e := range f.edits {
+		if e.start < last {
+			if e.text != "" || e.end <= last {
+				continue
+			}
+			e.start = last
+		}
+		buf.Write(f.src[last:e.start])
+		buf.WriteString(e.text)
+		last = e.end
+	}
+	buf.Write(f.src[last:])
+	// Deleting the last declarations leaves blank lines behind.
+	out := buf.Bytes()
+	for bytes.HasSuffix(out, []byte("\n\n")) {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+// keywordBefore returns the offset of the keyword kw preceding off,
+// separated from it by white space and any of the bytes in skip,
+// or -1 if there is none.
+func (f *gooFile) keywordBefore(off int, kw, skip string) int {
+	for off > 0 && (isSpace(f.src[off-1]) || strings.IndexByte(skip, f.src[off-1]) >= 0) {
+		off--
+	}
+	start := off - len(kw)
+	if start < 0 || string(f.src[start:off]) != kw || start > 0 && isIdentByte(f.src[start-1]) {
+		return -1
+	}
+	return start
+}
+
+// lineStart returns the offset of the start of the line holding off.
+func (f *gooFile) lineStart(off int) int {
+	return bytes.LastIndexByte(f.src[:off], '\n') + 1
+}
+
+// lineEnd returns the offset after the end of the line holding off.
+func (f *gooFile) lineEnd(off int) int {
+	if i := bytes.IndexByte(f.src[off:], '\n'); i >= 0 {
+		return off + i + 1
+	}
+	return len(f.src)
+}
+
+// deleteLines deletes the lines from the one holding start up to
+// the one holding end-1, together with the // comment lines right
+// above them and one blank line if they are between two blank lines.
+func (f *gooFile) deleteLines(start, end int) {
+	start = f.lineStart(start)
+	end = f.lineEnd(end - 1)
+	for start > 0 {
+		prev := f.lineStart(start - 1)
+		if !bytes.HasPrefix(bytes.TrimSpace(f.src[prev:start]), []byte("//")) {
+			break
+		}
+		start = prev
+	}
+	if start > 0 && len(bytes.TrimSpace(f.src[f.lineStart(start-1):start])) == 0 {
+		if next := f.lineEnd(end); end < len(f.src) && len(bytes.TrimSpace(f.src[end:next])) == 0 {
+			end = next
+		}
+	}
+	f.replace(start, end, "")
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+func isIdentByte(b byte) bool {
+	return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9' || b == '_' || b >= 0x80
+}
+
+// isNamed reports whether x is the name n.
+func isNamed(x syntax.Expr, n string) bool {
+	name, ok := x.(*syntax.Name)
+	return ok && name.Value == n
+}
+
+// gooImporters holds the importers by directory.
+var gooImporters = make(map[string]*exportImporter)
+
+func gooImporter(dir string) *exportImporter {
+	imp := gooImporters[dir]
+	if imp == nil {
+		imp = &exportImporter{
+			dir:      dir,
+			exports:  make(map[string]string),
+			packages: make(map[string]*types2.Package),
+		}
+		gooImporters[dir] = imp
+	}
+	return imp
+}
+
+// An exportImporter imports packages from the export data that
+// 'go list -export' builds, as seen from dir.
+type exportImporter struct {
+	dir      string
+	exports  map[string]string // import path to export data file
+	packages map[string]*types2.Package
+}
+
+func (imp *exportImporter) Import(path string) (*types2.Package, error) {
+	if path == "unsafe" {
+		return types2.Unsafe, nil
+	}
+	if _, ok := imp.exports[path]; !ok {
+		if err := imp.list(path); err != nil {
+			return nil, err
+		}
+	}
+	file := imp.exports[path]
+	if file == "" {
+		return nil, fmt.Errorf("could not import %s (no export data)", path)
+	}
+	return importer.Import(imp.packages, path, imp.dir, func(string) (io.ReadCloser, error) {
+		return os.Open(file)
+	})
+}
+
+// list records the export data files of path and its dependencies.
+func (imp *exportImporter) list(path string) error {
+	cmd := exec.Command(filepath.Join(build.Default.GOROOT, "bin", "go"), "list", "-e", "-export", "-deps", "-f", "{{.ImportPath}}\t{{.Export}}", "--", path)
+	cmd.Dir = imp.dir
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("could not import %s: %v", path, err)
+	}
+	for line := range strings.Lines(string(out)) {
+		pkg, file, _ := strings.Cut(strings.TrimSpace(line), "\t")
+		imp.exports[pkg] = file
+	}
+	if _, ok := imp.exports[path]; !ok {
+		imp.exports[path] = ""
+	}
+	return nil
+}
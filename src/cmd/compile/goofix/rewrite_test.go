@@ -0,0 +1,107 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"flag"
+	"internal/diff"
+	"internal/testenv"
+	"internal/txtar"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the goo rewrites")
+
+// TestGooRewrites checks the goo rewrites against the golden files in
+// testdata. Each is a txtar archive named after the rewrite it
+// tests, optionally followed by _suffix, or "all" for the rewrites
+// enabled by default. Its files are
+//
+//	go       a Go source file
+//	goo      the result of the rewrite of go
+//	reverse  the result of the reverse rewrite of goo, if not go
+//
+// An archive without a go file tests the reverse rewrite only.
+func TestGooRewrites(t *testing.T) {
+	testenv.MustHaveGoBuild(t) // for go list -export
+
+	files, err := filepath.Glob("testdata/*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(old map[string]bool) { allowed = old }(allowed)
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".txt")
+		t.Run(name, func(t *testing.T) {
+			allowed = nil
+			if fix, _, _ := strings.Cut(name, "_"); fix != "all" {
+				allowed = map[string]bool{fix: true}
+			}
+			ar, err := txtar.ParseFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := make(map[string]*txtar.File)
+			for i, f := range ar.Files {
+				want[f.Name] = &ar.Files[i]
+			}
+			if want["goo"] == nil || want["go"] == nil && want["reverse"] == nil {
+				t.Fatal("missing goo, go or reverse file")
+			}
+
+			if src := want["go"]; src != nil {
+				compareGolden(t, "rewrite", rewriteFile(t, src.Data, false), want["goo"])
+			}
+			got := rewriteFile(t, want["goo"].Data, true)
+			switch rev := want["reverse"]; {
+			case rev != nil:
+				compareGolden(t, "reverse rewrite", got, rev)
+			case *update && string(got) != string(want["go"].Data):
+				ar.Files = append(ar.Files, txtar.File{Name: "reverse", Data: got})
+			case !*update:
+				compareGolden(t, "reverse rewrite", got, want["go"])
+			}
+
+			if *update {
+				if err := os.WriteFile(file, txtar.Format(ar), 0666); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// rewriteFile applies the goo rewrites, or their reverse, to src
+// in a file of its own package: x.go, or x.goo for the reverse.
+func rewriteFile(t *testing.T, src []byte, reverse bool) []byte {
+	name := "x.go"
+	if reverse {
+		name = "x.goo"
+	}
+	filename := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(filename, src, 0666); err != nil {
+		t.Fatal(err)
+	}
+	out, _, err := gooRewrite(filename, src, reverse)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// compareGolden compares got with the golden file want, or updates it.
+func compareGolden(t *testing.T, what string, got []byte, want *txtar.File) {
+	t.Helper()
+	if *update {
+		want.Data = got
+		return
+	}
+	if string(got) != string(want.Data) {
+		t.Errorf("%s:\n%s", what, diff.Diff("want", want.Data, "got", got))
+	}
+}
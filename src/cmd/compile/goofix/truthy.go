@@ -0,0 +1,114 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"cmd/compile/internal/syntax"
+	"cmd/compile/internal/types2"
+)
+
+func init() {
+	registerGoo(gooTruthyFix)
+}
+
+var gooTruthyFix = gooFix{
+	name: "truthy",
+	desc: `Rewrite if x != nil to if x for pointers, maps, channels, functions
+and interfaces, whose goo truth is being non-nil. Reversed, if and for
+conditions that are not booleans are compared with the zero value of
+their type.`,
+	fix:     gooTruthy,
+	reverse: gooUntruthy,
+}
+
+// nilTruth reports whether a value of type t is true in goo
+// if and only if it is not nil.
+func nilTruth(t types2.Type) bool {
+	switch t := t.Underlying().(type) {
+	case *types2.Pointer, *types2.Map, *types2.Chan, *types2.Signature, *types2.Interface:
+		return true
+	case *types2.Basic:
+		return t.Kind() == types2.UnsafePointer
+	}
+	return false
+}
+
+func gooTruthy(f *gooFile) bool {
+	fixed := false
+	syntax.Inspect(f.file, func(n syntax.Node) bool {
+		s, ok := n.(*syntax.IfStmt)
+		if !ok {
+			return true
+		}
+		x, ok := s.Cond.(*syntax.Operation)
+		if !ok || x.Op != syntax.Neq || !isNamed(x.Y, "nil") {
+			return true
+		}
+		if _, ok := f.info.Uses[x.Y.(*syntax.Name)].(*types2.Nil); !ok {
+			return true
+		}
+		if t := f.typeOf(x.X); t == nil || !nilTruth(t) {
+			return true
+		}
+		f.replace(f.offset(syntax.StartPos(x)), f.condEnd(s.Then), f.text(x.X, f.offset(x.Pos())))
+		fixed = true
+		return true
+	})
+	return fixed
+}
+
+func gooUntruthy(f *gooFile) bool {
+	fixed := false
+	syntax.Inspect(f.file, func(n syntax.Node) bool {
+		var cond syntax.Expr
+		var body *syntax.BlockStmt
+		switch s := n.(type) {
+		case *syntax.IfStmt:
+			cond, body = s.Cond, s.Then
+		case *syntax.ForStmt:
+			if s.Post != nil {
+				return true // the condition does not end at the body
+			}
+			cond, body = s.Cond, s.Body
+		default:
+			return true
+		}
+		if cond == nil {
+			return true
+		}
+		t := f.typeOf(cond)
+		if t == nil {
+			return true
+		}
+		if b, ok := t.Underlying().(*types2.Basic); ok && b.Info()&types2.IsBoolean != 0 {
+			return true
+		}
+		text, zero := f.text(cond, f.condEnd(body)), ""
+		switch u := t.Underlying().(type) {
+		case *types2.Basic:
+			switch {
+			case u.Info()&types2.IsNumeric != 0:
+				zero = "0"
+			case u.Info()&types2.IsString != 0:
+				zero = `""`
+			case u.Kind() == types2.UnsafePointer:
+				zero = "nil"
+			}
+		case *types2.Slice:
+			text, zero = "len("+text+")", "0"
+		default:
+			if nilTruth(t) {
+				zero = "nil"
+			}
+		}
+		if zero == "" {
+			return true // structs and arrays are always true
+		}
+		f.replace(f.offset(syntax.StartPos(cond)), f.condEnd(body), text+" != "+zero)
+		fixed = true
+		return true
+	})
+	return fixed
+}
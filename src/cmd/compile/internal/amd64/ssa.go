@@ -1401,6 +1401,21 @@ func ssaGenValue(s *ssagen.State, v *ssa.Value) {
 		p6 := s.Prog(x86.AJNE)
 		p6.To.Type = obj.TYPE_BRANCH
 		p6.To.SetTarget(p1)
+	case ssa.OpAMD64LoweredVecOp32F, ssa.OpAMD64LoweredVecOp64F,
+		ssa.OpAMD64LoweredVecOpScalar32F, ssa.OpAMD64LoweredVecOpScalar64F,
+		ssa.OpAMD64LoweredVecSum32F, ssa.OpAMD64LoweredVecSum64F,
+		ssa.OpAMD64LoweredVecDot32F, ssa.OpAMD64LoweredVecDot64F,
+		ssa.OpAMD64LoweredVecOpAVX32F, ssa.OpAMD64LoweredVecOpAVX64F,
+		ssa.OpAMD64LoweredVecOpScalarAVX32F, ssa.OpAMD64LoweredVecOpScalarAVX64F,
+		ssa.OpAMD64LoweredVecSumAVX32F, ssa.OpAMD64LoweredVecSumAVX64F,
+		ssa.OpAMD64LoweredVecDotAVX32F, ssa.OpAMD64LoweredVecDotAVX64F,
+		ssa.OpAMD64LoweredVecOp8, ssa.OpAMD64LoweredVecOp16, ssa.OpAMD64LoweredVecOp32, ssa.OpAMD64LoweredVecOp64,
+		ssa.OpAMD64LoweredVecOpScalar8, ssa.OpAMD64LoweredVecOpScalar16, ssa.OpAMD64LoweredVecOpScalar32, ssa.OpAMD64LoweredVecOpScalar64,
+		ssa.OpAMD64LoweredVecSum8, ssa.OpAMD64LoweredVecSum16, ssa.OpAMD64LoweredVecSum32, ssa.OpAMD64LoweredVecSum64,
+		ssa.OpAMD64LoweredVecOpAVX8, ssa.OpAMD64LoweredVecOpAVX16, ssa.OpAMD64LoweredVecOpAVX32, ssa.OpAMD64LoweredVecOpAVX64,
+		ssa.OpAMD64LoweredVecOpScalarAVX8, ssa.OpAMD64LoweredVecOpScalarAVX16, ssa.OpAMD64LoweredVecOpScalarAVX32, ssa.OpAMD64LoweredVecOpScalarAVX64,
+		ssa.OpAMD64LoweredVecSumAVX8, ssa.OpAMD64LoweredVecSumAVX16, ssa.OpAMD64LoweredVecSumAVX32, ssa.OpAMD64LoweredVecSumAVX64:
+		ssaGenVec(s, v)
 	case ssa.OpAMD64PrefetchT0, ssa.OpAMD64PrefetchNTA:
 		p := s.Prog(v.Op.Asm())
 		p.From.Type = obj.TYPE_MEM
@@ -0,0 +1,564 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package amd64
+
+import (
+	"math/bits"
+
+	"cmd/compile/internal/ssa"
+	"cmd/compile/internal/ssagen"
+	"cmd/internal/obj"
+	"cmd/internal/obj/x86"
+)
+
+// vecBlock is the number of bytes each iteration of the SIMD loops
+// handles: four XMM or two YMM registers.
+const vecBlock = 64
+
+// vecInsns are the instructions for one operation of a VecOp: packed
+// SSE, packed AVX and scalar SSE, for float32 and float64 elements.
+var vecInsns = [...][2][3]obj.As{
+	ssa.VecAdd: {{x86.AADDPS, x86.AVADDPS, x86.AADDSS}, {x86.AADDPD, x86.AVADDPD, x86.AADDSD}},
+	ssa.VecSub: {{x86.ASUBPS, x86.AVSUBPS, x86.ASUBSS}, {x86.ASUBPD, x86.AVSUBPD, x86.ASUBSD}},
+	ssa.VecMul: {{x86.AMULPS, x86.AVMULPS, x86.AMULSS}, {x86.AMULPD, x86.AVMULPD, x86.AMULSD}},
+	ssa.VecDiv: {{x86.ADIVPS, x86.AVDIVPS, x86.ADIVSS}, {x86.ADIVPD, x86.AVDIVPD, x86.ADIVSD}},
+}
+
+// vecIntInsns are the packed SSE and AVX instructions for each VecKind
+// on integers of 1, 2, 4 and 8 bytes. Only 16 and 32 bit integers are
+// multiplied; PMULLD needs SSE4.1, so GOAMD64=v2.
+var vecIntInsns = [...][4][2]obj.As{
+	ssa.VecAdd: {{x86.APADDB, x86.AVPADDB}, {x86.APADDW, x86.AVPADDW}, {x86.APADDL, x86.AVPADDD}, {x86.APADDQ, x86.AVPADDQ}},
+	ssa.VecSub: {{x86.APSUBB, x86.AVPSUBB}, {x86.APSUBW, x86.AVPSUBW}, {x86.APSUBL, x86.AVPSUBD}, {x86.APSUBQ, x86.AVPSUBQ}},
+	ssa.VecMul: {{}, {x86.APMULLW, x86.AVPMULLW}, {x86.APMULLD, x86.AVPMULLD}, {}},
+}
+
+// vecGPInsns are the instructions on AX for the remaining integers of
+// 1, 2, 4 and 8 bytes: for each VecKind, and to load, store and negate.
+var vecGPInsns = [...][4]obj.As{
+	ssa.VecAdd: {x86.AADDB, x86.AADDW, x86.AADDL, x86.AADDQ},
+	ssa.VecSub: {x86.ASUBB, x86.ASUBW, x86.ASUBL, x86.ASUBQ},
+	ssa.VecMul: {0, x86.AIMULW, x86.AIMULL, x86.AIMULQ},
+}
+
+var (
+	vecGPLoad  = [4]obj.As{x86.AMOVBLZX, x86.AMOVWLZX, x86.AMOVL, x86.AMOVQ}
+	vecGPStore = [4]obj.As{x86.AMOVB, x86.AMOVW, x86.AMOVL, x86.AMOVQ}
+	vecGPNeg   = [4]obj.As{x86.ANEGB, x86.ANEGW, x86.ANEGL, x86.ANEGQ}
+	vecBcast   = [4]obj.As{x86.AVPBROADCASTB, x86.AVPBROADCASTW, x86.AVPBROADCASTD, x86.AVPBROADCASTQ}
+)
+
+// vecAsm emits the instructions of a SIMD loop, with the element type
+// and the instruction choices that depend on it.
+type vecAsm struct {
+	s       *ssagen.State
+	f64     bool
+	integer bool  // integer rather than float elements
+	size    int64 // element size in bytes
+	avx     bool
+}
+
+func (a *vecAsm) insn(k ssa.VecKind, i int) obj.As {
+	w := 0
+	if a.f64 {
+		w = 1
+	}
+	return vecInsns[k][w][i]
+}
+
+// lg returns the base 2 logarithm of the element size.
+func (a *vecAsm) lg() int {
+	return bits.TrailingZeros64(uint64(a.size))
+}
+
+func (a *vecAsm) packed(k ssa.VecKind) obj.As {
+	if a.integer {
+		if a.avx {
+			return vecIntInsns[k][a.lg()][1]
+		}
+		return vecIntInsns[k][a.lg()][0]
+	}
+	if a.avx {
+		return a.insn(k, 1)
+	}
+	return a.insn(k, 0)
+}
+
+func (a *vecAsm) scalar(k ssa.VecKind) obj.As { return a.insn(k, 2) }
+
+func (a *vecAsm) movScalar() obj.As {
+	if a.f64 {
+		return x86.AMOVSD
+	}
+	return x86.AMOVSS
+}
+
+// vreg returns the i'th vector register, X or Y depending on a.avx.
+func (a *vecAsm) vreg(i int) int16 {
+	if a.avx {
+		return x86.REG_Y0 + int16(i)
+	}
+	return x86.REG_X0 + int16(i)
+}
+
+// load emits MOVUPS off(base), dst.
+func (a *vecAsm) load(base int16, off int64, dst int16) {
+	as := x86.AMOVUPS
+	if a.avx {
+		as = x86.AVMOVUPS
+	}
+	p := a.s.Prog(as)
+	p.From.Type = obj.TYPE_MEM
+	p.From.Reg = base
+	p.From.Offset = off
+	p.To.Type = obj.TYPE_REG
+	p.To.Reg = dst
+}
+
+// store emits MOVUPS src, off(base).
+func (a *vecAsm) store(src, base int16, off int64) {
+	as := x86.AMOVUPS
+	if a.avx {
+		as = x86.AVMOVUPS
+	}
+	p := a.s.Prog(as)
+	p.From.Type = obj.TYPE_REG
+	p.From.Reg = src
+	p.To.Type = obj.TYPE_MEM
+	p.To.Reg = base
+	p.To.Offset = off
+}
+
+// op emits dst = x op y for a packed instruction, where y is the
+// register y or, if y is zero, the memory at off(base). Legacy SSE
+// instructions are two-operand, so x must be dst unless a.avx.
+func (a *vecAsm) op(as obj.As, dst, x, y, base int16, off int64) {
+	p := a.s.Prog(as)
+	if y != 0 {
+		p.From.Type = obj.TYPE_REG
+		p.From.Reg = y
+	} else {
+		p.From.Type = obj.TYPE_MEM
+		p.From.Reg = base
+		p.From.Offset = off
+	}
+	if a.avx {
+		p.AddRestSourceReg(x)
+	} else if x != dst {
+		panic("bad SSE operands")
+	}
+	p.To.Type = obj.TYPE_REG
+	p.To.Reg = dst
+}
+
+// gp emits as (base), AX, or as AX, (base) if store is set.
+func (a *vecAsm) gp(as obj.As, base int16, store bool) {
+	p := a.s.Prog(as)
+	mem, reg := &p.From, &p.To
+	if store {
+		mem, reg = reg, mem
+	}
+	mem.Type = obj.TYPE_MEM
+	mem.Reg = base
+	reg.Type = obj.TYPE_REG
+	reg.Reg = x86.REG_AX
+}
+
+// addConst emits ADDQ $c, r.
+func (a *vecAsm) addConst(r int16, c int64) {
+	p := a.s.Prog(x86.AADDQ)
+	p.From.Type = obj.TYPE_CONST
+	p.From.Offset = c
+	p.To.Type = obj.TYPE_REG
+	p.To.Reg = r
+}
+
+// loop emits
+//
+//	loop:	CMPQ CX, $n
+//		JLT  exit
+//		body
+//		ADDQ $vecBlock, ptr for each ptr
+//		SUBQ $n, CX
+//		JMP  loop
+//	exit:
+//
+// where n is the number of elements in vecBlock bytes.
+func (a *vecAsm) loop(body func(), ptrs ...int16) {
+	n := vecBlock / a.size
+	top := a.s.Prog(x86.ACMPQ)
+	top.From.Type = obj.TYPE_REG
+	top.From.Reg = x86.REG_CX
+	top.To.Type = obj.TYPE_CONST
+	top.To.Offset = n
+	exit := a.s.Prog(x86.AJLT)
+	exit.To.Type = obj.TYPE_BRANCH
+	body()
+	for _, r := range ptrs {
+		a.addConst(r, vecBlock)
+	}
+	p := a.s.Prog(x86.ASUBQ)
+	p.From.Type = obj.TYPE_CONST
+	p.From.Offset = n
+	p.To.Type = obj.TYPE_REG
+	p.To.Reg = x86.REG_CX
+	j := a.s.Prog(obj.AJMP)
+	j.To.Type = obj.TYPE_BRANCH
+	j.To.SetTarget(top)
+	exit.To.SetTarget(a.s.Pc())
+}
+
+// tail emits the loop over the remaining CX elements, one at a time:
+//
+//	tail:	TESTQ CX, CX
+//		JEQ   done
+//		body
+//		ADDQ  $size, ptr for each ptr
+//		DECQ  CX
+//		JMP   tail
+//	done:
+func (a *vecAsm) tail(body func(), ptrs ...int16) {
+	top := opregreg(a.s, x86.ATESTQ, x86.REG_CX, x86.REG_CX)
+	done := a.s.Prog(x86.AJEQ)
+	done.To.Type = obj.TYPE_BRANCH
+	body()
+	for _, r := range ptrs {
+		a.addConst(r, a.size)
+	}
+	p := a.s.Prog(x86.ADECQ)
+	p.To.Type = obj.TYPE_REG
+	p.To.Reg = x86.REG_CX
+	j := a.s.Prog(obj.AJMP)
+	j.To.Type = obj.TYPE_BRANCH
+	j.To.SetTarget(top)
+	done.To.SetTarget(a.s.Pc())
+}
+
+// vzeroupper emits VZEROUPPER after AVX instructions, to avoid the
+// penalty for mixing them with legacy SSE ones.
+func (a *vecAsm) vzeroupper() {
+	if a.avx {
+		a.s.Prog(x86.AVZEROUPPER)
+	}
+}
+
+// ssaGenVec emits the SIMD loop of a LoweredVec op. The arguments are
+// in DI (dst), SI (x), DX (y) or X8 (scalar y of floats) or DX (scalar
+// y of integers), and CX (n), as fixed by the op's regInfo.
+func ssaGenVec(s *ssagen.State, v *ssa.Value) {
+	a := &vecAsm{s: s, size: 4}
+	switch v.Op {
+	case ssa.OpAMD64LoweredVecOp64F, ssa.OpAMD64LoweredVecOpScalar64F, ssa.OpAMD64LoweredVecSum64F, ssa.OpAMD64LoweredVecDot64F,
+		ssa.OpAMD64LoweredVecOpAVX64F, ssa.OpAMD64LoweredVecOpScalarAVX64F, ssa.OpAMD64LoweredVecSumAVX64F, ssa.OpAMD64LoweredVecDotAVX64F:
+		a.f64, a.size = true, 8
+	case ssa.OpAMD64LoweredVecOp8, ssa.OpAMD64LoweredVecOpScalar8, ssa.OpAMD64LoweredVecSum8,
+		ssa.OpAMD64LoweredVecOpAVX8, ssa.OpAMD64LoweredVecOpScalarAVX8, ssa.OpAMD64LoweredVecSumAVX8:
+		a.integer, a.size = true, 1
+	case ssa.OpAMD64LoweredVecOp16, ssa.OpAMD64LoweredVecOpScalar16, ssa.OpAMD64LoweredVecSum16,
+		ssa.OpAMD64LoweredVecOpAVX16, ssa.OpAMD64LoweredVecOpScalarAVX16, ssa.OpAMD64LoweredVecSumAVX16:
+		a.integer, a.size = true, 2
+	case ssa.OpAMD64LoweredVecOp32, ssa.OpAMD64LoweredVecOpScalar32, ssa.OpAMD64LoweredVecSum32,
+		ssa.OpAMD64LoweredVecOpAVX32, ssa.OpAMD64LoweredVecOpScalarAVX32, ssa.OpAMD64LoweredVecSumAVX32:
+		a.integer, a.size = true, 4
+	case ssa.OpAMD64LoweredVecOp64, ssa.OpAMD64LoweredVecOpScalar64, ssa.OpAMD64LoweredVecSum64,
+		ssa.OpAMD64LoweredVecOpAVX64, ssa.OpAMD64LoweredVecOpScalarAVX64, ssa.OpAMD64LoweredVecSumAVX64:
+		a.integer, a.size = true, 8
+	}
+	switch v.Op {
+	case ssa.OpAMD64LoweredVecOpAVX32F, ssa.OpAMD64LoweredVecOpAVX64F,
+		ssa.OpAMD64LoweredVecOpScalarAVX32F, ssa.OpAMD64LoweredVecOpScalarAVX64F,
+		ssa.OpAMD64LoweredVecSumAVX32F, ssa.OpAMD64LoweredVecSumAVX64F,
+		ssa.OpAMD64LoweredVecDotAVX32F, ssa.OpAMD64LoweredVecDotAVX64F,
+		ssa.OpAMD64LoweredVecOpAVX8, ssa.OpAMD64LoweredVecOpAVX16, ssa.OpAMD64LoweredVecOpAVX32, ssa.OpAMD64LoweredVecOpAVX64,
+		ssa.OpAMD64LoweredVecOpScalarAVX8, ssa.OpAMD64LoweredVecOpScalarAVX16, ssa.OpAMD64LoweredVecOpScalarAVX32, ssa.OpAMD64LoweredVecOpScalarAVX64,
+		ssa.OpAMD64LoweredVecSumAVX8, ssa.OpAMD64LoweredVecSumAVX16, ssa.OpAMD64LoweredVecSumAVX32, ssa.OpAMD64LoweredVecSumAVX64:
+		a.avx = true
+	}
+	switch v.Op {
+	case ssa.OpAMD64LoweredVecOp32F, ssa.OpAMD64LoweredVecOp64F, ssa.OpAMD64LoweredVecOpAVX32F, ssa.OpAMD64LoweredVecOpAVX64F,
+		ssa.OpAMD64LoweredVecOp8, ssa.OpAMD64LoweredVecOp16, ssa.OpAMD64LoweredVecOp32, ssa.OpAMD64LoweredVecOp64,
+		ssa.OpAMD64LoweredVecOpAVX8, ssa.OpAMD64LoweredVecOpAVX16, ssa.OpAMD64LoweredVecOpAVX32, ssa.OpAMD64LoweredVecOpAVX64:
+		a.vecOp(ssa.VecKind(v.AuxInt))
+	case ssa.OpAMD64LoweredVecOpScalar32F, ssa.OpAMD64LoweredVecOpScalar64F, ssa.OpAMD64LoweredVecOpScalarAVX32F, ssa.OpAMD64LoweredVecOpScalarAVX64F,
+		ssa.OpAMD64LoweredVecOpScalar8, ssa.OpAMD64LoweredVecOpScalar16, ssa.OpAMD64LoweredVecOpScalar32, ssa.OpAMD64LoweredVecOpScalar64,
+		ssa.OpAMD64LoweredVecOpScalarAVX8, ssa.OpAMD64LoweredVecOpScalarAVX16, ssa.OpAMD64LoweredVecOpScalarAVX32, ssa.OpAMD64LoweredVecOpScalarAVX64:
+		a.vecOpScalar(ssa.VecKind(v.AuxInt))
+	case ssa.OpAMD64LoweredVecSum32F, ssa.OpAMD64LoweredVecSum64F, ssa.OpAMD64LoweredVecSumAVX32F, ssa.OpAMD64LoweredVecSumAVX64F,
+		ssa.OpAMD64LoweredVecSum8, ssa.OpAMD64LoweredVecSum16, ssa.OpAMD64LoweredVecSum32, ssa.OpAMD64LoweredVecSum64,
+		ssa.OpAMD64LoweredVecSumAVX8, ssa.OpAMD64LoweredVecSumAVX16, ssa.OpAMD64LoweredVecSumAVX32, ssa.OpAMD64LoweredVecSumAVX64:
+		a.vecReduce(false)
+	case ssa.OpAMD64LoweredVecDot32F, ssa.OpAMD64LoweredVecDot64F, ssa.OpAMD64LoweredVecDotAVX32F, ssa.OpAMD64LoweredVecDotAVX64F:
+		a.vecReduce(true)
+	default:
+		v.Fatalf("bad vec op %s", v.LongString())
+	}
+}
+
+// vecOp stores x[i] op y[i] into dst[i].
+func (a *vecAsm) vecOp(k ssa.VecKind) {
+	regs := vecBlock / 16
+	if a.avx {
+		regs = vecBlock / 32
+	}
+	width := int64(vecBlock / regs)
+	a.loop(func() {
+		for i := range regs {
+			a.load(x86.REG_SI, int64(i)*width, a.vreg(i))
+		}
+		for i := range regs {
+			if a.avx {
+				a.op(a.packed(k), a.vreg(i), a.vreg(i), 0, x86.REG_DX, int64(i)*width)
+				continue
+			}
+			// Legacy SSE needs aligned memory operands.
+			a.load(x86.REG_DX, int64(i)*width, a.vreg(4+i))
+			a.op(a.packed(k), a.vreg(i), a.vreg(i), a.vreg(4+i), 0, 0)
+		}
+		for i := range regs {
+			a.store(a.vreg(i), x86.REG_DI, int64(i)*width)
+		}
+	}, x86.REG_DI, x86.REG_SI, x86.REG_DX)
+	a.vzeroupper()
+	a.tail(func() {
+		if a.integer {
+			a.gp(vecGPLoad[a.lg()], x86.REG_SI, false)
+			a.gp(vecGPInsns[k][a.lg()], x86.REG_DX, false)
+			a.gp(vecGPStore[a.lg()], x86.REG_DI, true)
+			return
+		}
+		p := a.s.Prog(a.movScalar())
+		p.From.Type = obj.TYPE_MEM
+		p.From.Reg = x86.REG_SI
+		p.To.Type = obj.TYPE_REG
+		p.To.Reg = x86.REG_X0
+		p = a.s.Prog(a.scalar(k))
+		p.From.Type = obj.TYPE_MEM
+		p.From.Reg = x86.REG_DX
+		p.To.Type = obj.TYPE_REG
+		p.To.Reg = x86.REG_X0
+		p = a.s.Prog(a.movScalar())
+		p.From.Type = obj.TYPE_REG
+		p.From.Reg = x86.REG_X0
+		p.To.Type = obj.TYPE_MEM
+		p.To.Reg = x86.REG_DI
+	}, x86.REG_DI, x86.REG_SI, x86.REG_DX)
+}
+
+// vecOpScalar stores x[i] op s into dst[i], or s op x[i] for the
+// reversed kinds, where s is in X8, or in DX for integers.
+func (a *vecAsm) vecOpScalar(k ssa.VecKind) {
+	rev := false
+	switch k {
+	case ssa.VecSubRev:
+		k, rev = ssa.VecSub, true
+	case ssa.VecDivRev:
+		k, rev = ssa.VecDiv, true
+	}
+
+	// Broadcast s to every lane of X8, or Y8.
+	switch {
+	case a.integer:
+		opregreg(a.s, x86.AMOVQ, x86.REG_X8, x86.REG_DX)
+		if a.avx {
+			opregreg(a.s, vecBcast[a.lg()], x86.REG_Y8, x86.REG_X8)
+			break
+		}
+		if a.size == 1 {
+			opregreg(a.s, x86.APUNPCKLBW, x86.REG_X8, x86.REG_X8)
+		}
+		if a.size <= 2 {
+			opregreg(a.s, x86.APUNPCKLWL, x86.REG_X8, x86.REG_X8)
+		}
+		if a.size <= 4 {
+			p := a.s.Prog(x86.APSHUFD)
+			p.From.Type = obj.TYPE_CONST
+			p.From.Offset = 0
+			p.AddRestSourceReg(x86.REG_X8)
+			p.To.Type = obj.TYPE_REG
+			p.To.Reg = x86.REG_X8
+		} else {
+			opregreg(a.s, x86.APUNPCKLQDQ, x86.REG_X8, x86.REG_X8)
+		}
+	case a.avx:
+		as := x86.AVBROADCASTSS
+		if a.f64 {
+			as = x86.AVBROADCASTSD
+		}
+		opregreg(a.s, as, x86.REG_Y8, x86.REG_X8)
+	case a.f64:
+		opregreg(a.s, x86.AUNPCKLPD, x86.REG_X8, x86.REG_X8)
+	default:
+		opregreg(a.s, x86.AUNPCKLPS, x86.REG_X8, x86.REG_X8)
+		opregreg(a.s, x86.AMOVLHPS, x86.REG_X8, x86.REG_X8)
+	}
+
+	regs := vecBlock / 16
+	if a.avx {
+		regs = vecBlock / 32
+	}
+	width := int64(vecBlock / regs)
+	a.loop(func() {
+		for i := range regs {
+			a.load(x86.REG_SI, int64(i)*width, a.vreg(i))
+		}
+		for i := range regs {
+			switch {
+			case !rev:
+				a.op(a.packed(k), a.vreg(i), a.vreg(i), a.vreg(8), 0, 0)
+			case a.avx:
+				a.op(a.packed(k), a.vreg(i), a.vreg(8), a.vreg(i), 0, 0)
+			default:
+				opregreg(a.s, x86.AMOVAPS, a.vreg(4+i), x86.REG_X8)
+				a.op(a.packed(k), a.vreg(4+i), a.vreg(4+i), a.vreg(i), 0, 0)
+				opregreg(a.s, x86.AMOVAPS, a.vreg(i), a.vreg(4+i))
+			}
+		}
+		for i := range regs {
+			a.store(a.vreg(i), x86.REG_DI, int64(i)*width)
+		}
+	}, x86.REG_DI, x86.REG_SI)
+	a.vzeroupper()
+	a.tail(func() {
+		if a.integer {
+			a.gp(vecGPLoad[a.lg()], x86.REG_SI, false)
+			opregreg(a.s, vecGPInsns[k][a.lg()], x86.REG_AX, x86.REG_DX)
+			if rev {
+				p := a.s.Prog(vecGPNeg[a.lg()])
+				p.To.Type = obj.TYPE_REG
+				p.To.Reg = x86.REG_AX
+			}
+			a.gp(vecGPStore[a.lg()], x86.REG_DI, true)
+			return
+		}
+		p := a.s.Prog(a.movScalar())
+		p.From.Type = obj.TYPE_MEM
+		p.From.Reg = x86.REG_SI
+		p.To.Type = obj.TYPE_REG
+		p.To.Reg = x86.REG_X0
+		if rev {
+			opregreg(a.s, x86.AMOVAPS, x86.REG_X1, x86.REG_X8)
+			opregreg(a.s, a.scalar(k), x86.REG_X1, x86.REG_X0)
+			opregreg(a.s, x86.AMOVAPS, x86.REG_X0, x86.REG_X1)
+		} else {
+			opregreg(a.s, a.scalar(k), x86.REG_X0, x86.REG_X8)
+		}
+		p = a.s.Prog(a.movScalar())
+		p.From.Type = obj.TYPE_REG
+		p.From.Reg = x86.REG_X0
+		p.To.Type = obj.TYPE_MEM
+		p.To.Reg = x86.REG_DI
+	}, x86.REG_DI, x86.REG_SI)
+}
+
+// vecReduce leaves the sum of x[i], or of x[i]*y[i] if dot is set, in
+// X0, or the sum of integers in AX. The loop accumulates into one
+// register per vector of the block, which are then added together, and
+// then the lanes of X0.
+func (a *vecAsm) vecReduce(dot bool) {
+	regs := vecBlock / 16
+	if a.avx {
+		regs = vecBlock / 32
+	}
+	width := int64(vecBlock / regs)
+	xorps := x86.AXORPS
+	if a.avx {
+		xorps = x86.AVXORPS
+	}
+	for i := range regs {
+		p := opregreg(a.s, xorps, a.vreg(i), a.vreg(i))
+		if a.avx {
+			p.AddRestSourceReg(a.vreg(i))
+		}
+	}
+	ptrs := []int16{x86.REG_SI}
+	if dot {
+		ptrs = append(ptrs, x86.REG_DX)
+	}
+	a.loop(func() {
+		// Legacy SSE needs aligned memory operands, so x[i], and
+		// y[i] in X5, go through the temporary X4.
+		for i := range regs {
+			t := a.vreg(4 + i)
+			if !a.avx {
+				t = x86.REG_X4
+			}
+			a.load(x86.REG_SI, int64(i)*width, t)
+			if dot {
+				if a.avx {
+					a.op(a.packed(ssa.VecMul), t, t, 0, x86.REG_DX, int64(i)*width)
+				} else {
+					a.load(x86.REG_DX, int64(i)*width, x86.REG_X5)
+					a.op(a.packed(ssa.VecMul), t, t, x86.REG_X5, 0, 0)
+				}
+			}
+			a.op(a.packed(ssa.VecAdd), a.vreg(i), a.vreg(i), t, 0, 0)
+		}
+	}, ptrs...)
+
+	// Add up the accumulators, then the lanes of X0.
+	for n := regs; n > 1; n /= 2 {
+		for i := range n / 2 {
+			a.op(a.packed(ssa.VecAdd), a.vreg(i), a.vreg(i), a.vreg(i+n/2), 0, 0)
+		}
+	}
+	if a.avx {
+		p := a.s.Prog(x86.AVEXTRACTF128)
+		p.From.Type = obj.TYPE_CONST
+		p.From.Offset = 1
+		p.AddRestSourceReg(x86.REG_Y0)
+		p.To.Type = obj.TYPE_REG
+		p.To.Reg = x86.REG_X1
+		a.vzeroupper()
+		a.avx = false
+		a.op(a.packed(ssa.VecAdd), x86.REG_X0, x86.REG_X0, x86.REG_X1, 0, 0)
+	}
+	if a.integer {
+		// Add the upper half of the lanes to the lower half, until
+		// one is left.
+		for shift := int64(8); shift >= a.size; shift /= 2 {
+			opregreg(a.s, x86.AMOVAPS, x86.REG_X1, x86.REG_X0)
+			p := a.s.Prog(x86.APSRLO)
+			p.From.Type = obj.TYPE_CONST
+			p.From.Offset = shift
+			p.To.Type = obj.TYPE_REG
+			p.To.Reg = x86.REG_X1
+			a.op(a.packed(ssa.VecAdd), x86.REG_X0, x86.REG_X0, x86.REG_X1, 0, 0)
+		}
+		opregreg(a.s, x86.AMOVQ, x86.REG_AX, x86.REG_X0)
+		a.tail(func() {
+			a.gp(vecGPInsns[ssa.VecAdd][a.lg()], x86.REG_SI, false)
+		}, ptrs...)
+		return
+	}
+	opregreg(a.s, x86.AMOVHLPS, x86.REG_X1, x86.REG_X0)
+	if a.f64 {
+		opregreg(a.s, x86.AADDSD, x86.REG_X0, x86.REG_X1)
+	} else {
+		opregreg(a.s, x86.AADDPS, x86.REG_X0, x86.REG_X1)
+		opregreg(a.s, x86.AMOVAPS, x86.REG_X1, x86.REG_X0)
+		p := a.s.Prog(x86.APSRLQ)
+		p.From.Type = obj.TYPE_CONST
+		p.From.Offset = 32
+		p.To.Type = obj.TYPE_REG
+		p.To.Reg = x86.REG_X1
+		opregreg(a.s, x86.AADDSS, x86.REG_X0, x86.REG_X1)
+	}
+
+	a.tail(func() {
+		p := a.s.Prog(a.movScalar())
+		p.From.Type = obj.TYPE_MEM
+		p.From.Reg = x86.REG_SI
+		p.To.Type = obj.TYPE_REG
+		p.To.Reg = x86.REG_X1
+		if dot {
+			p = a.s.Prog(a.scalar(ssa.VecMul))
+			p.From.Type = obj.TYPE_MEM
+			p.From.Reg = x86.REG_DX
+			p.To.Type = obj.TYPE_REG
+			p.To.Reg = x86.REG_X1
+		}
+		opregreg(a.s, a.scalar(ssa.VecAdd), x86.REG_X0, x86.REG_X1)
+	}, ptrs...)
+}
@@ -1169,6 +1169,14 @@ func ssaGenValue(s *ssagen.State, v *ssa.Value) {
 		p.From.Offset = int64(condCode)
 		p.To.Type = obj.TYPE_REG
 		p.To.Reg = v.Reg()
+	case ssa.OpARM64LoweredVecOp32F, ssa.OpARM64LoweredVecOp64F,
+		ssa.OpARM64LoweredVecOpScalar32F, ssa.OpARM64LoweredVecOpScalar64F,
+		ssa.OpARM64LoweredVecSum32F, ssa.OpARM64LoweredVecSum64F,
+		ssa.OpARM64LoweredVecDot32F, ssa.OpARM64LoweredVecDot64F,
+		ssa.OpARM64LoweredVecOp8, ssa.OpARM64LoweredVecOp16, ssa.OpARM64LoweredVecOp32, ssa.OpARM64LoweredVecOp64,
+		ssa.OpARM64LoweredVecOpScalar8, ssa.OpARM64LoweredVecOpScalar16, ssa.OpARM64LoweredVecOpScalar32, ssa.OpARM64LoweredVecOpScalar64,
+		ssa.OpARM64LoweredVecSum8, ssa.OpARM64LoweredVecSum16, ssa.OpARM64LoweredVecSum32, ssa.OpARM64LoweredVecSum64:
+		ssaGenVec(s, v)
 	case ssa.OpARM64PRFM:
 		p := s.Prog(v.Op.Asm())
 		p.From.Type = obj.TYPE_MEM
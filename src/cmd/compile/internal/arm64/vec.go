@@ -0,0 +1,433 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package arm64
+
+import (
+	"math/bits"
+
+	"cmd/compile/internal/ssa"
+	"cmd/compile/internal/ssagen"
+	"cmd/internal/obj"
+	"cmd/internal/obj/arm64"
+)
+
+// vecBlock is the number of bytes each iteration of the SIMD loops
+// handles, in four vector registers.
+const vecBlock = 64
+
+// The assembler has no mnemonics for the vector floating-point
+// arithmetic, so the loops use the encodings, for the .4S and .2D
+// arrangements, of
+//
+//	OP Vd.<T>, Vn.<T>, Vm.<T>
+//
+// to be combined with the registers by vop.
+var vecWords = [...][2]uint32{
+	ssa.VecAdd: {0x4e20d400, 0x4e60d400}, // FADD
+	ssa.VecSub: {0x4ea0d400, 0x4ee0d400}, // FSUB
+	ssa.VecMul: {0x6e20dc00, 0x6e60dc00}, // FMUL
+	ssa.VecDiv: {0x6e20fc00, 0x6e60fc00}, // FDIV
+}
+
+const (
+	vecFADDP = 0x6e20d400 // FADDP Vd.4S, Vn.4S, Vm.4S; | 1<<22 for .2D
+	vecDUP   = 0x4e040400 // DUP Vd.4S, Vn.S[0]; ^ 0xc<<16 for DUP Vd.2D, Vn.D[0]
+)
+
+// vecIntWords are the encodings of the integer instructions for the
+// .16B arrangement, which are | lg<<22 for elements of 1<<lg bytes. MUL
+// has no .2D form, and there is no vector division.
+var vecIntWords = [...]uint32{
+	ssa.VecAdd: 0x4e208400, // ADD
+	ssa.VecSub: 0x6e208400, // SUB
+	ssa.VecMul: 0x4e209c00, // MUL
+}
+
+const (
+	vecADDP   = 0x4e20bc00 // ADDP Vd.16B, Vn.16B, Vm.16B; | lg<<22 as above
+	vecDUPGen = 0x4e000c00 // DUP Vd.16B, Wn; | 1<<(16+lg) as above
+)
+
+// vecGPInsns are the instructions on general registers for each
+// VecKind, for the remaining integers.
+var vecGPInsns = [...]obj.As{
+	ssa.VecAdd: arm64.AADD,
+	ssa.VecSub: arm64.ASUB,
+	ssa.VecMul: arm64.AMUL,
+}
+
+var (
+	vecGPLoad  = [4]obj.As{arm64.AMOVBU, arm64.AMOVHU, arm64.AMOVWU, arm64.AMOVD}
+	vecGPStore = [4]obj.As{arm64.AMOVB, arm64.AMOVH, arm64.AMOVW, arm64.AMOVD}
+)
+
+// vecScalarInsns are the scalar instructions for each VecKind, for
+// float32 and float64 elements.
+var vecScalarInsns = [...][2]obj.As{
+	ssa.VecAdd: {arm64.AFADDS, arm64.AFADDD},
+	ssa.VecSub: {arm64.AFSUBS, arm64.AFSUBD},
+	ssa.VecMul: {arm64.AFMULS, arm64.AFMULD},
+	ssa.VecDiv: {arm64.AFDIVS, arm64.AFDIVD},
+}
+
+// vecAsm emits the instructions of a SIMD loop, with the element type
+// and the instruction choices that depend on it.
+type vecAsm struct {
+	s       *ssagen.State
+	f64     bool
+	integer bool  // integer rather than float elements
+	size    int64 // element size in bytes
+}
+
+func (a *vecAsm) w() int {
+	if a.f64 {
+		return 1
+	}
+	return 0
+}
+
+// lg returns the base 2 logarithm of the element size.
+func (a *vecAsm) lg() int {
+	return bits.TrailingZeros64(uint64(a.size))
+}
+
+// enc returns the encoding of the vector instruction for k.
+func (a *vecAsm) enc(k ssa.VecKind) uint32 {
+	if a.integer {
+		return vecIntWords[k] | uint32(a.lg())<<22
+	}
+	return vecWords[k][a.w()]
+}
+
+// word emits the instruction enc as a WORD.
+func (a *vecAsm) word(enc uint32) {
+	p := a.s.Prog(arm64.AWORD)
+	p.To.Type = obj.TYPE_CONST
+	p.To.Offset = int64(enc)
+}
+
+// vop emits OP Vd, Vn, Vm for the encoding op, so Vd = Vn op Vm.
+func (a *vecAsm) vop(op uint32, d, n, m int16) {
+	a.word(op | vnum(m)<<16 | vnum(n)<<5 | vnum(d))
+}
+
+// vnum returns the number of the vector register of r, an F register.
+func vnum(r int16) uint32 {
+	return uint32(r-arm64.REG_F0) & 31
+}
+
+// pair emits FLDPQ.P 32(base), (r, r+1), or FSTPQ.P (r, r+1), 32(base)
+// if store is set.
+func (a *vecAsm) pair(store bool, base, r int16) {
+	as := arm64.AFLDPQ
+	if store {
+		as = arm64.AFSTPQ
+	}
+	p := a.s.Prog(as)
+	p.Scond = arm64.C_XPOST
+	mem, regs := &p.From, &p.To
+	if store {
+		mem, regs = regs, mem
+	}
+	mem.Type = obj.TYPE_MEM
+	mem.Reg = base
+	mem.Offset = 2 * 16
+	regs.Type = obj.TYPE_REGREG
+	regs.Reg = r
+	regs.Offset = int64(r + 1)
+}
+
+// scalar emits FMOVS.P size(base), r, or FMOVS.P r, size(base) if store
+// is set, or the FMOVD forms.
+func (a *vecAsm) scalar(store bool, base, r int16) {
+	as := arm64.AFMOVS
+	if a.f64 {
+		as = arm64.AFMOVD
+	}
+	p := a.s.Prog(as)
+	p.Scond = arm64.C_XPOST
+	mem, reg := &p.From, &p.To
+	if store {
+		mem, reg = reg, mem
+	}
+	mem.Type = obj.TYPE_MEM
+	mem.Reg = base
+	mem.Offset = a.size
+	reg.Type = obj.TYPE_REG
+	reg.Reg = r
+}
+
+// gp emits as.P size(base), r, or as.P r, size(base) if store is set,
+// for the general register r.
+func (a *vecAsm) gp(as obj.As, store bool, base, r int16) {
+	p := a.s.Prog(as)
+	p.Scond = arm64.C_XPOST
+	mem, reg := &p.From, &p.To
+	if store {
+		mem, reg = reg, mem
+	}
+	mem.Type = obj.TYPE_MEM
+	mem.Reg = base
+	mem.Offset = a.size
+	reg.Type = obj.TYPE_REG
+	reg.Reg = r
+}
+
+// gpop emits d = n op m on general registers.
+func (a *vecAsm) gpop(as obj.As, d, n, m int16) {
+	p := a.s.Prog(as)
+	p.From.Type = obj.TYPE_REG
+	p.From.Reg = m
+	p.Reg = n
+	p.To.Type = obj.TYPE_REG
+	p.To.Reg = d
+}
+
+// sop emits the scalar d = n op m.
+func (a *vecAsm) sop(k ssa.VecKind, d, n, m int16) {
+	p := a.s.Prog(vecScalarInsns[k][a.w()])
+	p.From.Type = obj.TYPE_REG
+	p.From.Reg = m
+	p.Reg = n
+	p.To.Type = obj.TYPE_REG
+	p.To.Reg = d
+}
+
+// loop emits
+//
+//	loop:	CMP  $n, R3
+//		BLT  exit
+//		body
+//		SUB  $n, R3
+//		B    loop
+//	exit:
+//
+// where n is the number of elements in vecBlock bytes. The body advances
+// the pointers.
+func (a *vecAsm) loop(body func()) {
+	n := vecBlock / a.size
+	top := a.s.Prog(arm64.ACMP)
+	top.From.Type = obj.TYPE_CONST
+	top.From.Offset = n
+	top.Reg = arm64.REG_R3
+	exit := a.s.Prog(arm64.ABLT)
+	exit.To.Type = obj.TYPE_BRANCH
+	body()
+	p := a.s.Prog(arm64.ASUB)
+	p.From.Type = obj.TYPE_CONST
+	p.From.Offset = n
+	p.To.Type = obj.TYPE_REG
+	p.To.Reg = arm64.REG_R3
+	j := a.s.Prog(obj.AJMP)
+	j.To.Type = obj.TYPE_BRANCH
+	j.To.SetTarget(top)
+	exit.To.SetTarget(a.s.Pc())
+}
+
+// tail emits the loop over the remaining R3 elements, one at a time:
+//
+//	tail:	CBZ  R3, done
+//		body
+//		SUB  $1, R3
+//		B    tail
+//	done:
+//
+// The body advances the pointers.
+func (a *vecAsm) tail(body func()) {
+	top := a.s.Prog(arm64.ACBZ)
+	top.From.Type = obj.TYPE_REG
+	top.From.Reg = arm64.REG_R3
+	top.To.Type = obj.TYPE_BRANCH
+	body()
+	p := a.s.Prog(arm64.ASUB)
+	p.From.Type = obj.TYPE_CONST
+	p.From.Offset = 1
+	p.To.Type = obj.TYPE_REG
+	p.To.Reg = arm64.REG_R3
+	j := a.s.Prog(obj.AJMP)
+	j.To.Type = obj.TYPE_BRANCH
+	j.To.SetTarget(top)
+	top.To.SetTarget(a.s.Pc())
+}
+
+// ssaGenVec emits the SIMD loop of a LoweredVec op. The arguments are
+// in R0 (dst), R1 (x), R2 (y) or F16 (scalar y of floats) or R2 (scalar
+// y of integers), and R3 (n), as fixed by the op's regInfo.
+func ssaGenVec(s *ssagen.State, v *ssa.Value) {
+	a := &vecAsm{s: s, size: 4}
+	switch v.Op {
+	case ssa.OpARM64LoweredVecOp64F, ssa.OpARM64LoweredVecOpScalar64F, ssa.OpARM64LoweredVecSum64F, ssa.OpARM64LoweredVecDot64F:
+		a.f64, a.size = true, 8
+	case ssa.OpARM64LoweredVecOp8, ssa.OpARM64LoweredVecOpScalar8, ssa.OpARM64LoweredVecSum8:
+		a.integer, a.size = true, 1
+	case ssa.OpARM64LoweredVecOp16, ssa.OpARM64LoweredVecOpScalar16, ssa.OpARM64LoweredVecSum16:
+		a.integer, a.size = true, 2
+	case ssa.OpARM64LoweredVecOp32, ssa.OpARM64LoweredVecOpScalar32, ssa.OpARM64LoweredVecSum32:
+		a.integer, a.size = true, 4
+	case ssa.OpARM64LoweredVecOp64, ssa.OpARM64LoweredVecOpScalar64, ssa.OpARM64LoweredVecSum64:
+		a.integer, a.size = true, 8
+	}
+	switch v.Op {
+	case ssa.OpARM64LoweredVecOp32F, ssa.OpARM64LoweredVecOp64F,
+		ssa.OpARM64LoweredVecOp8, ssa.OpARM64LoweredVecOp16, ssa.OpARM64LoweredVecOp32, ssa.OpARM64LoweredVecOp64:
+		a.vecOp(ssa.VecKind(v.AuxInt))
+	case ssa.OpARM64LoweredVecOpScalar32F, ssa.OpARM64LoweredVecOpScalar64F,
+		ssa.OpARM64LoweredVecOpScalar8, ssa.OpARM64LoweredVecOpScalar16, ssa.OpARM64LoweredVecOpScalar32, ssa.OpARM64LoweredVecOpScalar64:
+		a.vecOpScalar(ssa.VecKind(v.AuxInt))
+	case ssa.OpARM64LoweredVecSum32F, ssa.OpARM64LoweredVecSum64F,
+		ssa.OpARM64LoweredVecSum8, ssa.OpARM64LoweredVecSum16, ssa.OpARM64LoweredVecSum32, ssa.OpARM64LoweredVecSum64:
+		a.vecReduce(false)
+	case ssa.OpARM64LoweredVecDot32F, ssa.OpARM64LoweredVecDot64F:
+		a.vecReduce(true)
+	default:
+		v.Fatalf("bad vec op %s", v.LongString())
+	}
+}
+
+// vecOp stores x[i] op y[i] into dst[i].
+func (a *vecAsm) vecOp(k ssa.VecKind) {
+	a.loop(func() {
+		a.pair(false, arm64.REG_R1, arm64.REG_F0)
+		a.pair(false, arm64.REG_R1, arm64.REG_F2)
+		a.pair(false, arm64.REG_R2, arm64.REG_F4)
+		a.pair(false, arm64.REG_R2, arm64.REG_F6)
+		for i := range int16(4) {
+			a.vop(a.enc(k), arm64.REG_F0+i, arm64.REG_F0+i, arm64.REG_F4+i)
+		}
+		a.pair(true, arm64.REG_R0, arm64.REG_F0)
+		a.pair(true, arm64.REG_R0, arm64.REG_F2)
+	})
+	a.tail(func() {
+		if a.integer {
+			a.gp(vecGPLoad[a.lg()], false, arm64.REG_R1, arm64.REG_R4)
+			a.gp(vecGPLoad[a.lg()], false, arm64.REG_R2, arm64.REG_R5)
+			a.gpop(vecGPInsns[k], arm64.REG_R4, arm64.REG_R4, arm64.REG_R5)
+			a.gp(vecGPStore[a.lg()], true, arm64.REG_R0, arm64.REG_R4)
+			return
+		}
+		a.scalar(false, arm64.REG_R1, arm64.REG_F0)
+		a.scalar(false, arm64.REG_R2, arm64.REG_F4)
+		a.sop(k, arm64.REG_F0, arm64.REG_F0, arm64.REG_F4)
+		a.scalar(true, arm64.REG_R0, arm64.REG_F0)
+	})
+}
+
+// vecOpScalar stores x[i] op s into dst[i], or s op x[i] for the
+// reversed kinds, where s is in F16, or in R2 for integers.
+func (a *vecAsm) vecOpScalar(k ssa.VecKind) {
+	rev := false
+	switch k {
+	case ssa.VecSubRev:
+		k, rev = ssa.VecSub, true
+	case ssa.VecDivRev:
+		k, rev = ssa.VecDiv, true
+	}
+
+	// Broadcast s to every lane of V16.
+	switch {
+	case a.integer:
+		a.word(vecDUPGen | 1<<(16+a.lg()) | uint32(arm64.REG_R2&31)<<5 | vnum(arm64.REG_F16))
+	case a.f64:
+		a.word(vecDUP ^ 0xc<<16 | vnum(arm64.REG_F16)<<5 | vnum(arm64.REG_F16))
+	default:
+		a.word(vecDUP | vnum(arm64.REG_F16)<<5 | vnum(arm64.REG_F16))
+	}
+
+	a.loop(func() {
+		a.pair(false, arm64.REG_R1, arm64.REG_F0)
+		a.pair(false, arm64.REG_R1, arm64.REG_F2)
+		for i := range int16(4) {
+			if rev {
+				a.vop(a.enc(k), arm64.REG_F0+i, arm64.REG_F16, arm64.REG_F0+i)
+			} else {
+				a.vop(a.enc(k), arm64.REG_F0+i, arm64.REG_F0+i, arm64.REG_F16)
+			}
+		}
+		a.pair(true, arm64.REG_R0, arm64.REG_F0)
+		a.pair(true, arm64.REG_R0, arm64.REG_F2)
+	})
+	a.tail(func() {
+		if a.integer {
+			a.gp(vecGPLoad[a.lg()], false, arm64.REG_R1, arm64.REG_R4)
+			if rev {
+				a.gpop(vecGPInsns[k], arm64.REG_R4, arm64.REG_R2, arm64.REG_R4)
+			} else {
+				a.gpop(vecGPInsns[k], arm64.REG_R4, arm64.REG_R4, arm64.REG_R2)
+			}
+			a.gp(vecGPStore[a.lg()], true, arm64.REG_R0, arm64.REG_R4)
+			return
+		}
+		a.scalar(false, arm64.REG_R1, arm64.REG_F0)
+		if rev {
+			a.sop(k, arm64.REG_F0, arm64.REG_F16, arm64.REG_F0)
+		} else {
+			a.sop(k, arm64.REG_F0, arm64.REG_F0, arm64.REG_F16)
+		}
+		a.scalar(true, arm64.REG_R0, arm64.REG_F0)
+	})
+}
+
+// vecReduce leaves the sum of x[i], or of x[i]*y[i] if dot is set, in
+// F16, or the sum of integers in R0. The loop accumulates into V16-V19,
+// which are then added together, and then the lanes of V16.
+func (a *vecAsm) vecReduce(dot bool) {
+	for i := range int16(4) {
+		p := a.s.Prog(arm64.AFMOVD)
+		p.From.Type = obj.TYPE_REG
+		p.From.Reg = arm64.REGZERO
+		p.To.Type = obj.TYPE_REG
+		p.To.Reg = arm64.REG_F16 + i
+	}
+	a.loop(func() {
+		a.pair(false, arm64.REG_R1, arm64.REG_F0)
+		a.pair(false, arm64.REG_R1, arm64.REG_F2)
+		if dot {
+			a.pair(false, arm64.REG_R2, arm64.REG_F4)
+			a.pair(false, arm64.REG_R2, arm64.REG_F6)
+			for i := range int16(4) {
+				a.vop(vecWords[ssa.VecMul][a.w()], arm64.REG_F0+i, arm64.REG_F0+i, arm64.REG_F4+i)
+			}
+		}
+		for i := range int16(4) {
+			a.vop(a.enc(ssa.VecAdd), arm64.REG_F16+i, arm64.REG_F16+i, arm64.REG_F0+i)
+		}
+	})
+
+	// Add up the accumulators, then the lanes of V16.
+	add := a.enc(ssa.VecAdd)
+	a.vop(add, arm64.REG_F16, arm64.REG_F16, arm64.REG_F17)
+	a.vop(add, arm64.REG_F18, arm64.REG_F18, arm64.REG_F19)
+	a.vop(add, arm64.REG_F16, arm64.REG_F16, arm64.REG_F18)
+	if a.integer {
+		for n := 16 / a.size; n > 1; n /= 2 {
+			a.vop(vecADDP|uint32(a.lg())<<22, arm64.REG_F16, arm64.REG_F16, arm64.REG_F16)
+		}
+		p := a.s.Prog(arm64.AFMOVD)
+		p.From.Type = obj.TYPE_REG
+		p.From.Reg = arm64.REG_F16
+		p.To.Type = obj.TYPE_REG
+		p.To.Reg = arm64.REG_R0
+		a.tail(func() {
+			a.gp(vecGPLoad[a.lg()], false, arm64.REG_R1, arm64.REG_R4)
+			a.gpop(arm64.AADD, arm64.REG_R0, arm64.REG_R0, arm64.REG_R4)
+		})
+		return
+	}
+	if a.f64 {
+		a.vop(vecFADDP|1<<22, arm64.REG_F16, arm64.REG_F16, arm64.REG_F16)
+	} else {
+		a.vop(vecFADDP, arm64.REG_F16, arm64.REG_F16, arm64.REG_F16)
+		a.vop(vecFADDP, arm64.REG_F16, arm64.REG_F16, arm64.REG_F16)
+	}
+
+	a.tail(func() {
+		a.scalar(false, arm64.REG_R1, arm64.REG_F0)
+		if dot {
+			a.scalar(false, arm64.REG_R2, arm64.REG_F4)
+			a.sop(ssa.VecMul, arm64.REG_F0, arm64.REG_F0, arm64.REG_F4)
+		}
+		a.sop(ssa.VecAdd, arm64.REG_F16, arm64.REG_F16, arm64.REG_F0)
+	})
+}
@@ -94,6 +94,7 @@ type CmdFlags struct {
 	Complete           bool         "help:\"compiling complete package (no C or assembly)\""
 	ClobberDead        bool         "help:\"clobber dead stack slots (for debugging)\""
 	ClobberDeadReg     bool         "help:\"clobber dead registers (for debugging)\""
+	Contracts          bool         "help:\"check goo requires and ensures contracts\""
 	Dwarf              bool         "help:\"generate DWARF symbols\""
 	DwarfBASEntries    *bool        "help:\"use base address selection entries in DWARF\""                        // &Ctxt.UseBASEntries, set below
 	DwarfLocationLists *bool        "help:\"add location lists to DWARF in optimized mode\""                      // &Ctxt.Flag_locationlists, set below
@@ -137,6 +138,7 @@ type CmdFlags struct {
 		ImportDirs   []string                 // appended to by -I
 		ImportMap    map[string]string        // set by -importcfg
 		PackageFile  map[string]string        // set by -importcfg; nil means not in use
+		AutoImports  map[string][]string      // set by -importcfg; goo: package paths by name
 		CoverageInfo *covcmd.CoverFixupConfig // set by -coveragecfg
 		SpectreIndex bool                     // set by -spectre=index or -spectre=all
 		// Whether we are adding any sort of code instrumentation, such as
@@ -175,6 +177,7 @@ func ParseFlags() {
 	Flag.LinkShared = &Ctxt.Flag_linkshared
 	Flag.Shared = &Ctxt.Flag_shared
 	Flag.WB = true
+	Flag.Contracts = true
 
 	Debug.ConcurrentOk = true
 	Debug.MaxShapeLen = 500
@@ -536,6 +539,14 @@ func readImportCfg(file string) {
 				log.Fatalf(`%s:%d: invalid packagefile: syntax is "packagefile path=filename"`, file, lineNum)
 			}
 			Flag.Cfg.PackageFile[before] = after
+		case "autoimport":
+			if !hasEq || before == "" || after == "" {
+				log.Fatalf(`%s:%d: invalid autoimport: syntax is "autoimport name=path"`, file, lineNum)
+			}
+			if Flag.Cfg.AutoImports == nil {
+				Flag.Cfg.AutoImports = make(map[string][]string)
+			}
+			Flag.Cfg.AutoImports[before] = append(Flag.Cfg.AutoImports[before], after)
 		}
 	}
 }
@@ -81,6 +81,9 @@ func (e *escape) stmt(n ir.Node) {
 	case ir.OCHECK:
 		n := n.(*ir.CheckStmt)
 		e.discard(n.Cond)
+		if n.Msg != nil {
+			e.assignHeap(n.Msg, "check message", n)
+		}
 
 	case ir.OFOR:
 		n := n.(*ir.ForStmt)
@@ -122,6 +122,10 @@ func Main(archInit func(*ssagen.ArchInfo)) {
 	ir.Pkgs.Coverage = types.NewPkg("go.coverage", "runtime/coverage")
 	ir.Pkgs.Coverage.Prefix = "runtime/coverage"
 
+	// goo: pseudo-package for the printer of the dbg builtin.
+	ir.Pkgs.Dbg = types.NewPkg("go.dbg", "runtime/dbg")
+	ir.Pkgs.Dbg.Prefix = "runtime/dbg"
+
 	// Record flags that affect the build result. (And don't
 	// record flags that don't, since that would cause spurious
 	// changes in the binary.)
@@ -202,6 +206,7 @@ func Main(archInit func(*ssagen.ArchInfo)) {
 
 	typecheck.InitUniverse()
 	typecheck.InitRuntime()
+	typecheck.InitDbg()
 	rttype.Init()
 
 	// Parse and typecheck input.
@@ -10,6 +10,7 @@ import (
 	"cmd/compile/internal/syntax"
 	"cmd/compile/internal/types2"
 	"cmd/internal/src"
+	"go/constant"
 	"internal/pkgbits"
 )
 
@@ -435,7 +436,11 @@ func (pr *pkgReader) objIdx(idx pkgbits.Index) (*types2.Package, string) {
 			pos := r.pos()
 			tparams := r.typeParamNames()
 			sig := r.signature(nil, nil, tparams)
-			return types2.NewFunc(pos, objPkg, objName, sig)
+			fn := types2.NewFunc(pos, objPkg, objName, sig)
+			_ = r.pos() // declaration position, for the compiler
+			r.paramDefaults(fn)
+			r.contracts(fn)
+			return fn
 
 		case pkgbits.ObjType:
 			pos := r.pos()
@@ -453,6 +458,12 @@ func (pr *pkgReader) objIdx(idx pkgbits.Index) (*types2.Package, string) {
 				for i := range methods {
 					methods[i] = r.method()
 				}
+				for _, m := range methods {
+					r.paramDefaults(m)
+				}
+				for _, m := range methods {
+					r.contracts(m)
+				}
 
 				return
 			})
@@ -542,6 +553,34 @@ func (r *reader) method() *types2.Func {
 	return types2.NewFunc(pos, pkg, name, sig)
 }
 
+// paramDefaults reads the default parameter values of fn, which
+// follow the rest of its object definition.
+func (r *reader) paramDefaults(fn *types2.Func) {
+	if r.Data.Len() == 0 {
+		return // no defaults recorded
+	}
+	for i, n := 0, r.Len(); i < n; i++ {
+		if r.Bool() {
+			isNil := r.Bool()
+			var val constant.Value
+			if r.Bool() {
+				val = r.Value()
+			}
+			types2.SetParamDefault(fn, i, val, isNil)
+		}
+	}
+}
+
+// contracts reads the requires and ensures clauses of fn, which follow
+// its parameter defaults.
+func (r *reader) contracts(fn *types2.Func) {
+	if r.Data.Len() == 0 {
+		return // no contracts recorded
+	}
+	requires := r.Strings()
+	types2.SetContracts(fn, requires, r.Strings())
+}
+
 func (r *reader) qualifiedIdent() (*types2.Package, string) { return r.ident(pkgbits.SyncSym) }
 func (r *reader) localIdent() (*types2.Package, string)     { return r.ident(pkgbits.SyncLocalIdent) }
 func (r *reader) selector() (*types2.Package, string)       { return r.ident(pkgbits.SyncSelector) }
@@ -499,6 +499,9 @@ func stmtFmt(n Node, s fmt.State) {
 	case OCHECK:
 		n := n.(*CheckStmt)
 		fmt.Fprintf(s, "check %v", n.Cond)
+		if n.Msg != nil {
+			fmt.Fprintf(s, ", %v", n.Msg)
+		}
 
 	case OLABEL:
 		n := n.(*LabelStmt)
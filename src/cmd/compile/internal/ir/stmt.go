@@ -176,10 +176,13 @@ func (n *BranchStmt) SetOp(op Op) {
 
 func (n *BranchStmt) Sym() *types.Sym { return n.Label }
 
-// A CheckStmt is a check statement: check Cond.
+// A CheckStmt is a check statement: check Cond or check Cond, Msg.
 type CheckStmt struct {
 	miniStmt
 	Cond Node
+	Msg  Node // string, evaluated only if the check fails
+
+	Contract string // goo: the requires or ensures clause this checks, if any
 }
 
 func NewCheckStmt(pos src.XPos, cond Node) *CheckStmt {
@@ -202,6 +205,9 @@ func (n *CheckStmt) doChildren(do func(Node) bool) bool {
 	if n.Cond != nil && do(n.Cond) {
 		return true
 	}
+	if n.Msg != nil && do(n.Msg) {
+		return true
+	}
 	return false
 }
 func (n *CheckStmt) doChildrenWithHidden(do func(Node) bool) bool {
@@ -211,6 +217,9 @@ func (n *CheckStmt) doChildrenWithHidden(do func(Node) bool) bool {
 	if n.Cond != nil && do(n.Cond) {
 		return true
 	}
+	if n.Msg != nil && do(n.Msg) {
+		return true
+	}
 	return false
 }
 func (n *CheckStmt) editChildren(edit func(Node) Node) {
@@ -218,12 +227,18 @@ func (n *CheckStmt) editChildren(edit func(Node) Node) {
 	if n.Cond != nil {
 		n.Cond = edit(n.Cond)
 	}
+	if n.Msg != nil {
+		n.Msg = edit(n.Msg)
+	}
 }
 func (n *CheckStmt) editChildrenWithHidden(edit func(Node) Node) {
 	editNodes(n.init, edit)
 	if n.Cond != nil {
 		n.Cond = edit(n.Cond)
 	}
+	if n.Msg != nil {
+		n.Msg = edit(n.Msg)
+	}
 }
 
 // A CaseClause is a case statement in a switch or select: case List: Body.
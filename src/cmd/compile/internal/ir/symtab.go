@@ -84,4 +84,5 @@ var Pkgs struct {
 	Runtime      *types.Pkg
 	InternalMaps *types.Pkg
 	Coverage     *types.Pkg
+	Dbg          *types.Pkg // goo
 }
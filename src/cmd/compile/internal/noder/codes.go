@@ -29,6 +29,12 @@ const (
 	stmtFor
 	stmtSwitch
 	stmtSelect
+	stmtCondValue      // goo value of an if or switch expression branch
+	stmtCompValue      // goo element or key of a comprehension. Followed by a bool indicating a key
+	stmtAssignOperator // goo assignment operation calling an operator method. Followed by the method expression after the position
+	stmtWith           // goo with statement
+	stmtWithExit       // goo exit from with statements by a branch statement. Followed by the number of resources to close
+	stmtDestructure    // goo destructuring assignment. Followed by a codePattern selecting the lowering
 )
 
 // A codeExpr distinguishes among expression encodings.
@@ -64,8 +70,72 @@ const (
 	exprRecv
 	exprReshape
 	exprRuntimeBuiltin // a reference to a runtime function from transformed syntax. Followed by string name, e.g., "panicrangeexit"
+	exprIn             // goo membership test x in y. Followed by a codeIn selecting the lowering
+	exprHashIndex      // goo 1-based index x#i. Followed by a bool indicating a string operand
+	exprHashSlice      // goo 1-based inclusive slice x#i:j. Followed by a bool indicating a string operand
+	exprHashMapIndex   // goo x#i on a map with integer keys, indexing the key i-1
+	exprCond           // goo if or switch expression. Followed by its type and the statement
+	exprCollection     // goo chain of collection method calls. Followed by the source and a codeColl per call
+	exprComp           // goo comprehension. Followed by its type, a bool indicating preallocation and the loop
+	exprVector         // goo elementwise operation on slices of numbers. Followed by the operator, its type and the operands
+	exprVectorReduce   // goo sum or dot built-in. Followed by the arguments
+	exprOperator       // goo binary operation calling an operator method. Followed by the operator, its type, the method expression and the operands
+	exprOld            // goo old call in an ensures clause. Followed by the index of the value on entry
+	exprSpread         // goo composite literal with spread elements. Followed by its type and a bool per element indicating a spread
+	exprNamedCall      // goo call with named arguments out of parameter order. Followed by the parameter indices of the arguments as written and the call
+	exprDbg            // goo dbg built-in. Followed by the file name and line of the call, the source text of the argument and the argument
 )
 
+// A codeIn distinguishes among the lowerings of the membership test
+// x in y, as selected by the type of y.
+type codeIn int
+
+const (
+	inSlice      codeIn = iota // slice or array: element search
+	inMap                      // map: comma-ok key lookup
+	inString                   // string: substring search
+	inStringRune               // string: rune search
+	inStringByte               // string: byte search
+	inRange                    // integer: 0 <= x && x < y
+)
+
+// A codePattern distinguishes among the lowerings of the destructuring
+// assignment pattern := x, as selected by the type of x.
+type codePattern int
+
+const (
+	patternStruct codePattern = iota // struct or pointer to struct: field selection
+	patternMap                       // map: key lookup, comma-ok with an ok variable
+	patternSlice                     // slice or array: length check and indexing
+)
+
+// A codeColl identifies a goo collection method call in a chain like
+// xs.map(f).filter(g).sum(). The streaming methods map, filter, keys and
+// values are fused into the loop of the method that ends the chain.
+type codeColl int
+
+const (
+	collMap    codeColl = iota // map(f): streaming
+	collFilter                 // filter(f): streaming
+	collKeys                   // keys(): streaming
+	collValues                 // values(): streaming
+	collReduce
+	collSum
+	collMin
+	collMax
+	collSort
+	collSortBy
+	collGroupBy
+	collJoin
+	collEach
+	collAny
+	collAll
+)
+
+// streaming reports whether c passes elements on to the next method of
+// the chain rather than ending it.
+func (c codeColl) streaming() bool { return c <= collValues }
+
 type codeAssign int
 
 func (c codeAssign) Marker() pkgbits.SyncMarker { return pkgbits.SyncAssign }
@@ -36,10 +36,6 @@ func checkFiles(m posMap, noders []*noder) (*types2.Package, *types2.Info, map[*
 	for i, p := range noders {
 		files[i] = p.file
 
-		// if file uses printf but doesn't import fmt => auto-inject import "fmt"
-		// removed after de5ce568ce 2025-07-25 00:17 because we always import "fmt"
-		// injectFmtImportIfNeeded(p.file)
-
 		// The file.Pos() is the position of the package clause.
 		// If there's a //line directive before that, file.Pos().Base()
 		// refers to that directive, not the file itself.
@@ -62,6 +58,7 @@ func checkFiles(m posMap, noders []*noder) (*types2.Package, *types2.Info, map[*
 		Importer:           &importer,
 		Sizes:              types2.SizesFor("gc", buildcfg.GOARCH),
 		EnableAlias:        true,
+		AutoImports:        base.Flag.Cfg.AutoImports,
 	}
 	if base.Flag.ErrorURL {
 		conf.ErrorURL = " [go.dev/e/%s]"
@@ -75,6 +72,7 @@ func checkFiles(m posMap, noders []*noder) (*types2.Package, *types2.Info, map[*
 		Scopes:             make(map[syntax.Node]*types2.Scope),
 		Instances:          make(map[*syntax.Name]types2.Instance),
 		FileVersions:       make(map[*syntax.PosBase]string),
+		Operators:          make(map[syntax.Node]*syntax.SelectorExpr),
 		// expand as needed
 	}
 	conf.Error = func(err error) {
@@ -159,6 +159,25 @@ type reader struct {
 
 	// Label to return to.
 	retlabel *types.Sym
+
+	// condTemps is a stack of the temporaries receiving the branch
+	// values of the goo if and switch expressions being read.
+	condTemps []*ir.Name
+
+	// compTemps is a stack of the temporaries receiving the elements,
+	// and the keys of maps, of the goo comprehensions being read.
+	compTemps [][2]*ir.Name
+
+	// olds holds the values on entry of the old calls in the goo
+	// ensures clauses of the function body being read, and ensures
+	// labels the clauses, which return statements branch to.
+	olds    []*ir.Name
+	ensures *types.Sym
+
+	// withStack holds the resources of the goo with statements being
+	// executed by the function body being read, and withErrs the
+	// errors from closing them; both are nil until a with statement.
+	withStack, withErrs *ir.Name
 }
 
 // A readerDict represents an instantiated "compile-time dictionary,"
@@ -1341,7 +1360,7 @@ func (r *reader) funcBody(fn *ir.Func) {
 			return
 		}
 
-		body := r.stmts()
+		body := r.funcStmts()
 		if body == nil {
 			body = []ir.Node{typecheck.Stmt(ir.NewBlockStmt(src.NoXPos, nil))}
 		}
@@ -1352,6 +1371,71 @@ func (r *reader) funcBody(fn *ir.Func) {
 	r.marker.WriteTo(fn)
 }
 
+// ensuresGen numbers the labels of ensures clauses, which must stay
+// unique when functions with contracts are inlined into one another.
+var ensuresGen int
+
+// funcStmts reads the statements of a function body along with the
+// clauses of its goo contracts. The requires clauses are checked first
+// and the values of the old calls in the ensures clauses saved. If there
+// are ensures clauses, each return statement assigns the results and
+// branches to them, and they are checked before a final return.
+func (r *reader) funcStmts() ir.Nodes {
+	var body ir.Nodes
+	for range r.Len() {
+		body.Append(typecheck.Stmt(r.contract()))
+	}
+
+	r.olds = make([]*ir.Name, r.Len())
+	for i := range r.olds {
+		pos := r.pos()
+		r.olds[i] = r.tempCopy(pos, r.expr(), &body)
+	}
+
+	ensures := make([]ir.Node, r.Len())
+	for i := range ensures {
+		ensures[i] = r.contract()
+	}
+	if len(ensures) == 0 {
+		return r.withBody(append(body, r.stmts()...))
+	}
+
+	r.ensures = typecheck.LookupNum(".ensures", ensuresGen)
+	ensuresGen++
+	body.Append(r.stmts()...)
+	pos := ensures[0].Pos()
+	body.Append(ir.NewLabelStmt(pos, r.ensures))
+	for _, n := range ensures {
+		body.Append(typecheck.Stmt(n))
+	}
+	body.Append(typecheck.Stmt(ir.NewReturnStmt(pos, nil)))
+	r.ensures = nil
+	return r.withBody(body)
+}
+
+// contract reads a requires or ensures clause as a check statement.
+func (r *reader) contract() *ir.CheckStmt {
+	pos := r.pos()
+	n := ir.NewCheckStmt(pos, r.expr())
+	n.Contract = r.String()
+	return n
+}
+
+// ensuresReturn returns a statement that substitutes for the given
+// return statement in a function with ensures clauses: it assigns the
+// results, if any, and branches to the clauses.
+func (r *reader) ensuresReturn(pos src.XPos, results []ir.Node) *ir.BlockStmt {
+	var block []ir.Node
+	if len(results) != 0 {
+		sig := r.curfn.Type()
+		endParams := sig.NumRecvs() + sig.NumParams()
+		resvars := r.curfn.Dcl[endParams : endParams+sig.NumResults()]
+		block = append(block, ir.NewAssignListStmt(pos, ir.OAS2, ir.ToNodes(resvars), results))
+	}
+	block = append(block, ir.NewBranchStmt(pos, ir.OGOTO, r.ensures))
+	return ir.NewBlockStmt(pos, block)
+}
+
 // syntheticBody adds a synthetic body to r.curfn if appropriate, and
 // reports whether it did.
 func (r *reader) syntheticBody(pos src.XPos) bool {
@@ -1735,8 +1819,23 @@ func (r *reader) stmt1(tag codeStmt, out *ir.Nodes) ir.Node {
 		lhs := r.expr()
 		pos := r.pos()
 		rhs := r.expr()
+		if lhs.Type().IsSlice() {
+			return r.assignOpTo(pos, lhs, out, func(x ir.Node) ir.Node {
+				return r.vector(pos, op, lhs.Type(), x, rhs)
+			})
+		}
 		return ir.NewAssignOpStmt(pos, op, lhs, rhs)
 
+	case stmtAssignOperator:
+		op := r.op()
+		lhs := r.expr()
+		pos := r.pos()
+		fn := r.expr()
+		rhs := r.expr()
+		return r.assignOpTo(pos, lhs, out, func(x ir.Node) ir.Node {
+			return r.operator(pos, op, lhs.Type(), fn, x, rhs)
+		})
+
 	case stmtIncDec:
 		op := r.op()
 		lhs := r.expr()
@@ -1769,7 +1868,12 @@ func (r *reader) stmt1(tag codeStmt, out *ir.Nodes) ir.Node {
 		return stmt
 
 	case stmtExpr:
-		return r.expr()
+		x := r.expr()
+		if x.Op() == ir.OCONVNOP {
+			// goo: dbg(x) as a statement, lowered to the value of x
+			return typecheck.Stmt(ir.NewAssignStmt(x.Pos(), ir.BlankNode, x))
+		}
+		return x
 
 	case stmtFor:
 		return r.forStmt(label)
@@ -1785,11 +1889,33 @@ func (r *reader) stmt1(tag codeStmt, out *ir.Nodes) ir.Node {
 	case stmtReturn:
 		pos := r.pos()
 		results := r.multiExpr()
+		if r.ensures != nil {
+			return r.ensuresReturn(pos, results)
+		}
 		return ir.NewReturnStmt(pos, results)
 
 	case stmtSelect:
 		return r.selectStmt(label)
 
+	case stmtCondValue:
+		pos := r.pos()
+		tmp := r.condTemps[len(r.condTemps)-1]
+		return ir.NewAssignStmt(pos, tmp, r.expr())
+
+	case stmtCompValue:
+		pos := r.pos()
+		isKey := r.Bool()
+		tmps := r.compTemps[len(r.compTemps)-1]
+		res, key := tmps[0], tmps[1]
+		switch x := r.expr(); {
+		case isKey:
+			return ir.NewAssignStmt(pos, key, x)
+		case key != nil:
+			return ir.NewAssignStmt(pos, ir.NewIndexExpr(pos, res, key), x)
+		default:
+			return ir.NewAssignStmt(pos, res, ir.NewCallExpr(pos, ir.OAPPEND, nil, []ir.Node{res, x}))
+		}
+
 	case stmtSend:
 		pos := r.pos()
 		ch := r.expr()
@@ -1799,10 +1925,22 @@ func (r *reader) stmt1(tag codeStmt, out *ir.Nodes) ir.Node {
 	case stmtCheck:
 		pos := r.pos()
 		cond := r.expr()
-		return ir.NewCheckStmt(pos, cond)
+		n := ir.NewCheckStmt(pos, cond)
+		n.Msg = r.optExpr()
+		return n
 
 	case stmtSwitch:
 		return r.switchStmt(label)
+
+	case stmtWith:
+		return r.withStmt()
+
+	case stmtWithExit:
+		pos := r.pos()
+		return r.withPop(pos, r.Len())
+
+	case stmtDestructure:
+		return r.destructure()
 	}
 }
 
@@ -1851,6 +1989,205 @@ func (r *reader) blockStmt() []ir.Node {
 	return stmts
 }
 
+// withStmt reads a goo with statement. Its resources are pushed onto
+// r.withStack after each binding and popped and closed at the end of
+// the block; a function-wide deferred call closes those that remain
+// when the function returns or panics.
+func (r *reader) withStmt() ir.Node {
+	r.openScope()
+	pos := r.pos()
+	if r.withStack == nil {
+		r.withStack = r.temp(pos, types.NewSlice(types.Types[types.TINTER]))
+		r.withErrs = r.temp(pos, types.ErrorType)
+	}
+
+	var out ir.Nodes
+	n := r.Len()
+	for range n {
+		pos := r.pos()
+		try := r.Bool()
+		names, lhs := r.assignList()
+		rhs := r.multiExpr()
+
+		if len(lhs) == 1 {
+			as := ir.NewAssignStmt(pos, lhs[0], rhs[0])
+			as.Def = r.initDefn(as, names)
+			out.Append(typecheck.Stmt(as))
+		} else {
+			as := ir.NewAssignListStmt(pos, ir.OAS2, lhs, rhs)
+			as.Def = r.initDefn(as, names)
+			out.Append(typecheck.Stmt(as))
+		}
+		if try {
+			out.Append(typecheck.Stmt(r.withTry(pos, rhs[len(rhs)-1])))
+		}
+
+		conv := ir.NewConvExpr(pos, ir.OCONV, types.Types[types.TINTER], lhs[0])
+		conv.TypeWord, conv.SrcRType = r.convRTTI(pos)
+		conv.SetImplicit(true)
+		push := ir.NewCallExpr(pos, ir.OAPPEND, nil, []ir.Node{r.withStack, typecheck.Expr(conv)})
+		out.Append(typecheck.Stmt(ir.NewAssignStmt(pos, r.withStack, typecheck.Expr(push))))
+	}
+
+	out.Append(r.blockStmt()...)
+	out.Append(typecheck.Stmt(r.withPop(pos, n)))
+	r.closeAnotherScope()
+	return ir.NewBlockStmt(pos, out)
+}
+
+// destructure reads a goo destructuring assignment and lowers it to
+//
+//	tmp := x
+//	a, b, rest = tmp.a, tmp.b, tmp.rest
+//
+// selecting fields of a struct, elements of a slice or array, after
+// checking its length, or values of a map, each looked up with comma-ok
+// if there is an ok variable.
+func (r *reader) destructure() ir.Node {
+	kind := codePattern(r.Int())
+	pos := r.pos()
+	names, lhs := r.assignList()
+
+	var init ir.Nodes
+	tmp := r.tempCopy(pos, r.expr(), &init)
+	n := r.Len()
+	rest := r.Bool()
+
+	var rtype ir.Node
+	var keyType *types.Type
+	switch kind {
+	case patternMap:
+		rtype = r.rtype(pos)
+		keyType = r.typ()
+	case patternSlice:
+		// if len(tmp) != n { panicpattern(n, len(tmp), rest) }, or < n with a rest
+		op := ir.ONE
+		if rest {
+			op = ir.OLT
+		}
+		have := typecheck.Expr(ir.NewUnaryExpr(pos, ir.OLEN, tmp))
+		cond := ir.NewBinaryExpr(pos, op, have, ir.NewInt(pos, int64(n)))
+		args := []ir.Node{ir.NewInt(pos, int64(n)), have, ir.NewBool(pos, rest)}
+		fail := typecheck.Call(pos, typecheck.LookupRuntime("panicpattern"), args, false)
+		init.Append(typecheck.Stmt(ir.NewIfStmt(pos, cond, []ir.Node{fail}, nil)))
+	}
+
+	rhs := make([]ir.Node, len(lhs))
+	var ok ir.Node
+	for i := range rhs {
+		var res ir.Node
+		switch {
+		case i == n && rest:
+			res = ir.NewSliceExpr(pos, ir.OSLICE, tmp, ir.NewInt(pos, int64(n)), nil, nil)
+		case i >= n:
+			res = ok
+		case kind == patternStruct:
+			res = ir.NewSelectorExpr(pos, ir.OXDOT, tmp, r.selector())
+		case kind == patternMap:
+			key := ir.NewBasicLit(pos, keyType, constant.MakeString(r.String()))
+			index := ir.NewIndexExpr(pos, tmp, key)
+			index.RType = rtype
+			if len(rhs) == n {
+				res = index
+				break
+			}
+			// v, found := tmp[key]
+			v := r.temp(pos, tmp.Type().Elem())
+			found := r.temp(pos, types.Types[types.TBOOL])
+			init.Append(typecheck.Stmt(ir.NewDecl(pos, ir.ODCL, v)))
+			init.Append(typecheck.Stmt(ir.NewDecl(pos, ir.ODCL, found)))
+			init.Append(typecheck.Stmt(ir.NewAssignListStmt(pos, ir.OAS2, []ir.Node{v, found}, []ir.Node{index})))
+			res = v
+			if ok == nil {
+				ok = found
+			} else {
+				ok = typecheck.Expr(ir.NewLogicalExpr(pos, ir.OANDAND, ok, found))
+			}
+		default:
+			res = ir.NewIndexExpr(pos, tmp, ir.NewInt(pos, int64(i)))
+		}
+		res = typecheck.Expr(res)
+
+		if r.Bool() {
+			conv := ir.NewConvExpr(pos, ir.OCONV, r.typ(), res)
+			conv.TypeWord, conv.SrcRType = r.convRTTI(pos)
+			conv.SetImplicit(true)
+			res = typecheck.Expr(conv)
+		}
+		rhs[i] = res
+	}
+
+	as := ir.NewAssignListStmt(pos, ir.OAS2, lhs, rhs)
+	as.Def = r.initDefn(as, names)
+	init.Append(typecheck.Stmt(as))
+	return ir.NewBlockStmt(pos, init)
+}
+
+// withTry returns the statement that follows a with binding ending in
+// ? whose error is err: if err is not nil, the function returns it
+// along with zero values if its last result is an error, and panics
+// with it otherwise.
+func (r *reader) withTry(pos src.XPos, err ir.Node) ir.Node {
+	var fail ir.Node
+	sig := r.curfn.Type()
+	if n := sig.NumResults(); n > 0 && types.Identical(sig.Result(n-1).Type, types.ErrorType) {
+		results := make([]ir.Node, n)
+		for i := range n - 1 {
+			results[i] = ir.NewZero(pos, sig.Result(i).Type)
+		}
+		results[n-1] = err
+		if r.ensures != nil {
+			fail = r.ensuresReturn(pos, results)
+		} else {
+			fail = ir.NewReturnStmt(pos, results)
+		}
+	} else {
+		fail = ir.NewUnaryExpr(pos, ir.OPANIC, err)
+	}
+	cond := ir.NewBinaryExpr(pos, ir.ONE, err, typecheck.NodNil())
+	return ir.NewIfStmt(pos, cond, []ir.Node{fail}, nil)
+}
+
+// withPop returns a call closing the last n resources on r.withStack.
+func (r *reader) withPop(pos src.XPos, n int) ir.Node {
+	args := []ir.Node{
+		typecheck.NodAddrAt(pos, r.withStack),
+		ir.NewInt(pos, int64(n)),
+		typecheck.Conv(typecheck.NodAddrAt(pos, r.withErrs), types.Types[types.TUNSAFEPTR]),
+	}
+	return typecheck.Call(pos, typecheck.LookupRuntime("withpop"), args, false)
+}
+
+// withBody prepends to the function body the declarations of the with
+// statements' stack and errors, if it has with statements, and the
+// deferred call closing the resources that remain on the stack. The
+// errors are joined into the last result if it is an error.
+func (r *reader) withBody(body ir.Nodes) ir.Nodes {
+	if r.withStack == nil {
+		return body
+	}
+	pos := r.curfn.Pos()
+	res := typecheck.NodNil()
+	sig := r.curfn.Type()
+	if n := sig.NumResults(); n > 0 && types.Identical(sig.Result(n-1).Type, types.ErrorType) {
+		endParams := sig.NumRecvs() + sig.NumParams()
+		res = typecheck.NodAddrAt(pos, r.curfn.Dcl[endParams+n-1])
+	}
+	args := []ir.Node{
+		typecheck.NodAddrAt(pos, r.withStack),
+		typecheck.Conv(typecheck.NodAddrAt(pos, r.withErrs), types.Types[types.TUNSAFEPTR]),
+		typecheck.Conv(res, types.Types[types.TUNSAFEPTR]),
+	}
+	call := typecheck.Call(pos, typecheck.LookupRuntime("withunwind"), args, false)
+	init := []ir.Node{
+		typecheck.Stmt(ir.NewDecl(pos, ir.ODCL, r.withStack)),
+		typecheck.Stmt(ir.NewDecl(pos, ir.ODCL, r.withErrs)),
+		typecheck.Stmt(ir.NewGoDeferStmt(pos, ir.ODEFER, call)),
+	}
+	r.withStack, r.withErrs = nil, nil
+	return append(init, body...)
+}
+
 func (r *reader) forStmt(label *types.Sym) ir.Node {
 	r.Sync(pkgbits.SyncForStmt)
 
@@ -2173,6 +2510,18 @@ func (r *reader) expr() (res ir.Node) {
 	case exprCompLit:
 		return r.compLit()
 
+	case exprNamedCall:
+		pos := r.pos()
+		nparams := r.Len()
+		order := make([]int, r.Len())
+		for i := range order {
+			order[i] = r.Len()
+		}
+		return r.namedCall(pos, nparams, order)
+
+	case exprSpread:
+		return r.spreadLit()
+
 	case exprFuncLit:
 		return r.funcLit()
 
@@ -2564,9 +2913,844 @@ func (r *reader) expr() (res ir.Node) {
 	case exprRuntimeBuiltin:
 		builtin := typecheck.LookupRuntime(r.String())
 		return builtin
+
+	case exprIn:
+		kind := codeIn(r.Int())
+		negate := r.Bool()
+		typ := r.typ()
+		x := r.expr()
+		pos := r.pos()
+		y := r.expr()
+		n := r.membership(pos, kind, x, y)
+		if negate {
+			n = typecheck.Expr(ir.NewUnaryExpr(pos, ir.ONOT, n))
+		}
+		if !types.Identical(n.Type(), typ) {
+			n = typecheck.Conv(n, typ)
+		}
+		return n
+
+	case exprHashIndex:
+		isString := r.Bool()
+		x := r.expr()
+		pos := r.pos()
+		i := r.expr()
+		return r.hashIndex(pos, isString, x, i, nil)
+
+	case exprHashSlice:
+		isString := r.Bool()
+		x := r.expr()
+		pos := r.pos()
+		i := r.expr()
+		j := r.expr()
+		return r.hashIndex(pos, isString, x, i, j)
+
+	case exprHashMapIndex:
+		// x[i-1]
+		x := r.expr()
+		pos := r.pos()
+		i := r.expr()
+		key := typecheck.Expr(ir.NewBinaryExpr(pos, ir.OSUB, i, ir.NewOne(pos, i.Type())))
+		n := typecheck.Expr(ir.NewIndexExpr(pos, x, key)).(*ir.IndexExpr)
+		n.RType = r.rtype(pos)
+		return n
+
+	case exprCond:
+		pos := r.pos()
+		typ := r.typ()
+		return r.condExpr(pos, typ)
+
+	case exprComp:
+		pos := r.pos()
+		typ := r.typ()
+		prealloc := r.Bool()
+		return r.compExpr(pos, typ, prealloc)
+
+	case exprOperator:
+		op := r.op()
+		pos := r.pos()
+		typ := r.typ()
+		fn := r.expr()
+		x := r.expr()
+		y := r.expr()
+		return r.operator(pos, op, typ, fn, x, y)
+
+	case exprVector:
+		op := r.op()
+		pos := r.pos()
+		typ := r.typ()
+		x := r.expr()
+		y := r.expr()
+		return r.vector(pos, op, typ, x, y)
+
+	case exprOld:
+		return r.olds[r.Len()]
+
+	case exprDbg:
+		pos := r.pos()
+		where := r.String()
+		text := r.String()
+		x := r.expr()
+		return r.dbg(pos, where, text, x)
+
+	case exprVectorReduce:
+		pos := r.pos()
+		args := r.exprs()
+		var y ir.Node
+		if len(args) == 2 {
+			y = args[1]
+		}
+		return r.vectorReduce(pos, args[0], y)
+
+	case exprCollection:
+		pos := r.pos()
+		x := r.expr()
+		calls := make([]collCall, r.Len())
+		for i := range calls {
+			call := &calls[i]
+			call.code = codeColl(r.Int())
+			call.pos = r.pos()
+			switch call.code {
+			case collReduce:
+				call.args = []ir.Node{r.expr(), r.expr()}
+			case collJoin:
+				call.args = []ir.Node{r.expr()}
+			default:
+				call.args = r.exprs()
+			}
+		}
+		var typ *types.Type
+		if r.Bool() {
+			typ = r.typ()
+		}
+		return r.collection(pos, x, calls, typ)
 	}
 }
 
+// A collCall is a call in a chain of goo collection method calls.
+type collCall struct {
+	code codeColl
+	pos  src.XPos
+	args []ir.Node
+}
+
+// collection lowers the chain of goo collection method calls on x into a
+// single loop over x, with the calls fused into its body. For example,
+// xs.map(f).filter(g).sum() becomes
+//
+//	sum := 0
+//	for _, v := range xs {
+//		w := f(v)
+//		if !g(w) {
+//			continue
+//		}
+//		sum += w
+//	}
+//
+// typ is the type of the result, or nil for each, which yields a
+// statement. The function arguments are evaluated before the loop.
+func (r *reader) collection(pos src.XPos, x ir.Node, calls []collCall, typ *types.Type) ir.Node {
+	var init ir.Nodes
+	decl := func(typ *types.Type, val ir.Node) *ir.Name {
+		tmp := r.temp(pos, typ)
+		init.Append(typecheck.Stmt(ir.NewDecl(pos, ir.ODCL, tmp)))
+		init.Append(typecheck.Stmt(ir.NewAssignStmt(pos, tmp, val)))
+		return tmp
+	}
+	stmt := func(n ir.Node) ir.Node { return typecheck.Stmt(n) }
+	call := func(pos src.XPos, fn ir.Node, args ...ir.Node) ir.Node {
+		return typecheck.Call(pos, fn, args, false)
+	}
+	appendExpr := func(s, v ir.Node, dots bool) ir.Node {
+		n := ir.NewCallExpr(pos, ir.OAPPEND, nil, []ir.Node{s, v})
+		n.IsDDD = dots
+		return typecheck.Expr(n)
+	}
+	appendTo := func(s *ir.Name, v ir.Node) ir.Node {
+		return stmt(ir.NewAssignStmt(pos, s, appendExpr(s, v, false)))
+	}
+
+	if x.Op() != ir.ONAME {
+		x = r.tempCopy(pos, x, &init)
+	}
+	for _, c := range calls {
+		for i, arg := range c.args {
+			if !ir.IsConstNode(arg) && !(arg.Op() == ir.ONAME && arg.(*ir.Name).Class == ir.PFUNC) {
+				c.args[i] = r.tempCopy(pos, arg, &init)
+			}
+		}
+	}
+
+	// The loop variables; cur holds the key and value of the current map
+	// entry, or the current element.
+	var key, val ir.Node
+	var cur []ir.Node
+	if xt := x.Type(); xt.IsMap() {
+		key = decl(xt.Key(), ir.NewZero(pos, xt.Key()))
+		val = decl(xt.Elem(), ir.NewZero(pos, xt.Elem()))
+		cur = []ir.Node{key, val}
+	} else {
+		key = ir.BlankNode
+		val = decl(xt.Elem(), ir.NewZero(pos, xt.Elem()))
+		cur = []ir.Node{val}
+	}
+
+	// The streaming calls.
+	var body ir.Nodes
+	filtered := false
+	const collect codeColl = -1 // end of a chain of streaming calls
+	last := calls[len(calls)-1]
+	if last.code.streaming() {
+		last = collCall{code: collect, pos: pos}
+	} else {
+		calls = calls[:len(calls)-1]
+	}
+	for _, c := range calls {
+		switch c.code {
+		case collMap:
+			res := call(c.pos, c.args[0], cur...)
+			w := decl(res.Type(), ir.NewZero(pos, res.Type()))
+			body.Append(stmt(ir.NewAssignStmt(c.pos, w, res)))
+			cur = []ir.Node{w}
+		case collFilter:
+			skip := ir.NewUnaryExpr(c.pos, ir.ONOT, call(c.pos, c.args[0], cur...))
+			body.Append(stmt(ir.NewIfStmt(c.pos, skip, []ir.Node{ir.NewBranchStmt(c.pos, ir.OCONTINUE, nil)}, nil)))
+			filtered = true
+		case collKeys:
+			cur = cur[:1]
+		case collValues:
+			cur = cur[1:]
+		}
+	}
+	v := cur[len(cur)-1]
+
+	// The call that ends the chain.
+	var res *ir.Name
+	if typ != nil {
+		res = decl(typ, ir.NewZero(pos, typ))
+	}
+	var after ir.Nodes // statements after the loop
+	sortslice := func(keys, elems ir.Node) {
+		ptr := func(s ir.Node) ir.Node {
+			return typecheck.Conv(typecheck.Expr(ir.NewUnaryExpr(pos, ir.OUNSAFESLICEDATA, s)), types.Types[types.TUNSAFEPTR])
+		}
+		args := []ir.Node{reflectdata.TypePtrAt(pos, keys.Type().Elem()), ptr(keys)}
+		if elems != nil {
+			args = append(args, reflectdata.TypePtrAt(pos, elems.Type().Elem()), ptr(elems))
+		} else {
+			args = append(args, ir.NewNilExpr(pos, types.NewPtr(types.Types[types.TUINT8])), ir.NewNilExpr(pos, types.Types[types.TUNSAFEPTR]))
+		}
+		args = append(args, typecheck.Expr(ir.NewUnaryExpr(pos, ir.OLEN, keys)))
+		after.Append(stmt(call(pos, typecheck.LookupRuntime("sortslice"), args...)))
+	}
+	makeSlice := func(s *ir.Name) {
+		// Without a filter, the length of x is known.
+		if !filtered {
+			n := typecheck.Expr(ir.NewUnaryExpr(pos, ir.OLEN, x))
+			mk := ir.NewCallExpr(pos, ir.OMAKE, nil, []ir.Node{ir.TypeNode(s.Type()), ir.NewInt(pos, 0), n})
+			init.Append(stmt(ir.NewAssignStmt(pos, s, mk)))
+		}
+	}
+	makeMap := func(m *ir.Name) {
+		mk := ir.NewCallExpr(pos, ir.OMAKE, nil, []ir.Node{ir.TypeNode(m.Type())})
+		init.Append(stmt(ir.NewAssignStmt(pos, m, mk)))
+	}
+
+	switch last.code {
+	case collect:
+		if len(cur) == 2 {
+			// res[k] = v
+			makeMap(res)
+			body.Append(stmt(ir.NewAssignStmt(pos, ir.NewIndexExpr(pos, res, cur[0]), v)))
+			break
+		}
+		makeSlice(res)
+		body.Append(appendTo(res, v))
+
+	case collReduce:
+		init.Append(stmt(ir.NewAssignStmt(pos, res, last.args[0])))
+		body.Append(stmt(ir.NewAssignStmt(last.pos, res, call(last.pos, last.args[1], res, v))))
+
+	case collSum:
+		body.Append(stmt(ir.NewAssignOpStmt(last.pos, ir.OADD, res, v)))
+
+	case collMin, collMax:
+		op, msg := ir.OMIN, "min of empty collection"
+		if last.code == collMax {
+			op, msg = ir.OMAX, "max of empty collection"
+		}
+		some := decl(types.Types[types.TBOOL], ir.NewBool(pos, false))
+		body.Append(stmt(ir.NewIfStmt(last.pos, some,
+			[]ir.Node{ir.NewAssignStmt(last.pos, res, ir.NewCallExpr(last.pos, op, nil, []ir.Node{res, v}))},
+			[]ir.Node{ir.NewAssignStmt(last.pos, res, v), ir.NewAssignStmt(last.pos, some, ir.NewBool(pos, true))})))
+		after.Append(stmt(ir.NewIfStmt(last.pos, ir.NewUnaryExpr(last.pos, ir.ONOT, some),
+			[]ir.Node{ir.NewUnaryExpr(last.pos, ir.OPANIC, ir.NewString(last.pos, msg))}, nil)))
+
+	case collSort:
+		makeSlice(res)
+		body.Append(appendTo(res, v))
+		sortslice(res, nil)
+
+	case collSortBy:
+		k := call(last.pos, last.args[0], v)
+		keys := decl(types.NewSlice(k.Type()), ir.NewZero(pos, types.NewSlice(k.Type())))
+		makeSlice(keys)
+		makeSlice(res)
+		body.Append(appendTo(keys, k), appendTo(res, v))
+		sortslice(keys, res)
+
+	case collGroupBy:
+		// res[k] = append(res[k], v)
+		makeMap(res)
+		k := decl(typ.Key(), ir.NewZero(pos, typ.Key()))
+		body.Append(stmt(ir.NewAssignStmt(last.pos, k, call(last.pos, last.args[0], v))))
+		group := appendExpr(ir.NewIndexExpr(pos, res, k), v, false)
+		body.Append(stmt(ir.NewAssignStmt(pos, ir.NewIndexExpr(pos, res, k), group)))
+
+	case collJoin:
+		// buf = append(buf, sep...); buf = append(buf, v...)
+		bytes := types.NewSlice(types.ByteType)
+		buf := decl(bytes, ir.NewZero(pos, bytes))
+		some := decl(types.Types[types.TBOOL], ir.NewBool(pos, false))
+		appendString := func(s ir.Node) ir.Node {
+			s = typecheck.Conv(s, types.Types[types.TSTRING])
+			return stmt(ir.NewAssignStmt(pos, buf, appendExpr(buf, s, true)))
+		}
+		body.Append(stmt(ir.NewIfStmt(last.pos, some,
+			[]ir.Node{appendString(last.args[0])},
+			[]ir.Node{ir.NewAssignStmt(last.pos, some, ir.NewBool(pos, true))})))
+		body.Append(appendString(v))
+		after.Append(stmt(ir.NewAssignStmt(pos, res, typecheck.Conv(buf, typ))))
+
+	case collEach:
+		body.Append(stmt(call(last.pos, last.args[0], cur...)))
+
+	case collAny, collAll:
+		// if f(v) == want { res = want; break }
+		want := last.code == collAny
+		init.Append(stmt(ir.NewAssignStmt(pos, res, ir.NewBool(pos, !want))))
+		cond := call(last.pos, last.args[0], cur...)
+		if !want {
+			cond = ir.NewUnaryExpr(last.pos, ir.ONOT, cond)
+		}
+		body.Append(stmt(ir.NewIfStmt(last.pos, cond,
+			[]ir.Node{ir.NewAssignStmt(last.pos, res, ir.NewBool(pos, want)), ir.NewBranchStmt(last.pos, ir.OBREAK, nil)}, nil)))
+
+	default:
+		base.FatalfAt(pos, "unexpected collection method %v", last.code)
+	}
+
+	init.Append(stmt(ir.NewRangeStmt(pos, key, val, x, body, false)))
+	init.Append(after...)
+	if res == nil {
+		return stmt(ir.NewBlockStmt(pos, init))
+	}
+	return ir.InitExpr(init, res)
+}
+
+// condExpr lowers a goo if or switch expression into the statement
+// itself, with each branch assigning its value to a temporary that
+// provides the value of the expression. Simple branches are turned into
+// conditional moves by the SSA backend.
+func (r *reader) condExpr(pos src.XPos, typ *types.Type) ir.Node {
+	tmp := r.temp(pos, typ)
+	init := []ir.Node{typecheck.Stmt(ir.NewDecl(pos, ir.ODCL, tmp))}
+
+	r.condTemps = append(r.condTemps, tmp)
+	if stmt := r.stmt(); stmt != nil {
+		init = append(init, stmt)
+	}
+	r.condTemps = r.condTemps[:len(r.condTemps)-1]
+
+	return ir.InitExpr(init, tmp)
+}
+
+// compExpr lowers a goo comprehension into its loop, which appends each
+// element to a temporary slice, or assigns it to its key in a temporary
+// map, that provides the value of the expression. If prealloc is set,
+// the slice or map is allocated with room for one element per
+// iteration of the loop; otherwise a slice starts out nil.
+func (r *reader) compExpr(pos src.XPos, typ *types.Type, prealloc bool) ir.Node {
+	res := r.temp(pos, typ)
+	init := []ir.Node{typecheck.Stmt(ir.NewDecl(pos, ir.ODCL, res))}
+	var key *ir.Name
+	if typ.IsMap() {
+		key = r.temp(pos, typ.Key())
+		init = append(init, typecheck.Stmt(ir.NewDecl(pos, ir.ODCL, key)))
+	}
+
+	r.compTemps = append(r.compTemps, [2]*ir.Name{res, key})
+	loop := r.stmt()
+	r.compTemps = r.compTemps[:len(r.compTemps)-1]
+
+	var size ir.Node
+	if rang, ok := loop.(*ir.RangeStmt); ok && prealloc {
+		// The range expression is evaluated once, before the loop.
+		x := rang.X
+		if x.Op() != ir.ONAME && !ir.IsConstNode(x) {
+			var pre ir.Nodes
+			rang.X = r.tempCopy(pos, x, &pre)
+			x = rang.X
+			init = append(init, pre...)
+		}
+		if x.Type().IsInteger() {
+			// A negative count ranges over nothing: max(int(x), 0).
+			n := typecheck.Conv(x, types.Types[types.TINT])
+			size = typecheck.Expr(ir.NewCallExpr(pos, ir.OMAX, nil, []ir.Node{n, ir.NewZero(pos, n.Type())}))
+		} else {
+			size = typecheck.Expr(ir.NewUnaryExpr(pos, ir.OLEN, x))
+		}
+	}
+	var mk ir.Node
+	switch {
+	case typ.IsMap():
+		args := []ir.Node{ir.TypeNode(typ)}
+		if size != nil {
+			args = append(args, size)
+		}
+		mk = ir.NewCallExpr(pos, ir.OMAKE, nil, args)
+	case size != nil:
+		mk = ir.NewCallExpr(pos, ir.OMAKE, nil, []ir.Node{ir.TypeNode(typ), ir.NewInt(pos, 0), size})
+	default:
+		mk = ir.NewZero(pos, typ)
+	}
+	init = append(init, typecheck.Stmt(ir.NewAssignStmt(pos, res, mk)), loop)
+
+	return ir.InitExpr(init, res)
+}
+
+// namedCall reads a call with nparams arguments whose named arguments
+// were moved to their parameters, and copies the arguments to
+// temporaries in the order they were written, given by their parameter
+// indices in order, so that they are evaluated in that order. The copies
+// are made after the function value and receiver are evaluated.
+func (r *reader) namedCall(pos src.XPos, nparams int, order []int) ir.Node {
+	call := r.expr().(*ir.CallExpr)
+	params := call.Args[len(call.Args)-nparams:]
+
+	var init ir.Nodes
+	for _, i := range order {
+		if !ir.IsConstNode(params[i]) {
+			params[i] = r.tempCopy(pos, params[i], &init)
+		}
+	}
+	params[0] = ir.InitExpr(init, params[0])
+	return call
+}
+
+// hashIndex lowers the goo index expression x#i, or the inclusive slice
+// expression x#i:j if j is non-nil, into ordinary indexing with 0-based
+// indices computed by the runtime. The runtime helpers also perform the
+// bounds checks, so that panics report the 1-based indices as written.
+// Strings are indexed and sliced by runes.
+func (r *reader) hashIndex(pos src.XPos, isString bool, x, i, j ir.Node) ir.Node {
+	if isString {
+		// The runtime helpers take and return plain strings; a slice of
+		// a string of a named type keeps that type.
+		typ := x.Type()
+		if typ != types.Types[types.TSTRING] {
+			x = typecheck.Expr(ir.NewConvExpr(pos, ir.OCONV, types.Types[types.TSTRING], x))
+		}
+		if j == nil {
+			return typecheck.Call(pos, typecheck.LookupRuntime("hashrune"), []ir.Node{x, i}, false)
+		}
+		slice := typecheck.Call(pos, typecheck.LookupRuntime("hashslicestring"), []ir.Node{x, i, j}, false)
+		if typ != types.Types[types.TSTRING] {
+			slice = typecheck.Expr(ir.NewConvExpr(pos, ir.OCONV, typ, slice))
+		}
+		return slice
+	}
+
+	// The length of an array is known statically. A slice is copied unless
+	// it is a plain variable, so that x is evaluated only once and x#i
+	// remains assignable.
+	var init ir.Nodes
+	var n ir.Node
+	switch typ := x.Type(); {
+	case typ.IsArray():
+		n = ir.NewInt(pos, typ.NumElem())
+	case typ.IsPtr():
+		n = ir.NewInt(pos, typ.Elem().NumElem())
+	default:
+		if x.Op() != ir.ONAME {
+			x = r.tempCopy(pos, x, &init)
+		}
+		n = typecheck.Expr(ir.NewUnaryExpr(pos, ir.OLEN, x))
+	}
+
+	if j == nil {
+		// x[hashindex(i, len(x))]
+		k := typecheck.Call(pos, typecheck.LookupRuntime("hashindex"), []ir.Node{i, n}, false)
+		index := typecheck.Expr(ir.NewIndexExpr(pos, x, k)).(*ir.IndexExpr)
+		index.SetBounded(true)
+		return ir.InitExpr(init, index)
+	}
+
+	// lo, hi = hashslice(i, j, len(x)); x[lo:hi]
+	lo := r.temp(pos, types.Types[types.TINT])
+	hi := r.temp(pos, types.Types[types.TINT])
+	init.Append(typecheck.Stmt(ir.NewDecl(pos, ir.ODCL, lo)))
+	init.Append(typecheck.Stmt(ir.NewDecl(pos, ir.ODCL, hi)))
+	call := typecheck.Call(pos, typecheck.LookupRuntime("hashslice"), []ir.Node{i, j, n}, false)
+	init.Append(typecheck.Stmt(ir.NewAssignListStmt(pos, ir.OAS2, []ir.Node{lo, hi}, []ir.Node{call})))
+	slice := typecheck.Expr(ir.NewSliceExpr(pos, ir.OSLICE, x, lo, hi, nil)).(*ir.SliceExpr)
+	slice.SetBounded(true)
+	return ir.InitExpr(init, slice)
+}
+
+// vecFuncs names the runtime functions for goo's arithmetic on slices
+// of numbers, by operator, for the operands slice op slice, slice op
+// number and number op slice.
+var vecFuncs = map[ir.Op][3]string{
+	ir.OADD: {"vadd", "vadds", "vadds"},
+	ir.OSUB: {"vsub", "vsubs", "vrsubs"},
+	ir.OMUL: {"vmul", "vmuls", "vmuls"},
+	ir.ODIV: {"vdiv", "vdivs", "vrdivs"},
+}
+
+// vecInts are the signed integer kinds by size in bytes, whose runtime
+// functions serve the unsigned integers of the same size too.
+var vecInts = map[int64]types.Kind{
+	1: types.TINT8,
+	2: types.TINT16,
+	4: types.TINT32,
+	8: types.TINT64,
+}
+
+// vecFunc returns the runtime function for the elementwise operation
+// of form form (an index into vecFuncs) on slices of elem, and the type
+// of its scalar operand, or nil if there is none. The runtime has no
+// functions for the division of integers, nor for the multiplication
+// of 8 and 64 bit integers.
+func vecFunc(op ir.Op, form int, elem *types.Type) (ir.Node, *types.Type) {
+	fns, ok := vecFuncs[op]
+	if !ok {
+		return nil, nil
+	}
+	switch {
+	case elem.Kind() == types.TFLOAT32:
+		return typecheck.LookupRuntime(fns[form] + "F32"), types.Types[types.TFLOAT32]
+	case elem.Kind() == types.TFLOAT64:
+		return typecheck.LookupRuntime(fns[form] + "F64"), types.Types[types.TFLOAT64]
+	case !elem.IsInteger() || op == ir.ODIV:
+		return nil, nil
+	case op == ir.OMUL && elem.Size() != 2 && elem.Size() != 4:
+		return nil, nil
+	}
+	return typecheck.LookupRuntime(fmt.Sprintf("%sI%d", fns[form], 8*elem.Size())), types.Types[vecInts[elem.Size()]]
+}
+
+// vecLenCheck appends to init the check that the slices x and y of an
+// elementwise operation have the same length:
+//
+//	if len(x) != len(y) { panicveclen(len(x), len(y)) }
+func vecLenCheck(pos src.XPos, x, y ir.Node, init *ir.Nodes) {
+	nx := ir.NewUnaryExpr(pos, ir.OLEN, x)
+	ny := ir.NewUnaryExpr(pos, ir.OLEN, y)
+	panicveclen := typecheck.Call(pos, typecheck.LookupRuntime("panicveclen"), []ir.Node{nx, ny}, false)
+	ne := ir.NewBinaryExpr(pos, ir.ONE, nx, ny)
+	init.Append(typecheck.Stmt(ir.NewIfStmt(pos, ne, []ir.Node{panicveclen}, nil)))
+}
+
+// vecLoop appends to init the loop
+//
+//	for i := 0; i < len(s); i++ { body(i) }
+func (r *reader) vecLoop(pos src.XPos, s ir.Node, init *ir.Nodes, body func(i ir.Node) ir.Node) {
+	i := r.temp(pos, types.Types[types.TINT])
+	init.Append(typecheck.Stmt(ir.NewDecl(pos, ir.ODCL, i)))
+	init.Append(typecheck.Stmt(ir.NewAssignStmt(pos, i, ir.NewInt(pos, 0))))
+	cond := ir.NewBinaryExpr(pos, ir.OLT, i, ir.NewUnaryExpr(pos, ir.OLEN, s))
+	post := ir.NewAssignStmt(pos, i, ir.NewBinaryExpr(pos, ir.OADD, i, ir.NewInt(pos, 1)))
+	init.Append(typecheck.Stmt(ir.NewForStmt(pos, nil, cond, post, []ir.Node{body(i)}, false)))
+}
+
+// vecIndex returns s[i] for a slice s, whose length the caller has
+// checked, or s itself for a number.
+func vecIndex(pos src.XPos, s, i ir.Node) ir.Node {
+	if !s.Type().IsSlice() {
+		return s
+	}
+	index := typecheck.Expr(ir.NewIndexExpr(pos, s, i)).(*ir.IndexExpr)
+	index.SetBounded(true)
+	return index
+}
+
+// vecData returns unsafe.Pointer(unsafe.SliceData(s)).
+func vecData(pos src.XPos, s ir.Node) ir.Node {
+	return typecheck.Conv(typecheck.Expr(ir.NewUnaryExpr(pos, ir.OUNSAFESLICEDATA, s)), types.Types[types.TUNSAFEPTR])
+}
+
+// vector lowers the goo elementwise operation x op y of type typ, where
+// x or y is a slice of numbers and the other operand is a slice of the
+// same type or a number. Two slices must have the same length.
+// Arithmetic calls the runtime, whose functions the SSA backend
+// replaces with SIMD loops on amd64 and arm64:
+//
+//	res := make(typ, len(x))
+//	vaddF64(unsafe.Pointer(unsafe.SliceData(res)), ..., len(res))
+//
+// Other operations, those vecFunc has no function for and comparisons,
+// are plain loops:
+//
+//	for i := 0; i < len(res); i++ { res[i] = x[i] op y[i] }
+func (r *reader) vector(pos src.XPos, op ir.Op, typ *types.Type, x, y ir.Node) ir.Node {
+	var init ir.Nodes
+
+	// Evaluate both operands, in order.
+	if x.Op() != ir.ONAME {
+		x = r.tempCopy(pos, x, &init)
+	}
+	if y.Op() != ir.ONAME {
+		y = r.tempCopy(pos, y, &init)
+	}
+	form, s := 0, x
+	switch {
+	case !y.Type().IsSlice():
+		form = 1
+	case !x.Type().IsSlice():
+		form, s = 2, y
+	default:
+		vecLenCheck(pos, x, y, &init)
+	}
+
+	mk := ir.NewCallExpr(pos, ir.OMAKE, nil, []ir.Node{ir.TypeNode(typ), ir.NewUnaryExpr(pos, ir.OLEN, s)})
+	res := r.tempCopy(pos, typecheck.Expr(mk), &init)
+
+	if fn, scalar := vecFunc(op, form, s.Type().Elem()); fn != nil {
+		args := []ir.Node{vecData(pos, res)}
+		switch form {
+		case 0:
+			args = append(args, vecData(pos, x), vecData(pos, y))
+		case 1:
+			args = append(args, vecData(pos, x), typecheck.Conv(y, scalar))
+		case 2:
+			args = append(args, vecData(pos, y), typecheck.Conv(x, scalar))
+		}
+		args = append(args, typecheck.Expr(ir.NewUnaryExpr(pos, ir.OLEN, res)))
+		init.Append(typecheck.Stmt(typecheck.Call(pos, fn, args, false)))
+		return ir.InitExpr(init, res)
+	}
+
+	r.vecLoop(pos, res, &init, func(i ir.Node) ir.Node {
+		v := ir.NewBinaryExpr(pos, op, vecIndex(pos, x, i), vecIndex(pos, y, i))
+		return ir.NewAssignStmt(pos, vecIndex(pos, res, i), v)
+	})
+	return ir.InitExpr(init, res)
+}
+
+// assignOpTo lowers a goo assignment operation lhs op= rhs, such as one
+// on a slice of numbers, to lhs = value(lhs), appending to out the
+// statements that evaluate the operands of lhs once.
+func (r *reader) assignOpTo(pos src.XPos, lhs ir.Node, out *ir.Nodes, value func(x ir.Node) ir.Node) ir.Node {
+	// ref returns a new reference to the variable denoted by lhs.
+	var ref func() ir.Node
+	switch lhs.Op() {
+	case ir.ONAME:
+		ref = func() ir.Node { return lhs }
+	case ir.OINDEXMAP:
+		lhs := lhs.(*ir.IndexExpr)
+		m := r.tempCopy(pos, lhs.X, out)
+		k := r.tempCopy(pos, lhs.Index, out)
+		ref = func() ir.Node { return typecheck.Expr(ir.NewIndexExpr(pos, m, k)) }
+	default:
+		p := r.tempCopy(pos, typecheck.NodAddrAt(pos, lhs), out)
+		ref = func() ir.Node { return typecheck.Expr(ir.NewStarExpr(pos, p)) }
+	}
+	return typecheck.Stmt(ir.NewAssignStmt(pos, ref(), value(ref())))
+}
+
+// operator lowers the goo binary operation x op y of result type typ,
+// which calls the operator method expression fn, to
+//
+//	fn(x, y)          // arithmetic, or == with Equal
+//	fn(new(U), x, y)  // arithmetic with a method of math/big's form, for x of type *U
+//	!fn(x, y)         // != with Equal
+//	fn(x, y) op 0     // comparisons with Compare
+func (r *reader) operator(pos src.XPos, op ir.Op, typ *types.Type, fn, x, y ir.Node) ir.Node {
+	args := []ir.Node{x, y}
+	if fn.Type().NumParams() == 3 {
+		args = append([]ir.Node{typecheck.Expr(ir.NewUnaryExpr(pos, ir.ONEW, ir.TypeNode(x.Type().Elem())))}, args...)
+	}
+	n := typecheck.Call(pos, fn, args, false)
+	switch {
+	case op == ir.OEQ || op == ir.ONE:
+		if n.Type().IsBoolean() {
+			if op == ir.ONE {
+				n = typecheck.Expr(ir.NewUnaryExpr(pos, ir.ONOT, n))
+			}
+			break
+		}
+		fallthrough
+	case op == ir.OLT || op == ir.OLE || op == ir.OGT || op == ir.OGE:
+		n = typecheck.Expr(ir.NewBinaryExpr(pos, op, n, ir.NewInt(pos, 0)))
+	}
+	return typecheck.Conv(n, typ)
+}
+
+// vectorReduce lowers the goo built-in sum(x), or dot(x, y) if y is not
+// nil, to
+//
+//	res := elem(vsumF64(unsafe.Pointer(unsafe.SliceData(x)), len(x)))  // or vdotF64, vsumI32
+//
+// for floats, and sums of integers, and to a loop for dot products of
+// integers:
+//
+//	res := elem(0)
+//	for i := 0; i < len(x); i++ { res += x[i] * y[i] }
+func (r *reader) vectorReduce(pos src.XPos, x, y ir.Node) ir.Node {
+	var init ir.Nodes
+	if x.Op() != ir.ONAME {
+		x = r.tempCopy(pos, x, &init)
+	}
+	if y != nil {
+		if y.Op() != ir.ONAME {
+			y = r.tempCopy(pos, y, &init)
+		}
+		vecLenCheck(pos, x, y, &init)
+	}
+
+	elem := x.Type().Elem()
+	if elem.IsFloat() || elem.IsInteger() && y == nil {
+		fn := "vsum"
+		args := []ir.Node{vecData(pos, x)}
+		if y != nil {
+			fn = "vdot"
+			args = append(args, vecData(pos, y))
+		}
+		switch {
+		case elem.Kind() == types.TFLOAT32:
+			fn += "F32"
+		case elem.Kind() == types.TFLOAT64:
+			fn += "F64"
+		default:
+			fn += fmt.Sprintf("I%d", 8*elem.Size())
+		}
+		args = append(args, typecheck.Expr(ir.NewUnaryExpr(pos, ir.OLEN, x)))
+		return ir.InitExpr(init, typecheck.Conv(typecheck.Call(pos, typecheck.LookupRuntime(fn), args, false), elem))
+	}
+
+	res := r.temp(pos, elem)
+	init.Append(typecheck.Stmt(ir.NewDecl(pos, ir.ODCL, res)))
+	init.Append(typecheck.Stmt(ir.NewAssignStmt(pos, res, nil)))
+	r.vecLoop(pos, x, &init, func(i ir.Node) ir.Node {
+		v := vecIndex(pos, x, i)
+		if y != nil {
+			v = ir.NewBinaryExpr(pos, ir.OMUL, v, vecIndex(pos, y, i))
+		}
+		return ir.NewAssignStmt(pos, res, ir.NewBinaryExpr(pos, ir.OADD, res, v))
+	})
+	return ir.InitExpr(init, res)
+}
+
+// dbg lowers the goo built-in dbg(x) at where, the file name and line
+// of the call, to
+//
+//	tmp := x
+//	dbg.Print(where, text, &tmp)
+//	tmp
+//
+// where text is the source text of x and dbg is package runtime/dbg.
+func (r *reader) dbg(pos src.XPos, where, text string, x ir.Node) ir.Node {
+	var init ir.Nodes
+	tmp := r.tempCopy(pos, x, &init)
+	ptr := typecheck.Conv(typecheck.NodAddrAt(pos, tmp), types.Types[types.TINTER])
+	args := []ir.Node{ir.NewString(pos, where), ir.NewString(pos, text), ptr}
+	init.Append(typecheck.Stmt(typecheck.Call(pos, typecheck.LookupDbg("Print"), args, false)))
+	return ir.InitExpr(init, tmp)
+}
+
+// maxInlineMembership is the maximum number of elements of a constant
+// slice literal for which x in y is expanded into a chain of comparisons.
+const maxInlineMembership = 8
+
+// membership lowers the membership test x in y. x has already been
+// converted to the type it is compared against (element, key or
+// rune type).
+func (r *reader) membership(pos src.XPos, kind codeIn, x, y ir.Node) ir.Node {
+	switch kind {
+	case inString:
+		return typecheck.Call(pos, typecheck.LookupRuntime("stringcontains"), []ir.Node{y, x}, false)
+
+	case inStringRune:
+		return typecheck.Call(pos, typecheck.LookupRuntime("stringcontainsrune"), []ir.Node{y, x}, false)
+
+	case inStringByte:
+		return typecheck.Call(pos, typecheck.LookupRuntime("stringcontainsbyte"), []ir.Node{y, x}, false)
+	}
+
+	var init ir.Nodes
+	if !ir.IsConstNode(x) {
+		x = r.tempCopy(pos, x, &init)
+	}
+
+	var res ir.Node
+	switch kind {
+	case inRange:
+		// 0 <= x && x < y
+		lo := typecheck.Expr(ir.NewBinaryExpr(pos, ir.OLE, ir.NewZero(pos, x.Type()), x))
+		hi := typecheck.Expr(ir.NewBinaryExpr(pos, ir.OLT, x, y))
+		res = typecheck.Expr(ir.NewLogicalExpr(pos, ir.OANDAND, lo, hi))
+
+	case inMap:
+		// _, found = y[x]
+		found := r.temp(pos, types.Types[types.TBOOL])
+		init.Append(typecheck.Stmt(ir.NewDecl(pos, ir.ODCL, found)))
+		index := ir.NewIndexExpr(pos, y, x)
+		index.RType = r.rtype(pos)
+		as := ir.NewAssignListStmt(pos, ir.OAS2, []ir.Node{ir.BlankNode, found}, []ir.Node{index})
+		init.Append(typecheck.Stmt(as))
+		res = found
+
+	case inSlice:
+		if lit, ok := y.(*ir.CompLitExpr); ok && isConstList(lit.List) && len(lit.List) <= maxInlineMembership &&
+			(!lit.Type().IsArray() || lit.Type().NumElem() == int64(len(lit.List))) {
+			// x == y[0] || x == y[1] || ..., unless the elements of an
+			// array literal such as [3]int{1} end in zero values
+			res = ir.NewBool(pos, false)
+			for i, elem := range lit.List {
+				eq := typecheck.Expr(ir.NewBinaryExpr(pos, ir.OEQ, x, elem))
+				if i == 0 {
+					res = eq
+				} else {
+					res = typecheck.Expr(ir.NewLogicalExpr(pos, ir.OOROR, res, eq))
+				}
+			}
+			break
+		}
+
+		// found = false
+		// for i := 0; i < len(y); i++ { if y[i] == x { found = true; break } }
+		y = r.tempCopy(pos, y, &init)
+		found := r.temp(pos, types.Types[types.TBOOL])
+		init.Append(typecheck.Stmt(ir.NewDecl(pos, ir.ODCL, found)))
+		init.Append(typecheck.Stmt(ir.NewAssignStmt(pos, found, ir.NewBool(pos, false))))
+		i := r.temp(pos, types.Types[types.TINT])
+		init.Append(typecheck.Stmt(ir.NewDecl(pos, ir.ODCL, i)))
+		init.Append(typecheck.Stmt(ir.NewAssignStmt(pos, i, ir.NewInt(pos, 0))))
+		cond := ir.NewBinaryExpr(pos, ir.OLT, i, ir.NewUnaryExpr(pos, ir.OLEN, y))
+		post := ir.NewAssignStmt(pos, i, ir.NewBinaryExpr(pos, ir.OADD, i, ir.NewInt(pos, 1)))
+		match := ir.NewIfStmt(pos, ir.NewBinaryExpr(pos, ir.OEQ, ir.NewIndexExpr(pos, y, i), x),
+			[]ir.Node{ir.NewAssignStmt(pos, found, ir.NewBool(pos, true)), ir.NewBranchStmt(pos, ir.OBREAK, nil)}, nil)
+		init.Append(typecheck.Stmt(ir.NewForStmt(pos, nil, cond, post, []ir.Node{match}, false)))
+		res = found
+
+	default:
+		base.FatalfAt(pos, "unexpected membership kind %v", kind)
+	}
+
+	return ir.InitExpr(init, res)
+}
+
+// isConstList reports whether list consists of unkeyed constants only.
+func isConstList(list []ir.Node) bool {
+	for _, n := range list {
+		if !ir.IsConstNode(n) {
+			return false
+		}
+	}
+	return true
+}
+
 // funcInst reads an instantiated function reference, and returns
 // three (possibly nil) expressions related to it:
 //
@@ -3072,6 +4256,158 @@ func (r *reader) compLit() ir.Node {
 	return lit
 }
 
+// spreadLit lowers a goo composite literal with spread elements ...x
+// into statements that build it in a temporary, after evaluating the
+// elements in order. A slice is allocated once, with room for all its
+// elements, and the elements and those of the spreads are appended to
+// it. The entries of spread maps and fields of spread structs, and the
+// key:value elements, are assigned in order, so that later ones
+// overwrite earlier ones.
+func (r *reader) spreadLit() ir.Node {
+	pos := r.pos()
+	typ := r.typ()
+	var rtype ir.Node
+	if typ.IsMap() {
+		rtype = r.rtype(pos)
+	}
+
+	var init ir.Nodes
+	stmt := func(n ir.Node) { init.Append(typecheck.Stmt(n)) }
+	eval := func(x ir.Node) ir.Node {
+		if x.Op() == ir.ONAME || ir.IsConstNode(x) {
+			return x
+		}
+		return r.tempCopy(pos, x, &init)
+	}
+
+	// conv returns the conversion of the elements, keys or values of a
+	// spread to the type dst, or nil if they have that type.
+	conv := func(pos src.XPos, dst *types.Type) func(ir.Node) ir.Node {
+		if !r.Bool() {
+			return nil
+		}
+		typeWord, srcRType := r.convRTTI(pos)
+		return func(v ir.Node) ir.Node {
+			n := ir.NewConvExpr(pos, ir.OCONV, dst, v)
+			n.TypeWord, n.SrcRType = typeWord, srcRType
+			n.SetImplicit(true)
+			return typecheck.Expr(n)
+		}
+	}
+
+	type element struct {
+		pos     src.XPos
+		spread  bool
+		key, x  ir.Node
+		field   *types.Field
+		rtype   ir.Node // of a spread map
+		keyConv func(ir.Node) ir.Node
+		conv    func(ir.Node) ir.Node
+	}
+	elems := make([]element, r.Len())
+	for i := range elems {
+		e := &elems[i]
+		e.spread = r.Bool()
+		e.pos = r.pos()
+		switch {
+		case e.spread && typ.IsStruct():
+			e.x = eval(r.expr())
+		case e.spread:
+			e.x = eval(r.expr())
+			if e.x.Type().IsMap() {
+				e.rtype = r.rtype(e.pos)
+				e.keyConv = conv(e.pos, typ.Key())
+			}
+			e.conv = conv(e.pos, typ.Elem())
+		case typ.IsStruct():
+			e.field = typ.Field(r.Len())
+			e.x = eval(r.expr())
+		case typ.IsMap():
+			e.key = eval(r.expr())
+			e.x = eval(r.expr())
+		default:
+			e.x = eval(r.expr())
+		}
+	}
+
+	res := r.temp(pos, typ)
+	stmt(ir.NewDecl(pos, ir.ODCL, res))
+
+	// size is the number of elements of a slice, or a hint for a map.
+	var size ir.Node
+	if !typ.IsStruct() {
+		size = ir.NewInt(pos, 0)
+		for _, e := range elems {
+			n := ir.NewInt(pos, 1)
+			if e.spread {
+				n = typecheck.Expr(ir.NewUnaryExpr(pos, ir.OLEN, e.x))
+			}
+			size = typecheck.Expr(ir.NewBinaryExpr(pos, ir.OADD, size, n))
+		}
+	}
+	switch {
+	case typ.IsSlice():
+		stmt(ir.NewAssignStmt(pos, res, ir.NewCallExpr(pos, ir.OMAKE, nil, []ir.Node{ir.TypeNode(typ), ir.NewInt(pos, 0), size})))
+	case typ.IsMap():
+		stmt(ir.NewAssignStmt(pos, res, ir.NewCallExpr(pos, ir.OMAKE, nil, []ir.Node{ir.TypeNode(typ), size})))
+	default:
+		stmt(ir.NewAssignStmt(pos, res, nil))
+	}
+
+	appendTo := func(pos src.XPos, v ir.Node, dots bool) ir.Node {
+		n := ir.NewCallExpr(pos, ir.OAPPEND, nil, []ir.Node{res, v})
+		n.IsDDD = dots
+		return ir.NewAssignStmt(pos, res, n)
+	}
+	setEntry := func(pos src.XPos, k, v ir.Node) ir.Node {
+		index := ir.NewIndexExpr(pos, res, k)
+		index.RType = rtype
+		return ir.NewAssignStmt(pos, index, v)
+	}
+	apply := func(f func(ir.Node) ir.Node, v ir.Node) ir.Node {
+		if f == nil {
+			return v
+		}
+		return f(v)
+	}
+
+	for _, e := range elems {
+		switch {
+		case e.spread && typ.IsStruct():
+			stmt(ir.NewAssignStmt(e.pos, res, e.x))
+		case e.spread && typ.IsSlice() && e.conv == nil && e.x.Type().IsSlice():
+			stmt(appendTo(e.pos, e.x, true))
+		case e.spread:
+			// for k, v := range x { res[k] = v } or { res = append(res, v) }
+			key := ir.Node(ir.BlankNode)
+			if e.x.Type().IsMap() {
+				k := r.temp(e.pos, e.x.Type().Key())
+				stmt(ir.NewDecl(e.pos, ir.ODCL, k))
+				key = k
+			}
+			val := r.temp(e.pos, e.x.Type().Elem())
+			stmt(ir.NewDecl(e.pos, ir.ODCL, val))
+			var body ir.Node
+			if typ.IsMap() {
+				body = setEntry(e.pos, apply(e.keyConv, key), apply(e.conv, val))
+			} else {
+				body = appendTo(e.pos, apply(e.conv, val), false)
+			}
+			rang := ir.NewRangeStmt(e.pos, key, val, e.x, []ir.Node{body}, false)
+			rang.RType = e.rtype
+			stmt(rang)
+		case typ.IsStruct():
+			stmt(ir.NewAssignStmt(e.pos, ir.NewSelectorExpr(e.pos, ir.OXDOT, res, e.field.Sym), e.x))
+		case typ.IsMap():
+			stmt(setEntry(e.pos, e.key, e.x))
+		default:
+			stmt(appendTo(e.pos, e.x, false))
+		}
+	}
+
+	return ir.InitExpr(init, res)
+}
+
 func (r *reader) funcLit() ir.Node {
 	r.Sync(pkgbits.SyncFuncLit)
 
@@ -3568,7 +4904,7 @@ func unifiedInlineCall(callerfn *ir.Func, call *ir.CallExpr, fn *ir.Func, inlInd
 		if !r.syntheticBody(call.Pos()) {
 			assert(r.Bool()) // have body
 
-			r.curfn.Body = r.stmts()
+			r.curfn.Body = r.funcStmts()
 			r.curfn.Endlineno = r.pos()
 		}
 
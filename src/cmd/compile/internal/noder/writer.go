@@ -197,6 +197,28 @@ type writer struct {
 	// derived tracks whether the type being written out references any
 	// type parameters. It's unused for writing non-type things.
 	derived bool
+
+	// condTypes maps the statements providing the branch values of goo
+	// if and switch expressions to the type of the expression.
+	condTypes map[*syntax.ExprStmt]types2.Type
+
+	// compTypes maps the statements providing the elements and keys of
+	// goo comprehensions to the element and key types.
+	compTypes map[*syntax.ExprStmt]compType
+
+	// olds maps the old calls in the goo ensures clauses of the
+	// function body being written to the indices of their values.
+	olds map[*syntax.CallExpr]int
+
+	// withs is the stack of the goo with statements enclosing the
+	// statement being written.
+	withs []*syntax.WithStmt
+}
+
+// A compType is the type of an element or key of a comprehension.
+type compType struct {
+	typ   types2.Type
+	isKey bool
 }
 
 // A writerDict tracks types and objects that are used by a declaration.
@@ -826,6 +848,42 @@ func (pw *pkgWriter) objIdx(obj types2.Object) index {
 	return w.Idx
 }
 
+// paramDefaults writes the default parameter values of fn, so that
+// importers can fill them in at call sites. Only constant and nil values
+// are written; other defaults are only marked present, and importers
+// report calls that omit their arguments. They follow everything else
+// in the object's RelocObj definition, where go/types importers, which
+// don't know about defaults, never look.
+func (w *writer) paramDefaults(fn *types2.Func) {
+	params := fn.Signature().Params()
+	n := 0
+	for i := 0; i < params.Len(); i++ {
+		if ok, _, _ := types2.ParamDefault(fn, i); ok {
+			n = i + 1
+		}
+	}
+
+	w.Len(n)
+	for i := 0; i < n; i++ {
+		ok, val, isNil := types2.ParamDefault(fn, i)
+		w.Bool(ok)
+		if ok {
+			w.Bool(isNil)
+			if w.Bool(val != nil) {
+				w.Value(val)
+			}
+		}
+	}
+}
+
+// contracts writes the source texts of the requires and ensures clauses
+// of fn for documentation. They follow its parameter defaults.
+func (w *writer) contracts(fn *types2.Func) {
+	requires, ensures := types2.Contracts(fn)
+	w.Strings(requires)
+	w.Strings(ensures)
+}
+
 // doObj writes the RelocObj definition for obj to w, and the
 // RelocObjExt definition to wext.
 func (w *writer) doObj(wext *writer, obj types2.Object) pkgbits.CodeObj {
@@ -853,6 +911,8 @@ func (w *writer) doObj(wext *writer, obj types2.Object) pkgbits.CodeObj {
 		w.typeParamNames(sig.TypeParams())
 		w.signature(sig)
 		w.pos(decl)
+		w.paramDefaults(obj)
+		w.contracts(obj)
 		wext.funcExt(obj)
 		return pkgbits.ObjFunc
 
@@ -886,6 +946,12 @@ func (w *writer) doObj(wext *writer, obj types2.Object) pkgbits.CodeObj {
 		for i := 0; i < named.NumMethods(); i++ {
 			w.method(wext, named.Method(i))
 		}
+		for i := 0; i < named.NumMethods(); i++ {
+			w.paramDefaults(named.Method(i))
+		}
+		for i := 0; i < named.NumMethods(); i++ {
+			w.contracts(named.Method(i))
+		}
 
 		return pkgbits.ObjType
 
@@ -1106,7 +1172,7 @@ func (w *writer) funcExt(obj *types2.Func) {
 	}
 
 	sig, block := obj.Type().(*types2.Signature), decl.Body
-	body, closureVars := w.p.bodyIdx(sig, block, w.dict)
+	body, closureVars := w.p.bodyIdx(sig, decl.Contracts, block, w.dict)
 	if len(closureVars) > 0 {
 		fmt.Fprintln(os.Stderr, "CLOSURE", closureVars)
 	}
@@ -1168,21 +1234,82 @@ func (w *writer) pragmaFlag(p ir.PragmaFlag) {
 // @@@ Function bodies
 
 // bodyIdx returns the index for the given function body (specified by
-// block), adding it to the export data
-func (pw *pkgWriter) bodyIdx(sig *types2.Signature, block *syntax.BlockStmt, dict *writerDict) (idx index, closureVars []posVar) {
+// block) and its goo contract clauses, adding it to the export data
+func (pw *pkgWriter) bodyIdx(sig *types2.Signature, contracts []*syntax.Contract, block *syntax.BlockStmt, dict *writerDict) (idx index, closureVars []posVar) {
 	w := pw.newWriter(pkgbits.SectionBody, pkgbits.SyncFuncBody)
 	w.sig = sig
 	w.dict = dict
 
 	w.declareParams(sig)
 	if w.Bool(block != nil) {
-		w.stmts(block.List)
+		w.funcStmts(contracts, block.List)
 		w.pos(block.Rbrace)
 	}
 
 	return w.Flush(), w.closureVars
 }
 
+// funcStmts writes the statements of a function body, preceded by the
+// clauses of its goo contracts: the requires clauses, the arguments of
+// the old calls in the ensures clauses, which are evaluated on entry,
+// and the ensures clauses. The compiler flag -contracts=false drops the
+// clauses.
+func (w *writer) funcStmts(contracts []*syntax.Contract, stmts []syntax.Stmt) {
+	var requires, ensures []*syntax.Contract
+	if base.Flag.Contracts {
+		for _, c := range contracts {
+			if c.Ensures {
+				ensures = append(ensures, c)
+			} else {
+				requires = append(requires, c)
+			}
+		}
+	}
+
+	w.Len(len(requires))
+	for _, c := range requires {
+		w.contract(c)
+	}
+
+	var olds []*syntax.CallExpr
+	for _, c := range ensures {
+		syntax.Inspect(c.Cond, func(n syntax.Node) bool {
+			if call, ok := n.(*syntax.CallExpr); ok && w.p.isBuiltin(call.Fun, "old") {
+				olds = append(olds, call)
+				return false // old calls don't nest
+			}
+			return true
+		})
+	}
+	w.Len(len(olds))
+	for i, call := range olds {
+		if w.olds == nil {
+			w.olds = make(map[*syntax.CallExpr]int)
+		}
+		w.olds[call] = i
+		w.pos(call)
+		w.expr(call.ArgList[0])
+	}
+
+	w.Len(len(ensures))
+	for _, c := range ensures {
+		w.contract(c)
+	}
+
+	w.stmts(stmts)
+}
+
+// contract writes a requires or ensures clause with its source text.
+func (w *writer) contract(c *syntax.Contract) {
+	w.pos(c)
+	w.expr(c.Cond)
+	keyword := "requires "
+	if c.Ensures {
+		keyword = "ensures "
+	}
+	w.String(keyword + c.Text)
+}
+
 func (w *writer) declareParams(sig *types2.Signature) {
 	addLocals := func(params *types2.Tuple) {
 		for i := 0; i < params.Len(); i++ {
@@ -1302,6 +1429,14 @@ func (w *writer) stmt1(stmt syntax.Stmt) {
 			w.expr(stmt.Lhs)
 			w.pos(stmt)
 
+		case stmt.Op != 0 && stmt.Op != syntax.Def && w.p.info.Operators[stmt.Lhs] != nil:
+			w.Code(stmtAssignOperator)
+			w.op(binOps[stmt.Op])
+			w.expr(stmt.Lhs)
+			w.pos(stmt)
+			w.expr(w.p.info.Operators[stmt.Lhs])
+			w.expr(stmt.Rhs)
+
 		case stmt.Op != 0 && stmt.Op != syntax.Def:
 			w.Code(stmtAssignOp)
 			w.op(binOps[stmt.Op])
@@ -1311,10 +1446,17 @@ func (w *writer) stmt1(stmt syntax.Stmt) {
 			var typ types2.Type
 			if stmt.Op != syntax.Shl && stmt.Op != syntax.Shr {
 				typ = w.p.typeOf(stmt.Lhs)
+				if elem := vectorElem(typ); elem != nil && vectorElem(w.p.typeOf(stmt.Rhs)) == nil {
+					typ = elem // goo: elementwise operation with a number
+				}
 			}
 			w.implicitConvExpr(typ, stmt.Rhs)
 
 		default:
+			if pat, ok := syntax.UnpackListExpr(stmt.Lhs)[0].(*syntax.PatternExpr); ok {
+				w.destructure(stmt, pat)
+				break
+			}
 			w.assignStmt(stmt, stmt.Lhs, stmt.Rhs)
 		}
 
@@ -1323,6 +1465,11 @@ func (w *writer) stmt1(stmt syntax.Stmt) {
 		w.blockStmt(stmt)
 
 	case *syntax.BranchStmt:
+		if n := w.withExits(stmt); n > 0 {
+			w.Code(stmtWithExit)
+			w.pos(stmt)
+			w.Len(n)
+		}
 		w.Code(stmtBranch)
 		w.pos(stmt)
 		var op ir.Op
@@ -1359,6 +1506,7 @@ func (w *writer) stmt1(stmt syntax.Stmt) {
 		w.Code(stmtCheck)
 		w.pos(stmt)
 		w.expr(stmt.Cond)
+		w.optExpr(stmt.Msg)
 
 	case *syntax.DeclStmt:
 		for _, decl := range stmt.DeclList {
@@ -1366,6 +1514,19 @@ func (w *writer) stmt1(stmt syntax.Stmt) {
 		}
 
 	case *syntax.ExprStmt:
+		if typ, ok := w.condTypes[stmt]; ok {
+			w.Code(stmtCondValue)
+			w.pos(stmt)
+			w.implicitConvExpr(typ, stmt.X)
+			break
+		}
+		if t, ok := w.compTypes[stmt]; ok {
+			w.Code(stmtCompValue)
+			w.pos(stmt)
+			w.Bool(t.isKey)
+			w.implicitConvExpr(t.typ, stmt.X)
+			break
+		}
 		w.Code(stmtExpr)
 		w.expr(stmt.X)
 
@@ -1408,6 +1569,10 @@ func (w *writer) stmt1(stmt syntax.Stmt) {
 	case *syntax.SwitchStmt:
 		w.Code(stmtSwitch)
 		w.switchStmt(stmt)
+
+	case *syntax.WithStmt:
+		w.Code(stmtWith)
+		w.withStmt(stmt)
 	}
 }
 
@@ -1475,27 +1640,96 @@ func (w *writer) assignStmt(pos poser, lhs0, rhs0 syntax.Expr) {
 	}
 
 	dstType := func(i int) types2.Type {
-		dst := lhs[i]
-
-		// Finding dstType is somewhat involved, because for VarDecl
-		// statements, the Names are only added to the info.{Defs,Uses}
-		// maps, not to info.Types.
-		if name, ok := syntax.Unparen(dst).(*syntax.Name); ok {
-			if name.Value == "_" {
-				return nil // ok: no implicit conversion
-			} else if defi, ok := w.p.info.Defs[name].(*types2.Var); ok {
-				return defi.Type()
-			} else if use, ok := w.p.info.Uses[name].(*types2.Var); ok {
-				return use.Type()
-			} else {
-				w.p.fatalf(dst, "cannot find type of destination object: %v", dst)
-			}
+		return w.dstType(lhs[i])
+	}
+
+	w.multiExpr(pos, dstType, rhs)
+}
+
+// dstType returns the type of the destination dst of an assignment, or
+// nil if dst is the blank identifier.
+func (w *writer) dstType(dst syntax.Expr) types2.Type {
+	// Finding dstType is somewhat involved, because for VarDecl
+	// statements, the Names are only added to the info.{Defs,Uses}
+	// maps, not to info.Types.
+	if name, ok := syntax.Unparen(dst).(*syntax.Name); ok {
+		if name.Value == "_" {
+			return nil // ok: no implicit conversion
+		} else if defi, ok := w.p.info.Defs[name].(*types2.Var); ok {
+			return defi.Type()
+		} else if use, ok := w.p.info.Uses[name].(*types2.Var); ok {
+			return use.Type()
+		} else {
+			w.p.fatalf(dst, "cannot find type of destination object: %v", dst)
 		}
+	}
+
+	return w.p.typeOf(dst)
+}
 
-		return w.p.typeOf(dst)
+// destructure writes the goo destructuring assignment stmt, whose left
+// side starts with the pattern pat. The reader lowers it to assignments
+// from the fields, map values or elements of a temporary holding the
+// value on the right side.
+func (w *writer) destructure(stmt *syntax.AssignStmt, pat *syntax.PatternExpr) {
+	var names []syntax.Expr
+	for _, name := range pat.Names {
+		names = append(names, name)
 	}
+	if pat.Rest != nil {
+		names = append(names, pat.Rest)
+	}
+	names = append(names, syntax.UnpackListExpr(stmt.Lhs)[1:]...)
 
-	w.multiExpr(pos, dstType, rhs)
+	typ := w.p.typeOf(stmt.Rhs)
+	kind := patternStruct
+	switch types2.CoreType(typ).(type) {
+	case *types2.Map:
+		kind = patternMap
+	case *types2.Slice, *types2.Array:
+		kind = patternSlice
+	}
+
+	w.Code(stmtDestructure)
+	w.Int(int(kind))
+	w.pos(stmt)
+	w.Len(len(names))
+	for _, name := range names {
+		w.assign(name)
+	}
+	w.expr(stmt.Rhs)
+	w.Len(len(pat.Names))
+	w.Bool(pat.Rest != nil)
+	if kind == patternMap {
+		w.rtype(typ)
+		w.typ(types2.CoreType(typ).(*types2.Map).Key())
+	}
+
+	for i, name := range names {
+		var src types2.Type
+		switch {
+		case i == len(pat.Names) && pat.Rest != nil:
+			src = typ
+			if arr, ok := types2.CoreType(typ).(*types2.Array); ok {
+				src = types2.NewSlice(arr.Elem())
+			}
+		case i >= len(pat.Names):
+			src = types2.Typ[types2.Bool] // ok
+		case kind == patternStruct:
+			obj, _, _ := types2.LookupFieldOrMethod(typ, false, w.p.curpkg, pat.Names[i].Value)
+			w.selector(obj)
+			src = obj.Type()
+		case kind == patternMap:
+			w.String(pat.Names[i].Value)
+			src = types2.CoreType(typ).(*types2.Map).Elem()
+		default:
+			src = types2.CoreType(typ).(interface{ Elem() types2.Type }).Elem()
+		}
+		if dst := w.dstType(name); w.Bool(dst != nil && !types2.Identical(src, dst)) {
+			w.typ(dst)
+			w.convRTTI(src, dst)
+		}
+	}
 }
 
 func (w *writer) blockStmt(stmt *syntax.BlockStmt) {
@@ -1602,6 +1836,66 @@ func (w *writer) ifStmt(stmt *syntax.IfStmt) {
 	w.closeAnotherScope()
 }
 
+// withStmt writes the goo with statement stmt: for each binding, the
+// assignment of its variables and, for a binding ending in ?, of the
+// error to a temporary, and the conversion of the resource to any, which
+// the reader pushes; followed by the block.
+func (w *writer) withStmt(stmt *syntax.WithStmt) {
+	w.openScope(stmt.Pos())
+	w.pos(stmt)
+	w.Len(len(stmt.Bindings))
+	for _, b := range stmt.Bindings {
+		names := syntax.UnpackListExpr(b.Lhs)
+		w.pos(b)
+		w.Bool(b.Try)
+
+		// As if w.assignList(b.Lhs), with a blank for the error.
+		if b.Try {
+			w.Len(len(names) + 1)
+		} else {
+			w.Len(len(names))
+		}
+		for _, name := range names {
+			w.assign(name)
+		}
+		if b.Try {
+			w.Code(assignBlank)
+		}
+
+		dstType := func(i int) types2.Type {
+			if i < len(names) {
+				return w.p.info.Defs[names[i].(*syntax.Name)].(*types2.Var).Type()
+			}
+			return nil
+		}
+		w.multiExpr(b, dstType, []syntax.Expr{b.Rhs})
+		w.convRTTI(dstType(0), anyTypeName.Type())
+	}
+
+	w.withs = append(w.withs, stmt)
+	w.blockStmt(stmt.Body)
+	w.withs = w.withs[:len(w.withs)-1]
+	w.closeAnotherScope()
+}
+
+// withExits returns the number of resources of the enclosing with
+// statements that the branch statement stmt leaves.
+func (w *writer) withExits(stmt *syntax.BranchStmt) int {
+	if stmt.Target == nil {
+		return 0
+	}
+	pos := stmt.Target.Pos()
+	n := 0
+	for i := len(w.withs) - 1; i >= 0; i-- {
+		body := w.withs[i].Body
+		if pos.Cmp(body.Pos()) > 0 && pos.Cmp(body.Rbrace) < 0 {
+			break
+		}
+		n += len(w.withs[i].Bindings)
+	}
+	return n
+}
+
 func (w *writer) selectStmt(stmt *syntax.SelectStmt) {
 	w.Sync(pkgbits.SyncSelectStmt)
 
@@ -1882,9 +2176,60 @@ func (w *writer) expr(expr syntax.Expr) {
 		w.p.unexpected("expression", expr)
 
 	case *syntax.CompositeLit:
+		if hasSpread(expr) {
+			w.Code(exprSpread)
+			w.spreadLit(expr)
+			break
+		}
 		w.Code(exprCompLit)
 		w.compLit(expr)
 
+	case *syntax.CondExpr:
+		typ := w.p.typeOf(expr)
+		if w.condTypes == nil {
+			w.condTypes = make(map[*syntax.ExprStmt]types2.Type)
+		}
+		for _, s := range syntax.CondValues(expr) {
+			w.condTypes[s] = typ
+		}
+
+		w.Code(exprCond)
+		w.pos(expr)
+		w.typ(typ)
+		w.stmt(expr.Stmt)
+
+	case *syntax.CompExpr:
+		typ := w.p.typeOf(expr)
+		clauses, cond, values := syntax.CompClauses(expr)
+		if w.compTypes == nil {
+			w.compTypes = make(map[*syntax.ExprStmt]compType)
+		}
+		if expr.Map {
+			m := types2.CoreType(typ).(*types2.Map)
+			w.compTypes[values[0]] = compType{m.Key(), true}
+			w.compTypes[values[1]] = compType{m.Elem(), false}
+		} else {
+			w.compTypes[values[0]] = compType{sliceElem(typ), false}
+		}
+
+		// With a single for clause and no filter, the number of elements
+		// is the length of the range expression, unless it is a string.
+		prealloc := false
+		if len(clauses) == 1 && cond == nil {
+			switch t := types2.CoreType(w.p.typeOf(clauses[0].X)).(type) {
+			case *types2.Basic:
+				prealloc = t.Info()&types2.IsInteger != 0
+			case *types2.Slice, *types2.Array, *types2.Map:
+				prealloc = true
+			}
+		}
+
+		w.Code(exprComp)
+		w.pos(expr)
+		w.typ(typ)
+		w.Bool(prealloc)
+		w.stmt(expr.Loop)
+
 	case *syntax.FuncLit:
 		w.Code(exprFuncLit)
 		w.funcLit(expr)
@@ -1939,6 +2284,10 @@ func (w *writer) expr(expr syntax.Expr) {
 		}
 
 	case *syntax.IndexExpr:
+		if expr.OneBased {
+			w.hashIndex(expr.X, expr.Index, nil, expr)
+			break
+		}
 		_ = w.p.typeOf(expr.Index) // ensure this is an index expression, not an instantiation
 
 		xtyp := w.p.typeOf(expr.X)
@@ -1957,6 +2306,10 @@ func (w *writer) expr(expr syntax.Expr) {
 		}
 
 	case *syntax.SliceExpr:
+		if expr.OneBased {
+			w.hashIndex(expr.X, expr.Index[0], expr.Index[1], expr)
+			break
+		}
 		w.Code(exprSlice)
 		w.expr(expr.X)
 		w.pos(expr)
@@ -1974,6 +2327,32 @@ func (w *writer) expr(expr syntax.Expr) {
 		w.rtype(iface)
 
 	case *syntax.Operation:
+		if expr.Op == syntax.In || expr.Op == syntax.NotIn {
+			w.membership(expr)
+			break
+		}
+
+		if fn := w.p.info.Operators[expr]; fn != nil {
+			w.Code(exprOperator)
+			w.op(binOps[expr.Op])
+			w.pos(expr)
+			w.typ(w.p.typeOf(expr))
+			w.expr(fn)
+			w.expr(expr.X)
+			w.expr(expr.Y)
+			break
+		}
+
+		if w.p.isVector(expr) {
+			w.Code(exprVector)
+			w.op(binOps[expr.Op])
+			w.pos(expr)
+			w.typ(w.p.typeOf(expr))
+			w.vectorOperand(expr.X, expr.Y)
+			w.vectorOperand(expr.Y, expr.X)
+			break
+		}
+
 		if expr.Y == nil {
 			w.Code(exprUnaryOp)
 			w.op(unOps[expr.Op])
@@ -2016,6 +2395,10 @@ func (w *writer) expr(expr syntax.Expr) {
 
 		var rtype types2.Type
 		if tv.IsBuiltin() {
+			if w.p.collectionMethod(expr) != "" {
+				w.collection(expr)
+				return
+			}
 			switch obj, _ := lookupObj(w.p, syntax.Unparen(expr.Fun)); obj.Name() {
 			case "make":
 				assert(len(expr.ArgList) >= 1)
@@ -2082,6 +2465,37 @@ func (w *writer) expr(expr syntax.Expr) {
 				}
 				return
 
+			case "old":
+				w.Code(exprOld)
+				w.Len(w.olds[expr])
+				return
+
+			case "sum", "dot":
+				w.Code(exprVectorReduce)
+				w.pos(expr)
+				w.exprs(expr.ArgList)
+				return
+
+			case "dbg":
+				assert(len(expr.ArgList) == 1)
+				assert(!expr.HasDots)
+
+				w.Code(exprDbg)
+				w.pos(expr)
+				pos := expr.Fun.Pos()
+				file := pos.RelFilename()
+				file = file[strings.LastIndexAny(file, `/\`)+1:]
+				w.String(fmt.Sprintf("%s:%d", file, pos.Line()))
+				text := expr.ArgText
+				if text == "" {
+					var b strings.Builder
+					syntax.Fprint(&b, expr.ArgList[0], syntax.LineForm)
+					text = b.String()
+				}
+				w.String(text)
+				w.implicitConvExpr(w.p.typeOf(expr), expr.ArgList[0])
+				return
+
 			case "append":
 				rtype = sliceElem(w.p.typeOf(expr))
 			case "copy":
@@ -2129,6 +2543,17 @@ func (w *writer) expr(expr syntax.Expr) {
 		sigType := types2.CoreType(tv.Type).(*types2.Signature)
 		paramTypes := sigType.Params()
 
+		if expr.ArgOrder != nil {
+			// goo: evaluate named arguments in the order they are written
+			w.Code(exprNamedCall)
+			w.pos(expr)
+			w.Len(paramTypes.Len())
+			w.Len(len(expr.ArgOrder))
+			for _, i := range expr.ArgOrder {
+				w.Len(i)
+			}
+		}
+
 		w.Code(exprCall)
 		writeFunExpr()
 		w.pos(expr)
@@ -2307,6 +2732,156 @@ func (w *writer) multiExpr(pos poser, dstType func(int) types2.Type, exprs []syn
 	}
 }
 
+// hashIndex writes the goo index expression x#i, or the slice
+// expression x#i:j if j is non-nil. Indices are converted to int,
+// which is what the runtime helpers expect, or to the key type of a map.
+func (w *writer) hashIndex(x, i, j syntax.Expr, expr syntax.Expr) {
+	xtyp := w.p.typeOf(x)
+	if typ, ok := types2.CoreType(xtyp).(*types2.Map); ok {
+		w.Code(exprHashMapIndex)
+		w.expr(x)
+		w.pos(expr)
+		w.implicitConvExpr(typ.Key(), i)
+		w.rtype(xtyp)
+		return
+	}
+
+	isString := false
+	if typ, ok := types2.CoreType(xtyp).(*types2.Basic); ok {
+		isString = typ.Info()&types2.IsString != 0
+	}
+
+	if j == nil {
+		w.Code(exprHashIndex)
+	} else {
+		w.Code(exprHashSlice)
+	}
+	w.Bool(isString)
+	w.expr(x)
+	w.pos(expr)
+	w.convertExpr(types2.Typ[types2.Int], i, false)
+	if j != nil {
+		w.convertExpr(types2.Typ[types2.Int], j, false)
+	}
+}
+
+// collCodes maps the names of the goo collection methods to their codes.
+var collCodes = map[string]codeColl{
+	"map":     collMap,
+	"filter":  collFilter,
+	"keys":    collKeys,
+	"values":  collValues,
+	"reduce":  collReduce,
+	"sum":     collSum,
+	"min":     collMin,
+	"max":     collMax,
+	"sort":    collSort,
+	"sortBy":  collSortBy,
+	"groupBy": collGroupBy,
+	"join":    collJoin,
+	"each":    collEach,
+	"any":     collAny,
+	"all":     collAll,
+}
+
+// collectionMethod returns the name of the goo collection method called
+// by expr, as in xs.map(f), or "" if expr is not such a call.
+func (pw *pkgWriter) collectionMethod(expr syntax.Expr) string {
+	call, ok := syntax.Unparen(expr).(*syntax.CallExpr)
+	if !ok {
+		return ""
+	}
+	sel, ok := syntax.Unparen(call.Fun).(*syntax.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	// Unlike unsafe's built-ins, collection methods belong to no package.
+	if b, ok := pw.info.Uses[sel.Sel].(*types2.Builtin); ok && b.Pkg() == nil {
+		return b.Name()
+	}
+	return ""
+}
+
+// vectorElem returns the element type of typ if it is a slice of
+// integers or floats, which goo's arithmetic operators apply to
+// elementwise, and nil otherwise.
+func vectorElem(typ types2.Type) types2.Type {
+	if s, ok := typ.Underlying().(*types2.Slice); ok {
+		if b, ok := s.Elem().Underlying().(*types2.Basic); ok && b.Info()&(types2.IsInteger|types2.IsFloat) != 0 {
+			return s.Elem()
+		}
+	}
+	return nil
+}
+
+// isVector reports whether the binary operation expr applies
+// elementwise to a slice of numbers. A comparison does if types2 gave
+// it a []bool mask as its type.
+func (pw *pkgWriter) isVector(expr *syntax.Operation) bool {
+	if expr.Y == nil {
+		return false
+	}
+	switch expr.Op {
+	case syntax.Eql, syntax.Neq, syntax.Lss, syntax.Leq, syntax.Gtr, syntax.Geq:
+		s, ok := pw.typeOf(expr).Underlying().(*types2.Slice)
+		return ok && types2.Identical(s.Elem(), types2.Typ[types2.Bool])
+	case syntax.Add, syntax.Sub, syntax.Mul, syntax.Div:
+		return vectorElem(pw.typeOf(expr.X)) != nil || vectorElem(pw.typeOf(expr.Y)) != nil
+	}
+	return false
+}
+
+// vectorOperand writes the operand x of an elementwise operation with
+// the other operand y. A number is converted to the element type.
+func (w *writer) vectorOperand(x, y syntax.Expr) {
+	var typ types2.Type
+	if vectorElem(w.p.typeOf(x)) == nil {
+		typ = vectorElem(w.p.typeOf(y))
+	}
+	w.implicitConvExpr(typ, x)
+}
+
+// collection writes the chain of goo collection method calls ending in
+// expr, like xs.map(f).filter(g).sum(). The reader lowers the chain into
+// a single loop over the source collection: streaming calls inside the
+// chain allocate no intermediate collections. Other calls, like sort,
+// end a chain and their result is the source of the next one.
+func (w *writer) collection(expr *syntax.CallExpr) {
+	calls := []*syntax.CallExpr{expr}
+	var src syntax.Expr
+	for {
+		sel := syntax.Unparen(calls[len(calls)-1].Fun).(*syntax.SelectorExpr)
+		if name := w.p.collectionMethod(sel.X); name == "" || !collCodes[name].streaming() {
+			src = sel.X
+			break
+		}
+		calls = append(calls, syntax.Unparen(sel.X).(*syntax.CallExpr))
+	}
+
+	w.Code(exprCollection)
+	w.pos(expr)
+	w.expr(src)
+	w.Len(len(calls))
+	for i := len(calls) - 1; i >= 0; i-- {
+		call := calls[i]
+		code := collCodes[w.p.collectionMethod(call)]
+		w.Int(int(code))
+		w.pos(call)
+		switch code {
+		case collReduce:
+			w.implicitConvExpr(w.p.typeOf(call), call.ArgList[0])
+			w.expr(call.ArgList[1])
+		case collJoin:
+			w.implicitConvExpr(types2.Typ[types2.String], call.ArgList[0])
+		default:
+			w.exprs(call.ArgList)
+		}
+	}
+	if tv := w.p.typeAndValue(expr); w.Bool(!tv.IsVoid()) {
+		w.typ(tv.Type)
+	}
+}
+
 // implicitConvExpr is like expr, but if dst is non-nil and different
 // from expr's type, then an implicit conversion operation is inserted
 // at expr's position.
@@ -2314,6 +2889,58 @@ func (w *writer) implicitConvExpr(dst types2.Type, expr syntax.Expr) {
 	w.convertExpr(dst, expr, true)
 }
 
+// membership writes the membership test x in y (or x ∉ y). The
+// reader lowers it according to the kind of y; x is converted to
+// the element, key or rune type it is compared against.
+func (w *writer) membership(expr *syntax.Operation) {
+	xtyp := w.p.typeOf(expr.X)
+	ytyp := w.p.typeOf(expr.Y)
+
+	var kind codeIn
+	var elem types2.Type
+	switch typ := types2.CoreType(ytyp).(type) {
+	case *types2.Slice:
+		kind, elem = inSlice, typ.Elem()
+	case *types2.Array:
+		kind, elem = inSlice, typ.Elem()
+	case *types2.Map:
+		kind, elem = inMap, typ.Key()
+	case *types2.Basic:
+		switch {
+		case typ.Info()&types2.IsInteger != 0:
+			kind, elem = inRange, ytyp
+		case types2.CoreType(xtyp).(*types2.Basic).Info()&types2.IsString != 0:
+			kind, elem = inString, types2.Typ[types2.String]
+		case types2.CoreType(xtyp).(*types2.Basic).Kind() == types2.Uint8:
+			kind, elem = inStringByte, types2.Typ[types2.Uint8]
+		default:
+			kind, elem = inStringRune, types2.Typ[types2.Int32]
+		}
+	default:
+		w.p.fatalf(expr, "unexpected membership container type: %v", ytyp)
+	}
+
+	w.Code(exprIn)
+	w.Int(int(kind))
+	w.Bool(expr.Op == syntax.NotIn)
+	w.typ(types2.Default(w.p.typeOf(expr)))
+	if kind == inStringRune {
+		w.convertExpr(elem, expr.X, false) // untyped or other integer to rune
+	} else {
+		w.implicitConvExpr(elem, expr.X)
+	}
+	w.pos(expr)
+	switch kind {
+	case inString, inStringRune, inStringByte:
+		w.implicitConvExpr(types2.Typ[types2.String], expr.Y)
+	default:
+		w.expr(expr.Y)
+	}
+	if kind == inMap {
+		w.rtype(ytyp)
+	}
+}
+
 func (w *writer) convertExpr(dst types2.Type, expr syntax.Expr, implicit bool) {
 	src := w.p.typeOf(expr)
 
@@ -2390,10 +3017,91 @@ func (w *writer) compLit(lit *syntax.CompositeLit) {
 	}
 }
 
+// hasSpread reports whether the composite literal lit has a goo spread
+// element ...x.
+func hasSpread(lit *syntax.CompositeLit) bool {
+	for _, elem := range lit.ElemList {
+		if _, ok := elem.(*syntax.DotsType); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// spreadLit writes the goo slice, map or struct literal lit, which has
+// spread elements. Like compLit, it converts the other elements to the
+// element, key or field types of the literal. A spread slice or map is
+// followed by the conversions of its elements, or keys and values, and
+// a spread struct is converted itself.
+func (w *writer) spreadLit(lit *syntax.CompositeLit) {
+	typ := w.p.typeOf(lit)
+
+	w.pos(lit)
+	w.typ(typ)
+
+	var keyType, elemType types2.Type
+	var structType *types2.Struct
+	switch typ0 := typ; typ := types2.CoreType(typ).(type) {
+	default:
+		w.p.fatalf(lit, "unexpected spread literal type: %v", typ)
+	case *types2.Map:
+		w.rtype(typ0)
+		keyType, elemType = typ.Key(), typ.Elem()
+	case *types2.Slice:
+		elemType = typ.Elem()
+	case *types2.Struct:
+		structType = typ
+	}
+	conv := func(src, dst types2.Type) {
+		if w.Bool(!types2.Identical(src, dst)) {
+			w.convRTTI(src, dst)
+		}
+	}
+
+	w.Len(len(lit.ElemList))
+	for _, elem := range lit.ElemList {
+		if dots, ok := elem.(*syntax.DotsType); w.Bool(ok) {
+			w.pos(dots)
+			if structType != nil {
+				w.implicitConvExpr(typ, dots.Elem)
+				continue
+			}
+			w.expr(dots.Elem)
+			switch xtyp := w.p.typeOf(dots.Elem); x := types2.CoreType(xtyp).(type) {
+			case *types2.Map:
+				w.rtype(xtyp)
+				conv(x.Key(), keyType)
+				conv(x.Elem(), elemType)
+			case *types2.Slice:
+				conv(x.Elem(), elemType)
+			case *types2.Array:
+				conv(x.Elem(), elemType)
+			}
+			continue
+		}
+
+		// Slice literals with spreads have no indices.
+		switch kv, _ := elem.(*syntax.KeyValueExpr); {
+		case structType != nil:
+			i := fieldIndex(w.p.info, structType, kv.Key.(*syntax.Name))
+			w.pos(kv.Key)
+			w.Len(i)
+			w.implicitConvExpr(structType.Field(i).Type(), kv.Value)
+		case keyType != nil:
+			w.pos(kv.Key)
+			w.implicitConvExpr(keyType, kv.Key)
+			w.implicitConvExpr(elemType, kv.Value)
+		default:
+			w.pos(elem)
+			w.implicitConvExpr(elemType, elem)
+		}
+	}
+}
+
 func (w *writer) funcLit(expr *syntax.FuncLit) {
 	sig := w.p.typeOf(expr).(*types2.Signature)
 
-	body, closureVars := w.p.bodyIdx(sig, expr.Body, w.dict)
+	body, closureVars := w.p.bodyIdx(sig, nil, expr.Body, w.dict)
 
 	w.Sync(pkgbits.SyncFuncLit)
 	w.pos(expr)
@@ -3164,6 +3872,8 @@ func (pw *pkgWriter) terminates(stmt syntax.Stmt) bool {
 		return (cond < 0 || pw.terminates(stmt.Then)) && (cond > 0 || pw.terminates(stmt.Else))
 	case *syntax.BlockStmt:
 		return pw.terminates(lastNonEmptyStmt(stmt.List))
+	case *syntax.WithStmt:
+		return pw.terminates(stmt.Body)
 	}
 
 	return false
@@ -38,6 +38,10 @@ func MakeTask() {
 		}
 		deps = append(deps, n.Linksym())
 	}
+	// goo: the dbg builtin calls into runtime/dbg without importing it.
+	if typecheck.DbgUsed {
+		deps = append(deps, ir.Pkgs.Dbg.Lookup(".inittask").Linksym())
+	}
 	if base.Flag.ASan {
 		// Make an initialization function to call runtime.asanregisterglobals to register an
 		// array of instrumented global variables when -asan is enabled. An instrumented global
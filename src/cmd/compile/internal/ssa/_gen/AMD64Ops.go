@@ -171,6 +171,15 @@ func init() {
 		fpstoreidx = regInfo{inputs: []regMask{gpspsb, gpsp, fp, 0}}
 
 		prefreg = regInfo{inputs: []regMask{gpspsbg}}
+
+		// goo's SIMD loops, which advance their pointers and count down n
+		vecop       = regInfo{inputs: []regMask{buildReg("DI"), buildReg("SI"), buildReg("DX"), cx}, clobbers: buildReg("DI SI DX CX X0 X1 X2 X3 X4 X5 X6 X7")}
+		vecopscalar = regInfo{inputs: []regMask{buildReg("DI"), buildReg("SI"), buildReg("X8"), cx}, clobbers: buildReg("DI SI CX X0 X1 X2 X3 X4 X5 X6 X7 X8")}
+		vecsum      = regInfo{inputs: []regMask{buildReg("SI"), cx}, outputs: []regMask{buildReg("X0")}, clobbers: buildReg("SI CX X1 X2 X3 X4 X5 X6 X7")}
+		vecdot      = regInfo{inputs: []regMask{buildReg("SI"), buildReg("DX"), cx}, outputs: []regMask{buildReg("X0")}, clobbers: buildReg("SI DX CX X1 X2 X3 X4 X5 X6 X7")}
+		vecopint       = regInfo{inputs: []regMask{buildReg("DI"), buildReg("SI"), buildReg("DX"), cx}, clobbers: buildReg("DI SI DX CX AX X0 X1 X2 X3 X4 X5 X6 X7")}
+		vecopscalarint = regInfo{inputs: []regMask{buildReg("DI"), buildReg("SI"), buildReg("DX"), cx}, clobbers: buildReg("DI SI CX AX X0 X1 X2 X3 X4 X5 X6 X7 X8")}
+		vecsumint      = regInfo{inputs: []regMask{buildReg("SI"), cx}, outputs: []regMask{ax}, clobbers: buildReg("SI CX X0 X1 X2 X3 X4 X5 X6 X7")}
 	)
 
 	var AMD64ops = []opData{
@@ -957,6 +966,58 @@ func init() {
 			faultOnNilArg1: true,
 		},
 
+		// goo's arithmetic on slices of floats: SIMD loops for the generic
+		// VecOp, VecOpScalar, VecSum and VecDot ops, with the same
+		// arguments. auxint of the VecOp ops is the VecKind of the
+		// operation. Each iteration handles 64 bytes, in four XMM
+		// registers or, in the AVX ops for GOAMD64=v3 and up, two YMM
+		// registers; the remaining elements follow one at a time. The
+		// loops are not preemptible, since asynchronous preemption does
+		// not preserve the upper halves of the YMM registers.
+		{name: "LoweredVecOp32F", argLength: 5, aux: "Int8", reg: vecop, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOp64F", argLength: 5, aux: "Int8", reg: vecop, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpScalar32F", argLength: 5, aux: "Int8", reg: vecopscalar, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpScalar64F", argLength: 5, aux: "Int8", reg: vecopscalar, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecSum32F", argLength: 3, reg: vecsum, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecSum64F", argLength: 3, reg: vecsum, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecDot32F", argLength: 4, reg: vecdot, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecDot64F", argLength: 4, reg: vecdot, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpAVX32F", argLength: 5, aux: "Int8", reg: vecop, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpAVX64F", argLength: 5, aux: "Int8", reg: vecop, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpScalarAVX32F", argLength: 5, aux: "Int8", reg: vecopscalar, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpScalarAVX64F", argLength: 5, aux: "Int8", reg: vecopscalar, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecSumAVX32F", argLength: 3, reg: vecsum, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecSumAVX64F", argLength: 3, reg: vecsum, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecDotAVX32F", argLength: 4, reg: vecdot, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecDotAVX64F", argLength: 4, reg: vecdot, clobberFlags: true, unsafePoint: true},
+
+		// The same for slices of integers, whose scalar is in DX and
+		// whose sum is in AX. The remaining elements go through AX.
+		{name: "LoweredVecOp8", argLength: 5, aux: "Int8", reg: vecopint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOp16", argLength: 5, aux: "Int8", reg: vecopint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOp32", argLength: 5, aux: "Int8", reg: vecopint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOp64", argLength: 5, aux: "Int8", reg: vecopint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpScalar8", argLength: 5, aux: "Int8", reg: vecopscalarint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpScalar16", argLength: 5, aux: "Int8", reg: vecopscalarint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpScalar32", argLength: 5, aux: "Int8", reg: vecopscalarint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpScalar64", argLength: 5, aux: "Int8", reg: vecopscalarint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecSum8", argLength: 3, reg: vecsumint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecSum16", argLength: 3, reg: vecsumint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecSum32", argLength: 3, reg: vecsumint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecSum64", argLength: 3, reg: vecsumint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpAVX8", argLength: 5, aux: "Int8", reg: vecopint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpAVX16", argLength: 5, aux: "Int8", reg: vecopint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpAVX32", argLength: 5, aux: "Int8", reg: vecopint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpAVX64", argLength: 5, aux: "Int8", reg: vecopint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpScalarAVX8", argLength: 5, aux: "Int8", reg: vecopscalarint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpScalarAVX16", argLength: 5, aux: "Int8", reg: vecopscalarint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpScalarAVX32", argLength: 5, aux: "Int8", reg: vecopscalarint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpScalarAVX64", argLength: 5, aux: "Int8", reg: vecopscalarint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecSumAVX8", argLength: 3, reg: vecsumint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecSumAVX16", argLength: 3, reg: vecsumint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecSumAVX32", argLength: 3, reg: vecsumint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecSumAVX64", argLength: 3, reg: vecsumint, clobberFlags: true, unsafePoint: true},
+
 		// (InvertFlags (CMPQ a b)) == (CMPQ b a)
 		// So if we want (SETL (CMPQ a b)) but we can't do that because a is a constant,
 		// then we do (SETL (InvertFlags (CMPQ b a))) instead.
@@ -189,6 +189,15 @@ func init() {
 		fpstore2       = regInfo{inputs: []regMask{gpspsbg, fp, fp}}
 		readflags      = regInfo{inputs: nil, outputs: []regMask{gp}}
 		prefreg        = regInfo{inputs: []regMask{gpspsbg}}
+
+		// goo's SIMD loops, which advance their pointers and count down n
+		vecop       = regInfo{inputs: []regMask{r0, r1, r2, r3}, clobbers: buildReg("R0 R1 R2 R3 F0 F1 F2 F3 F4 F5 F6 F7")}
+		vecopscalar = regInfo{inputs: []regMask{r0, r1, buildReg("F16"), r3}, clobbers: buildReg("R0 R1 R3 F0 F1 F2 F3 F4 F5 F6 F7 F16")}
+		vecsum      = regInfo{inputs: []regMask{r1, r3}, outputs: []regMask{buildReg("F16")}, clobbers: buildReg("R1 R3 F0 F1 F2 F3 F4 F5 F6 F7 F17 F18 F19")}
+		vecdot      = regInfo{inputs: []regMask{r1, r2, r3}, outputs: []regMask{buildReg("F16")}, clobbers: buildReg("R1 R2 R3 F0 F1 F2 F3 F4 F5 F6 F7 F17 F18 F19")}
+		vecopint       = regInfo{inputs: []regMask{r0, r1, r2, r3}, clobbers: buildReg("R0 R1 R2 R3 R4 R5 F0 F1 F2 F3 F4 F5 F6 F7")}
+		vecopscalarint = regInfo{inputs: []regMask{r0, r1, r2, r3}, clobbers: buildReg("R0 R1 R3 R4 F0 F1 F2 F3 F4 F5 F6 F7 F16")}
+		vecsumint      = regInfo{inputs: []regMask{r1, r3}, outputs: []regMask{r0}, clobbers: buildReg("R1 R3 R4 F0 F1 F2 F3 F4 F5 F6 F7 F16 F17 F18 F19")}
 	)
 	ops := []opData{
 		// binary ops
@@ -624,6 +633,38 @@ func init() {
 			faultOnNilArg1: true,
 		},
 
+		// goo's arithmetic on slices of floats: SIMD loops for the generic
+		// VecOp, VecOpScalar, VecSum and VecDot ops, with the same
+		// arguments. auxint of the VecOp ops is the VecKind of the
+		// operation. Each iteration handles 64 bytes in four vector
+		// registers; the remaining elements follow one at a time. The
+		// loops are not preemptible, since asynchronous preemption only
+		// preserves the low 64 bits of the vector registers.
+		{name: "LoweredVecOp32F", argLength: 5, aux: "Int8", reg: vecop, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOp64F", argLength: 5, aux: "Int8", reg: vecop, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpScalar32F", argLength: 5, aux: "Int8", reg: vecopscalar, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpScalar64F", argLength: 5, aux: "Int8", reg: vecopscalar, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecSum32F", argLength: 3, reg: vecsum, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecSum64F", argLength: 3, reg: vecsum, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecDot32F", argLength: 4, reg: vecdot, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecDot64F", argLength: 4, reg: vecdot, clobberFlags: true, unsafePoint: true},
+
+		// The same for slices of integers, whose scalar is in R2 and
+		// whose sum is in R0. The remaining elements go through R4 and
+		// R5.
+		{name: "LoweredVecOp8", argLength: 5, aux: "Int8", reg: vecopint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOp16", argLength: 5, aux: "Int8", reg: vecopint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOp32", argLength: 5, aux: "Int8", reg: vecopint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOp64", argLength: 5, aux: "Int8", reg: vecopint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpScalar8", argLength: 5, aux: "Int8", reg: vecopscalarint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpScalar16", argLength: 5, aux: "Int8", reg: vecopscalarint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpScalar32", argLength: 5, aux: "Int8", reg: vecopscalarint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecOpScalar64", argLength: 5, aux: "Int8", reg: vecopscalarint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecSum8", argLength: 3, reg: vecsumint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecSum16", argLength: 3, reg: vecsumint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecSum32", argLength: 3, reg: vecsumint, clobberFlags: true, unsafePoint: true},
+		{name: "LoweredVecSum64", argLength: 3, reg: vecsumint, clobberFlags: true, unsafePoint: true},
+
 		// Scheduler ensures LoweredGetClosurePtr occurs only in entry block,
 		// and sorts it to the very beginning of the block to prevent other
 		// use of R26 (arm64.REGCTXT, the closure pointer)
@@ -662,6 +662,35 @@ var genericOps = []opData{
 	// Prefetch instruction
 	{name: "PrefetchCache", argLength: 2, hasSideEffects: true},         // Do prefetch arg0 to cache. arg0=addr, arg1=memory.
 	{name: "PrefetchCacheStreamed", argLength: 2, hasSideEffects: true}, // Do non-temporal or streamed prefetch arg0 to cache. arg0=addr, arg1=memory.
+
+	// goo's arithmetic on slices of floats, on arrays of n elements.
+	// auxint is the VecKind of the operation. Only generated by the
+	// intrinsics for runtime.vaddF32 and friends on architectures that
+	// lower them to SIMD loops.
+	{name: "VecOp32F", argLength: 5, typ: "Mem", aux: "Int8"},       // arg0=dst, arg1=x, arg2=y, arg3=n, arg4=mem. Stores x[i] op y[i] into dst[i]. Returns memory.
+	{name: "VecOp64F", argLength: 5, typ: "Mem", aux: "Int8"},       // arg0=dst, arg1=x, arg2=y, arg3=n, arg4=mem. Stores x[i] op y[i] into dst[i]. Returns memory.
+	{name: "VecOpScalar32F", argLength: 5, typ: "Mem", aux: "Int8"}, // arg0=dst, arg1=x, arg2=scalar s, arg3=n, arg4=mem. Stores x[i] op s into dst[i]. Returns memory.
+	{name: "VecOpScalar64F", argLength: 5, typ: "Mem", aux: "Int8"}, // arg0=dst, arg1=x, arg2=scalar s, arg3=n, arg4=mem. Stores x[i] op s into dst[i]. Returns memory.
+	{name: "VecSum32F", argLength: 3, typ: "Float32"},               // arg0=x, arg1=n, arg2=mem. Returns the sum of the x[i].
+	{name: "VecSum64F", argLength: 3, typ: "Float64"},               // arg0=x, arg1=n, arg2=mem. Returns the sum of the x[i].
+	{name: "VecDot32F", argLength: 4, typ: "Float32"},               // arg0=x, arg1=y, arg2=n, arg3=mem. Returns the sum of the x[i]*y[i].
+	{name: "VecDot64F", argLength: 4, typ: "Float64"},               // arg0=x, arg1=y, arg2=n, arg3=mem. Returns the sum of the x[i]*y[i].
+
+	// goo's arithmetic on slices of integers of 8, 16, 32 and 64 bits,
+	// signed or not, as above. The VecOp ops do not divide, and multiply
+	// only 16 and 32 bit integers.
+	{name: "VecOp8", argLength: 5, typ: "Mem", aux: "Int8"},        // arg0=dst, arg1=x, arg2=y, arg3=n, arg4=mem. Stores x[i] op y[i] into dst[i]. Returns memory.
+	{name: "VecOp16", argLength: 5, typ: "Mem", aux: "Int8"},       // arg0=dst, arg1=x, arg2=y, arg3=n, arg4=mem. Stores x[i] op y[i] into dst[i]. Returns memory.
+	{name: "VecOp32", argLength: 5, typ: "Mem", aux: "Int8"},       // arg0=dst, arg1=x, arg2=y, arg3=n, arg4=mem. Stores x[i] op y[i] into dst[i]. Returns memory.
+	{name: "VecOp64", argLength: 5, typ: "Mem", aux: "Int8"},       // arg0=dst, arg1=x, arg2=y, arg3=n, arg4=mem. Stores x[i] op y[i] into dst[i]. Returns memory.
+	{name: "VecOpScalar8", argLength: 5, typ: "Mem", aux: "Int8"},  // arg0=dst, arg1=x, arg2=scalar s, arg3=n, arg4=mem. Stores x[i] op s into dst[i]. Returns memory.
+	{name: "VecOpScalar16", argLength: 5, typ: "Mem", aux: "Int8"}, // arg0=dst, arg1=x, arg2=scalar s, arg3=n, arg4=mem. Stores x[i] op s into dst[i]. Returns memory.
+	{name: "VecOpScalar32", argLength: 5, typ: "Mem", aux: "Int8"}, // arg0=dst, arg1=x, arg2=scalar s, arg3=n, arg4=mem. Stores x[i] op s into dst[i]. Returns memory.
+	{name: "VecOpScalar64", argLength: 5, typ: "Mem", aux: "Int8"}, // arg0=dst, arg1=x, arg2=scalar s, arg3=n, arg4=mem. Stores x[i] op s into dst[i]. Returns memory.
+	{name: "VecSum8", argLength: 3, typ: "Int8"},                   // arg0=x, arg1=n, arg2=mem. Returns the sum of the x[i].
+	{name: "VecSum16", argLength: 3, typ: "Int16"},                 // arg0=x, arg1=n, arg2=mem. Returns the sum of the x[i].
+	{name: "VecSum32", argLength: 3, typ: "Int32"},                 // arg0=x, arg1=n, arg2=mem. Returns the sum of the x[i].
+	{name: "VecSum64", argLength: 3, typ: "Int64"},                 // arg0=x, arg1=n, arg2=mem. Returns the sum of the x[i].
 }
 
 //     kind          controls          successors   implicit exit
@@ -476,6 +476,20 @@ const (
 	BoundsKindCount
 )
 
+// A VecKind is the operation of a goo VecOp or VecOpScalar op, which
+// combines each element x of an array with y, the element of another
+// array or the scalar.
+type VecKind uint8
+
+const (
+	VecAdd    VecKind = iota // x + y
+	VecSub                   // x - y
+	VecMul                   // x * y
+	VecDiv                   // x / y
+	VecSubRev                // y - x, for VecOpScalar only
+	VecDivRev                // y / x, for VecOpScalar only
+)
+
 // boundsABI determines which register arguments a bounds check call should use. For an [a:b:c] slice, we do:
 //
 //	CMPQ c, cap
@@ -1059,6 +1059,46 @@ const (
 	OpAMD64CALLinter
 	OpAMD64DUFFCOPY
 	OpAMD64REPMOVSQ
+	OpAMD64LoweredVecOp32F
+	OpAMD64LoweredVecOp64F
+	OpAMD64LoweredVecOpScalar32F
+	OpAMD64LoweredVecOpScalar64F
+	OpAMD64LoweredVecSum32F
+	OpAMD64LoweredVecSum64F
+	OpAMD64LoweredVecDot32F
+	OpAMD64LoweredVecDot64F
+	OpAMD64LoweredVecOpAVX32F
+	OpAMD64LoweredVecOpAVX64F
+	OpAMD64LoweredVecOpScalarAVX32F
+	OpAMD64LoweredVecOpScalarAVX64F
+	OpAMD64LoweredVecSumAVX32F
+	OpAMD64LoweredVecSumAVX64F
+	OpAMD64LoweredVecDotAVX32F
+	OpAMD64LoweredVecDotAVX64F
+	OpAMD64LoweredVecOp8
+	OpAMD64LoweredVecOp16
+	OpAMD64LoweredVecOp32
+	OpAMD64LoweredVecOp64
+	OpAMD64LoweredVecOpScalar8
+	OpAMD64LoweredVecOpScalar16
+	OpAMD64LoweredVecOpScalar32
+	OpAMD64LoweredVecOpScalar64
+	OpAMD64LoweredVecSum8
+	OpAMD64LoweredVecSum16
+	OpAMD64LoweredVecSum32
+	OpAMD64LoweredVecSum64
+	OpAMD64LoweredVecOpAVX8
+	OpAMD64LoweredVecOpAVX16
+	OpAMD64LoweredVecOpAVX32
+	OpAMD64LoweredVecOpAVX64
+	OpAMD64LoweredVecOpScalarAVX8
+	OpAMD64LoweredVecOpScalarAVX16
+	OpAMD64LoweredVecOpScalarAVX32
+	OpAMD64LoweredVecOpScalarAVX64
+	OpAMD64LoweredVecSumAVX8
+	OpAMD64LoweredVecSumAVX16
+	OpAMD64LoweredVecSumAVX32
+	OpAMD64LoweredVecSumAVX64
 	OpAMD64InvertFlags
 	OpAMD64LoweredGetG
 	OpAMD64LoweredGetClosurePtr
@@ -1719,6 +1759,26 @@ const (
 	OpARM64LoweredZero
 	OpARM64DUFFCOPY
 	OpARM64LoweredMove
+	OpARM64LoweredVecOp32F
+	OpARM64LoweredVecOp64F
+	OpARM64LoweredVecOpScalar32F
+	OpARM64LoweredVecOpScalar64F
+	OpARM64LoweredVecSum32F
+	OpARM64LoweredVecSum64F
+	OpARM64LoweredVecDot32F
+	OpARM64LoweredVecDot64F
+	OpARM64LoweredVecOp8
+	OpARM64LoweredVecOp16
+	OpARM64LoweredVecOp32
+	OpARM64LoweredVecOp64
+	OpARM64LoweredVecOpScalar8
+	OpARM64LoweredVecOpScalar16
+	OpARM64LoweredVecOpScalar32
+	OpARM64LoweredVecOpScalar64
+	OpARM64LoweredVecSum8
+	OpARM64LoweredVecSum16
+	OpARM64LoweredVecSum32
+	OpARM64LoweredVecSum64
 	OpARM64LoweredGetClosurePtr
 	OpARM64LoweredGetCallerSP
 	OpARM64LoweredGetCallerPC
@@ -3386,6 +3446,26 @@ const (
 	OpClobberReg
 	OpPrefetchCache
 	OpPrefetchCacheStreamed
+	OpVecOp32F
+	OpVecOp64F
+	OpVecOpScalar32F
+	OpVecOpScalar64F
+	OpVecSum32F
+	OpVecSum64F
+	OpVecDot32F
+	OpVecDot64F
+	OpVecOp8
+	OpVecOp16
+	OpVecOp32
+	OpVecOp64
+	OpVecOpScalar8
+	OpVecOpScalar16
+	OpVecOpScalar32
+	OpVecOpScalar64
+	OpVecSum8
+	OpVecSum16
+	OpVecSum32
+	OpVecSum64
 )
 
 var opcodeTable = [...]opInfo{
@@ -13974,6 +14054,650 @@ var opcodeTable = [...]opInfo{
 			clobbers: 194, // CX SI DI
 		},
 	},
+	{
+		name:         "LoweredVecOp32F",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 16711878, // CX DX SI DI X0 X1 X2 X3 X4 X5 X6 X7
+		},
+	},
+	{
+		name:         "LoweredVecOp64F",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 16711878, // CX DX SI DI X0 X1 X2 X3 X4 X5 X6 X7
+		},
+	},
+	{
+		name:         "LoweredVecOpScalar32F",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128},      // DI
+				{1, 64},       // SI
+				{2, 16777216}, // X8
+				{3, 2},        // CX
+			},
+			clobbers: 33489090, // CX SI DI X0 X1 X2 X3 X4 X5 X6 X7 X8
+		},
+	},
+	{
+		name:         "LoweredVecOpScalar64F",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128},      // DI
+				{1, 64},       // SI
+				{2, 16777216}, // X8
+				{3, 2},        // CX
+			},
+			clobbers: 33489090, // CX SI DI X0 X1 X2 X3 X4 X5 X6 X7 X8
+		},
+	},
+	{
+		name:         "LoweredVecSum32F",
+		argLen:       3,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 64}, // SI
+				{1, 2},  // CX
+			},
+			clobbers: 16646210, // CX SI X1 X2 X3 X4 X5 X6 X7
+			outputs: []outputInfo{
+				{0, 65536}, // X0
+			},
+		},
+	},
+	{
+		name:         "LoweredVecSum64F",
+		argLen:       3,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 64}, // SI
+				{1, 2},  // CX
+			},
+			clobbers: 16646210, // CX SI X1 X2 X3 X4 X5 X6 X7
+			outputs: []outputInfo{
+				{0, 65536}, // X0
+			},
+		},
+	},
+	{
+		name:         "LoweredVecDot32F",
+		argLen:       4,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 64}, // SI
+				{1, 4},  // DX
+				{2, 2},  // CX
+			},
+			clobbers: 16646214, // CX DX SI X1 X2 X3 X4 X5 X6 X7
+			outputs: []outputInfo{
+				{0, 65536}, // X0
+			},
+		},
+	},
+	{
+		name:         "LoweredVecDot64F",
+		argLen:       4,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 64}, // SI
+				{1, 4},  // DX
+				{2, 2},  // CX
+			},
+			clobbers: 16646214, // CX DX SI X1 X2 X3 X4 X5 X6 X7
+			outputs: []outputInfo{
+				{0, 65536}, // X0
+			},
+		},
+	},
+	{
+		name:         "LoweredVecOpAVX32F",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 16711878, // CX DX SI DI X0 X1 X2 X3 X4 X5 X6 X7
+		},
+	},
+	{
+		name:         "LoweredVecOpAVX64F",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 16711878, // CX DX SI DI X0 X1 X2 X3 X4 X5 X6 X7
+		},
+	},
+	{
+		name:         "LoweredVecOpScalarAVX32F",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128},      // DI
+				{1, 64},       // SI
+				{2, 16777216}, // X8
+				{3, 2},        // CX
+			},
+			clobbers: 33489090, // CX SI DI X0 X1 X2 X3 X4 X5 X6 X7 X8
+		},
+	},
+	{
+		name:         "LoweredVecOpScalarAVX64F",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128},      // DI
+				{1, 64},       // SI
+				{2, 16777216}, // X8
+				{3, 2},        // CX
+			},
+			clobbers: 33489090, // CX SI DI X0 X1 X2 X3 X4 X5 X6 X7 X8
+		},
+	},
+	{
+		name:         "LoweredVecSumAVX32F",
+		argLen:       3,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 64}, // SI
+				{1, 2},  // CX
+			},
+			clobbers: 16646210, // CX SI X1 X2 X3 X4 X5 X6 X7
+			outputs: []outputInfo{
+				{0, 65536}, // X0
+			},
+		},
+	},
+	{
+		name:         "LoweredVecSumAVX64F",
+		argLen:       3,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 64}, // SI
+				{1, 2},  // CX
+			},
+			clobbers: 16646210, // CX SI X1 X2 X3 X4 X5 X6 X7
+			outputs: []outputInfo{
+				{0, 65536}, // X0
+			},
+		},
+	},
+	{
+		name:         "LoweredVecDotAVX32F",
+		argLen:       4,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 64}, // SI
+				{1, 4},  // DX
+				{2, 2},  // CX
+			},
+			clobbers: 16646214, // CX DX SI X1 X2 X3 X4 X5 X6 X7
+			outputs: []outputInfo{
+				{0, 65536}, // X0
+			},
+		},
+	},
+	{
+		name:         "LoweredVecDotAVX64F",
+		argLen:       4,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 64}, // SI
+				{1, 4},  // DX
+				{2, 2},  // CX
+			},
+			clobbers: 16646214, // CX DX SI X1 X2 X3 X4 X5 X6 X7
+			outputs: []outputInfo{
+				{0, 65536}, // X0
+			},
+		},
+	},
+	{
+		name:         "LoweredVecOp8",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 16711879, // AX CX DX SI DI X0 X1 X2 X3 X4 X5 X6 X7
+		},
+	},
+	{
+		name:         "LoweredVecOp16",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 16711879, // AX CX DX SI DI X0 X1 X2 X3 X4 X5 X6 X7
+		},
+	},
+	{
+		name:         "LoweredVecOp32",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 16711879, // AX CX DX SI DI X0 X1 X2 X3 X4 X5 X6 X7
+		},
+	},
+	{
+		name:         "LoweredVecOp64",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 16711879, // AX CX DX SI DI X0 X1 X2 X3 X4 X5 X6 X7
+		},
+	},
+	{
+		name:         "LoweredVecOpScalar8",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 33489091, // AX CX SI DI X0 X1 X2 X3 X4 X5 X6 X7 X8
+		},
+	},
+	{
+		name:         "LoweredVecOpScalar16",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 33489091, // AX CX SI DI X0 X1 X2 X3 X4 X5 X6 X7 X8
+		},
+	},
+	{
+		name:         "LoweredVecOpScalar32",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 33489091, // AX CX SI DI X0 X1 X2 X3 X4 X5 X6 X7 X8
+		},
+	},
+	{
+		name:         "LoweredVecOpScalar64",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 33489091, // AX CX SI DI X0 X1 X2 X3 X4 X5 X6 X7 X8
+		},
+	},
+	{
+		name:         "LoweredVecSum8",
+		argLen:       3,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 64}, // SI
+				{1, 2},  // CX
+			},
+			clobbers: 16711746, // CX SI X0 X1 X2 X3 X4 X5 X6 X7
+			outputs: []outputInfo{
+				{0, 1}, // AX
+			},
+		},
+	},
+	{
+		name:         "LoweredVecSum16",
+		argLen:       3,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 64}, // SI
+				{1, 2},  // CX
+			},
+			clobbers: 16711746, // CX SI X0 X1 X2 X3 X4 X5 X6 X7
+			outputs: []outputInfo{
+				{0, 1}, // AX
+			},
+		},
+	},
+	{
+		name:         "LoweredVecSum32",
+		argLen:       3,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 64}, // SI
+				{1, 2},  // CX
+			},
+			clobbers: 16711746, // CX SI X0 X1 X2 X3 X4 X5 X6 X7
+			outputs: []outputInfo{
+				{0, 1}, // AX
+			},
+		},
+	},
+	{
+		name:         "LoweredVecSum64",
+		argLen:       3,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 64}, // SI
+				{1, 2},  // CX
+			},
+			clobbers: 16711746, // CX SI X0 X1 X2 X3 X4 X5 X6 X7
+			outputs: []outputInfo{
+				{0, 1}, // AX
+			},
+		},
+	},
+	{
+		name:         "LoweredVecOpAVX8",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 16711879, // AX CX DX SI DI X0 X1 X2 X3 X4 X5 X6 X7
+		},
+	},
+	{
+		name:         "LoweredVecOpAVX16",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 16711879, // AX CX DX SI DI X0 X1 X2 X3 X4 X5 X6 X7
+		},
+	},
+	{
+		name:         "LoweredVecOpAVX32",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 16711879, // AX CX DX SI DI X0 X1 X2 X3 X4 X5 X6 X7
+		},
+	},
+	{
+		name:         "LoweredVecOpAVX64",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 16711879, // AX CX DX SI DI X0 X1 X2 X3 X4 X5 X6 X7
+		},
+	},
+	{
+		name:         "LoweredVecOpScalarAVX8",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 33489091, // AX CX SI DI X0 X1 X2 X3 X4 X5 X6 X7 X8
+		},
+	},
+	{
+		name:         "LoweredVecOpScalarAVX16",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 33489091, // AX CX SI DI X0 X1 X2 X3 X4 X5 X6 X7 X8
+		},
+	},
+	{
+		name:         "LoweredVecOpScalarAVX32",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 33489091, // AX CX SI DI X0 X1 X2 X3 X4 X5 X6 X7 X8
+		},
+	},
+	{
+		name:         "LoweredVecOpScalarAVX64",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 128}, // DI
+				{1, 64},  // SI
+				{2, 4},   // DX
+				{3, 2},   // CX
+			},
+			clobbers: 33489091, // AX CX SI DI X0 X1 X2 X3 X4 X5 X6 X7 X8
+		},
+	},
+	{
+		name:         "LoweredVecSumAVX8",
+		argLen:       3,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 64}, // SI
+				{1, 2},  // CX
+			},
+			clobbers: 16711746, // CX SI X0 X1 X2 X3 X4 X5 X6 X7
+			outputs: []outputInfo{
+				{0, 1}, // AX
+			},
+		},
+	},
+	{
+		name:         "LoweredVecSumAVX16",
+		argLen:       3,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 64}, // SI
+				{1, 2},  // CX
+			},
+			clobbers: 16711746, // CX SI X0 X1 X2 X3 X4 X5 X6 X7
+			outputs: []outputInfo{
+				{0, 1}, // AX
+			},
+		},
+	},
+	{
+		name:         "LoweredVecSumAVX32",
+		argLen:       3,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 64}, // SI
+				{1, 2},  // CX
+			},
+			clobbers: 16711746, // CX SI X0 X1 X2 X3 X4 X5 X6 X7
+			outputs: []outputInfo{
+				{0, 1}, // AX
+			},
+		},
+	},
+	{
+		name:         "LoweredVecSumAVX64",
+		argLen:       3,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 64}, // SI
+				{1, 2},  // CX
+			},
+			clobbers: 16711746, // CX SI X0 X1 X2 X3 X4 X5 X6 X7
+			outputs: []outputInfo{
+				{0, 1}, // AX
+			},
+		},
+	},
 	{
 		name:   "InvertFlags",
 		argLen: 1,
@@ -23088,6 +23812,328 @@ var opcodeTable = [...]opInfo{
 			clobbers: 16973824, // R16 R17 R25
 		},
 	},
+	{
+		name:         "LoweredVecOp32F",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 1}, // R0
+				{1, 2}, // R1
+				{2, 4}, // R2
+				{3, 8}, // R3
+			},
+			clobbers: 547608330255, // R0 R1 R2 R3 F0 F1 F2 F3 F4 F5 F6 F7
+		},
+	},
+	{
+		name:         "LoweredVecOp64F",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 1}, // R0
+				{1, 2}, // R1
+				{2, 4}, // R2
+				{3, 8}, // R3
+			},
+			clobbers: 547608330255, // R0 R1 R2 R3 F0 F1 F2 F3 F4 F5 F6 F7
+		},
+	},
+	{
+		name:         "LoweredVecOpScalar32F",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 1},               // R0
+				{1, 2},               // R1
+				{2, 140737488355328}, // F16
+				{3, 8},               // R3
+			},
+			clobbers: 141285096685579, // R0 R1 R3 F0 F1 F2 F3 F4 F5 F6 F7 F16
+		},
+	},
+	{
+		name:         "LoweredVecOpScalar64F",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 1},               // R0
+				{1, 2},               // R1
+				{2, 140737488355328}, // F16
+				{3, 8},               // R3
+			},
+			clobbers: 141285096685579, // R0 R1 R3 F0 F1 F2 F3 F4 F5 F6 F7 F16
+		},
+	},
+	{
+		name:         "LoweredVecSum32F",
+		argLen:       3,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 2}, // R1
+				{1, 8}, // R3
+			},
+			clobbers: 1970872445304842, // R1 R3 F0 F1 F2 F3 F4 F5 F6 F7 F17 F18 F19
+			outputs: []outputInfo{
+				{0, 140737488355328}, // F16
+			},
+		},
+	},
+	{
+		name:         "LoweredVecSum64F",
+		argLen:       3,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 2}, // R1
+				{1, 8}, // R3
+			},
+			clobbers: 1970872445304842, // R1 R3 F0 F1 F2 F3 F4 F5 F6 F7 F17 F18 F19
+			outputs: []outputInfo{
+				{0, 140737488355328}, // F16
+			},
+		},
+	},
+	{
+		name:         "LoweredVecDot32F",
+		argLen:       4,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 2}, // R1
+				{1, 4}, // R2
+				{2, 8}, // R3
+			},
+			clobbers: 1970872445304846, // R1 R2 R3 F0 F1 F2 F3 F4 F5 F6 F7 F17 F18 F19
+			outputs: []outputInfo{
+				{0, 140737488355328}, // F16
+			},
+		},
+	},
+	{
+		name:         "LoweredVecDot64F",
+		argLen:       4,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 2}, // R1
+				{1, 4}, // R2
+				{2, 8}, // R3
+			},
+			clobbers: 1970872445304846, // R1 R2 R3 F0 F1 F2 F3 F4 F5 F6 F7 F17 F18 F19
+			outputs: []outputInfo{
+				{0, 140737488355328}, // F16
+			},
+		},
+	},
+	{
+		name:         "LoweredVecOp8",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 1}, // R0
+				{1, 2}, // R1
+				{2, 4}, // R2
+				{3, 8}, // R3
+			},
+			clobbers: 547608330303, // R0 R1 R2 R3 R4 R5 F0 F1 F2 F3 F4 F5 F6 F7
+		},
+	},
+	{
+		name:         "LoweredVecOp16",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 1}, // R0
+				{1, 2}, // R1
+				{2, 4}, // R2
+				{3, 8}, // R3
+			},
+			clobbers: 547608330303, // R0 R1 R2 R3 R4 R5 F0 F1 F2 F3 F4 F5 F6 F7
+		},
+	},
+	{
+		name:         "LoweredVecOp32",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 1}, // R0
+				{1, 2}, // R1
+				{2, 4}, // R2
+				{3, 8}, // R3
+			},
+			clobbers: 547608330303, // R0 R1 R2 R3 R4 R5 F0 F1 F2 F3 F4 F5 F6 F7
+		},
+	},
+	{
+		name:         "LoweredVecOp64",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 1}, // R0
+				{1, 2}, // R1
+				{2, 4}, // R2
+				{3, 8}, // R3
+			},
+			clobbers: 547608330303, // R0 R1 R2 R3 R4 R5 F0 F1 F2 F3 F4 F5 F6 F7
+		},
+	},
+	{
+		name:         "LoweredVecOpScalar8",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 1}, // R0
+				{1, 2}, // R1
+				{2, 4}, // R2
+				{3, 8}, // R3
+			},
+			clobbers: 141285096685595, // R0 R1 R3 R4 F0 F1 F2 F3 F4 F5 F6 F7 F16
+		},
+	},
+	{
+		name:         "LoweredVecOpScalar16",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 1}, // R0
+				{1, 2}, // R1
+				{2, 4}, // R2
+				{3, 8}, // R3
+			},
+			clobbers: 141285096685595, // R0 R1 R3 R4 F0 F1 F2 F3 F4 F5 F6 F7 F16
+		},
+	},
+	{
+		name:         "LoweredVecOpScalar32",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 1}, // R0
+				{1, 2}, // R1
+				{2, 4}, // R2
+				{3, 8}, // R3
+			},
+			clobbers: 141285096685595, // R0 R1 R3 R4 F0 F1 F2 F3 F4 F5 F6 F7 F16
+		},
+	},
+	{
+		name:         "LoweredVecOpScalar64",
+		auxType:      auxInt8,
+		argLen:       5,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 1}, // R0
+				{1, 2}, // R1
+				{2, 4}, // R2
+				{3, 8}, // R3
+			},
+			clobbers: 141285096685595, // R0 R1 R3 R4 F0 F1 F2 F3 F4 F5 F6 F7 F16
+		},
+	},
+	{
+		name:         "LoweredVecSum8",
+		argLen:       3,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 2}, // R1
+				{1, 8}, // R3
+			},
+			clobbers: 2111609933660186, // R1 R3 R4 F0 F1 F2 F3 F4 F5 F6 F7 F16 F17 F18 F19
+			outputs: []outputInfo{
+				{0, 1}, // R0
+			},
+		},
+	},
+	{
+		name:         "LoweredVecSum16",
+		argLen:       3,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 2}, // R1
+				{1, 8}, // R3
+			},
+			clobbers: 2111609933660186, // R1 R3 R4 F0 F1 F2 F3 F4 F5 F6 F7 F16 F17 F18 F19
+			outputs: []outputInfo{
+				{0, 1}, // R0
+			},
+		},
+	},
+	{
+		name:         "LoweredVecSum32",
+		argLen:       3,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 2}, // R1
+				{1, 8}, // R3
+			},
+			clobbers: 2111609933660186, // R1 R3 R4 F0 F1 F2 F3 F4 F5 F6 F7 F16 F17 F18 F19
+			outputs: []outputInfo{
+				{0, 1}, // R0
+			},
+		},
+	},
+	{
+		name:         "LoweredVecSum64",
+		argLen:       3,
+		clobberFlags: true,
+		unsafePoint:  true,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 2}, // R1
+				{1, 8}, // R3
+			},
+			clobbers: 2111609933660186, // R1 R3 R4 F0 F1 F2 F3 F4 F5 F6 F7 F16 F17 F18 F19
+			outputs: []outputInfo{
+				{0, 1}, // R0
+			},
+		},
+	},
 	{
 		name:      "LoweredGetClosurePtr",
 		argLen:    0,
@@ -42676,6 +43722,118 @@ var opcodeTable = [...]opInfo{
 		hasSideEffects: true,
 		generic:        true,
 	},
+	{
+		name:    "VecOp32F",
+		auxType: auxInt8,
+		argLen:  5,
+		generic: true,
+	},
+	{
+		name:    "VecOp64F",
+		auxType: auxInt8,
+		argLen:  5,
+		generic: true,
+	},
+	{
+		name:    "VecOpScalar32F",
+		auxType: auxInt8,
+		argLen:  5,
+		generic: true,
+	},
+	{
+		name:    "VecOpScalar64F",
+		auxType: auxInt8,
+		argLen:  5,
+		generic: true,
+	},
+	{
+		name:    "VecSum32F",
+		argLen:  3,
+		generic: true,
+	},
+	{
+		name:    "VecSum64F",
+		argLen:  3,
+		generic: true,
+	},
+	{
+		name:    "VecDot32F",
+		argLen:  4,
+		generic: true,
+	},
+	{
+		name:    "VecDot64F",
+		argLen:  4,
+		generic: true,
+	},
+	{
+		name:    "VecOp8",
+		auxType: auxInt8,
+		argLen:  5,
+		generic: true,
+	},
+	{
+		name:    "VecOp16",
+		auxType: auxInt8,
+		argLen:  5,
+		generic: true,
+	},
+	{
+		name:    "VecOp32",
+		auxType: auxInt8,
+		argLen:  5,
+		generic: true,
+	},
+	{
+		name:    "VecOp64",
+		auxType: auxInt8,
+		argLen:  5,
+		generic: true,
+	},
+	{
+		name:    "VecOpScalar8",
+		auxType: auxInt8,
+		argLen:  5,
+		generic: true,
+	},
+	{
+		name:    "VecOpScalar16",
+		auxType: auxInt8,
+		argLen:  5,
+		generic: true,
+	},
+	{
+		name:    "VecOpScalar32",
+		auxType: auxInt8,
+		argLen:  5,
+		generic: true,
+	},
+	{
+		name:    "VecOpScalar64",
+		auxType: auxInt8,
+		argLen:  5,
+		generic: true,
+	},
+	{
+		name:    "VecSum8",
+		argLen:  3,
+		generic: true,
+	},
+	{
+		name:    "VecSum16",
+		argLen:  3,
+		generic: true,
+	},
+	{
+		name:    "VecSum32",
+		argLen:  3,
+		generic: true,
+	},
+	{
+		name:    "VecSum64",
+		argLen:  3,
+		generic: true,
+	},
 }
 
 func (o Op) Asm() obj.As          { return opcodeTable[o].asm }
@@ -1163,6 +1163,46 @@ func rewriteValueAMD64(v *Value) bool {
 	case OpTrunc64to8:
 		v.Op = OpCopy
 		return true
+	case OpVecDot32F:
+		return rewriteValueAMD64_OpVecDot32F(v)
+	case OpVecDot64F:
+		return rewriteValueAMD64_OpVecDot64F(v)
+	case OpVecOp16:
+		return rewriteValueAMD64_OpVecOp16(v)
+	case OpVecOp32:
+		return rewriteValueAMD64_OpVecOp32(v)
+	case OpVecOp32F:
+		return rewriteValueAMD64_OpVecOp32F(v)
+	case OpVecOp64:
+		return rewriteValueAMD64_OpVecOp64(v)
+	case OpVecOp64F:
+		return rewriteValueAMD64_OpVecOp64F(v)
+	case OpVecOp8:
+		return rewriteValueAMD64_OpVecOp8(v)
+	case OpVecOpScalar16:
+		return rewriteValueAMD64_OpVecOpScalar16(v)
+	case OpVecOpScalar32:
+		return rewriteValueAMD64_OpVecOpScalar32(v)
+	case OpVecOpScalar32F:
+		return rewriteValueAMD64_OpVecOpScalar32F(v)
+	case OpVecOpScalar64:
+		return rewriteValueAMD64_OpVecOpScalar64(v)
+	case OpVecOpScalar64F:
+		return rewriteValueAMD64_OpVecOpScalar64F(v)
+	case OpVecOpScalar8:
+		return rewriteValueAMD64_OpVecOpScalar8(v)
+	case OpVecSum16:
+		return rewriteValueAMD64_OpVecSum16(v)
+	case OpVecSum32:
+		return rewriteValueAMD64_OpVecSum32(v)
+	case OpVecSum32F:
+		return rewriteValueAMD64_OpVecSum32F(v)
+	case OpVecSum64:
+		return rewriteValueAMD64_OpVecSum64(v)
+	case OpVecSum64F:
+		return rewriteValueAMD64_OpVecSum64F(v)
+	case OpVecSum8:
+		return rewriteValueAMD64_OpVecSum8(v)
 	case OpWB:
 		v.Op = OpAMD64LoweredWB
 		return true
@@ -25053,9 +25093,9 @@ func rewriteValueAMD64_OpCondSelect(v *Value) bool {
 		v.AddArg3(y, x, cond)
 		return true
 	}
-	// match: (CondSelect <t> x y check)
-	// cond: !check.Type.IsFlags() && check.Type.Size() == 1
-	// result: (CondSelect <t> x y (MOVBQZX <typ.UInt64> check))
+	// match: (CondSelect <t> x y checks)
+	// cond: !checks.Type.IsFlags() && checks.Type.Size() == 1
+	// result: (CondSelect <t> x y (MOVBQZX <typ.UInt64> checks))
 	for {
 		t := v.Type
 		x := v_0
@@ -25071,9 +25111,9 @@ func rewriteValueAMD64_OpCondSelect(v *Value) bool {
 		v.AddArg3(x, y, v0)
 		return true
 	}
-	// match: (CondSelect <t> x y check)
-	// cond: !check.Type.IsFlags() && check.Type.Size() == 2
-	// result: (CondSelect <t> x y (MOVWQZX <typ.UInt64> check))
+	// match: (CondSelect <t> x y checks)
+	// cond: !checks.Type.IsFlags() && checks.Type.Size() == 2
+	// result: (CondSelect <t> x y (MOVWQZX <typ.UInt64> checks))
 	for {
 		t := v.Type
 		x := v_0
@@ -25089,9 +25129,9 @@ func rewriteValueAMD64_OpCondSelect(v *Value) bool {
 		v.AddArg3(x, y, v0)
 		return true
 	}
-	// match: (CondSelect <t> x y check)
-	// cond: !check.Type.IsFlags() && check.Type.Size() == 4
-	// result: (CondSelect <t> x y (MOVLQZX <typ.UInt64> check))
+	// match: (CondSelect <t> x y checks)
+	// cond: !checks.Type.IsFlags() && checks.Type.Size() == 4
+	// result: (CondSelect <t> x y (MOVLQZX <typ.UInt64> checks))
 	for {
 		t := v.Type
 		x := v_0
@@ -25107,9 +25147,9 @@ func rewriteValueAMD64_OpCondSelect(v *Value) bool {
 		v.AddArg3(x, y, v0)
 		return true
 	}
-	// match: (CondSelect <t> x y check)
-	// cond: !check.Type.IsFlags() && check.Type.Size() == 8 && (is64BitInt(t) || isPtr(t))
-	// result: (CMOVQNE y x (CMPQconst [0] check))
+	// match: (CondSelect <t> x y checks)
+	// cond: !checks.Type.IsFlags() && checks.Type.Size() == 8 && (is64BitInt(t) || isPtr(t))
+	// result: (CMOVQNE y x (CMPQconst [0] checks))
 	for {
 		t := v.Type
 		x := v_0
@@ -25125,9 +25165,9 @@ func rewriteValueAMD64_OpCondSelect(v *Value) bool {
 		v.AddArg3(y, x, v0)
 		return true
 	}
-	// match: (CondSelect <t> x y check)
-	// cond: !check.Type.IsFlags() && check.Type.Size() == 8 && is32BitInt(t)
-	// result: (CMOVLNE y x (CMPQconst [0] check))
+	// match: (CondSelect <t> x y checks)
+	// cond: !checks.Type.IsFlags() && checks.Type.Size() == 8 && is32BitInt(t)
+	// result: (CMOVLNE y x (CMPQconst [0] checks))
 	for {
 		t := v.Type
 		x := v_0
@@ -25143,9 +25183,9 @@ func rewriteValueAMD64_OpCondSelect(v *Value) bool {
 		v.AddArg3(y, x, v0)
 		return true
 	}
-	// match: (CondSelect <t> x y check)
-	// cond: !check.Type.IsFlags() && check.Type.Size() == 8 && is16BitInt(t)
-	// result: (CMOVWNE y x (CMPQconst [0] check))
+	// match: (CondSelect <t> x y checks)
+	// cond: !checks.Type.IsFlags() && checks.Type.Size() == 8 && is16BitInt(t)
+	// result: (CMOVWNE y x (CMPQconst [0] checks))
 	for {
 		t := v.Type
 		x := v_0
@@ -29778,6 +29818,812 @@ func rewriteValueAMD64_OpTrunc(v *Value) bool {
 		return true
 	}
 }
+func rewriteValueAMD64_OpVecDot32F(v *Value) bool {
+	v_3 := v.Args[3]
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecDot32F x y n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecDotAVX32F x y n mem)
+	for {
+		x := v_0
+		y := v_1
+		n := v_2
+		mem := v_3
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecDotAVX32F)
+		v.AddArg4(x, y, n, mem)
+		return true
+	}
+	// match: (VecDot32F x y n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecDot32F x y n mem)
+	for {
+		x := v_0
+		y := v_1
+		n := v_2
+		mem := v_3
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecDot32F)
+		v.AddArg4(x, y, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecDot64F(v *Value) bool {
+	v_3 := v.Args[3]
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecDot64F x y n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecDotAVX64F x y n mem)
+	for {
+		x := v_0
+		y := v_1
+		n := v_2
+		mem := v_3
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecDotAVX64F)
+		v.AddArg4(x, y, n, mem)
+		return true
+	}
+	// match: (VecDot64F x y n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecDot64F x y n mem)
+	for {
+		x := v_0
+		y := v_1
+		n := v_2
+		mem := v_3
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecDot64F)
+		v.AddArg4(x, y, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecOp16(v *Value) bool {
+	v_4 := v.Args[4]
+	v_3 := v.Args[3]
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecOp16 [op] dst x y n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecOpAVX16 [op] dst x y n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		y := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOpAVX16)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, y, n, mem)
+		return true
+	}
+	// match: (VecOp16 [op] dst x y n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecOp16 [op] dst x y n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		y := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOp16)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, y, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecOp32(v *Value) bool {
+	v_4 := v.Args[4]
+	v_3 := v.Args[3]
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecOp32 [op] dst x y n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecOpAVX32 [op] dst x y n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		y := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOpAVX32)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, y, n, mem)
+		return true
+	}
+	// match: (VecOp32 [op] dst x y n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecOp32 [op] dst x y n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		y := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOp32)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, y, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecOp32F(v *Value) bool {
+	v_4 := v.Args[4]
+	v_3 := v.Args[3]
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecOp32F [op] dst x y n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecOpAVX32F [op] dst x y n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		y := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOpAVX32F)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, y, n, mem)
+		return true
+	}
+	// match: (VecOp32F [op] dst x y n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecOp32F [op] dst x y n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		y := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOp32F)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, y, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecOp64(v *Value) bool {
+	v_4 := v.Args[4]
+	v_3 := v.Args[3]
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecOp64 [op] dst x y n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecOpAVX64 [op] dst x y n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		y := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOpAVX64)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, y, n, mem)
+		return true
+	}
+	// match: (VecOp64 [op] dst x y n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecOp64 [op] dst x y n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		y := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOp64)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, y, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecOp64F(v *Value) bool {
+	v_4 := v.Args[4]
+	v_3 := v.Args[3]
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecOp64F [op] dst x y n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecOpAVX64F [op] dst x y n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		y := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOpAVX64F)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, y, n, mem)
+		return true
+	}
+	// match: (VecOp64F [op] dst x y n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecOp64F [op] dst x y n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		y := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOp64F)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, y, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecOp8(v *Value) bool {
+	v_4 := v.Args[4]
+	v_3 := v.Args[3]
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecOp8 [op] dst x y n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecOpAVX8 [op] dst x y n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		y := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOpAVX8)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, y, n, mem)
+		return true
+	}
+	// match: (VecOp8 [op] dst x y n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecOp8 [op] dst x y n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		y := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOp8)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, y, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecOpScalar16(v *Value) bool {
+	v_4 := v.Args[4]
+	v_3 := v.Args[3]
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecOpScalar16 [op] dst x s n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecOpScalarAVX16 [op] dst x s n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		s := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOpScalarAVX16)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, s, n, mem)
+		return true
+	}
+	// match: (VecOpScalar16 [op] dst x s n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecOpScalar16 [op] dst x s n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		s := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOpScalar16)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, s, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecOpScalar32(v *Value) bool {
+	v_4 := v.Args[4]
+	v_3 := v.Args[3]
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecOpScalar32 [op] dst x s n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecOpScalarAVX32 [op] dst x s n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		s := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOpScalarAVX32)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, s, n, mem)
+		return true
+	}
+	// match: (VecOpScalar32 [op] dst x s n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecOpScalar32 [op] dst x s n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		s := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOpScalar32)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, s, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecOpScalar32F(v *Value) bool {
+	v_4 := v.Args[4]
+	v_3 := v.Args[3]
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecOpScalar32F [op] dst x s n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecOpScalarAVX32F [op] dst x s n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		s := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOpScalarAVX32F)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, s, n, mem)
+		return true
+	}
+	// match: (VecOpScalar32F [op] dst x s n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecOpScalar32F [op] dst x s n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		s := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOpScalar32F)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, s, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecOpScalar64(v *Value) bool {
+	v_4 := v.Args[4]
+	v_3 := v.Args[3]
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecOpScalar64 [op] dst x s n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecOpScalarAVX64 [op] dst x s n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		s := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOpScalarAVX64)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, s, n, mem)
+		return true
+	}
+	// match: (VecOpScalar64 [op] dst x s n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecOpScalar64 [op] dst x s n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		s := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOpScalar64)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, s, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecOpScalar64F(v *Value) bool {
+	v_4 := v.Args[4]
+	v_3 := v.Args[3]
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecOpScalar64F [op] dst x s n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecOpScalarAVX64F [op] dst x s n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		s := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOpScalarAVX64F)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, s, n, mem)
+		return true
+	}
+	// match: (VecOpScalar64F [op] dst x s n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecOpScalar64F [op] dst x s n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		s := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOpScalar64F)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, s, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecOpScalar8(v *Value) bool {
+	v_4 := v.Args[4]
+	v_3 := v.Args[3]
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecOpScalar8 [op] dst x s n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecOpScalarAVX8 [op] dst x s n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		s := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOpScalarAVX8)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, s, n, mem)
+		return true
+	}
+	// match: (VecOpScalar8 [op] dst x s n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecOpScalar8 [op] dst x s n mem)
+	for {
+		op := auxIntToInt8(v.AuxInt)
+		dst := v_0
+		x := v_1
+		s := v_2
+		n := v_3
+		mem := v_4
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecOpScalar8)
+		v.AuxInt = int8ToAuxInt(op)
+		v.AddArg5(dst, x, s, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecSum16(v *Value) bool {
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecSum16 x n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecSumAVX16 x n mem)
+	for {
+		x := v_0
+		n := v_1
+		mem := v_2
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecSumAVX16)
+		v.AddArg3(x, n, mem)
+		return true
+	}
+	// match: (VecSum16 x n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecSum16 x n mem)
+	for {
+		x := v_0
+		n := v_1
+		mem := v_2
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecSum16)
+		v.AddArg3(x, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecSum32(v *Value) bool {
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecSum32 x n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecSumAVX32 x n mem)
+	for {
+		x := v_0
+		n := v_1
+		mem := v_2
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecSumAVX32)
+		v.AddArg3(x, n, mem)
+		return true
+	}
+	// match: (VecSum32 x n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecSum32 x n mem)
+	for {
+		x := v_0
+		n := v_1
+		mem := v_2
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecSum32)
+		v.AddArg3(x, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecSum32F(v *Value) bool {
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecSum32F x n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecSumAVX32F x n mem)
+	for {
+		x := v_0
+		n := v_1
+		mem := v_2
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecSumAVX32F)
+		v.AddArg3(x, n, mem)
+		return true
+	}
+	// match: (VecSum32F x n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecSum32F x n mem)
+	for {
+		x := v_0
+		n := v_1
+		mem := v_2
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecSum32F)
+		v.AddArg3(x, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecSum64(v *Value) bool {
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecSum64 x n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecSumAVX64 x n mem)
+	for {
+		x := v_0
+		n := v_1
+		mem := v_2
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecSumAVX64)
+		v.AddArg3(x, n, mem)
+		return true
+	}
+	// match: (VecSum64 x n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecSum64 x n mem)
+	for {
+		x := v_0
+		n := v_1
+		mem := v_2
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecSum64)
+		v.AddArg3(x, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecSum64F(v *Value) bool {
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecSum64F x n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecSumAVX64F x n mem)
+	for {
+		x := v_0
+		n := v_1
+		mem := v_2
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecSumAVX64F)
+		v.AddArg3(x, n, mem)
+		return true
+	}
+	// match: (VecSum64F x n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecSum64F x n mem)
+	for {
+		x := v_0
+		n := v_1
+		mem := v_2
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecSum64F)
+		v.AddArg3(x, n, mem)
+		return true
+	}
+	return false
+}
+func rewriteValueAMD64_OpVecSum8(v *Value) bool {
+	v_2 := v.Args[2]
+	v_1 := v.Args[1]
+	v_0 := v.Args[0]
+	// match: (VecSum8 x n mem)
+	// cond: buildcfg.GOAMD64 >= 3
+	// result: (LoweredVecSumAVX8 x n mem)
+	for {
+		x := v_0
+		n := v_1
+		mem := v_2
+		if !(buildcfg.GOAMD64 >= 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecSumAVX8)
+		v.AddArg3(x, n, mem)
+		return true
+	}
+	// match: (VecSum8 x n mem)
+	// cond: buildcfg.GOAMD64 < 3
+	// result: (LoweredVecSum8 x n mem)
+	for {
+		x := v_0
+		n := v_1
+		mem := v_2
+		if !(buildcfg.GOAMD64 < 3) {
+			break
+		}
+		v.reset(OpAMD64LoweredVecSum8)
+		v.AddArg3(x, n, mem)
+		return true
+	}
+	return false
+}
 func rewriteValueAMD64_OpZero(v *Value) bool {
 	v_1 := v.Args[1]
 	v_0 := v.Args[0]
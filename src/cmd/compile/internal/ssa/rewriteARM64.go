@@ -1115,6 +1115,66 @@ func rewriteValueARM64(v *Value) bool {
 	case OpTrunc64to8:
 		v.Op = OpCopy
 		return true
+	case OpVecDot32F:
+		v.Op = OpARM64LoweredVecDot32F
+		return true
+	case OpVecDot64F:
+		v.Op = OpARM64LoweredVecDot64F
+		return true
+	case OpVecOp16:
+		v.Op = OpARM64LoweredVecOp16
+		return true
+	case OpVecOp32:
+		v.Op = OpARM64LoweredVecOp32
+		return true
+	case OpVecOp32F:
+		v.Op = OpARM64LoweredVecOp32F
+		return true
+	case OpVecOp64:
+		v.Op = OpARM64LoweredVecOp64
+		return true
+	case OpVecOp64F:
+		v.Op = OpARM64LoweredVecOp64F
+		return true
+	case OpVecOp8:
+		v.Op = OpARM64LoweredVecOp8
+		return true
+	case OpVecOpScalar16:
+		v.Op = OpARM64LoweredVecOpScalar16
+		return true
+	case OpVecOpScalar32:
+		v.Op = OpARM64LoweredVecOpScalar32
+		return true
+	case OpVecOpScalar32F:
+		v.Op = OpARM64LoweredVecOpScalar32F
+		return true
+	case OpVecOpScalar64:
+		v.Op = OpARM64LoweredVecOpScalar64
+		return true
+	case OpVecOpScalar64F:
+		v.Op = OpARM64LoweredVecOpScalar64F
+		return true
+	case OpVecOpScalar8:
+		v.Op = OpARM64LoweredVecOpScalar8
+		return true
+	case OpVecSum16:
+		v.Op = OpARM64LoweredVecSum16
+		return true
+	case OpVecSum32:
+		v.Op = OpARM64LoweredVecSum32
+		return true
+	case OpVecSum32F:
+		v.Op = OpARM64LoweredVecSum32F
+		return true
+	case OpVecSum64:
+		v.Op = OpARM64LoweredVecSum64
+		return true
+	case OpVecSum64F:
+		v.Op = OpARM64LoweredVecSum64F
+		return true
+	case OpVecSum8:
+		v.Op = OpARM64LoweredVecSum8
+		return true
 	case OpWB:
 		v.Op = OpARM64LoweredWB
 		return true
@@ -238,6 +238,109 @@ func initIntrinsics(cfg *intrinsicBuildConfig) {
 	addF("internal/runtime/sys", "PrefetchStreamed", makePrefetchFunc(ssa.OpPrefetchCacheStreamed),
 		sys.AMD64, sys.ARM64, sys.Loong64, sys.PPC64)
 
+	/******** goo's arithmetic on slices of numbers ********/
+	makeVecOpFunc := func(op ssa.Op, k ssa.VecKind) func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
+		return func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
+			v := s.newValue4I(op, types.TypeMem, int64(k), args[0], args[1], args[2], args[3])
+			v.AddArg(s.mem())
+			s.vars[memVar] = v
+			return nil
+		}
+	}
+	for _, f := range []struct {
+		name string
+		op   ssa.Op
+		k    ssa.VecKind
+	}{
+		{"vaddF32", ssa.OpVecOp32F, ssa.VecAdd},
+		{"vsubF32", ssa.OpVecOp32F, ssa.VecSub},
+		{"vmulF32", ssa.OpVecOp32F, ssa.VecMul},
+		{"vdivF32", ssa.OpVecOp32F, ssa.VecDiv},
+		{"vaddsF32", ssa.OpVecOpScalar32F, ssa.VecAdd},
+		{"vsubsF32", ssa.OpVecOpScalar32F, ssa.VecSub},
+		{"vmulsF32", ssa.OpVecOpScalar32F, ssa.VecMul},
+		{"vdivsF32", ssa.OpVecOpScalar32F, ssa.VecDiv},
+		{"vrsubsF32", ssa.OpVecOpScalar32F, ssa.VecSubRev},
+		{"vrdivsF32", ssa.OpVecOpScalar32F, ssa.VecDivRev},
+		{"vaddF64", ssa.OpVecOp64F, ssa.VecAdd},
+		{"vsubF64", ssa.OpVecOp64F, ssa.VecSub},
+		{"vmulF64", ssa.OpVecOp64F, ssa.VecMul},
+		{"vdivF64", ssa.OpVecOp64F, ssa.VecDiv},
+		{"vaddsF64", ssa.OpVecOpScalar64F, ssa.VecAdd},
+		{"vsubsF64", ssa.OpVecOpScalar64F, ssa.VecSub},
+		{"vmulsF64", ssa.OpVecOpScalar64F, ssa.VecMul},
+		{"vdivsF64", ssa.OpVecOpScalar64F, ssa.VecDiv},
+		{"vrsubsF64", ssa.OpVecOpScalar64F, ssa.VecSubRev},
+		{"vrdivsF64", ssa.OpVecOpScalar64F, ssa.VecDivRev},
+		{"vaddI8", ssa.OpVecOp8, ssa.VecAdd},
+		{"vsubI8", ssa.OpVecOp8, ssa.VecSub},
+		{"vaddsI8", ssa.OpVecOpScalar8, ssa.VecAdd},
+		{"vsubsI8", ssa.OpVecOpScalar8, ssa.VecSub},
+		{"vrsubsI8", ssa.OpVecOpScalar8, ssa.VecSubRev},
+		{"vaddI16", ssa.OpVecOp16, ssa.VecAdd},
+		{"vsubI16", ssa.OpVecOp16, ssa.VecSub},
+		{"vaddsI16", ssa.OpVecOpScalar16, ssa.VecAdd},
+		{"vsubsI16", ssa.OpVecOpScalar16, ssa.VecSub},
+		{"vrsubsI16", ssa.OpVecOpScalar16, ssa.VecSubRev},
+		{"vaddI32", ssa.OpVecOp32, ssa.VecAdd},
+		{"vsubI32", ssa.OpVecOp32, ssa.VecSub},
+		{"vaddsI32", ssa.OpVecOpScalar32, ssa.VecAdd},
+		{"vsubsI32", ssa.OpVecOpScalar32, ssa.VecSub},
+		{"vrsubsI32", ssa.OpVecOpScalar32, ssa.VecSubRev},
+		{"vaddI64", ssa.OpVecOp64, ssa.VecAdd},
+		{"vsubI64", ssa.OpVecOp64, ssa.VecSub},
+		{"vaddsI64", ssa.OpVecOpScalar64, ssa.VecAdd},
+		{"vsubsI64", ssa.OpVecOpScalar64, ssa.VecSub},
+		{"vrsubsI64", ssa.OpVecOpScalar64, ssa.VecSubRev},
+		{"vmulI16", ssa.OpVecOp16, ssa.VecMul},
+		{"vmulsI16", ssa.OpVecOpScalar16, ssa.VecMul},
+	} {
+		addF("runtime", f.name, makeVecOpFunc(f.op, f.k), sys.AMD64, sys.ARM64)
+	}
+	// PMULLD, which multiplies 32 bit integers, is SSE4.1.
+	vmulI32 := []sys.ArchFamily{sys.ARM64}
+	if cfg.goamd64 >= 2 {
+		vmulI32 = append(vmulI32, sys.AMD64)
+	}
+	addF("runtime", "vmulI32", makeVecOpFunc(ssa.OpVecOp32, ssa.VecMul), vmulI32...)
+	addF("runtime", "vmulsI32", makeVecOpFunc(ssa.OpVecOpScalar32, ssa.VecMul), vmulI32...)
+	for _, f := range []struct {
+		name string
+		op   ssa.Op
+		typ  types.Kind
+	}{
+		{"vsumI8", ssa.OpVecSum8, types.TINT8},
+		{"vsumI16", ssa.OpVecSum16, types.TINT16},
+		{"vsumI32", ssa.OpVecSum32, types.TINT32},
+		{"vsumI64", ssa.OpVecSum64, types.TINT64},
+	} {
+		addF("runtime", f.name,
+			func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
+				return s.newValue3(f.op, types.Types[f.typ], args[0], args[1], s.mem())
+			},
+			sys.AMD64, sys.ARM64)
+	}
+	addF("runtime", "vsumF32",
+		func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
+			return s.newValue3(ssa.OpVecSum32F, types.Types[types.TFLOAT32], args[0], args[1], s.mem())
+		},
+		sys.AMD64, sys.ARM64)
+	addF("runtime", "vsumF64",
+		func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
+			return s.newValue3(ssa.OpVecSum64F, types.Types[types.TFLOAT64], args[0], args[1], s.mem())
+		},
+		sys.AMD64, sys.ARM64)
+	addF("runtime", "vdotF32",
+		func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
+			return s.newValue4(ssa.OpVecDot32F, types.Types[types.TFLOAT32], args[0], args[1], args[2], s.mem())
+		},
+		sys.AMD64, sys.ARM64)
+	addF("runtime", "vdotF64",
+		func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
+			return s.newValue4(ssa.OpVecDot64F, types.Types[types.TFLOAT64], args[0], args[1], args[2], s.mem())
+		},
+		sys.AMD64, sys.ARM64)
+
 	/******** internal/runtime/atomic ********/
 	type atomicOpEmitter func(s *state, n *ir.CallExpr, args []*ssa.Value, op ssa.Op, typ types.Kind, needReturn bool)
 
@@ -149,6 +149,56 @@ var wantIntrinsics = map[testIntrinsicKey]struct{}{
 	{"amd64", "math/bits", "TrailingZeros8"}:                           struct{}{},
 	{"amd64", "runtime", "KeepAlive"}:                                  struct{}{},
 	{"amd64", "runtime", "slicebytetostringtmp"}:                       struct{}{},
+	{"amd64", "runtime", "vaddF32"}:                                    struct{}{},
+	{"amd64", "runtime", "vaddF64"}:                                    struct{}{},
+	{"amd64", "runtime", "vaddI16"}:                                    struct{}{},
+	{"amd64", "runtime", "vaddI32"}:                                    struct{}{},
+	{"amd64", "runtime", "vaddI64"}:                                    struct{}{},
+	{"amd64", "runtime", "vaddI8"}:                                     struct{}{},
+	{"amd64", "runtime", "vaddsF32"}:                                   struct{}{},
+	{"amd64", "runtime", "vaddsF64"}:                                   struct{}{},
+	{"amd64", "runtime", "vaddsI16"}:                                   struct{}{},
+	{"amd64", "runtime", "vaddsI32"}:                                   struct{}{},
+	{"amd64", "runtime", "vaddsI64"}:                                   struct{}{},
+	{"amd64", "runtime", "vaddsI8"}:                                    struct{}{},
+	{"amd64", "runtime", "vdivF32"}:                                    struct{}{},
+	{"amd64", "runtime", "vdivF64"}:                                    struct{}{},
+	{"amd64", "runtime", "vdivsF32"}:                                   struct{}{},
+	{"amd64", "runtime", "vdivsF64"}:                                   struct{}{},
+	{"amd64", "runtime", "vdotF32"}:                                    struct{}{},
+	{"amd64", "runtime", "vdotF64"}:                                    struct{}{},
+	{"amd64", "runtime", "vmulF32"}:                                    struct{}{},
+	{"amd64", "runtime", "vmulF64"}:                                    struct{}{},
+	{"amd64", "runtime", "vmulI16"}:                                    struct{}{},
+	{"amd64", "runtime", "vmulsF32"}:                                   struct{}{},
+	{"amd64", "runtime", "vmulsF64"}:                                   struct{}{},
+	{"amd64", "runtime", "vmulsI16"}:                                   struct{}{},
+	{"amd64", "runtime", "vrdivsF32"}:                                  struct{}{},
+	{"amd64", "runtime", "vrdivsF64"}:                                  struct{}{},
+	{"amd64", "runtime", "vrsubsF32"}:                                  struct{}{},
+	{"amd64", "runtime", "vrsubsF64"}:                                  struct{}{},
+	{"amd64", "runtime", "vrsubsI16"}:                                  struct{}{},
+	{"amd64", "runtime", "vrsubsI32"}:                                  struct{}{},
+	{"amd64", "runtime", "vrsubsI64"}:                                  struct{}{},
+	{"amd64", "runtime", "vrsubsI8"}:                                   struct{}{},
+	{"amd64", "runtime", "vsubF32"}:                                    struct{}{},
+	{"amd64", "runtime", "vsubF64"}:                                    struct{}{},
+	{"amd64", "runtime", "vsubI16"}:                                    struct{}{},
+	{"amd64", "runtime", "vsubI32"}:                                    struct{}{},
+	{"amd64", "runtime", "vsubI64"}:                                    struct{}{},
+	{"amd64", "runtime", "vsubI8"}:                                     struct{}{},
+	{"amd64", "runtime", "vsubsF32"}:                                   struct{}{},
+	{"amd64", "runtime", "vsubsF64"}:                                   struct{}{},
+	{"amd64", "runtime", "vsubsI16"}:                                   struct{}{},
+	{"amd64", "runtime", "vsubsI32"}:                                   struct{}{},
+	{"amd64", "runtime", "vsubsI64"}:                                   struct{}{},
+	{"amd64", "runtime", "vsubsI8"}:                                    struct{}{},
+	{"amd64", "runtime", "vsumF32"}:                                    struct{}{},
+	{"amd64", "runtime", "vsumF64"}:                                    struct{}{},
+	{"amd64", "runtime", "vsumI16"}:                                    struct{}{},
+	{"amd64", "runtime", "vsumI32"}:                                    struct{}{},
+	{"amd64", "runtime", "vsumI64"}:                                    struct{}{},
+	{"amd64", "runtime", "vsumI8"}:                                     struct{}{},
 	{"amd64", "sync", "runtime_LoadAcquintptr"}:                        struct{}{},
 	{"amd64", "sync", "runtime_StoreReluintptr"}:                       struct{}{},
 	{"amd64", "sync/atomic", "AddInt32"}:                               struct{}{},
@@ -320,6 +370,58 @@ var wantIntrinsics = map[testIntrinsicKey]struct{}{
 	{"arm64", "runtime", "KeepAlive"}:                                  struct{}{},
 	{"arm64", "runtime", "publicationBarrier"}:                         struct{}{},
 	{"arm64", "runtime", "slicebytetostringtmp"}:                       struct{}{},
+	{"arm64", "runtime", "vaddF32"}:                                    struct{}{},
+	{"arm64", "runtime", "vaddF64"}:                                    struct{}{},
+	{"arm64", "runtime", "vaddI16"}:                                    struct{}{},
+	{"arm64", "runtime", "vaddI32"}:                                    struct{}{},
+	{"arm64", "runtime", "vaddI64"}:                                    struct{}{},
+	{"arm64", "runtime", "vaddI8"}:                                     struct{}{},
+	{"arm64", "runtime", "vaddsF32"}:                                   struct{}{},
+	{"arm64", "runtime", "vaddsF64"}:                                   struct{}{},
+	{"arm64", "runtime", "vaddsI16"}:                                   struct{}{},
+	{"arm64", "runtime", "vaddsI32"}:                                   struct{}{},
+	{"arm64", "runtime", "vaddsI64"}:                                   struct{}{},
+	{"arm64", "runtime", "vaddsI8"}:                                    struct{}{},
+	{"arm64", "runtime", "vdivF32"}:                                    struct{}{},
+	{"arm64", "runtime", "vdivF64"}:                                    struct{}{},
+	{"arm64", "runtime", "vdivsF32"}:                                   struct{}{},
+	{"arm64", "runtime", "vdivsF64"}:                                   struct{}{},
+	{"arm64", "runtime", "vdotF32"}:                                    struct{}{},
+	{"arm64", "runtime", "vdotF64"}:                                    struct{}{},
+	{"arm64", "runtime", "vmulF32"}:                                    struct{}{},
+	{"arm64", "runtime", "vmulF64"}:                                    struct{}{},
+	{"arm64", "runtime", "vmulI16"}:                                    struct{}{},
+	{"arm64", "runtime", "vmulI32"}:                                    struct{}{},
+	{"arm64", "runtime", "vmulsF32"}:                                   struct{}{},
+	{"arm64", "runtime", "vmulsF64"}:                                   struct{}{},
+	{"arm64", "runtime", "vmulsI16"}:                                   struct{}{},
+	{"arm64", "runtime", "vmulsI32"}:                                   struct{}{},
+	{"arm64", "runtime", "vrdivsF32"}:                                  struct{}{},
+	{"arm64", "runtime", "vrdivsF64"}:                                  struct{}{},
+	{"arm64", "runtime", "vrsubsF32"}:                                  struct{}{},
+	{"arm64", "runtime", "vrsubsF64"}:                                  struct{}{},
+	{"arm64", "runtime", "vrsubsI16"}:                                  struct{}{},
+	{"arm64", "runtime", "vrsubsI32"}:                                  struct{}{},
+	{"arm64", "runtime", "vrsubsI64"}:                                  struct{}{},
+	{"arm64", "runtime", "vrsubsI8"}:                                   struct{}{},
+	{"arm64", "runtime", "vsubF32"}:                                    struct{}{},
+	{"arm64", "runtime", "vsubF64"}:                                    struct{}{},
+	{"arm64", "runtime", "vsubI16"}:                                    struct{}{},
+	{"arm64", "runtime", "vsubI32"}:                                    struct{}{},
+	{"arm64", "runtime", "vsubI64"}:                                    struct{}{},
+	{"arm64", "runtime", "vsubI8"}:                                     struct{}{},
+	{"arm64", "runtime", "vsubsF32"}:                                   struct{}{},
+	{"arm64", "runtime", "vsubsF64"}:                                   struct{}{},
+	{"arm64", "runtime", "vsubsI16"}:                                   struct{}{},
+	{"arm64", "runtime", "vsubsI32"}:                                   struct{}{},
+	{"arm64", "runtime", "vsubsI64"}:                                   struct{}{},
+	{"arm64", "runtime", "vsubsI8"}:                                    struct{}{},
+	{"arm64", "runtime", "vsumF32"}:                                    struct{}{},
+	{"arm64", "runtime", "vsumF64"}:                                    struct{}{},
+	{"arm64", "runtime", "vsumI16"}:                                    struct{}{},
+	{"arm64", "runtime", "vsumI32"}:                                    struct{}{},
+	{"arm64", "runtime", "vsumI64"}:                                    struct{}{},
+	{"arm64", "runtime", "vsumI8"}:                                     struct{}{},
 	{"arm64", "sync", "runtime_LoadAcquintptr"}:                        struct{}{},
 	{"arm64", "sync", "runtime_StoreReluintptr"}:                       struct{}{},
 	{"arm64", "sync/atomic", "AddInt32"}:                               struct{}{},
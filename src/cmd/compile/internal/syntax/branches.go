@@ -323,6 +323,10 @@ func (ls *labelScope) blockBranches(parent *block, ctxt targets, lstmt *LabeledS
 			for _, cc := range s.Body {
 				innerBlock(inner, cc.Pos(), cc.Body)
 			}
+
+		case *WithStmt:
+			inner := targets{ctxt.breaks, ctxt.continues, -1}
+			innerBlock(inner, s.Body.Pos(), s.Body.List)
 		}
 	}
 
@@ -109,11 +109,24 @@ type (
 		Name       *Name
 		TParamList []*Field // nil means no type parameters
 		Type       *FuncType
-		Body       *BlockStmt // nil means no body (forward declaration)
+		Body       *BlockStmt  // nil means no body (forward declaration)
+		TopLevel   bool        // goo: implicit main or init holding the top-level statements
+		Contracts  []*Contract // goo: requires and ensures clauses, in source order
 		decl
 	}
 )
 
+// goo: a contract clause of a function declaration
+//
+//	requires Cond
+//	ensures Cond
+type Contract struct {
+	Ensures bool // ensures rather than requires
+	Cond    Expr
+	Text    string // source text of Cond
+	node
+}
+
 type decl struct{ node }
 
 func (*decl) aDecl() {}
@@ -164,7 +177,9 @@ type (
 	CompositeLit struct {
 		Type     Expr // nil means no literal type
 		ElemList []Expr
-		NKeys    int // number of elements with keys
+		NKeys    int  // number of elements with keys
+		Brace    bool // goo: {k: v} literal, of type map[any]any unless its context expects another
+		List     bool // goo: [a, b] literal, of type []any
 		Rbrace   Pos
 		expr
 	}
@@ -176,9 +191,49 @@ type (
 	}
 
 	// func Type { Body }
+	// (a, b) => Body (with Lambda set)
 	FuncLit struct {
 		Type *FuncType
 		Body *BlockStmt
+		// Lambda indicates the goo form x => e or (a, b) => { ... }.
+		// Its parameters have no types (Field.Type is nil): the type
+		// checker infers them, and the result types, from the context.
+		// An expression body e is represented as { return e }.
+		Lambda bool
+		expr
+	}
+
+	// if Cond { Then } else { Else } or switch Tag { Body }, as an expression
+	// The last statement of each branch is an ExprStmt providing the
+	// branch value (see CondValues).
+	CondExpr struct {
+		Stmt Stmt // *IfStmt or *SwitchStmt
+		expr
+	}
+
+	// [Elem for x in X … if Cond] or {Key: Elem for x in X … if Cond}
+	// Loop is the equivalent statement: the nested for-in loops of the
+	// for clauses, the innermost one holding the if statement of the
+	// filter, if any, whose body consists of ExprStmts providing the
+	// elements, or the keys and elements (see CompClauses).
+	CompExpr struct {
+		Map    bool // {Key: Elem …}
+		Loop   *ForStmt
+		Rbrack Pos
+		expr
+	}
+
+	// goo: a destructuring pattern, the left side of an assignment or
+	// of in in a for statement header
+	//
+	//	{Names}          fields of a struct or keys of a map
+	//	[Names]          elements of a slice or array
+	//	[Names, ...Rest]
+	PatternExpr struct {
+		Brace  bool // {Names}
+		Names  []*Name
+		Rest   *Name // or nil
+		Rbrack Pos   // position of the closing } or ]
 		expr
 	}
 
@@ -197,13 +252,18 @@ type (
 
 	// X[Index]
 	// X[T1, T2, ...] (with Ti = Index.(*ListExpr).ElemList[i])
+	// X#Index (with OneBased set)
 	IndexExpr struct {
 		X     Expr
 		Index Expr
+		// OneBased indicates the goo form X#Index: Index counts from 1,
+		// negative indices count from the end and strings yield runes.
+		OneBased bool
 		expr
 	}
 
 	// X[Index[0] : Index[1] : Index[2]]
+	// X#Index[0]:Index[1] (with OneBased set)
 	SliceExpr struct {
 		X     Expr
 		Index [3]Expr
@@ -212,6 +272,9 @@ type (
 		// TODO(mdempsky): This is only needed to report the "3-index
 		// slice of string" error when Index[2] is missing.
 		Full bool
+		// OneBased indicates the goo form X#i:j, which selects the
+		// elements i through j inclusive, counting from 1.
+		OneBased bool
 		expr
 	}
 
@@ -238,9 +301,12 @@ type (
 
 	// Fun(ArgList[0], ArgList[1], ...)
 	CallExpr struct {
-		Fun     Expr
-		ArgList []Expr // nil means no arguments
-		HasDots bool   // last argument is followed by ...
+		Fun      Expr
+		ArgList  []Expr // nil means no arguments
+		HasDots  bool   // last argument is followed by ...
+		NoParens bool   // goo: call statement without parentheses, as in put 42
+		ArgText  string // goo: source text of the arguments of a call of dbg
+		ArgOrder []int  // goo: set by types2 if named arguments were moved to their parameters: the ArgList indices of the arguments as written
 		expr
 	}
 
@@ -264,7 +330,8 @@ type (
 		expr
 	}
 
-	// ...Elem
+	// ...Elem; goo: also a spread ...X in a slice or map literal, and
+	// the ...Rest of a destructuring pattern
 	DotsType struct {
 		Elem Expr
 		expr
@@ -282,6 +349,10 @@ type (
 	Field struct {
 		Name *Name // nil means anonymous field/parameter (structs/parameters), or embedded element (interfaces)
 		Type Expr  // field names declared in a list share the same Type (identical pointers)
+
+		// Default is the default value of a function parameter, or nil.
+		// Parameter names declared in a list share the same Default.
+		Default Expr
 		node
 	}
 
@@ -405,6 +476,7 @@ type (
 
 	CheckStmt struct {
 		Cond Expr
+		Msg  Expr // goo: check Cond, Msg; or nil
 		stmt
 	}
 
@@ -437,6 +509,13 @@ type (
 		Rbrace Pos
 		stmt
 	}
+
+	// goo: with Bindings { Body }
+	WithStmt struct {
+		Bindings []*WithBinding
+		Body     *BlockStmt
+		stmt
+	}
 )
 
 type (
@@ -444,6 +523,7 @@ type (
 		Lhs Expr // nil means no Lhs = or Lhs :=
 		Def bool // means :=
 		X   Expr // range X
+		In  bool // goo: for Lhs in X; implies Def
 		simpleStmt
 	}
 
@@ -460,6 +540,15 @@ type (
 		Colon Pos
 		node
 	}
+
+	// goo: Lhs := Rhs or Lhs := Rhs? in a with statement; the first
+	// name of Lhs is the resource closed at the end of the statement
+	WithBinding struct {
+		Lhs Expr // Name or ListExpr of Names
+		Rhs Expr
+		Try bool // Rhs?: Rhs has an additional error result
+		node
+	}
 )
 
 type stmt struct{ node }
@@ -10,6 +10,7 @@ import (
 	"io"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 const debug = false
@@ -31,7 +32,11 @@ type parser struct {
 	top    bool   // in top of file (before package clause)
 	fnest  int    // function nesting level (for error handling)
 	xnest  int    // expression nesting level (for complit ambiguity resolution)
+	knest  []int  // goo: xnest of the complit keys, case lists and indices being parsed, where a colon ends x#i
 	indent []byte // tracing support
+
+	inTest bool              // goo: in a test block, where check statements report to t
+	tests  map[string]string // goo: names of the test blocks seen, by function name
 }
 
 func (p *parser) init(file *PosBase, r io.Reader, errh ErrorHandler, pragh PragmaHandler, mode Mode) {
@@ -95,6 +100,7 @@ func (p *parser) init(file *PosBase, r io.Reader, errh ErrorHandler, pragh Pragm
 
 	p.fnest = 0
 	p.xnest = 0
+	p.knest = nil
 	p.indent = nil
 }
 
@@ -469,6 +475,18 @@ func (p *parser) fileOrNil() *File {
 				}
 				break
 			}
+			if p.lit == "test" {
+				name := p.name()
+				if p.tok == _Literal && p.kind == StringLit {
+					// goo: test "name" { ... }
+					f.DeclList = append(f.DeclList, p.testDecl(f, name.pos))
+					break
+				}
+				// a top-level statement starting with the name test
+				x := p.exprListFrom(p.binaryExpr(p.pexpr(name, false), 0))
+				f.TopLevelStmts = append(f.TopLevelStmts, p.simpleStmt(x, 0))
+				break
+			}
 			fallthrough
 
 		default:
@@ -500,7 +518,7 @@ func (p *parser) fileOrNil() *File {
 	f.EOF = p.pos()
 
 	// Generate implicit main function if needed
-	if f.PkgName != nil && f.PkgName.Value == "main" && len(f.TopLevelStmts) > 0 {
+	if f.PkgName != nil && len(f.TopLevelStmts) > 0 {
 		// Check if main function already exists
 		hasMain := false
 		for _, decl := range f.DeclList {
@@ -510,20 +528,26 @@ func (p *parser) fileOrNil() *File {
 			}
 		}
 
-		if !hasMain {
-			// Create synthetic main function
-			mainFunc := &FuncDecl{
-				Name: NewName(f.pos, "main"),
-				Type: &FuncType{},
-				Body: &BlockStmt{
-					List: f.TopLevelStmts,
-				},
-			}
-			mainFunc.SetPos(f.pos)
-			f.DeclList = append(f.DeclList, mainFunc)
-			// Clear TopLevelStmts since they're now in main
-			f.TopLevelStmts = nil
+		// The statements run in main, unless the package is not main
+		// or has a main function: then they run in an init function,
+		// before main. The type checker turns the implicit main into
+		// an init function as well if another file declares main.
+		name := "main"
+		if hasMain || f.PkgName.Value != "main" {
+			name = "init"
 		}
+		mainFunc := &FuncDecl{
+			Name: NewName(f.pos, name),
+			Type: &FuncType{},
+			Body: &BlockStmt{
+				List: f.TopLevelStmts,
+			},
+			TopLevel: true,
+		}
+		mainFunc.SetPos(f.pos)
+		f.DeclList = append(f.DeclList, mainFunc)
+		// Clear TopLevelStmts since they're now in main
+		f.TopLevelStmts = nil
 	}
 
 	return f
@@ -1080,6 +1104,152 @@ func (p *parser) varDecl(group *Group) Decl {
 	return d
 }
 
+// testDecl parses a goo test block after the name "test" at pos and
+// turns it into the test function
+//
+//	func Test_name(t *testing.T) { ... }
+//
+// The test function name is derived from the quoted test name, which is
+// the name the test runs under (see cmd/go/internal/load.gooTestFuncs).
+// If f doesn't import "testing" yet, an import is added.
+//
+//	TestDecl = "test" string_lit Block .
+func (p *parser) testDecl(f *File, pos Pos) *FuncDecl {
+	if trace {
+		defer p.trace("testDecl")()
+	}
+
+	lit := p.oliteral()
+	name, _ := strconv.Unquote(lit.Value)
+	testing := "testing"
+	if strings.HasSuffix(p.file.Filename(), "_test.goo") {
+		testing = p.testingName(f, pos)
+	} else {
+		p.errorAt(pos, "test blocks are only permitted in _test.goo files")
+	}
+
+	fname := testFuncName(name)
+	if other, ok := p.tests[fname]; ok {
+		p.errorAt(lit.pos, fmt.Sprintf("test %q and test %q both compile to function %s", name, other, fname))
+	}
+	if p.tests == nil {
+		p.tests = make(map[string]string)
+	}
+	p.tests[fname] = name
+
+	d := new(FuncDecl)
+	d.pos = pos
+	d.Name = NewName(lit.pos, fname)
+	d.Type = new(FuncType)
+	d.Type.pos = pos
+
+	// t *testing.T
+	typ := new(SelectorExpr)
+	typ.pos = pos
+	typ.X = NewName(pos, testing)
+	typ.Sel = NewName(pos, "T")
+	ptr := new(Operation)
+	ptr.pos = pos
+	ptr.Op = Mul
+	ptr.X = typ
+	par := new(Field)
+	par.pos = pos
+	par.Name = NewName(pos, "t")
+	par.Type = ptr
+	d.Type.ParamList = []*Field{par}
+
+	if p.tok != _Lbrace {
+		p.syntaxError("expected { after test name")
+		p.advance(_Lbrace, _Rbrace)
+	}
+	inTest := p.inTest
+	p.inTest = true
+	d.Body = p.funcBody()
+	p.inTest = inTest
+
+	return d
+}
+
+// testingName returns the name under which f imports "testing",
+// adding an import at pos if there is none.
+func (p *parser) testingName(f *File, pos Pos) string {
+	for _, d := range f.DeclList {
+		if d, ok := d.(*ImportDecl); ok && d.Path != nil && d.Path.Value == `"testing"` {
+			if d.LocalPkgName == nil {
+				return "testing"
+			}
+			if n := d.LocalPkgName.Value; n != "_" && n != "." {
+				return n
+			}
+		}
+	}
+
+	d := new(ImportDecl)
+	d.pos = pos
+	d.Path = new(BasicLit)
+	d.Path.pos = pos
+	d.Path.Value = `"testing"`
+	d.Path.Kind = StringLit
+	f.DeclList = append([]Decl{d}, f.DeclList...)
+	return "testing"
+}
+
+// testFuncName returns the name of the function for the test block
+// with the given name: Test_ followed by the name with every character
+// other than a letter or digit replaced by an underscore.
+// It must match cmd/go/internal/load.gooTestFuncName.
+func testFuncName(name string) string {
+	return "Test_" + strings.Map(func(r rune) rune {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// testCheck turns the check statement s in a test block into
+//
+//	if Cond {} else { t.Error("check failed: Cond") }
+//
+// so that a failing check fails the test without stopping it.
+// The message of check Cond, Msg is reported after a colon.
+func testCheck(s *CheckStmt) *IfStmt {
+	pos := s.pos
+
+	msg := new(BasicLit)
+	msg.pos = pos
+	msg.Value = strconv.Quote("check failed: " + String(s.Cond))
+	msg.Kind = StringLit
+	fun := new(SelectorExpr)
+	fun.pos = pos
+	fun.X = NewName(pos, "t")
+	fun.Sel = NewName(pos, "Error")
+	call := new(CallExpr)
+	call.pos = pos
+	call.Fun = fun
+	call.ArgList = []Expr{msg}
+	if s.Msg != nil {
+		msg.Value = strconv.Quote("check failed: " + String(s.Cond) + ":")
+		call.ArgList = append(call.ArgList, s.Msg)
+	}
+	report := new(ExprStmt)
+	report.pos = pos
+	report.X = call
+
+	n := new(IfStmt)
+	n.pos = pos
+	n.Cond = s.Cond
+	n.Then = new(BlockStmt)
+	n.Then.pos = pos
+	n.Then.Rbrace = pos
+	els := new(BlockStmt)
+	els.pos = pos
+	els.List = []Stmt{report}
+	els.Rbrace = pos
+	n.Else = els
+	return n
+}
+
 // FunctionDecl = "func" FunctionName [ TypeParams ] ( Function | Signature ) .
 // FunctionName = identifier .
 // Function     = Signature FunctionBody .
@@ -1124,6 +1294,10 @@ func (p *parser) funcDeclOrNil() *FuncDecl {
 		p.advance(_Lbrace, _Semi)
 	}
 
+	for p.atContract() {
+		f.Contracts = append(f.Contracts, p.contract())
+	}
+
 	if p.tok == _Lbrace {
 		f.Body = p.funcBody()
 	}
@@ -1131,6 +1305,35 @@ func (p *parser) funcDeclOrNil() *FuncDecl {
 	return f
 }
 
+// atContract reports whether the current token starts a goo contract
+// clause. requires and ensures are no keywords: they start a clause
+// where the body or a result type of a function may begin.
+func (p *parser) atContract() bool {
+	return p.tok == _Name && (p.lit == "requires" || p.lit == "ensures")
+}
+
+// Contract = ( "requires" | "ensures" ) Expression .
+func (p *parser) contract() *Contract {
+	if trace {
+		defer p.trace("contract")()
+	}
+
+	c := new(Contract)
+	c.pos = p.pos()
+	c.Ensures = p.lit == "ensures"
+	p.next()
+
+	// The function body follows the condition, as in an if header.
+	outer := p.xnest
+	p.xnest = -1
+	start := p.keepText()
+	c.Cond = p.expr()
+	c.Text = strings.TrimSpace(p.text(start, max(p.prevEnd, start)))
+	p.xnest = outer
+
+	return c
+}
+
 func (p *parser) funcBody() *BlockStmt {
 	p.fnest++
 	errcnt := p.errcnt
@@ -1147,6 +1350,17 @@ func (p *parser) funcBody() *BlockStmt {
 	return body
 }
 
+// funcLitBody is like funcBody for the body of a function literal.
+// goo: its check statements panic as usual even inside a test block,
+// since the literal may run after the test or in another goroutine.
+func (p *parser) funcLitBody() *BlockStmt {
+	inTest := p.inTest
+	p.inTest = false
+	body := p.funcBody()
+	p.inTest = inTest
+	return body
+}
+
 // ----------------------------------------------------------------------------
 // Expressions
 
@@ -1165,7 +1379,7 @@ func (p *parser) binaryExpr(x Expr, prec int) Expr {
 	if x == nil {
 		x = p.unaryExpr()
 	}
-	for (p.tok == _Operator || p.tok == _Star) && p.prec > prec {
+	for p.binaryOp() && p.prec > prec {
 		t := new(Operation)
 		t.pos = p.pos()
 		t.Op = p.op
@@ -1178,6 +1392,17 @@ func (p *parser) binaryExpr(x Expr, prec int) Expr {
 	return x
 }
 
+// binaryOp reports whether the current token is a binary operator.
+// The name "in" following an operand is the membership operator
+// (x in xs) and is turned into an operator token in place, so that
+// "in" remains usable as an ordinary identifier everywhere else.
+func (p *parser) binaryOp() bool {
+	if p.tok == _Name && p.lit == "in" {
+		p.tok, p.op, p.prec = _Operator, In, precCmp
+	}
+	return p.tok == _Operator || p.tok == _Star
+}
+
 // UnaryExpr = PrimaryExpr | unary_op UnaryExpr .
 func (p *parser) unaryExpr() Expr {
 	if trace {
@@ -1303,7 +1528,12 @@ func (p *parser) operand(keep_parens bool) Expr {
 
 	switch p.tok {
 	case _Name:
-		return p.name()
+		n := p.name()
+		if p.tok == _Lambda {
+			// x => e
+			return p.lambda(n.pos, []*Name{n})
+		}
+		return n
 
 	case _Literal:
 		return p.oliteral()
@@ -1311,10 +1541,22 @@ func (p *parser) operand(keep_parens bool) Expr {
 	case _Lparen:
 		pos := p.pos()
 		p.next()
+		if p.got(_Rparen) {
+			// () => e
+			return p.lambda(pos, nil)
+		}
 		p.xnest++
 		x := p.expr()
 		p.xnest--
+		if p.tok == _Comma {
+			// (a, b) => e
+			return p.lambda(pos, p.lambdaParams(x))
+		}
 		p.want(_Rparen)
+		if n, ok := x.(*Name); ok && p.tok == _Lambda {
+			// (x) => e
+			return p.lambda(pos, []*Name{n})
+		}
 
 		// Optimization: Record presence of ()'s only where needed
 		// for error reporting. Don't bother in other cases; it is
@@ -1349,13 +1591,27 @@ func (p *parser) operand(keep_parens bool) Expr {
 			f := new(FuncLit)
 			f.pos = pos
 			f.Type = ftyp
-			f.Body = p.funcBody()
+			f.Body = p.funcLitBody()
 
 			p.xnest--
 			return f
 		}
 		return ftyp
 
+	case _If, _Switch:
+		// goo: if and switch expressions
+		x := new(CondExpr)
+		x.pos = p.pos()
+		p.xnest++
+		if p.tok == _If {
+			x.Stmt = p.ifStmt()
+		} else {
+			x.Stmt = p.switchStmt()
+		}
+		p.xnest--
+		condNest(x.Stmt)
+		return x
+
 	case _Map:
 		// Parse map type - could be map[K]V or map{...}
 		return p.mapTypeOrLiteral()
@@ -1375,7 +1631,12 @@ func (p *parser) operand(keep_parens bool) Expr {
 		}
 
 		// Check for ellipsis [...] arrays first
-		if p.got(_DotDotDot) {
+		if p.tok == _DotDotDot {
+			dots := p.spread()
+			if dots.Elem != nil {
+				// goo: [...a, 4]
+				return p.sliceLiteral(pos, dots)
+			}
 			p.want(_Rbrack)
 			t := new(ArrayType)
 			t.pos = pos
@@ -1392,8 +1653,20 @@ func (p *parser) operand(keep_parens bool) Expr {
 			return p.sliceLiteral(pos, first)
 		}
 
+		// goo: [x*x for x in xs if x > 0]
+		if p.tok == _For {
+			return p.comprehension(pos, nil, first, _Rbrack)
+		}
+
 		// Otherwise it's array type [expr]Type
+		rbrack := p.pos()
 		p.want(_Rbrack)
+		if p.tok == _Define || p.tok == _Assign {
+			// goo: [first] := xs
+			lit := newSliceLiteral(pos, first)
+			lit.Rbrace = rbrack
+			return lit
+		}
 		t := new(ArrayType)
 		t.pos = pos
 		t.Len = first
@@ -1462,6 +1735,15 @@ loop:
 				t.Sel = p.name()
 				x = t
 
+			case _Map:
+				// goo: the collection method xs.map(f)
+				t := new(SelectorExpr)
+				t.pos = pos
+				t.X = x
+				t.Sel = NewName(p.pos(), "map")
+				p.next()
+				x = t
+
 			case _Lparen:
 				p.next()
 				if p.got(_Type) {
@@ -1495,7 +1777,9 @@ loop:
 					p.syntaxError("expected operand")
 					i = p.badExpr()
 				} else {
+					p.knest = append(p.knest, p.xnest+1) // typeList nests once more
 					i, comma = p.typeList(false)
+					p.knest = p.knest[:len(p.knest)-1]
 				}
 				if comma || p.tok == _Rbrack {
 					p.want(_Rbrack)
@@ -1522,7 +1806,9 @@ loop:
 			t.Index[0] = i
 			if p.tok != _Colon && p.tok != _Rbrack {
 				// x[i:j...
+				p.knest = append(p.knest, p.xnest)
 				t.Index[1] = p.expr()
+				p.knest = p.knest[:len(p.knest)-1]
 			}
 			if p.tok == _Colon {
 				t.Full = true
@@ -1545,25 +1831,30 @@ loop:
 			x = t
 
 		case _Hash:
-			// 1-indexed array access: x#i becomes x[i-1]
+			// 1-indexed access: x#i, x#-1 (last element) and x#i:j (inclusive slice).
+			// The index is kept as written; types2 and the noder handle the offset.
 			p.next()
 			i := p.unaryExpr()
-			// Create IndexExpr with subtraction to convert 1-indexed to 0-indexed
+			if p.tok == _Colon && !p.colonEnds() {
+				// x#i:j - unless the colon ends a complit key, case,
+				// or index, so that "case x#1:", "{x#1: v}" and
+				// "a[x#1:]" keep their meaning. The upper bound binds
+				// tighter than comparisons: x#1:n-1 == y compares.
+				p.next()
+				t := new(SliceExpr)
+				t.pos = pos
+				t.X = x
+				t.Index[0] = i
+				t.Index[1] = p.binaryExpr(nil, precCmp)
+				t.OneBased = true
+				x = t
+				break
+			}
 			t := new(IndexExpr)
 			t.pos = pos
 			t.X = x
-			// Create literal 1
-			one := new(BasicLit)
-			one.pos = pos
-			one.Value = "1"
-			one.Kind = IntLit
-			// Create subtraction: i - 1
-			sub := new(Operation)
-			sub.pos = pos
-			sub.Op = Sub
-			sub.X = i
-			sub.Y = one
-			t.Index = sub
+			t.Index = i
+			t.OneBased = true
 			x = t
 
 		case _Lparen:
@@ -1571,7 +1862,14 @@ loop:
 			t.pos = pos
 			p.next()
 			t.Fun = x
-			t.ArgList, t.HasDots = p.argList()
+			if name, _ := x.(*Name); name != nil && name.Value == "dbg" {
+				// goo: the dbg builtin prints the source text of its argument
+				start := p.keepText()
+				t.ArgList, t.HasDots = p.argList()
+				t.ArgText = p.argText(start)
+			} else {
+				t.ArgList, t.HasDots = p.argList()
+			}
 			x = t
 
 		case _Lbrace:
@@ -1614,6 +1912,12 @@ loop:
 	return x
 }
 
+// colonEnds reports whether a colon at the current nesting level ends
+// a complit key or case list rather than continuing x#i as x#i:j.
+func (p *parser) colonEnds() bool {
+	return len(p.knest) > 0 && p.knest[len(p.knest)-1] == p.xnest
+}
+
 // isValue reports whether x syntactically must be a value (and not a type) expression.
 func isValue(x Expr) bool {
 	switch x := x.(type) {
@@ -1624,7 +1928,7 @@ func isValue(x Expr) bool {
 	case *ParenExpr:
 		return isValue(x.X)
 	case *IndexExpr:
-		return isValue(x.X) || isValue(x.Index)
+		return x.OneBased || isValue(x.X) || isValue(x.Index)
 	}
 	return false
 }
@@ -1643,6 +1947,66 @@ func (p *parser) bare_complitexpr() Expr {
 	return p.expr()
 }
 
+// lambdaParams parses the remaining parameter names of a lambda
+// "(a, b, ...) =>" after the first parameter x, up to and including
+// the closing parenthesis.
+func (p *parser) lambdaParams(x Expr) []*Name {
+	var params []*Name
+	for {
+		if n, ok := x.(*Name); ok {
+			params = append(params, n)
+		} else {
+			p.errorAt(x.Pos(), "lambda parameter must be a name")
+		}
+		if !p.got(_Comma) || p.tok == _Rparen {
+			break
+		}
+		x = p.name()
+	}
+	p.want(_Rparen)
+	return params
+}
+
+// lambda parses a compact function literal after its parameters.
+// The parameter and result types are inferred by the type checker.
+//
+//	Lambda = ( Name | "(" [ NameList [ "," ] ] ")" ) "=>" ( Expression | Block ) .
+func (p *parser) lambda(pos Pos, params []*Name) *FuncLit {
+	if trace {
+		defer p.trace("lambda")()
+	}
+
+	f := new(FuncLit)
+	f.pos = pos
+	f.Lambda = true
+	f.Type = new(FuncType)
+	f.Type.pos = pos
+	for _, n := range params {
+		par := new(Field)
+		par.pos = n.pos
+		par.Name = n
+		f.Type.ParamList = append(f.Type.ParamList, par)
+	}
+
+	p.want(_Lambda)
+	p.xnest++
+	if p.tok == _Lbrace {
+		f.Body = p.funcLitBody()
+	} else {
+		// x => e is short for x => { return e }
+		ret := new(ReturnStmt)
+		ret.pos = p.pos()
+		ret.Results = p.expr()
+		f.Body = new(BlockStmt)
+		f.Body.pos = ret.pos
+		f.Body.List = []Stmt{ret}
+		f.Body.Rbrace = p.pos()
+	}
+	p.xnest--
+
+	return f
+}
+
 // LiteralValue = "{" [ ElementList [ "," ] ] "}" .
 func (p *parser) complitexpr() *CompositeLit {
 	if trace {
@@ -1656,7 +2020,9 @@ func (p *parser) complitexpr() *CompositeLit {
 	p.want(_Lbrace)
 	x.Rbrace = p.list("composite literal", _Comma, _Rbrace, func() bool {
 		// value
+		p.knest = append(p.knest, p.xnest)
 		e := p.bare_complitexpr()
+		p.knest = p.knest[:len(p.knest)-1]
 		if p.tok == _Colon {
 			// key ':' value
 			l := new(KeyValueExpr)
@@ -1810,13 +2176,13 @@ func (p *parser) mapTypeOrLiteral() Expr {
 		p.want(_Lbrack)
 
 		// Check for empty map[] case
-		if p.tok == _Rbrack {
-			// Empty map literal map[]
+		if p.tok == _Rbrack || p.tok == _DotDotDot {
+			// Empty map literal map[], or map[...m, key:value]
 			return p.mapLiteralFromBracket(pos, nil)
 		}
 
 		// Look ahead to determine if this is map[key:value] or map[K]V
-		firstExpr := p.expr()
+		firstExpr := p.keyExpr()
 
 		if p.tok == _Colon {
 			// This is map[key:value key:value...] literal syntax
@@ -1853,6 +2219,45 @@ func (p *parser) sliceLiteral(pos Pos, first Expr) Expr {
 		defer p.trace("sliceLiteral")()
 	}
 
+	lit := newSliceLiteral(pos, first)
+
+	// Parse remaining elements
+	for p.tok == _Comma {
+		p.next()
+		if p.tok == _Rbrack {
+			break // trailing comma
+		}
+
+		// goo: [...a, 4] and [a, b, ...rest] := xs
+		if p.tok == _DotDotDot {
+			lit.ElemList = append(lit.ElemList, p.spread())
+			continue
+		}
+
+		expr := p.expr()
+		lit.ElemList = append(lit.ElemList, expr)
+	}
+
+	lit.Rbrace = p.pos()
+	p.want(_Rbrack)
+	return lit
+}
+
+// spread parses the goo spread element ...x of a slice or map literal,
+// or the rest ...x of a destructuring pattern. The element is nil if
+// ... is followed by ], as in the array type [...]T.
+func (p *parser) spread() *DotsType {
+	t := new(DotsType)
+	t.pos = p.pos()
+	p.want(_DotDotDot)
+	if p.tok != _Rbrack {
+		t.Elem = p.expr()
+	}
+	return t
+}
+
+// newSliceLiteral returns the slice literal []any{first} at pos.
+func newSliceLiteral(pos Pos, first Expr) *CompositeLit {
 	// Create slice type with inferred element type ([]any)
 	sliceType := new(SliceType)
 	sliceType.pos = pos
@@ -1865,23 +2270,10 @@ func (p *parser) sliceLiteral(pos Pos, first Expr) Expr {
 	lit := new(CompositeLit)
 	lit.pos = pos
 	lit.Type = sliceType
+	lit.List = true
 
 	// Add first element - for slice literals, elements are just expressions, not key-value pairs
 	lit.ElemList = append(lit.ElemList, first)
-
-	// Parse remaining elements
-	for p.tok == _Comma {
-		p.next()
-		if p.tok == _Rbrack {
-			break // trailing comma
-		}
-
-		expr := p.expr()
-		lit.ElemList = append(lit.ElemList, expr)
-	}
-
-	lit.Rbrace = p.pos()
-	p.want(_Rbrack)
 	return lit
 }
 
@@ -1898,6 +2290,14 @@ func (p *parser) convertSymbolKeyToString(keyExpr Expr) Expr {
 	return keyExpr
 }
 
+// keyExpr parses the key of a map literal element, which a colon ends.
+func (p *parser) keyExpr() Expr {
+	p.knest = append(p.knest, p.xnest)
+	x := p.expr()
+	p.knest = p.knest[:len(p.knest)-1]
+	return x
+}
+
 // mapLiteralFromBracket parses map[key:value key:value...] style map literals
 func (p *parser) mapLiteralFromBracket(pos Pos, firstKey Expr) Expr {
 	if trace {
@@ -1942,7 +2342,13 @@ func (p *parser) mapLiteralFromBracket(pos Pos, firstKey Expr) Expr {
 			break // closing bracket or empty map
 		}
 
-		keyExpr := p.expr()
+		// goo: map[...defaults, port: 8080]
+		if p.tok == _DotDotDot {
+			lit.ElemList = append(lit.ElemList, p.spread())
+			continue
+		}
+
+		keyExpr := p.keyExpr()
 		keyExpr = p.convertSymbolKeyToString(keyExpr)
 
 		p.want(_Colon)
@@ -1983,15 +2389,29 @@ func (p *parser) mapLiteralFromBrace() Expr {
 	lit := new(CompositeLit)
 	lit.pos = pos
 	lit.Type = mapType
+	lit.Brace = true
 
 	// Parse elements
 	for p.tok != _Rbrace && p.tok != _EOF {
-		keyExpr := p.expr()
-		keyExpr = p.convertSymbolKeyToString(keyExpr)
+		// goo: {...defaults, port: 8080}
+		if p.tok == _DotDotDot {
+			lit.ElemList = append(lit.ElemList, p.spread())
+			if !p.got(_Comma) {
+				break
+			}
+			continue
+		}
 
+		keyExpr := p.keyExpr()
 		p.want(_Colon)
 		valueExpr := p.expr()
 
+		// goo: {k: v for k, v in m if v > 0}
+		if len(lit.ElemList) == 0 && p.tok == _For {
+			return p.comprehension(pos, keyExpr, valueExpr, _Rbrace)
+		}
+		keyExpr = p.convertSymbolKeyToString(keyExpr)
+
 		// Create key-value pair
 		kvExpr := new(KeyValueExpr)
 		kvExpr.pos = keyExpr.Pos()
@@ -2010,6 +2430,63 @@ func (p *parser) mapLiteralFromBrace() Expr {
 	return lit
 }
 
+// comprehension parses the for and if clauses of the comprehension
+// [elem for x in X … if cond], or {key: elem for x in X … if cond} if key
+// is not nil, up to the closing token close, into the equivalent loop.
+func (p *parser) comprehension(pos Pos, key, elem Expr, close token) Expr {
+	if trace {
+		defer p.trace("comprehension")()
+	}
+
+	x := new(CompExpr)
+	x.pos = pos
+	x.Map = key != nil
+
+	var loops []*ForStmt
+	for p.tok == _For {
+		s := new(ForStmt)
+		s.pos = p.pos()
+		p.next()
+		lhs := p.exprList()
+		if r := p.inClause(lhs); r != nil {
+			s.Init = r
+		} else {
+			p.syntaxErrorAt(lhs.Pos(), "expected for x in X in comprehension")
+		}
+		s.Body = new(BlockStmt)
+		s.Body.pos = s.pos
+		loops = append(loops, s)
+	}
+
+	body := &loops[len(loops)-1].Body.List
+	if p.tok == _If {
+		s := new(IfStmt)
+		s.pos = p.pos()
+		p.next()
+		s.Cond = p.expr()
+		s.Then = new(BlockStmt)
+		s.Then.pos = s.pos
+		*body = []Stmt{s}
+		body = &s.Then.List
+	}
+	for _, v := range []Expr{key, elem} {
+		if v != nil {
+			s := new(ExprStmt)
+			s.pos = v.Pos()
+			s.X = v
+			*body = append(*body, s)
+		}
+	}
+	for i := len(loops) - 1; i > 0; i-- {
+		loops[i-1].Body.List = []Stmt{loops[i]}
+	}
+
+	x.Loop = loops[0]
+	x.Rbrack = p.pos()
+	p.want(close)
+	return x
+}
+
 func (p *parser) typeInstance(typ Expr) Expr {
 	if trace {
 		defer p.trace("typeInstance")()
@@ -2164,6 +2641,9 @@ func (p *parser) funcResult() []*Field {
 	if p.got(_Lparen) {
 		return p.paramList(nil, nil, _Rparen, false, false)
 	}
+	if p.atContract() {
+		return nil
+	}
 
 	pos := p.pos()
 	if typ := p.typeOrNil(); typ != nil {
@@ -2472,7 +2952,7 @@ func (p *parser) embeddedTerm() Expr {
 	return t
 }
 
-// ParameterDecl = [ IdentifierList ] [ "..." ] Type .
+// ParameterDecl = [ IdentifierList ] [ "..." ] Type [ "=" Expression ] .
 func (p *parser) paramDeclOrNil(name *Name, follow token) *Field {
 	if trace {
 		defer p.trace("paramDeclOrNil")()
@@ -2607,6 +3087,11 @@ func (p *parser) paramList(name *Name, typ Expr, close token, requireNames, dddo
 		} else {
 			par = p.paramDeclOrNil(name, close)
 		}
+		if par != nil && close == _Rparen && p.tok == _Assign {
+			// goo: default parameter value
+			p.next()
+			par.Default = p.expr()
+		}
 		name = nil // 1st name was consumed if present
 		typ = nil  // 1st type was consumed if present
 		if par != nil {
@@ -2641,16 +3126,19 @@ func (p *parser) paramList(name *Name, typ Expr, close token, requireNames, dddo
 		// some named or we're in a type parameter list => all must be named
 		var errPos Pos // left-most error position (or unknown)
 		var typ Expr   // current type (from right to left)
+		var def Expr   // current default value (from right to left)
 		for i := len(list) - 1; i >= 0; i-- {
 			par := list[i]
 			if par.Type != nil {
 				typ = par.Type
+				def = par.Default
 				if par.Name == nil {
 					errPos = StartPos(typ)
 					par.Name = NewName(errPos, "_")
 				}
 			} else if typ != nil {
 				par.Type = typ
+				par.Default = def
 			} else {
 				// par.Type == nil && typ == nil => we only have a par.Name
 				errPos = par.Name.Pos()
@@ -2737,6 +3225,12 @@ func (p *parser) simpleStmt(lhs Expr, keyword token) SimpleStmt {
 		lhs = p.exprList()
 	}
 
+	if keyword == _For && p.tok == _Lbrace {
+		if r := p.inClause(lhs); r != nil {
+			return r
+		}
+	}
+
 	if _, ok := lhs.(*ListExpr); !ok && p.tok != _Assign && p.tok != _Define {
 		// expr
 		pos := p.pos()
@@ -2787,6 +3281,7 @@ func (p *parser) simpleStmt(lhs Expr, keyword token) SimpleStmt {
 		}
 
 		// expr_list op= expr_list
+		lhs = p.pattern(lhs)
 		rhs := p.exprList()
 
 		if x, ok := rhs.(*TypeSwitchGuard); ok && keyword == _Switch && op == Def {
@@ -2826,6 +3321,48 @@ func (p *parser) newRangeClause(lhs Expr, defi bool) *RangeClause {
 	return r
 }
 
+// inClause returns the range clause of a goo for statement header
+// "x in X" or "k, v in X", which lhs holds as a membership operation,
+// or nil if lhs is not of that form. The variables are declared as
+// with :=; a single variable ranges over the elements of slices, arrays
+// and strings (see types2). A membership loop condition must be
+// parenthesized: for (x in xs) { ... }.
+func (p *parser) inClause(lhs Expr) *RangeClause {
+	var key Expr
+	x := lhs
+	if list, ok := lhs.(*ListExpr); ok {
+		if len(list.ElemList) != 2 {
+			return nil
+		}
+		key, x = list.ElemList[0], list.ElemList[1]
+		if _, ok := key.(*Name); !ok {
+			return nil
+		}
+	}
+	op, ok := x.(*Operation)
+	if !ok || op.Op != In || op.Y == nil {
+		return nil
+	}
+	op.X = p.pattern(op.X)
+	switch op.X.(type) {
+	case *Name, *PatternExpr:
+	default:
+		return nil
+	}
+
+	r := new(RangeClause)
+	r.pos = op.pos
+	r.Lhs = op.X
+	if key != nil {
+		lhs.(*ListExpr).ElemList[1] = op.X
+		r.Lhs = lhs
+	}
+	r.Def = true
+	r.In = true
+	r.X = op.Y
+	return r
+}
+
 func (p *parser) newAssignStmt(pos Pos, op Operator, lhs, rhs Expr) *AssignStmt {
 	a := new(AssignStmt)
 	a.pos = pos
@@ -2916,6 +3453,25 @@ func (p *parser) forStmt() Stmt {
 	s.pos = p.pos()
 
 	s.Init, s.Cond, s.Post = p.header(_For)
+	if s.Init == nil && s.Cond == nil && s.Post == nil && p.tok == _Lbrace {
+		// goo: for {a, b} in X
+		body, pat := p.blockOrPattern(true)
+		if body != nil {
+			s.Body = body
+			return s
+		}
+		r := new(RangeClause)
+		r.pos = p.pos()
+		p.next() // consume in
+		r.Lhs = pat
+		r.Def = true
+		r.In = true
+		outer := p.xnest
+		p.xnest = -1
+		r.X = p.expr()
+		p.xnest = outer
+		s.Init = r
+	}
 	s.Body = p.blockStmt("for clause")
 
 	return s
@@ -3159,7 +3715,9 @@ func (p *parser) caseClause() *CaseClause {
 	switch p.tok {
 	case _Case:
 		p.next()
+		p.knest = append(p.knest, p.xnest)
 		c.Cases = p.exprList()
+		p.knest = p.knest[:len(p.knest)-1]
 
 	case _Default:
 		p.next()
@@ -3187,7 +3745,9 @@ func (p *parser) commClause() *CommClause {
 	switch p.tok {
 	case _Case:
 		p.next()
+		p.knest = append(p.knest, p.xnest)
 		c.Comm = p.simpleStmt(nil, 0)
+		p.knest = p.knest[:len(p.knest)-1]
 
 		// The syntax restricts the possible simple statements here to:
 		//
@@ -3214,6 +3774,278 @@ func (p *parser) commClause() *CommClause {
 	return c
 }
 
+// isCallHead reports whether x, a (qualified) name, may be the
+// function of a call without parentheses.
+func isCallHead(x Expr) bool {
+	switch x := x.(type) {
+	case *Name:
+		return x.Value != "_"
+	case *SelectorExpr:
+		return isCallHead(x.X)
+	}
+	return false
+}
+
+// startsParenFreeArg reports whether the current token starts the
+// arguments of a call statement without parentheses. Since a newline
+// after a name ends the statement, the arguments are on the same line.
+// Tokens which may continue an expression, like ( or -, never start
+// arguments: put -1 is put - 1, and put (1) is put(1).
+func (p *parser) startsParenFreeArg() bool {
+	switch p.tok {
+	case _Literal, _Name, _Func, _Map, _Chan, _Struct, _Interface:
+		return true
+	case _Operator:
+		return p.op == Not
+	}
+	return false
+}
+
+// parenFreeCall parses the rest of the line after the function fun
+// as the arguments of the goo call statement
+//
+//	fun x, y
+//
+// which is fun(x, y). The type checker reports whether fun is a function.
+func (p *parser) parenFreeCall(fun Expr) *ExprStmt {
+	if trace {
+		defer p.trace("parenFreeCall")()
+	}
+
+	call := new(CallExpr)
+	call.pos = p.pos()
+	call.Fun = fun
+	call.NoParens = true
+	for {
+		call.ArgList = append(call.ArgList, p.expr())
+		if !p.got(_Comma) {
+			break
+		}
+	}
+
+	s := new(ExprStmt)
+	s.pos = fun.Pos()
+	s.X = call
+	return s
+}
+
+// withStmt parses the rest of the goo statement
+//
+//	WithStmt    = "with" WithBinding { "," WithBinding } Block .
+//	WithBinding = IdentifierList ":=" Expression [ "?" ] .
+//
+// after its with at pos. with is no keyword: it starts a with statement
+// where it is followed by a name.
+func (p *parser) withStmt(pos Pos) *WithStmt {
+	if trace {
+		defer p.trace("withStmt")()
+	}
+
+	s := new(WithStmt)
+	s.pos = pos
+
+	// The block follows the bindings, as in an if header.
+	outer := p.xnest
+	p.xnest = -1
+	for {
+		b := new(WithBinding)
+		b.pos = p.pos()
+		names := p.nameList(p.name())
+		if len(names) == 1 {
+			b.Lhs = names[0]
+		} else {
+			list := new(ListExpr)
+			list.pos = names[0].Pos()
+			for _, name := range names {
+				list.ElemList = append(list.ElemList, name)
+			}
+			b.Lhs = list
+		}
+		p.want(_Define)
+		b.Rhs = p.expr()
+		b.Try = p.got(_Question)
+		s.Bindings = append(s.Bindings, b)
+		if !p.got(_Comma) {
+			break
+		}
+	}
+	p.xnest = outer
+
+	s.Body = p.blockStmt("with clause")
+	return s
+}
+
+// nameStmt parses the rest of a statement that starts with the list of
+// expressions lhs, the first of which is a name.
+func (p *parser) nameStmt(lhs Expr) Stmt {
+	if label, ok := lhs.(*Name); ok && p.tok == _Colon {
+		return p.labeledStmtOrNil(label)
+	}
+	if name, ok := lhs.(*Name); ok && name.Value == "with" && p.tok == _Name {
+		return p.withStmt(name.Pos())
+	}
+	if p.gooFile() && isCallHead(lhs) && p.startsParenFreeArg() {
+		return p.parenFreeCall(lhs)
+	}
+	return p.simpleStmt(lhs, 0)
+}
+
+// stmtHead parses the expression list that starts a statement with a
+// name. In a goo file, a call head followed by the name in is left
+// alone, so that put in calls put with the variable in.
+func (p *parser) stmtHead() Expr {
+	x := p.unaryExpr()
+	if p.gooFile() && isCallHead(x) && p.tok == _Name && p.lit == "in" {
+		return x
+	}
+	return p.exprListFrom(p.binaryExpr(x, 0))
+}
+
+// gooFile reports whether the file being parsed is a goo source file,
+// where the statements of Go have a few more forms.
+func (p *parser) gooFile() bool {
+	return strings.HasSuffix(p.file.Filename(), ".goo")
+}
+
+// braceStmt parses a block or a goo destructuring assignment
+//
+//	{a, b} := x
+//	{a, b}, ok := m
+func (p *parser) braceStmt() Stmt {
+	if trace {
+		defer p.trace("braceStmt")()
+	}
+
+	block, pat := p.blockOrPattern(false)
+	if block != nil {
+		return block
+	}
+	var lhs Expr = pat
+	if p.got(_Comma) {
+		list := new(ListExpr)
+		list.pos = pat.Pos()
+		list.ElemList = append([]Expr{pat}, UnpackListExpr(p.exprList())...)
+		lhs = list
+	}
+	return p.simpleStmt(lhs, 0)
+}
+
+// blockOrPattern parses a block or, if the closing brace after a list of
+// names is followed by in (in a for statement header, if in is set) or by
+// :=, = or a comma (otherwise), the destructuring pattern {names}.
+func (p *parser) blockOrPattern(in bool) (*BlockStmt, *PatternExpr) {
+	if trace {
+		defer p.trace("blockOrPattern")()
+	}
+
+	s := new(BlockStmt)
+	s.pos = p.pos()
+	p.want(_Lbrace)
+	if p.tok != _Name {
+		s.List = p.stmtList()
+		s.Rbrace = p.pos()
+		p.want(_Rbrace)
+		return s, nil
+	}
+
+	p.clearPragma()
+	lhs := p.stmtHead()
+	if names := nameList(lhs); names != nil && p.tok == _Rbrace {
+		s.Rbrace = p.pos()
+		p.next()
+		if in && (p.tok == _Name && p.lit == "in" || p.tok == _Operator && p.op == In) || !in && (p.tok == _Define || p.tok == _Assign || p.tok == _Comma) {
+			x := new(PatternExpr)
+			x.pos = s.pos
+			x.Brace = true
+			x.Names = names
+			x.Rbrack = s.Rbrace
+			return nil, x
+		}
+		// a block holding an expression statement
+		if len(names) > 1 {
+			p.syntaxErrorAt(s.Rbrace, "unexpected }, expected := or = or comma")
+		}
+		x := new(ExprStmt)
+		x.pos = lhs.Pos()
+		x.X = names[0]
+		s.List = []Stmt{x}
+		return s, nil
+	}
+
+	if first := p.nameStmt(lhs); first != nil {
+		s.List = append(s.List, first)
+		p.clearPragma()
+		// ";" is optional before "}"
+		if !p.got(_Semi) && p.tok != _Rbrace {
+			p.syntaxError("at end of statement")
+			p.advance(_Semi, _Rbrace, _Case, _Default)
+			p.got(_Semi) // avoid spurious empty statement
+		}
+		s.List = append(s.List, p.stmtList()...)
+	}
+	s.Rbrace = p.pos()
+	p.want(_Rbrace)
+	return s, nil
+}
+
+// nameList returns the names of x if x is a name or a list of names,
+// and nil otherwise.
+func nameList(x Expr) []*Name {
+	var names []*Name
+	for _, x := range UnpackListExpr(x) {
+		name, ok := x.(*Name)
+		if !ok {
+			return nil
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// pattern returns the destructuring pattern [a, b, ...rest] if x, the
+// left side of an assignment or of in, is a slice literal of names
+// followed by an optional rest, or a list starting with one; and x
+// otherwise. The type checker reports the other composite literals.
+func (p *parser) pattern(x Expr) Expr {
+	first := x
+	list, _ := x.(*ListExpr)
+	if list != nil {
+		first = list.ElemList[0]
+	}
+	lit, ok := first.(*CompositeLit)
+	if !ok || lit.NKeys > 0 || len(lit.ElemList) == 0 {
+		return x
+	}
+	if _, ok := lit.Type.(*SliceType); !ok {
+		return x
+	}
+
+	pat := new(PatternExpr)
+	pat.pos = lit.Pos()
+	pat.Rbrack = lit.Rbrace
+	for i, e := range lit.ElemList {
+		if d, ok := e.(*DotsType); ok && i == len(lit.ElemList)-1 && i > 0 {
+			e = d.Elem
+			if name, ok := e.(*Name); ok {
+				pat.Rest = name
+				break
+			}
+		}
+		name, ok := e.(*Name)
+		if !ok {
+			p.syntaxErrorAt(e.Pos(), "expected name or ...name in destructuring pattern")
+			return x
+		}
+		pat.Names = append(pat.Names, name)
+	}
+
+	if list != nil {
+		list.ElemList[0] = pat
+		return list
+	}
+	return pat
+}
+
 // stmtOrNil parses a statement if one is present, or else returns nil.
 //
 //	Statement =
@@ -3230,11 +4062,7 @@ func (p *parser) stmtOrNil() Stmt {
 	// look for it first before doing anything more expensive.
 	if p.tok == _Name {
 		p.clearPragma()
-		lhs := p.exprList()
-		if label, ok := lhs.(*Name); ok && p.tok == _Colon {
-			return p.labeledStmtOrNil(label)
-		}
-		return p.simpleStmt(lhs, 0)
+		return p.nameStmt(p.stmtHead())
 	}
 
 	switch p.tok {
@@ -3255,7 +4083,7 @@ func (p *parser) stmtOrNil() Stmt {
 
 	switch p.tok {
 	case _Lbrace:
-		return p.blockStmt("")
+		return p.braceStmt()
 
 	case _Operator, _Star:
 		switch p.op {
@@ -3302,6 +4130,13 @@ func (p *parser) stmtOrNil() Stmt {
 		s.pos = p.pos()
 		p.next()
 		s.Cond = p.expr()
+		if p.got(_Comma) || p.tok == _Literal && p.kind == StringLit {
+			// goo: check Cond, "message"
+			s.Msg = p.expr()
+		}
+		if p.inTest {
+			return testCheck(s)
+		}
 		return s
 
 	case _Go, _Defer:
@@ -3360,7 +4195,10 @@ func (p *parser) stmtList() (l []Stmt) {
 // optionally followed by a comma (if not empty), and closed by ")".
 // The last argument may be followed by "...".
 //
+// A named argument name: x is returned as a KeyValueExpr.
+//
 // argList = [ arg { "," arg } [ "..." ] [ "," ] ] ")" .
+// arg     = [ identifier ":" ] Expression .
 func (p *parser) argList() (list []Expr, hasDots bool) {
 	if trace {
 		defer p.trace("argList")()
@@ -3368,7 +4206,17 @@ func (p *parser) argList() (list []Expr, hasDots bool) {
 
 	p.xnest++
 	p.list("argument list", _Comma, _Rparen, func() bool {
-		list = append(list, p.expr())
+		x := p.expr()
+		if name, ok := x.(*Name); ok && p.tok == _Colon {
+			// goo: named argument
+			l := new(KeyValueExpr)
+			l.pos = p.pos()
+			p.next()
+			l.Key = name
+			l.Value = p.expr()
+			x = l
+		}
+		list = append(list, x)
 		hasDots = p.got(_DotDotDot)
 		return hasDots
 	})
@@ -3377,6 +4225,21 @@ func (p *parser) argList() (list []Expr, hasDots bool) {
 	return
 }
 
+// keepText keeps the source text from the current token on for argText
+// and returns its offset.
+func (p *parser) keepText() int {
+	start := p.off + p.b
+	p.keep(start)
+	return start
+}
+
+// argText returns the source text from the offset start to the closing
+// parenthesis of the argument list just parsed, without a trailing comma.
+func (p *parser) argText(start int) string {
+	text := strings.TrimSpace(p.text(start, max(p.prevEnd-len(")"), start)))
+	return strings.TrimSpace(strings.TrimSuffix(text, ","))
+}
+
 // ----------------------------------------------------------------------------
 // Common productions
 
@@ -3454,7 +4317,11 @@ func (p *parser) exprList() Expr {
 		defer p.trace("exprList")()
 	}
 
-	x := p.expr()
+	return p.exprListFrom(p.expr())
+}
+
+// exprListFrom parses the rest of an expression list starting with x.
+func (p *parser) exprListFrom(x Expr) Expr {
 	if p.got(_Comma) {
 		list := []Expr{x, p.expr()}
 		for p.got(_Comma) {
@@ -3519,6 +4386,94 @@ func Unparen(x Expr) Expr {
 	return x
 }
 
+// CondValues returns the statements providing the values of the if or
+// switch expression x, one per branch, in source order. The entry for
+// a branch that doesn't end in an expression statement is nil.
+// A missing else branch has no entry.
+func CondValues(x *CondExpr) []*ExprStmt {
+	var list []*ExprStmt
+	condBranches(x.Stmt, func(body []Stmt) {
+		var s *ExprStmt
+		if n := len(body); n > 0 {
+			s, _ = body[n-1].(*ExprStmt)
+		}
+		list = append(list, s)
+	})
+	return list
+}
+
+// CompClauses returns the range clauses of the for clauses of the
+// comprehension x, the condition of its if clause or nil, and the
+// statements providing the elements, preceded by the keys for maps.
+func CompClauses(x *CompExpr) (clauses []*RangeClause, cond Expr, values []*ExprStmt) {
+	body := x.Loop.Body.List
+	for s := x.Loop; s != nil; {
+		r, _ := s.Init.(*RangeClause)
+		clauses = append(clauses, r)
+		body = s.Body.List
+		s = nil
+		if len(body) == 1 {
+			s, _ = body[0].(*ForStmt)
+		}
+	}
+	if len(body) == 1 {
+		if s, ok := body[0].(*IfStmt); ok {
+			cond = s.Cond
+			body = s.Then.List
+		}
+	}
+	for _, s := range body {
+		values = append(values, s.(*ExprStmt))
+	}
+	return
+}
+
+// condNest turns if and switch statements ending a branch of the if or
+// switch expression stmt into nested expressions providing the value
+// of the branch.
+func condNest(stmt Stmt) {
+	condBranches(stmt, func(body []Stmt) {
+		n := len(body)
+		if n == 0 {
+			return
+		}
+		switch s := body[n-1].(type) {
+		case *IfStmt, *SwitchStmt:
+			x := new(CondExpr)
+			x.pos = s.Pos()
+			x.Stmt = s
+			e := new(ExprStmt)
+			e.pos = s.Pos()
+			e.X = x
+			body[n-1] = e
+			condNest(s)
+		}
+	})
+}
+
+// condBranches calls f with the body of each branch of the if or switch
+// statement stmt.
+func condBranches(stmt Stmt, f func(body []Stmt)) {
+	switch s := stmt.(type) {
+	case *IfStmt:
+		for s != nil {
+			f(s.Then.List)
+			switch e := s.Else.(type) {
+			case *IfStmt:
+				s = e
+				continue
+			case *BlockStmt:
+				f(e.List)
+			}
+			s = nil
+		}
+	case *SwitchStmt:
+		for _, c := range s.Body {
+			f(c.Body)
+		}
+	}
+}
+
 // UnpackListExpr unpacks a *ListExpr into a []Expr.
 func UnpackListExpr(x Expr) []Expr {
 	switch x := x.(type) {
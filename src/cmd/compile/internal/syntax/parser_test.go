@@ -393,3 +393,36 @@ func TestUnpackListExprAllocs(t *testing.T) {
 		errorf("UnpackListExpr allocated %v times", allocs)
 	}
 }
+
+// Test that the parser keeps the source text of the arguments of dbg
+// calls, also across refills of the source buffer.
+func TestDbgArgText(t *testing.T) {
+	long := "[]int{" + strings.Repeat("1, ", 5000) + "}"
+	for _, test := range []struct{ src, text string }{
+		{"dbg(x)", "x"},
+		{"dbg()", ""},
+		{"dbg(  a   +  b )", "a   +  b"},
+		{"dbg(f(x), y)", "f(x), y"},
+		{"dbg(\n\tx,\n)", "x"},
+		{"dbg(dbg(x) * 2)", "dbg(x) * 2"},
+		{"dbg(x /* c */)", "x /* c */"},
+		{"dbg(" + long + ")", long},
+		{strings.Repeat("// padding\n", 1000) + "dbg(" + long + ")", long},
+	} {
+		src := "package p; var _ = " + test.src
+		file, err := Parse(nil, strings.NewReader(src), nil, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var text string
+		Inspect(file, func(n Node) bool {
+			if call, ok := n.(*CallExpr); ok && text == "" {
+				text = call.ArgText
+			}
+			return true
+		})
+		if text != test.text {
+			t.Errorf("%.40q: got %.40q, want %.40q", test.src, text, test.text)
+		}
+	}
+}
@@ -182,10 +182,16 @@ func EndPos(n Node) Pos {
 			return MakePos(p.Base(), p.Line(), p.Col()+uint(len(n.Value)))
 		case *CompositeLit:
 			return n.Rbrace
+		case *CompExpr:
+			return n.Rbrack
+		case *PatternExpr:
+			return n.Rbrack
 		case *KeyValueExpr:
 			m = n.Value
 		case *FuncLit:
 			m = n.Body
+		case *CondExpr:
+			m = n.Stmt
 		case *ParenExpr:
 			m = n.X
 		case *SelectorExpr:
@@ -313,6 +319,8 @@ func EndPos(n Node) Pos {
 			return n.Rbrace
 		case *SelectStmt:
 			return n.Rbrace
+		case *WithStmt:
+			m = n.Body
 
 		// helper nodes
 		case *RangeClause:
@@ -329,6 +337,8 @@ func EndPos(n Node) Pos {
 				continue
 			}
 			return n.Colon
+		case *WithBinding:
+			m = n.Rhs
 
 		default:
 			return n.Pos()
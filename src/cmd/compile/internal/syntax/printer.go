@@ -366,7 +366,18 @@ func (p *printer) printRawNode(n Node) {
 		p.print(_Name, n.Value) // _Name requires actual value following immediately
 
 	case *FuncLit:
-		p.print(n.Type, blank)
+		if n.Lambda {
+			p.print(_Lparen)
+			for i, f := range n.Type.ParamList {
+				if i > 0 {
+					p.print(_Comma, blank)
+				}
+				p.printNode(f.Name)
+			}
+			p.print(_Rparen, blank, _Lambda, blank)
+		} else {
+			p.print(n.Type, blank)
+		}
 		if n.Body != nil {
 			if p.form == ShortForm {
 				p.print(_Lbrace)
@@ -397,6 +408,40 @@ func (p *printer) printRawNode(n Node) {
 		}
 		p.print(_Rbrace)
 
+	case *CondExpr:
+		p.print(n.Stmt)
+
+	case *CompExpr:
+		clauses, cond, values := CompClauses(n)
+		if n.Map {
+			p.print(_Lbrace, values[0].X, _Colon, blank, values[1].X)
+		} else {
+			p.print(_Lbrack, values[0].X)
+		}
+		for _, r := range clauses {
+			p.print(blank, _For, blank, r)
+		}
+		if cond != nil {
+			p.print(blank, _If, blank, cond)
+		}
+		if n.Map {
+			p.print(_Rbrace)
+		} else {
+			p.print(_Rbrack)
+		}
+
+	case *PatternExpr:
+		open, close := _Lbrack, _Rbrack
+		if n.Brace {
+			open, close = _Lbrace, _Rbrace
+		}
+		p.print(open)
+		p.printNameList(n.Names)
+		if n.Rest != nil {
+			p.print(_Comma, blank, _DotDotDot, n.Rest)
+		}
+		p.print(close)
+
 	case *ParenExpr:
 		p.print(_Lparen, n.X, _Rparen)
 
@@ -404,9 +449,17 @@ func (p *printer) printRawNode(n Node) {
 		p.print(n.X, _Dot, n.Sel)
 
 	case *IndexExpr:
+		if n.OneBased {
+			p.print(n.X, _Hash, n.Index)
+			break
+		}
 		p.print(n.X, _Lbrack, n.Index, _Rbrack)
 
 	case *SliceExpr:
+		if n.OneBased {
+			p.print(n.X, _Hash, n.Index[0], _Colon, n.Index[1])
+			break
+		}
 		p.print(n.X, _Lbrack)
 		if i := n.Index[0]; i != nil {
 			p.printNode(i)
@@ -430,6 +483,12 @@ func (p *printer) printRawNode(n Node) {
 		p.print(n.X, _Dot, _Lparen, _Type, _Rparen)
 
 	case *CallExpr:
+		if n.NoParens {
+			// goo: put 42
+			p.print(n.Fun, blank)
+			p.printExprList(n.ArgList)
+			break
+		}
 		p.print(n.Fun, _Lparen)
 		p.printExprList(n.ArgList)
 		if n.HasDots {
@@ -557,6 +616,12 @@ func (p *printer) printRawNode(n Node) {
 	case *CallStmt:
 		p.print(n.Tok, blank, n.Call)
 
+	case *CheckStmt:
+		p.print(_Check, blank, n.Cond)
+		if n.Msg != nil {
+			p.print(_Comma, blank, n.Msg)
+		}
+
 	case *ReturnStmt:
 		p.print(_Return)
 		if n.Results != nil {
@@ -602,7 +667,24 @@ func (p *printer) printRawNode(n Node) {
 		p.print(_Select, blank) // for now
 		p.printSelectBody(n.Body)
 
+	case *WithStmt:
+		p.print(NewName(n.Pos(), "with"), blank)
+		for i, b := range n.Bindings {
+			if i > 0 {
+				p.print(_Comma, blank)
+			}
+			p.print(b.Lhs, blank, _Define, blank, b.Rhs)
+			if b.Try {
+				p.print(_Question)
+			}
+		}
+		p.print(blank, n.Body)
+
 	case *RangeClause:
+		if n.In {
+			p.print(n.Lhs, blank, In, blank, n.X)
+			break
+		}
 		if n.Lhs != nil {
 			tok := _Assign
 			if n.Def {
@@ -700,10 +782,20 @@ func (p *printer) printRawNode(n Node) {
 			p.printParameterList(n.TParamList, _Func)
 		}
 		p.printSignature(n.Type)
+		for _, c := range n.Contracts {
+			p.print(blank, c)
+		}
 		if n.Body != nil {
 			p.print(blank, n.Body)
 		}
 
+	case *Contract:
+		keyword := "requires"
+		if n.Ensures {
+			keyword = "ensures"
+		}
+		p.print(NewName(n.Pos(), keyword), blank, n.Cond)
+
 	case *printGroup:
 		p.print(n.Tok, blank, _Lparen)
 		if len(n.Decls) > 0 {
@@ -917,6 +1009,10 @@ func (p *printer) printParameterList(list []*Field, tok token) {
 			p.print(blank)
 		}
 		p.printNode(f.Type)
+		if f.Default != nil {
+			p.print(blank, _Assign, blank)
+			p.printNode(f.Default)
+		}
 	}
 	// A type parameter list [P T] where the name P and the type expression T syntactically
 	// combine to another valid (value) expression requires a trailing comma, as in [P *T,]
@@ -140,12 +140,53 @@ var stringTests = [][2]string{
 	{"package p; type _[P ((C)),] int", "package p; type _[P C] int"},
 	{"package p; type _[P, Q ((C))] int", "package p; type _[P, Q C] int"},
 
+	// goo: for-in loops
+	dup("package p; func _() { for x in xs {} }"),
+	dup("package p; func _() { for k, v in m {} }"),
+	dup("package p; func _() { for (x in xs) {} }"),
+
+	// goo: comprehensions
+	dup("package p; var _ = [x * x for x in xs]"),
+	dup("package p; var _ = [x + y for x in xs for y in ys if x != y]"),
+	dup("package p; var _ = {k: v for k, v in m if v > 0}"),
+
+	// goo: collection methods
+	dup("package p; var _ = xs.map(f).filter(g).sum()"),
+
+	// goo: calls without parentheses and check messages
+	dup("package p; func _() { put 42 + 3 }"),
+	dup(`package p; func _() { fmt.Println x, "y" }`),
+	dup("package p; func _() { put(42) + 3 }"),
+	dup(`package p; func _() { check x > 0, "x must be positive" }`),
+
+	// goo: contracts
+	dup("package p; func _(x int) requires x > 0 {}"),
+	dup("package p; func _(x int) int requires x > 0 ensures result >= old(x) {}"),
+	dup("package p; func (T) _() (r int) ensures r > 0 ensures r < 10 {}"),
+	dup("package p; func _() requires (T{} == x)"),
+
+	// goo: with statements
+	dup("package p; func _() { with f := open(name) {} }"),
+	dup("package p; func _() { with f, err := open(name) {} }"),
+	dup("package p; func _() error { with f := open(a)?, g := open(b)? { f.Write(g) } }"),
+	dup("package p; func _() { with := 1; with(2) }"),
+
+	// goo: destructuring
+	dup("package p; func _() { {name, age} := person }"),
+	dup("package p; func _() { {id, tags}, ok := m }"),
+	dup("package p; func _() { {a} = s }"),
+	dup("package p; func _() { [first, second, ...rest] := xs }"),
+	dup("package p; func _() { [first] := xs }"),
+	dup("package p; func _() { for {k, v} in pairs {} }"),
+	dup("package p; func _() { for i, [a, b] in pairs {} }"),
+	dup("package p; func _() { { x }; { x, y = y, x }; { f(x) }; { L: x++ } }"),
+
 	// TODO(gri) expand
 }
 
 func TestPrintString(t *testing.T) {
 	for _, test := range stringTests {
-		ast, err := Parse(nil, strings.NewReader(test[0]), nil, nil, 0)
+		ast, err := Parse(NewFileBase("p.goo"), strings.NewReader(test[0]), nil, nil, 0)
 		if err != nil {
 			t.Error(err)
 			continue
@@ -41,6 +41,7 @@ type scanner struct {
 	kind      LitKind  // valid if tok is _Literal
 	op        Operator // valid if tok is _Operator, _Star, _AssignOp, or _IncOp
 	prec      int      // valid if tok is _Operator, _Star, _AssignOp, or _IncOp
+	prevEnd   int      // source offset of the end of the previous token
 }
 
 func (s *scanner) init(src io.Reader, errh func(line, col uint, msg string), mode uint) {
@@ -88,6 +89,7 @@ func (s *scanner) setLit(kind LitKind, ok bool) {
 func (s *scanner) next() {
 	nlsemi := s.nlsemi
 	s.nlsemi = false
+	s.prevEnd = s.offset()
 
 redo:
 	// skip white space
@@ -330,6 +332,11 @@ redo:
 			s.tok = _Operator
 			break
 		}
+		if s.ch == '>' {
+			s.nextch()
+			s.tok = _Lambda
+			break
+		}
 		s.tok = _Assign
 
 	case '!':
@@ -348,6 +355,27 @@ redo:
 		s.op, s.prec = Tilde, 0
 		s.tok = _Operator
 
+	// Unicode operators: ≠ for !=, ¬ for !, ∈ for in and ∉ for not in
+	case '≠':
+		s.nextch()
+		s.op, s.prec = Neq, precCmp
+		s.tok = _Operator
+
+	case '¬':
+		s.nextch()
+		s.op, s.prec = Not, 0
+		s.tok = _Operator
+
+	case '∈':
+		s.nextch()
+		s.op, s.prec = In, precCmp
+		s.tok = _Operator
+
+	case '∉':
+		s.nextch()
+		s.op, s.prec = NotIn, precCmp
+		s.tok = _Operator
+
 	case '#':
 		// Check if this is a 1-indexed array access operator or a comment
 		// Comments start at beginning of line or after whitespace
@@ -361,6 +389,10 @@ redo:
 			goto redo
 		}
 
+	case '?':
+		s.nextch()
+		s.tok = _Question
+
 	default:
 		s.errorf("invalid character %#U", s.ch)
 		s.nextch()
@@ -416,20 +448,6 @@ func (s *scanner) ident() {
 		return
 	}
 
-	// special case for '≠' as !=
-	if string(lit) == "≠" {
-		s.op, s.prec = Neq, precCmp
-		s.tok = _Operator
-		return
-	}
-
-	// special case for '¬' as !
-	if string(lit) == "¬" {
-		s.op, s.prec = Not, 0
-		s.tok = _Operator
-		return
-	}
-
 	// special case for 'and' as &&
 	if string(lit) == "and" {
 		s.op, s.prec = AndAnd, precAndAnd
@@ -463,8 +481,9 @@ func (s *scanner) atIdentChar(first bool) bool {
 		if first {
 			s.errorf("identifier cannot begin with digit %#U", s.ch)
 		}
-	case s.ch == '≠' || s.ch == '¬':
-		// allow special Unicode operators
+	case isOperatorRune(s.ch):
+		// Unicode operators are scanned as separate tokens (see next)
+		return false
 	case s.ch >= utf8.RuneSelf:
 		s.errorf("invalid character %#U in identifier", s.ch)
 	default:
@@ -497,6 +516,10 @@ func isLetter(ch rune) bool  { return 'a' <= lower(ch) && lower(ch) <= 'z' || ch
 func isDecimal(ch rune) bool { return '0' <= ch && ch <= '9' }
 func isHex(ch rune) bool     { return '0' <= ch && ch <= '9' || 'a' <= lower(ch) && lower(ch) <= 'f' }
 
+// isOperatorRune reports whether ch is one of the Unicode operator
+// characters accepted in place of their ASCII spelling.
+func isOperatorRune(ch rune) bool { return ch == '≠' || ch == '¬' || ch == '∈' || ch == '∉' }
+
 // digits accepts the sequence { digit | '_' }.
 // If base <= 10, digits accepts any decimal digit but records
 // the index (relative to the literal start) of a digit >= base
@@ -53,6 +53,11 @@ type source struct {
 	line, col uint   // source position of ch (0-based)
 	ch        rune   // most recently read character
 	chw       int    // width of ch
+
+	// goo: the source text from offset mark on is kept, see keep
+	off  int    // source offset of buf[0]
+	mark int    // source offset of the first byte kept, or -1
+	kept []byte // the source from mark to off
 }
 
 const sentinel = utf8.RuneSelf
@@ -70,6 +75,7 @@ func (s *source) init(in io.Reader, errh func(line, col uint, msg string)) {
 	s.line, s.col = 0, 0
 	s.ch = ' '
 	s.chw = 0
+	s.off, s.mark, s.kept = 0, -1, nil
 }
 
 // starting points for line and column numbers
@@ -94,6 +100,35 @@ func (s *source) start()          { s.b = s.r - s.chw }
 func (s *source) stop()           { s.b = -1 }
 func (s *source) segment() []byte { return s.buf[s.b : s.r-s.chw] }
 
+// offset returns the source offset of s.ch.
+func (s *source) offset() int { return s.off + s.r - s.chw }
+
+// keep keeps the source text from the offset start on, which must not
+// precede the active segment, so that text can return it. Nested calls
+// keep the text from the first start on.
+func (s *source) keep(start int) {
+	if s.mark < 0 {
+		s.mark = start
+	}
+}
+
+// text returns the source text between the offsets start and end,
+// which must be kept. The text is no longer kept once text has been
+// called for the start passed to keep first.
+func (s *source) text(start, end int) string {
+	var b []byte
+	if start < s.off {
+		b = append(b, s.kept[start-s.mark:min(end, s.off)-s.mark]...)
+	}
+	if end > s.off {
+		b = append(b, s.buf[max(start, s.off)-s.off:end-s.off]...)
+	}
+	if start <= s.mark {
+		s.mark, s.kept = -1, nil
+	}
+	return string(b)
+}
+
 // rewind rewinds the scanner's read position and character s.ch
 // to the start of the currently active segment, which must not
 // contain any newlines (otherwise position information will be
@@ -174,6 +209,10 @@ func (s *source) fill() {
 		s.b = 0 // after buffer has grown or content has been moved down
 	}
 	content := s.buf[b:s.e]
+	if s.mark >= 0 && s.mark < s.off+b {
+		s.kept = append(s.kept, s.buf[max(s.mark-s.off, 0):b]...)
+	}
+	s.off += b
 
 	// grow buffer or move content down
 	if len(content)*2 > len(s.buf) {
@@ -27,6 +27,7 @@ const (
 	_Assign   // =
 	_Define   // :=
 	_Arrow    // <-
+	_Lambda   // =>
 	_Star     // *
 
 	// delimiters
@@ -42,6 +43,7 @@ const (
 	_Dot       // .
 	_DotDotDot // ...
 	_Hash      // #
+	_Question  // ?
 
 	// keywords
 	_Break       // break
@@ -110,6 +112,7 @@ var TokenNames = [...]string{
 	_Assign:      "=",
 	_Define:      ":=",
 	_Arrow:       "<-",
+	_Lambda:      "=>",
 	_Star:        "*",
 	_Lparen:      "(",
 	_Lbrack:      "[", 
@@ -122,6 +125,8 @@ var TokenNames = [...]string{
 	_Colon:       ":",
 	_Dot:         ".",
 	_DotDotDot:   "...",
+	_Hash:        "#",
+	_Question:    "?",
 	_Break:       "break",
 	_Case:        "case",
 	_Chan:        "chan",
@@ -184,12 +189,14 @@ const (
 	AndAnd // &&
 
 	// precCmp
-	Eql // ==
-	Neq // !=
-	Lss // <
-	Leq // <=
-	Gtr // >
-	Geq // >=
+	Eql   // ==
+	Neq   // !=
+	Lss   // <
+	Leq   // <=
+	Gtr   // >
+	Geq   // >=
+	In    // in, ∈
+	NotIn // ∉
 
 	// precAdd
 	Add // +
@@ -222,6 +229,8 @@ var OperatorNames = [...]string{
 	Leq:    "<=",
 	Gtr:    ">",
 	Geq:    ">=",
+	In:     "in",
+	NotIn:  "∉",
 	Add:    "+",
 	Sub:    "-",
 	Or:     "|",
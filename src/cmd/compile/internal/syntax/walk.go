@@ -105,10 +105,16 @@ func (w walker) node(n Node) {
 		w.node(n.Name)
 		w.fieldList(n.TParamList)
 		w.node(n.Type)
+		for _, c := range n.Contracts {
+			w.node(c)
+		}
 		if n.Body != nil {
 			w.node(n.Body)
 		}
 
+	case *Contract:
+		w.node(n.Cond)
+
 	// expressions
 	case *BadExpr: // nothing to do
 	case *Name: // nothing to do
@@ -128,6 +134,18 @@ func (w walker) node(n Node) {
 		w.node(n.Type)
 		w.node(n.Body)
 
+	case *CondExpr:
+		w.node(n.Stmt)
+
+	case *CompExpr:
+		w.node(n.Loop)
+
+	case *PatternExpr:
+		w.nameList(n.Names)
+		if n.Rest != nil {
+			w.node(n.Rest)
+		}
+
 	case *ParenExpr:
 		w.node(n.X)
 
@@ -195,7 +213,12 @@ func (w walker) node(n Node) {
 		if n.Name != nil {
 			w.node(n.Name)
 		}
-		w.node(n.Type)
+		if n.Type != nil { // nil for lambda parameters
+			w.node(n.Type)
+		}
+		if n.Default != nil {
+			w.node(n.Default)
+		}
 
 	case *InterfaceType:
 		w.fieldList(n.MethodList)
@@ -289,6 +312,12 @@ func (w walker) node(n Node) {
 			w.node(s)
 		}
 
+	case *WithStmt:
+		for _, b := range n.Bindings {
+			w.node(b)
+		}
+		w.node(n.Body)
+
 	// helper nodes
 	case *RangeClause:
 		if n.Lhs != nil {
@@ -308,8 +337,15 @@ func (w walker) node(n Node) {
 		}
 		w.stmtList(n.Body)
 
+	case *WithBinding:
+		w.node(n.Lhs)
+		w.node(n.Rhs)
+
 	case *CheckStmt:
 		w.node(n.Cond)
+		if n.Msg != nil {
+			w.node(n.Msg)
+		}
 
 	default:
 		panic(fmt.Sprintf("internal error: unknown node type %T", n))
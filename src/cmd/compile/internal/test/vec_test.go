@@ -0,0 +1,50 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"bytes"
+	"internal/cpu"
+	"internal/testenv"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestVecKernels checks that the SIMD loops for goo's arithmetic on
+// slices of numbers compute the same results as the portable runtime
+// functions, which run when the intrinsics are off.
+func TestVecKernels(t *testing.T) {
+	testenv.MustHaveGoRun(t)
+	gotool := testenv.GoToolPath(t)
+	src := filepath.Join("testdata", "vec.goo")
+
+	run := func(env []string, args ...string) []byte {
+		t.Helper()
+		cmd := testenv.Command(t, gotool, append(append([]string{"run"}, args...), src)...)
+		cmd.Env = append(cmd.Environ(), env...)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("%v: %v\n%s", cmd, err, out)
+		}
+		return out
+	}
+
+	want := run(nil, "-gcflags=-d=ssa/intrinsics/off")
+	configs := map[string][]string{"default": nil}
+	if runtime.GOARCH == "amd64" {
+		configs["GOAMD64=v1"] = []string{"GOAMD64=v1"}
+		if cpu.X86.HasAVX2 {
+			configs["GOAMD64=v3"] = []string{"GOAMD64=v3"}
+		}
+	}
+	for name, env := range configs {
+		t.Run(name, func(t *testing.T) {
+			if got := run(env); !bytes.Equal(got, want) {
+				t.Errorf("output differs from the portable functions:\ngot:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
@@ -135,6 +135,86 @@ func rand32() uint32
 // truthiness conversion for if statements
 func truthy(interface{}) bool
 
+// membership tests for goo's in operator on strings
+func stringcontains(s, substr string) bool
+func stringcontainsrune(s string, r rune) bool
+func stringcontainsbyte(s string, b byte) bool
+
+// 1-based indexing for goo's # operator
+func hashindex(i, n int) int
+func hashslice(i, j, n int) (lo, hi int)
+func hashrune(s string, i int) rune
+func hashslicestring(s string, i, j int) string
+
+// sorting for goo's sort and sortBy collection methods
+func sortslice(keyType *byte, keys unsafe.Pointer, elemType *byte, elems unsafe.Pointer, n int)
+
+// goo's arithmetic on slices of numbers; these are intrinsics on amd64
+// and arm64
+func panicveclen(x, y int)
+func vaddF32(dst, x, y unsafe.Pointer, n int)
+func vsubF32(dst, x, y unsafe.Pointer, n int)
+func vmulF32(dst, x, y unsafe.Pointer, n int)
+func vdivF32(dst, x, y unsafe.Pointer, n int)
+func vaddsF32(dst, x unsafe.Pointer, s float32, n int)
+func vsubsF32(dst, x unsafe.Pointer, s float32, n int)
+func vmulsF32(dst, x unsafe.Pointer, s float32, n int)
+func vdivsF32(dst, x unsafe.Pointer, s float32, n int)
+func vrsubsF32(dst, x unsafe.Pointer, s float32, n int)
+func vrdivsF32(dst, x unsafe.Pointer, s float32, n int)
+func vsumF32(x unsafe.Pointer, n int) float32
+func vdotF32(x, y unsafe.Pointer, n int) float32
+func vaddF64(dst, x, y unsafe.Pointer, n int)
+func vsubF64(dst, x, y unsafe.Pointer, n int)
+func vmulF64(dst, x, y unsafe.Pointer, n int)
+func vdivF64(dst, x, y unsafe.Pointer, n int)
+func vaddsF64(dst, x unsafe.Pointer, s float64, n int)
+func vsubsF64(dst, x unsafe.Pointer, s float64, n int)
+func vmulsF64(dst, x unsafe.Pointer, s float64, n int)
+func vdivsF64(dst, x unsafe.Pointer, s float64, n int)
+func vrsubsF64(dst, x unsafe.Pointer, s float64, n int)
+func vrdivsF64(dst, x unsafe.Pointer, s float64, n int)
+func vsumF64(x unsafe.Pointer, n int) float64
+func vdotF64(x, y unsafe.Pointer, n int) float64
+func vaddI8(dst, x, y unsafe.Pointer, n int)
+func vsubI8(dst, x, y unsafe.Pointer, n int)
+func vaddsI8(dst, x unsafe.Pointer, s int8, n int)
+func vsubsI8(dst, x unsafe.Pointer, s int8, n int)
+func vrsubsI8(dst, x unsafe.Pointer, s int8, n int)
+func vsumI8(x unsafe.Pointer, n int) int8
+func vaddI16(dst, x, y unsafe.Pointer, n int)
+func vsubI16(dst, x, y unsafe.Pointer, n int)
+func vmulI16(dst, x, y unsafe.Pointer, n int)
+func vaddsI16(dst, x unsafe.Pointer, s int16, n int)
+func vsubsI16(dst, x unsafe.Pointer, s int16, n int)
+func vmulsI16(dst, x unsafe.Pointer, s int16, n int)
+func vrsubsI16(dst, x unsafe.Pointer, s int16, n int)
+func vsumI16(x unsafe.Pointer, n int) int16
+func vaddI32(dst, x, y unsafe.Pointer, n int)
+func vsubI32(dst, x, y unsafe.Pointer, n int)
+func vmulI32(dst, x, y unsafe.Pointer, n int)
+func vaddsI32(dst, x unsafe.Pointer, s int32, n int)
+func vsubsI32(dst, x unsafe.Pointer, s int32, n int)
+func vmulsI32(dst, x unsafe.Pointer, s int32, n int)
+func vrsubsI32(dst, x unsafe.Pointer, s int32, n int)
+func vsumI32(x unsafe.Pointer, n int) int32
+func vaddI64(dst, x, y unsafe.Pointer, n int)
+func vsubI64(dst, x, y unsafe.Pointer, n int)
+func vaddsI64(dst, x unsafe.Pointer, s int64, n int)
+func vsubsI64(dst, x unsafe.Pointer, s int64, n int)
+func vrsubsI64(dst, x unsafe.Pointer, s int64, n int)
+func vsumI64(x unsafe.Pointer, n int) int64
+
+// failed requires and ensures clauses of goo's contracts
+func contractFailed(clause string)
+
+// closing the resources of goo's with statements
+func withpop(s *[]interface{}, n int, errs unsafe.Pointer)
+func withunwind(s *[]interface{}, errs, res unsafe.Pointer)
+
+// slices of the wrong length in goo's destructuring assignments
+func panicpattern(n, have int, rest bool)
+
 // *byte is really *runtime.Type
 func makemap64(mapType *byte, hint int64, mapbuf *any) (hmap map[any]any)
 func makemap(mapType *byte, hint int, mapbuf *any) (hmap map[any]any)
@@ -112,129 +112,194 @@ var runtimeDecls = [...]struct {
 	{"rand", funcTag, 80},
 	{"rand32", funcTag, 81},
 	{"truthy", funcTag, 82},
-	{"makemap64", funcTag, 84},
-	{"makemap", funcTag, 85},
-	{"makemap_small", funcTag, 86},
-	{"mapaccess1", funcTag, 87},
-	{"mapaccess1_fast32", funcTag, 88},
-	{"mapaccess1_fast64", funcTag, 89},
-	{"mapaccess1_faststr", funcTag, 90},
-	{"mapaccess1_fat", funcTag, 91},
-	{"mapaccess2", funcTag, 92},
-	{"mapaccess2_fast32", funcTag, 93},
-	{"mapaccess2_fast64", funcTag, 94},
-	{"mapaccess2_faststr", funcTag, 95},
-	{"mapaccess2_fat", funcTag, 96},
-	{"mapassign", funcTag, 87},
-	{"mapassign_fast32", funcTag, 88},
-	{"mapassign_fast32ptr", funcTag, 97},
-	{"mapassign_fast64", funcTag, 89},
-	{"mapassign_fast64ptr", funcTag, 97},
-	{"mapassign_faststr", funcTag, 90},
-	{"mapiterinit", funcTag, 98},
-	{"mapIterStart", funcTag, 98},
-	{"mapdelete", funcTag, 98},
-	{"mapdelete_fast32", funcTag, 99},
-	{"mapdelete_fast64", funcTag, 100},
-	{"mapdelete_faststr", funcTag, 101},
-	{"mapiternext", funcTag, 102},
-	{"mapIterNext", funcTag, 102},
-	{"mapclear", funcTag, 103},
-	{"makechan64", funcTag, 105},
-	{"makechan", funcTag, 106},
-	{"chanrecv1", funcTag, 108},
-	{"chanrecv2", funcTag, 109},
-	{"chansend1", funcTag, 111},
-	{"closechan", funcTag, 112},
-	{"chanlen", funcTag, 113},
-	{"chancap", funcTag, 113},
-	{"writeBarrier", varTag, 115},
-	{"typedmemmove", funcTag, 116},
-	{"typedmemclr", funcTag, 117},
-	{"typedslicecopy", funcTag, 118},
-	{"selectnbsend", funcTag, 119},
-	{"selectnbrecv", funcTag, 120},
-	{"selectsetpc", funcTag, 121},
-	{"selectgo", funcTag, 122},
+	{"stringcontains", funcTag, 83},
+	{"stringcontainsrune", funcTag, 84},
+	{"stringcontainsbyte", funcTag, 85},
+	{"hashindex", funcTag, 86},
+	{"hashslice", funcTag, 87},
+	{"hashrune", funcTag, 88},
+	{"hashslicestring", funcTag, 89},
+	{"sortslice", funcTag, 90},
+	{"panicveclen", funcTag, 16},
+	{"vaddF32", funcTag, 91},
+	{"vsubF32", funcTag, 91},
+	{"vmulF32", funcTag, 91},
+	{"vdivF32", funcTag, 91},
+	{"vaddsF32", funcTag, 93},
+	{"vsubsF32", funcTag, 93},
+	{"vmulsF32", funcTag, 93},
+	{"vdivsF32", funcTag, 93},
+	{"vrsubsF32", funcTag, 93},
+	{"vrdivsF32", funcTag, 93},
+	{"vsumF32", funcTag, 94},
+	{"vdotF32", funcTag, 95},
+	{"vaddF64", funcTag, 91},
+	{"vsubF64", funcTag, 91},
+	{"vmulF64", funcTag, 91},
+	{"vdivF64", funcTag, 91},
+	{"vaddsF64", funcTag, 96},
+	{"vsubsF64", funcTag, 96},
+	{"vmulsF64", funcTag, 96},
+	{"vdivsF64", funcTag, 96},
+	{"vrsubsF64", funcTag, 96},
+	{"vrdivsF64", funcTag, 96},
+	{"vsumF64", funcTag, 97},
+	{"vdotF64", funcTag, 98},
+	{"vaddI8", funcTag, 91},
+	{"vsubI8", funcTag, 91},
+	{"vaddsI8", funcTag, 100},
+	{"vsubsI8", funcTag, 100},
+	{"vrsubsI8", funcTag, 100},
+	{"vsumI8", funcTag, 101},
+	{"vaddI16", funcTag, 91},
+	{"vsubI16", funcTag, 91},
+	{"vmulI16", funcTag, 91},
+	{"vaddsI16", funcTag, 103},
+	{"vsubsI16", funcTag, 103},
+	{"vmulsI16", funcTag, 103},
+	{"vrsubsI16", funcTag, 103},
+	{"vsumI16", funcTag, 104},
+	{"vaddI32", funcTag, 91},
+	{"vsubI32", funcTag, 91},
+	{"vmulI32", funcTag, 91},
+	{"vaddsI32", funcTag, 105},
+	{"vsubsI32", funcTag, 105},
+	{"vmulsI32", funcTag, 105},
+	{"vrsubsI32", funcTag, 105},
+	{"vsumI32", funcTag, 106},
+	{"vaddI64", funcTag, 91},
+	{"vsubI64", funcTag, 91},
+	{"vaddsI64", funcTag, 107},
+	{"vsubsI64", funcTag, 107},
+	{"vrsubsI64", funcTag, 107},
+	{"vsumI64", funcTag, 108},
+	{"contractFailed", funcTag, 29},
+	{"withpop", funcTag, 111},
+	{"withunwind", funcTag, 112},
+	{"panicpattern", funcTag, 113},
+	{"makemap64", funcTag, 115},
+	{"makemap", funcTag, 116},
+	{"makemap_small", funcTag, 117},
+	{"mapaccess1", funcTag, 118},
+	{"mapaccess1_fast32", funcTag, 119},
+	{"mapaccess1_fast64", funcTag, 120},
+	{"mapaccess1_faststr", funcTag, 121},
+	{"mapaccess1_fat", funcTag, 122},
+	{"mapaccess2", funcTag, 123},
+	{"mapaccess2_fast32", funcTag, 124},
+	{"mapaccess2_fast64", funcTag, 125},
+	{"mapaccess2_faststr", funcTag, 126},
+	{"mapaccess2_fat", funcTag, 127},
+	{"mapassign", funcTag, 118},
+	{"mapassign_fast32", funcTag, 119},
+	{"mapassign_fast32ptr", funcTag, 128},
+	{"mapassign_fast64", funcTag, 120},
+	{"mapassign_fast64ptr", funcTag, 128},
+	{"mapassign_faststr", funcTag, 121},
+	{"mapiterinit", funcTag, 129},
+	{"mapIterStart", funcTag, 129},
+	{"mapdelete", funcTag, 129},
+	{"mapdelete_fast32", funcTag, 130},
+	{"mapdelete_fast64", funcTag, 131},
+	{"mapdelete_faststr", funcTag, 132},
+	{"mapiternext", funcTag, 133},
+	{"mapIterNext", funcTag, 133},
+	{"mapclear", funcTag, 134},
+	{"makechan64", funcTag, 136},
+	{"makechan", funcTag, 137},
+	{"chanrecv1", funcTag, 139},
+	{"chanrecv2", funcTag, 140},
+	{"chansend1", funcTag, 142},
+	{"closechan", funcTag, 143},
+	{"chanlen", funcTag, 144},
+	{"chancap", funcTag, 144},
+	{"writeBarrier", varTag, 146},
+	{"typedmemmove", funcTag, 147},
+	{"typedmemclr", funcTag, 148},
+	{"typedslicecopy", funcTag, 149},
+	{"selectnbsend", funcTag, 150},
+	{"selectnbrecv", funcTag, 151},
+	{"selectsetpc", funcTag, 152},
+	{"selectgo", funcTag, 153},
 	{"block", funcTag, 9},
-	{"makeslice", funcTag, 123},
-	{"makeslice64", funcTag, 124},
-	{"makeslicecopy", funcTag, 125},
-	{"growslice", funcTag, 127},
-	{"unsafeslicecheckptr", funcTag, 128},
+	{"makeslice", funcTag, 154},
+	{"makeslice64", funcTag, 155},
+	{"makeslicecopy", funcTag, 156},
+	{"growslice", funcTag, 158},
+	{"unsafeslicecheckptr", funcTag, 159},
 	{"panicunsafeslicelen", funcTag, 9},
 	{"panicunsafeslicenilptr", funcTag, 9},
-	{"unsafestringcheckptr", funcTag, 129},
+	{"unsafestringcheckptr", funcTag, 160},
 	{"panicunsafestringlen", funcTag, 9},
 	{"panicunsafestringnilptr", funcTag, 9},
-	{"memmove", funcTag, 130},
-	{"memclrNoHeapPointers", funcTag, 131},
-	{"memclrHasPointers", funcTag, 131},
-	{"memequal", funcTag, 132},
-	{"memequal0", funcTag, 133},
-	{"memequal8", funcTag, 133},
-	{"memequal16", funcTag, 133},
-	{"memequal32", funcTag, 133},
-	{"memequal64", funcTag, 133},
-	{"memequal128", funcTag, 133},
-	{"f32equal", funcTag, 134},
-	{"f64equal", funcTag, 134},
-	{"c64equal", funcTag, 134},
-	{"c128equal", funcTag, 134},
-	{"strequal", funcTag, 134},
-	{"interequal", funcTag, 134},
-	{"nilinterequal", funcTag, 134},
-	{"memhash", funcTag, 135},
-	{"memhash0", funcTag, 136},
-	{"memhash8", funcTag, 136},
-	{"memhash16", funcTag, 136},
-	{"memhash32", funcTag, 136},
-	{"memhash64", funcTag, 136},
-	{"memhash128", funcTag, 136},
-	{"f32hash", funcTag, 137},
-	{"f64hash", funcTag, 137},
-	{"c64hash", funcTag, 137},
-	{"c128hash", funcTag, 137},
-	{"strhash", funcTag, 137},
-	{"interhash", funcTag, 137},
-	{"nilinterhash", funcTag, 137},
-	{"int64div", funcTag, 138},
-	{"uint64div", funcTag, 139},
-	{"int64mod", funcTag, 138},
-	{"uint64mod", funcTag, 139},
-	{"float64toint64", funcTag, 140},
-	{"float64touint64", funcTag, 141},
-	{"float64touint32", funcTag, 142},
-	{"int64tofloat64", funcTag, 143},
-	{"int64tofloat32", funcTag, 145},
-	{"uint64tofloat64", funcTag, 146},
-	{"uint64tofloat32", funcTag, 147},
-	{"uint32tofloat64", funcTag, 148},
-	{"complex128div", funcTag, 149},
+	{"memmove", funcTag, 161},
+	{"memclrNoHeapPointers", funcTag, 162},
+	{"memclrHasPointers", funcTag, 162},
+	{"memequal", funcTag, 163},
+	{"memequal0", funcTag, 164},
+	{"memequal8", funcTag, 164},
+	{"memequal16", funcTag, 164},
+	{"memequal32", funcTag, 164},
+	{"memequal64", funcTag, 164},
+	{"memequal128", funcTag, 164},
+	{"f32equal", funcTag, 165},
+	{"f64equal", funcTag, 165},
+	{"c64equal", funcTag, 165},
+	{"c128equal", funcTag, 165},
+	{"strequal", funcTag, 165},
+	{"interequal", funcTag, 165},
+	{"nilinterequal", funcTag, 165},
+	{"memhash", funcTag, 166},
+	{"memhash0", funcTag, 167},
+	{"memhash8", funcTag, 167},
+	{"memhash16", funcTag, 167},
+	{"memhash32", funcTag, 167},
+	{"memhash64", funcTag, 167},
+	{"memhash128", funcTag, 167},
+	{"f32hash", funcTag, 168},
+	{"f64hash", funcTag, 168},
+	{"c64hash", funcTag, 168},
+	{"c128hash", funcTag, 168},
+	{"strhash", funcTag, 168},
+	{"interhash", funcTag, 168},
+	{"nilinterhash", funcTag, 168},
+	{"int64div", funcTag, 169},
+	{"uint64div", funcTag, 170},
+	{"int64mod", funcTag, 169},
+	{"uint64mod", funcTag, 170},
+	{"float64toint64", funcTag, 171},
+	{"float64touint64", funcTag, 172},
+	{"float64touint32", funcTag, 173},
+	{"int64tofloat64", funcTag, 174},
+	{"int64tofloat32", funcTag, 175},
+	{"uint64tofloat64", funcTag, 176},
+	{"uint64tofloat32", funcTag, 177},
+	{"uint32tofloat64", funcTag, 178},
+	{"complex128div", funcTag, 179},
 	{"racefuncenter", funcTag, 31},
 	{"racefuncexit", funcTag, 9},
 	{"raceread", funcTag, 31},
 	{"racewrite", funcTag, 31},
-	{"racereadrange", funcTag, 150},
-	{"racewriterange", funcTag, 150},
-	{"msanread", funcTag, 150},
-	{"msanwrite", funcTag, 150},
-	{"msanmove", funcTag, 151},
-	{"asanread", funcTag, 150},
-	{"asanwrite", funcTag, 150},
-	{"checkptrAlignment", funcTag, 152},
-	{"checkptrArithmetic", funcTag, 154},
-	{"libfuzzerTraceCmp1", funcTag, 155},
-	{"libfuzzerTraceCmp2", funcTag, 156},
-	{"libfuzzerTraceCmp4", funcTag, 157},
-	{"libfuzzerTraceCmp8", funcTag, 158},
-	{"libfuzzerTraceConstCmp1", funcTag, 155},
-	{"libfuzzerTraceConstCmp2", funcTag, 156},
-	{"libfuzzerTraceConstCmp4", funcTag, 157},
-	{"libfuzzerTraceConstCmp8", funcTag, 158},
-	{"libfuzzerHookStrCmp", funcTag, 159},
-	{"libfuzzerHookEqualFold", funcTag, 159},
-	{"addCovMeta", funcTag, 161},
+	{"racereadrange", funcTag, 180},
+	{"racewriterange", funcTag, 180},
+	{"msanread", funcTag, 180},
+	{"msanwrite", funcTag, 180},
+	{"msanmove", funcTag, 181},
+	{"asanread", funcTag, 180},
+	{"asanwrite", funcTag, 180},
+	{"checkptrAlignment", funcTag, 182},
+	{"checkptrArithmetic", funcTag, 184},
+	{"libfuzzerTraceCmp1", funcTag, 185},
+	{"libfuzzerTraceCmp2", funcTag, 186},
+	{"libfuzzerTraceCmp4", funcTag, 187},
+	{"libfuzzerTraceCmp8", funcTag, 188},
+	{"libfuzzerTraceConstCmp1", funcTag, 185},
+	{"libfuzzerTraceConstCmp2", funcTag, 186},
+	{"libfuzzerTraceConstCmp4", funcTag, 187},
+	{"libfuzzerTraceConstCmp8", funcTag, 188},
+	{"libfuzzerHookStrCmp", funcTag, 189},
+	{"libfuzzerHookEqualFold", funcTag, 189},
+	{"addCovMeta", funcTag, 191},
 	{"x86HasPOPCNT", varTag, 6},
 	{"x86HasSSE41", varTag, 6},
 	{"x86HasFMA", varTag, 6},
@@ -244,12 +309,12 @@ var runtimeDecls = [...]struct {
 	{"loong64HasLAM_BH", varTag, 6},
 	{"loong64HasLSX", varTag, 6},
 	{"riscv64HasZbb", varTag, 6},
-	{"asanregisterglobals", funcTag, 131},
-	{"sliceequal", funcTag, 162},
+	{"asanregisterglobals", funcTag, 162},
+	{"sliceequal", funcTag, 165},
 }
 
 func runtimeTypes() []*types.Type {
-	var typs [163]*types.Type
+	var typs [192]*types.Type
 	typs[0] = types.ByteType
 	typs[1] = types.NewPtr(typs[0])
 	typs[2] = types.Types[types.TANY]
@@ -333,86 +398,115 @@ func runtimeTypes() []*types.Type {
 	typs[80] = newSig(nil, params(typs[24]))
 	typs[81] = newSig(nil, params(typs[65]))
 	typs[82] = newSig(params(typs[10]), params(typs[6]))
-	typs[83] = types.NewMap(typs[2], typs[2])
-	typs[84] = newSig(params(typs[1], typs[22], typs[3]), params(typs[83]))
-	typs[85] = newSig(params(typs[1], typs[15], typs[3]), params(typs[83]))
-	typs[86] = newSig(nil, params(typs[83]))
-	typs[87] = newSig(params(typs[1], typs[83], typs[3]), params(typs[3]))
-	typs[88] = newSig(params(typs[1], typs[83], typs[65]), params(typs[3]))
-	typs[89] = newSig(params(typs[1], typs[83], typs[24]), params(typs[3]))
-	typs[90] = newSig(params(typs[1], typs[83], typs[28]), params(typs[3]))
-	typs[91] = newSig(params(typs[1], typs[83], typs[3], typs[1]), params(typs[3]))
-	typs[92] = newSig(params(typs[1], typs[83], typs[3]), params(typs[3], typs[6]))
-	typs[93] = newSig(params(typs[1], typs[83], typs[65]), params(typs[3], typs[6]))
-	typs[94] = newSig(params(typs[1], typs[83], typs[24]), params(typs[3], typs[6]))
-	typs[95] = newSig(params(typs[1], typs[83], typs[28]), params(typs[3], typs[6]))
-	typs[96] = newSig(params(typs[1], typs[83], typs[3], typs[1]), params(typs[3], typs[6]))
-	typs[97] = newSig(params(typs[1], typs[83], typs[7]), params(typs[3]))
-	typs[98] = newSig(params(typs[1], typs[83], typs[3]), nil)
-	typs[99] = newSig(params(typs[1], typs[83], typs[65]), nil)
-	typs[100] = newSig(params(typs[1], typs[83], typs[24]), nil)
-	typs[101] = newSig(params(typs[1], typs[83], typs[28]), nil)
-	typs[102] = newSig(params(typs[3]), nil)
-	typs[103] = newSig(params(typs[1], typs[83]), nil)
-	typs[104] = types.NewChan(typs[2], types.Cboth)
-	typs[105] = newSig(params(typs[1], typs[22]), params(typs[104]))
-	typs[106] = newSig(params(typs[1], typs[15]), params(typs[104]))
-	typs[107] = types.NewChan(typs[2], types.Crecv)
-	typs[108] = newSig(params(typs[107], typs[3]), nil)
-	typs[109] = newSig(params(typs[107], typs[3]), params(typs[6]))
-	typs[110] = types.NewChan(typs[2], types.Csend)
-	typs[111] = newSig(params(typs[110], typs[3]), nil)
-	typs[112] = newSig(params(typs[110]), nil)
-	typs[113] = newSig(params(typs[2]), params(typs[15]))
-	typs[114] = types.NewArray(typs[0], 3)
-	typs[115] = types.NewStruct([]*types.Field{types.NewField(src.NoXPos, Lookup("enabled"), typs[6]), types.NewField(src.NoXPos, Lookup("pad"), typs[114]), types.NewField(src.NoXPos, Lookup("cgo"), typs[6]), types.NewField(src.NoXPos, Lookup("alignme"), typs[24])})
-	typs[116] = newSig(params(typs[1], typs[3], typs[3]), nil)
-	typs[117] = newSig(params(typs[1], typs[3]), nil)
-	typs[118] = newSig(params(typs[1], typs[3], typs[15], typs[3], typs[15]), params(typs[15]))
-	typs[119] = newSig(params(typs[110], typs[3]), params(typs[6]))
-	typs[120] = newSig(params(typs[3], typs[107]), params(typs[6], typs[6]))
-	typs[121] = newSig(params(typs[76]), nil)
-	typs[122] = newSig(params(typs[1], typs[1], typs[76], typs[15], typs[15], typs[6]), params(typs[15], typs[6]))
-	typs[123] = newSig(params(typs[1], typs[15], typs[15]), params(typs[7]))
-	typs[124] = newSig(params(typs[1], typs[22], typs[22]), params(typs[7]))
-	typs[125] = newSig(params(typs[1], typs[15], typs[15], typs[7]), params(typs[7]))
-	typs[126] = types.NewSlice(typs[2])
-	typs[127] = newSig(params(typs[3], typs[15], typs[15], typs[15], typs[1]), params(typs[126]))
-	typs[128] = newSig(params(typs[1], typs[7], typs[22]), nil)
-	typs[129] = newSig(params(typs[7], typs[22]), nil)
-	typs[130] = newSig(params(typs[3], typs[3], typs[5]), nil)
-	typs[131] = newSig(params(typs[7], typs[5]), nil)
-	typs[132] = newSig(params(typs[3], typs[3], typs[5]), params(typs[6]))
-	typs[133] = newSig(params(typs[3], typs[3]), params(typs[6]))
-	typs[134] = newSig(params(typs[7], typs[7]), params(typs[6]))
-	typs[135] = newSig(params(typs[3], typs[5], typs[5]), params(typs[5]))
-	typs[136] = newSig(params(typs[7], typs[5]), params(typs[5]))
-	typs[137] = newSig(params(typs[3], typs[5]), params(typs[5]))
-	typs[138] = newSig(params(typs[22], typs[22]), params(typs[22]))
-	typs[139] = newSig(params(typs[24], typs[24]), params(typs[24]))
-	typs[140] = newSig(params(typs[20]), params(typs[22]))
-	typs[141] = newSig(params(typs[20]), params(typs[24]))
-	typs[142] = newSig(params(typs[20]), params(typs[65]))
-	typs[143] = newSig(params(typs[22]), params(typs[20]))
-	typs[144] = types.Types[types.TFLOAT32]
-	typs[145] = newSig(params(typs[22]), params(typs[144]))
-	typs[146] = newSig(params(typs[24]), params(typs[20]))
-	typs[147] = newSig(params(typs[24]), params(typs[144]))
-	typs[148] = newSig(params(typs[65]), params(typs[20]))
-	typs[149] = newSig(params(typs[26], typs[26]), params(typs[26]))
-	typs[150] = newSig(params(typs[5], typs[5]), nil)
-	typs[151] = newSig(params(typs[5], typs[5], typs[5]), nil)
-	typs[152] = newSig(params(typs[7], typs[1], typs[5]), nil)
-	typs[153] = types.NewSlice(typs[7])
-	typs[154] = newSig(params(typs[7], typs[153]), nil)
-	typs[155] = newSig(params(typs[69], typs[69], typs[17]), nil)
-	typs[156] = newSig(params(typs[63], typs[63], typs[17]), nil)
-	typs[157] = newSig(params(typs[65], typs[65], typs[17]), nil)
-	typs[158] = newSig(params(typs[24], typs[24], typs[17]), nil)
-	typs[159] = newSig(params(typs[28], typs[28], typs[17]), nil)
-	typs[160] = types.NewArray(typs[0], 16)
-	typs[161] = newSig(params(typs[7], typs[65], typs[160], typs[28], typs[15], typs[69], typs[69]), params(typs[65]))
-	typs[162] = newSig(params(typs[7], typs[7]), params(typs[6]))  // func(unsafe.Pointer, unsafe.Pointer) bool
+	typs[83] = newSig(params(typs[28], typs[28]), params(typs[6]))
+	typs[84] = newSig(params(typs[28], typs[52]), params(typs[6]))
+	typs[85] = newSig(params(typs[28], typs[0]), params(typs[6]))
+	typs[86] = newSig(params(typs[15], typs[15]), params(typs[15]))
+	typs[87] = newSig(params(typs[15], typs[15], typs[15]), params(typs[15], typs[15]))
+	typs[88] = newSig(params(typs[28], typs[15]), params(typs[52]))
+	typs[89] = newSig(params(typs[28], typs[15], typs[15]), params(typs[28]))
+	typs[90] = newSig(params(typs[1], typs[7], typs[1], typs[7], typs[15]), nil)
+	typs[91] = newSig(params(typs[7], typs[7], typs[7], typs[15]), nil)
+	typs[92] = types.Types[types.TFLOAT32]
+	typs[93] = newSig(params(typs[7], typs[7], typs[92], typs[15]), nil)
+	typs[94] = newSig(params(typs[7], typs[15]), params(typs[92]))
+	typs[95] = newSig(params(typs[7], typs[7], typs[15]), params(typs[92]))
+	typs[96] = newSig(params(typs[7], typs[7], typs[20], typs[15]), nil)
+	typs[97] = newSig(params(typs[7], typs[15]), params(typs[20]))
+	typs[98] = newSig(params(typs[7], typs[7], typs[15]), params(typs[20]))
+	typs[99] = types.Types[types.TINT8]
+	typs[100] = newSig(params(typs[7], typs[7], typs[99], typs[15]), nil)
+	typs[101] = newSig(params(typs[7], typs[15]), params(typs[99]))
+	typs[102] = types.Types[types.TINT16]
+	typs[103] = newSig(params(typs[7], typs[7], typs[102], typs[15]), nil)
+	typs[104] = newSig(params(typs[7], typs[15]), params(typs[102]))
+	typs[105] = newSig(params(typs[7], typs[7], typs[12], typs[15]), nil)
+	typs[106] = newSig(params(typs[7], typs[15]), params(typs[12]))
+	typs[107] = newSig(params(typs[7], typs[7], typs[22], typs[15]), nil)
+	typs[108] = newSig(params(typs[7], typs[15]), params(typs[22]))
+	typs[109] = types.NewSlice(typs[10])
+	typs[110] = types.NewPtr(typs[109])
+	typs[111] = newSig(params(typs[110], typs[15], typs[7]), nil)
+	typs[112] = newSig(params(typs[110], typs[7], typs[7]), nil)
+	typs[113] = newSig(params(typs[15], typs[15], typs[6]), nil)
+	typs[114] = types.NewMap(typs[2], typs[2])
+	typs[115] = newSig(params(typs[1], typs[22], typs[3]), params(typs[114]))
+	typs[116] = newSig(params(typs[1], typs[15], typs[3]), params(typs[114]))
+	typs[117] = newSig(nil, params(typs[114]))
+	typs[118] = newSig(params(typs[1], typs[114], typs[3]), params(typs[3]))
+	typs[119] = newSig(params(typs[1], typs[114], typs[65]), params(typs[3]))
+	typs[120] = newSig(params(typs[1], typs[114], typs[24]), params(typs[3]))
+	typs[121] = newSig(params(typs[1], typs[114], typs[28]), params(typs[3]))
+	typs[122] = newSig(params(typs[1], typs[114], typs[3], typs[1]), params(typs[3]))
+	typs[123] = newSig(params(typs[1], typs[114], typs[3]), params(typs[3], typs[6]))
+	typs[124] = newSig(params(typs[1], typs[114], typs[65]), params(typs[3], typs[6]))
+	typs[125] = newSig(params(typs[1], typs[114], typs[24]), params(typs[3], typs[6]))
+	typs[126] = newSig(params(typs[1], typs[114], typs[28]), params(typs[3], typs[6]))
+	typs[127] = newSig(params(typs[1], typs[114], typs[3], typs[1]), params(typs[3], typs[6]))
+	typs[128] = newSig(params(typs[1], typs[114], typs[7]), params(typs[3]))
+	typs[129] = newSig(params(typs[1], typs[114], typs[3]), nil)
+	typs[130] = newSig(params(typs[1], typs[114], typs[65]), nil)
+	typs[131] = newSig(params(typs[1], typs[114], typs[24]), nil)
+	typs[132] = newSig(params(typs[1], typs[114], typs[28]), nil)
+	typs[133] = newSig(params(typs[3]), nil)
+	typs[134] = newSig(params(typs[1], typs[114]), nil)
+	typs[135] = types.NewChan(typs[2], types.Cboth)
+	typs[136] = newSig(params(typs[1], typs[22]), params(typs[135]))
+	typs[137] = newSig(params(typs[1], typs[15]), params(typs[135]))
+	typs[138] = types.NewChan(typs[2], types.Crecv)
+	typs[139] = newSig(params(typs[138], typs[3]), nil)
+	typs[140] = newSig(params(typs[138], typs[3]), params(typs[6]))
+	typs[141] = types.NewChan(typs[2], types.Csend)
+	typs[142] = newSig(params(typs[141], typs[3]), nil)
+	typs[143] = newSig(params(typs[141]), nil)
+	typs[144] = newSig(params(typs[2]), params(typs[15]))
+	typs[145] = types.NewArray(typs[0], 3)
+	typs[146] = types.NewStruct([]*types.Field{types.NewField(src.NoXPos, Lookup("enabled"), typs[6]), types.NewField(src.NoXPos, Lookup("pad"), typs[145]), types.NewField(src.NoXPos, Lookup("cgo"), typs[6]), types.NewField(src.NoXPos, Lookup("alignme"), typs[24])})
+	typs[147] = newSig(params(typs[1], typs[3], typs[3]), nil)
+	typs[148] = newSig(params(typs[1], typs[3]), nil)
+	typs[149] = newSig(params(typs[1], typs[3], typs[15], typs[3], typs[15]), params(typs[15]))
+	typs[150] = newSig(params(typs[141], typs[3]), params(typs[6]))
+	typs[151] = newSig(params(typs[3], typs[138]), params(typs[6], typs[6]))
+	typs[152] = newSig(params(typs[76]), nil)
+	typs[153] = newSig(params(typs[1], typs[1], typs[76], typs[15], typs[15], typs[6]), params(typs[15], typs[6]))
+	typs[154] = newSig(params(typs[1], typs[15], typs[15]), params(typs[7]))
+	typs[155] = newSig(params(typs[1], typs[22], typs[22]), params(typs[7]))
+	typs[156] = newSig(params(typs[1], typs[15], typs[15], typs[7]), params(typs[7]))
+	typs[157] = types.NewSlice(typs[2])
+	typs[158] = newSig(params(typs[3], typs[15], typs[15], typs[15], typs[1]), params(typs[157]))
+	typs[159] = newSig(params(typs[1], typs[7], typs[22]), nil)
+	typs[160] = newSig(params(typs[7], typs[22]), nil)
+	typs[161] = newSig(params(typs[3], typs[3], typs[5]), nil)
+	typs[162] = newSig(params(typs[7], typs[5]), nil)
+	typs[163] = newSig(params(typs[3], typs[3], typs[5]), params(typs[6]))
+	typs[164] = newSig(params(typs[3], typs[3]), params(typs[6]))
+	typs[165] = newSig(params(typs[7], typs[7]), params(typs[6]))
+	typs[166] = newSig(params(typs[3], typs[5], typs[5]), params(typs[5]))
+	typs[167] = newSig(params(typs[7], typs[5]), params(typs[5]))
+	typs[168] = newSig(params(typs[3], typs[5]), params(typs[5]))
+	typs[169] = newSig(params(typs[22], typs[22]), params(typs[22]))
+	typs[170] = newSig(params(typs[24], typs[24]), params(typs[24]))
+	typs[171] = newSig(params(typs[20]), params(typs[22]))
+	typs[172] = newSig(params(typs[20]), params(typs[24]))
+	typs[173] = newSig(params(typs[20]), params(typs[65]))
+	typs[174] = newSig(params(typs[22]), params(typs[20]))
+	typs[175] = newSig(params(typs[22]), params(typs[92]))
+	typs[176] = newSig(params(typs[24]), params(typs[20]))
+	typs[177] = newSig(params(typs[24]), params(typs[92]))
+	typs[178] = newSig(params(typs[65]), params(typs[20]))
+	typs[179] = newSig(params(typs[26], typs[26]), params(typs[26]))
+	typs[180] = newSig(params(typs[5], typs[5]), nil)
+	typs[181] = newSig(params(typs[5], typs[5], typs[5]), nil)
+	typs[182] = newSig(params(typs[7], typs[1], typs[5]), nil)
+	typs[183] = types.NewSlice(typs[7])
+	typs[184] = newSig(params(typs[7], typs[183]), nil)
+	typs[185] = newSig(params(typs[69], typs[69], typs[17]), nil)
+	typs[186] = newSig(params(typs[63], typs[63], typs[17]), nil)
+	typs[187] = newSig(params(typs[65], typs[65], typs[17]), nil)
+	typs[188] = newSig(params(typs[24], typs[24], typs[17]), nil)
+	typs[189] = newSig(params(typs[28], typs[28], typs[17]), nil)
+	typs[190] = types.NewArray(typs[0], 16)
+	typs[191] = newSig(params(typs[7], typs[65], typs[190], typs[28], typs[15], typs[69], typs[69]), params(typs[65]))
 	return typs[:]
 }
 
@@ -430,3 +524,19 @@ func coverageTypes() []*types.Type {
 	typs[1] = newSig(params(typs[0]), nil)
 	return typs[:]
 }
+
+var dbgDecls = [...]struct {
+	name string
+	tag  int
+	typ  int
+}{
+	{"Print", funcTag, 2},
+}
+
+func dbgTypes() []*types.Type {
+	var typs [3]*types.Type
+	typs[0] = types.Types[types.TSTRING]
+	typs[1] = types.Types[types.TINTER]
+	typs[2] = newSig(params(typs[0], typs[0], typs[1]), nil)
+	return typs[:]
+}
@@ -58,6 +58,7 @@ func params(tlist ...*types.Type) []*types.Field {
 
 	mkbuiltin(&b, "runtime")
 	mkbuiltin(&b, "coverage")
+	mkbuiltin(&b, "dbg")
 
 	var err error
 	out := b.Bytes()
@@ -188,6 +188,9 @@ func tcCheck(n *ir.CheckStmt) ir.Node {
 	if n.Cond.Type() != nil && n.Cond.Type().IsUntyped() {
 		n.Cond = DefaultLit(n.Cond, nil)
 	}
+	if n.Msg != nil {
+		n.Msg = DefaultLit(Expr(n.Msg), types.Types[types.TSTRING])
+	}
 	return n
 }
 
@@ -8,6 +8,7 @@ import (
 	"cmd/compile/internal/base"
 	"cmd/compile/internal/ir"
 	"cmd/compile/internal/types"
+	"cmd/internal/goobj"
 	"cmd/internal/obj"
 )
 
@@ -132,3 +133,38 @@ func LookupCoverage(name string) *ir.Name {
 	}
 	return sym.Def.(*ir.Name)
 }
+
+// InitDbg loads the definition of the function that goo's dbg builtin
+// calls (similar to InitRuntime above).
+func InitDbg() {
+	typs := dbgTypes()
+	for _, d := range &dbgDecls {
+		sym := ir.Pkgs.Dbg.Lookup(d.name)
+		typ := typs[d.typ]
+		switch d.tag {
+		case funcTag:
+			importfunc(sym, typ)
+		default:
+			base.Fatalf("unhandled declaration tag %v", d.tag)
+		}
+	}
+}
+
+// LookupDbg looks up the Go function 'name' in package runtime/dbg.
+// The package is not imported, so the first lookup records it for the
+// linker to load and sets DbgUsed.
+func LookupDbg(name string) *ir.Name {
+	sym := ir.Pkgs.Dbg.Lookup(name)
+	if sym == nil || sym.Def == nil {
+		base.Fatalf("LookupDbg: can't find runtime/dbg.%s", name)
+	}
+	if !DbgUsed {
+		DbgUsed = true
+		base.Ctxt.AddImport(ir.Pkgs.Dbg.Prefix, goobj.FingerprintType{})
+	}
+	return sym.Def.(*ir.Name)
+}
+
+// DbgUsed reports whether the package calls into runtime/dbg, which
+// must then be initialized before it.
+var DbgUsed bool
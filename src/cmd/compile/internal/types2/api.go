@@ -185,6 +185,12 @@ type Config struct {
 	//
 	// This flag will eventually be removed (with Go 1.24 at the earliest).
 	EnableAlias bool
+
+	// goo: AutoImports maps package names to the paths of the packages
+	// with that name which .goo files may use without importing them.
+	// A name used as a qualifier but not declared imports its package,
+	// or is an error if there is more than one.
+	AutoImports map[string][]string
 }
 
 func srcimporter_setUsesCgo(conf *Config) {
@@ -276,6 +282,14 @@ type Info struct {
 	// to their corresponding selections.
 	Selections map[*syntax.SelectorExpr]*Selection
 
+	// goo: Operators maps the binary operations that call an operator
+	// method, such as a + b for a.Add(b), to a method expression for the
+	// method. An assignment operation like a += b is keyed by its
+	// left-hand side. The method expressions are synthesized; their
+	// types and selections are recorded, but their receiver type
+	// expression is a blank identifier.
+	Operators map[syntax.Node]*syntax.SelectorExpr
+
 	// Scopes maps syntax.Nodes to the scopes they define. Package scopes are not
 	// associated with a specific node but with all files belonging to a package.
 	// Thus, the package scope can be found in the type-checked Package object.
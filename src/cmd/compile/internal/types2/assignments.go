@@ -266,6 +266,7 @@ func (checks *Checker) assignVar(lhs, rhs syntax.Expr, x *operand, context strin
 			}
 		}
 		x = new(operand)
+		checks.braceHint(rhs, T) // goo
 		checks.expr(target, x, rhs)
 	}
 
@@ -406,6 +407,7 @@ func (checks *Checker) initVars(lhs []*Var, orig_rhs []syntax.Expr, returnStmt s
 			if returnStmt != nil && desc == "" {
 				desc = "result variable"
 			}
+			checks.braceHint(orig_rhs[i], lhs.typ) // goo
 			checks.expr(newTarget(lhs.typ, desc), &x, orig_rhs[i])
 			checks.initVar(lhs, &x, context)
 		}
@@ -521,6 +523,14 @@ func (checks *Checker) assignVars(lhs, orig_rhs []syntax.Expr) {
 }
 
 func (checks *Checker) shortVarDecl(pos poser, lhs, rhs []syntax.Expr) {
+	checks.shortVarDeclFunc(pos, lhs, endPos(rhs[len(rhs)-1]), func(lhsVars []*Var) {
+		checks.initVars(lhsVars, rhs, nil)
+	})
+}
+
+// shortVarDeclFunc is like shortVarDecl but calls init to initialize the
+// lhs variables and declares the new ones at scopePos.
+func (checks *Checker) shortVarDeclFunc(pos poser, lhs []syntax.Expr, scopePos syntax.Pos, init func(lhsVars []*Var)) {
 	top := len(checks.delayed)
 	scope := checks.scope
 
@@ -581,7 +591,7 @@ func (checks *Checker) shortVarDecl(pos poser, lhs, rhs []syntax.Expr) {
 		}
 	}
 
-	checks.initVars(lhsVars, rhs, nil)
+	init(lhsVars)
 
 	// process function literals in rhs expressions before scope changes
 	checks.processDelayed(top)
@@ -596,7 +606,6 @@ func (checks *Checker) shortVarDecl(pos poser, lhs, rhs []syntax.Expr) {
 	// a function begins at the end of the ConstSpec or VarSpec (ShortVarDecl
 	// for short variable declarations) and ends at the end of the innermost
 	// containing block."
-	scopePos := endPos(rhs[len(rhs)-1])
 	for _, obj := range newVars {
 		checks.declare(scope, nil, obj, scopePos) // id = nil: recordDef already called
 	}
@@ -0,0 +1,141 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file implements typechecking of goo brace literals {k: v} in
+// contexts that expect a struct or map type, as in var p Person = {name: "Al"}.
+
+package types2
+
+import (
+	"cmd/compile/internal/syntax"
+	. "internal/types/errors"
+	"strconv"
+	"strings"
+)
+
+// braceHint records typ as the type expected of e by its context if e
+// is a brace literal or a list literal with spreads, or of such a
+// literal x if e is &x and typ is a pointer type.
+func (checks *Checker) braceHint(e syntax.Expr, typ Type) {
+	if typ == nil {
+		return
+	}
+	e = syntax.Unparen(e)
+	if op, _ := e.(*syntax.Operation); op != nil && op.Op == syntax.And && op.Y == nil {
+		p, _ := under(typ).(*Pointer)
+		if p == nil {
+			return
+		}
+		e, typ = syntax.Unparen(op.X), p.base
+	}
+	if lit, _ := e.(*syntax.CompositeLit); lit != nil && (lit.Brace || lit.List && hasSpread(lit.ElemList)) {
+		if checks.braceHints == nil {
+			checks.braceHints = make(map[*syntax.CompositeLit]Type)
+		}
+		checks.braceHints[lit] = typ
+	}
+}
+
+// braceArgs records the parameter types of the non-generic signature
+// sig as the types expected of the brace literal arguments of call.
+func (checks *Checker) braceArgs(call *syntax.CallExpr, sig *Signature) {
+	if sig.TypeParams().Len() > 0 {
+		return
+	}
+	n := sig.params.Len()
+	for i, arg := range call.ArgList {
+		var typ Type
+		switch {
+		case sig.variadic && i >= n-1:
+			if s, _ := under(sig.params.vars[n-1].typ).(*Slice); s != nil && !call.HasDots {
+				typ = s.elem
+			}
+		case i < n:
+			typ = sig.params.vars[i].typ
+		}
+		checks.braceHint(arg, typ)
+	}
+}
+
+// braceType reports whether the brace literal e takes the type typ
+// expected by its context rather than map[any]any, which it does if
+// typ is a struct or map type. For a struct, the keys of e, which the
+// parser turned into strings, become the names of the fields they match
+// case-insensitively. Elements with unknown keys are reported and
+// dropped.
+func (checks *Checker) braceType(e *syntax.CompositeLit, typ Type) bool {
+	if typ == nil {
+		return false
+	}
+	switch u := CoreType(typ).(type) {
+	case *Map:
+	case *Struct:
+		elems := e.ElemList[:0]
+		for _, elem := range e.ElemList {
+			kv, _ := elem.(*syntax.KeyValueExpr)
+			var key *syntax.BasicLit
+			if kv != nil {
+				key, _ = kv.Key.(*syntax.BasicLit)
+			}
+			if key == nil || key.Kind != syntax.StringLit {
+				elems = append(elems, elem) // reported by compositeLit
+				continue
+			}
+			name, _ := strconv.Unquote(key.Value)
+			i := fieldIndex(u.fields, checks.pkg, name, false)
+			if i < 0 {
+				i = fieldIndex(u.fields, checks.pkg, name, true)
+			}
+			if i < 0 {
+				msg := checks.sprintf("unknown field %s in struct literal of type %s", name, typ)
+				if alt := closestField(u.fields, name); alt != "" {
+					msg += ", did you mean " + alt + "?"
+				}
+				checks.error(key, MissingLitField, msg)
+				checks.use(kv.Value)
+				e.NKeys--
+				continue
+			}
+			kv.Key = syntax.NewName(key.Pos(), u.fields[i].name)
+			elems = append(elems, kv)
+		}
+		e.ElemList = elems
+	default:
+		return false
+	}
+	e.Type = nil
+	return true
+}
+
+// closestField returns the name of the field that is closest to name
+// by edit distance, ignoring case, if it is close enough to suggest.
+func closestField(fields []*Var, name string) string {
+	best, dist := "", len(name)/2+1
+	for _, f := range fields {
+		if d := editDistance(strings.ToLower(f.name), strings.ToLower(name)); d < dist {
+			best, dist = f.name, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	row := make([]int, len(b)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			prev, row[j] = row[j], min(row[j]+1, row[j-1]+1, prev+cost)
+		}
+	}
+	return row[len(b)]
+}
@@ -11,6 +11,7 @@ import (
 	"go/constant"
 	"go/token"
 	. "internal/types/errors"
+	"strings"
 )
 
 // builtin type-checks a call to the built-in specified by id and
@@ -30,6 +31,11 @@ func (checks *Checker) builtin(x *operand, call *syntax.CallExpr, id builtinId)
 		return
 	}
 
+	// goo: collection methods are checked against their collection
+	if id >= _CollMap {
+		return checks.collection(x, call, id)
+	}
+
 	// For len(x) and cap(x) we need to know if x contains any function calls or
 	// receive operations. Save/restore current setting and set hasCallOrRecv to
 	// false for the evaluation of x so that we can check it afterwards.
@@ -42,6 +48,20 @@ func (checks *Checker) builtin(x *operand, call *syntax.CallExpr, id builtinId)
 		checks.hasCallOrRecv = false
 	}
 
+	// goo: old(x) is evaluated on entry to the function and cannot
+	// contain another old call.
+	if id == _Old {
+		if !checks.inEnsures {
+			checks.errorf(call, InvalidCall, invalidOp+"%v used outside of an ensures clause", call)
+			checks.use(argList...)
+			return
+		}
+		defer func() {
+			checks.inEnsures = true
+		}()
+		checks.inEnsures = false
+	}
+
 	// Evaluate arguments for built-ins that use ordinary (value) arguments.
 	// For built-ins with special argument handling (make, new, etc.),
 	// evaluation is done by the respective built-in code.
@@ -50,7 +70,22 @@ func (checks *Checker) builtin(x *operand, call *syntax.CallExpr, id builtinId)
 	switch id {
 	default:
 		// check all arguments
-		args = checks.exprList(argList)
+		if id == _Append && len(argList) > 1 && !hasDots(call) {
+			// goo: appended brace literals take the element type;
+			// each value is a single value as in exprList
+			args = checks.exprList(argList[:1])
+			s, _ := CoreType(args[0].typ).(*Slice)
+			for _, arg := range argList[1:] {
+				if s != nil {
+					checks.braceHint(arg, s.elem)
+				}
+				var y operand
+				checks.expr(nil, &y, arg)
+				args = append(args, &y)
+			}
+		} else {
+			args = checks.exprList(argList)
+		}
 		nargs = len(args)
 		for _, a := range args {
 			if a.mode == invalid {
@@ -674,7 +709,6 @@ func (checks *Checker) builtin(x *operand, call *syntax.CallExpr, id builtinId)
 			checks.recordBuiltinType(call.Fun, makeSig(nil, &emptyInterface))
 		}
 
-
 	case _Print, _Println:
 		// print(x, y, ...)
 		// println(x, y, ...)
@@ -712,6 +746,61 @@ func (checks *Checker) builtin(x *operand, call *syntax.CallExpr, id builtinId)
 			checks.recordBuiltinType(call.Fun, makeSig(Typ[String], args[0].typ))
 		}
 
+	case _Sum, _Dot:
+		// goo:
+		// sum(x S) E
+		// dot(x, y S) E
+		// for a slice type S of integers or floats E
+		elem := vectorElem(x.typ)
+		if elem == nil {
+			checks.errorf(x, InvalidCall, invalidArg+"%s is not a slice of numbers", x)
+			return
+		}
+		params := []Type{x.typ}
+		if id == _Dot {
+			y := args[1]
+			if !Identical(x.typ, y.typ) {
+				checks.errorf(y, MismatchedTypes, invalidArg+"arguments to dot %s and %s have different types %s and %s", x, y, x.typ, y.typ)
+				return
+			}
+			params = append(params, y.typ)
+		}
+		x.mode = value
+		x.typ = elem
+		if checks.recordTypes() {
+			checks.recordBuiltinType(call.Fun, makeSig(elem, params...))
+		}
+
+	case _Old:
+		// goo: old(x T) T, the value of x on entry to the function
+		if x.mode != constant_ {
+			checks.assignment(x, nil, "argument to old")
+			if x.mode == invalid {
+				return
+			}
+			x.mode = value
+		}
+		if checks.recordTypes() {
+			checks.recordBuiltinType(call.Fun, makeSig(x.typ, x.typ))
+		}
+
+	case _Dbg:
+		// goo: dbg(x T) T, printing x to standard error
+		// The go command links the printer into packages whose .goo
+		// files call dbg.
+		if !strings.HasSuffix(call.Pos().RelFilename(), ".goo") {
+			checks.errorf(call.Fun, InvalidCall, invalidOp+"dbg is only available in .goo files")
+			return
+		}
+		checks.assignment(x, nil, "argument to dbg")
+		if x.mode == invalid {
+			return
+		}
+		x.mode = value
+		if checks.recordTypes() {
+			checks.recordBuiltinType(call.Fun, makeSig(x.typ, x.typ))
+		}
+
 	case _Add:
 		// unsafe.Add(ptr unsafe.Pointer, len IntegerType) unsafe.Pointer
 		checks.verifyVersionf(call.Fun, go1_17, "unsafe.Add")
@@ -124,6 +124,11 @@ var builtinCalls = []struct {
 	{"recover", `recover()`, `func() interface{}`},
 	{"recover", `_ = recover()`, `func() interface{}`},
 
+	{"sum", `var s []float64; _ = sum(s)`, `func([]float64) float64`},
+	{"sum", `type V []int8; var v V; _ = sum(v)`, `func(p.V) int8`},
+
+	{"dot", `var s []float32; _ = dot(s, s)`, `func([]float32, []float32) float32`},
+
 	{"Add", `var p unsafe.Pointer; _ = unsafe.Add(p, -1.0)`, `func(unsafe.Pointer, int) unsafe.Pointer`},
 	{"Add", `var p unsafe.Pointer; var n uintptr; _ = unsafe.Add(p, n)`, `func(unsafe.Pointer, uintptr) unsafe.Pointer`},
 	{"Add", `_ = unsafe.Add(nil, 0)`, `func(unsafe.Pointer, int) unsafe.Pointer`},
@@ -163,6 +168,8 @@ func TestBuiltinSignatures(t *testing.T) {
 	DefPredeclaredTestFuncs()
 
 	seen := map[string]bool{"trace": true} // no test for trace built-in; add it manually
+	seen["dbg"] = true                     // dbg is only available in .goo files
+	seen["old"] = true                     // old is only valid in an ensures clause; see TestOldSignature
 	for _, call := range builtinCalls {
 		testBuiltinSignature(t, call.name, call.src, call.sig)
 		seen[call.name] = true
@@ -181,6 +188,32 @@ func TestBuiltinSignatures(t *testing.T) {
 	}
 }
 
+// TestOldSignature checks the type recorded for the goo built-in old,
+// which cannot be called in the function body used by
+// testBuiltinSignature.
+func TestOldSignature(t *testing.T) {
+	const src = `package p; func _(x int) (r int) ensures r > old(x) { return x + 1 }`
+
+	uses := make(map[*syntax.Name]Object)
+	types := make(map[syntax.Expr]TypeAndValue)
+	mustTypecheck(src, nil, &Info{Uses: uses, Types: types})
+
+	for x := range types {
+		call, _ := x.(*syntax.CallExpr)
+		if call == nil {
+			continue
+		}
+		if got, want := types[call.Fun].Type.String(), "func(int) int"; got != want {
+			t.Errorf("got type %s; want %s", got, want)
+		}
+		if bin, _ := uses[call.Fun.(*syntax.Name)].(*Builtin); bin == nil || bin.Name() != "old" {
+			t.Errorf("%s does not denote the built-in old", ExprString(call.Fun))
+		}
+		return
+	}
+	t.Error("no call of old recorded")
+}
+
 func testBuiltinSignature(t *testing.T, name, src0, want string) {
 	src := fmt.Sprintf(`package p; import "unsafe"; type _ unsafe.Pointer /* use unsafe */; func _[P ~[]byte]() { %s }`, src0)
 
@@ -9,6 +9,7 @@ package types2
 import (
 	"cmd/compile/internal/syntax"
 	. "internal/types/errors"
+	"slices"
 	"strings"
 )
 
@@ -173,8 +174,7 @@ func (checks *Checker) callExpr(x *operand, call *syntax.CallExpr) exprKind {
 	if name, ok := call.Fun.(*syntax.Name); ok && (name.Value == "printf") {
 		// Check if this is not a user-defined function (no local definition found)
 		if checks.lookup("printf") == nil {
-			// For now, require manual fmt import until auto-import is working
-			if checks.lookup("fmt") != nil {
+			if checks.autoImportFmt(name) {
 				// Transform printf(...) to fmt.Printf(...) by modifying call in place
 				fmtName := syntax.NewName(name.Pos(), "fmt")
 				printfName := syntax.NewName(name.Pos(), "Printf")
@@ -189,44 +189,37 @@ func (checks *Checker) callExpr(x *operand, call *syntax.CallExpr) exprKind {
 			} else {
 				// Provide helpful error message
 				checks.errorf(name, UndeclaredName, "printf requires 'import \"fmt\"' - automatic import only for .goo files")
+				checks.use(call.ArgList...)
+				x.mode = invalid
+				x.expr = call
 				return expression
 			}
 		}
 	}
 
-	// Transform put calls to fmt.Printf calls with %v\n format TODO: put into a separate function
+	// Transform put calls to fmt.Println calls, which print their
+	// arguments separated by spaces
 	if name, ok := call.Fun.(*syntax.Name); ok && (name.Value == "put" || name.Value == "prints") {
 		// Check if this is not a user-defined function (no local definition found)
 		if checks.lookup("put") == nil {
-			// For now, require manual fmt import until auto-import is working
-			if checks.lookup("fmt") != nil {
-				// Transform put(arg) to fmt.Printf("%v\n", arg) by modifying call in place
+			if checks.autoImportFmt(name) {
+				// Transform put(args) to fmt.Println(args) by modifying call in place
 				fmtName := syntax.NewName(name.Pos(), "fmt")
-				printfName := syntax.NewName(name.Pos(), "Printf")
+				printlnName := syntax.NewName(name.Pos(), "Println")
 				selector := &syntax.SelectorExpr{
 					X:   fmtName,
-					Sel: printfName,
+					Sel: printlnName,
 				}
 				selector.SetPos(name.Pos())
 
-				// Create format string "%v\n"
-				formatStr := &syntax.BasicLit{
-					Kind:  syntax.StringLit,
-					Value: "\"%v\\n\"",
-				}
-				formatStr.SetPos(name.Pos())
-
-				// Insert format string as first argument
-				newArgs := make([]syntax.Expr, len(call.ArgList)+1)
-				newArgs[0] = formatStr
-				copy(newArgs[1:], call.ArgList)
-				call.ArgList = newArgs
-
 				// Replace the function in the original call
 				call.Fun = selector
 			} else {
 				// Provide helpful error message
 				checks.errorf(name, UndeclaredName, "put requires 'import \"fmt\"' - automatic import only for .goo files")
+				checks.use(call.ArgList...)
+				x.mode = invalid
+				x.expr = call
 				return expression
 			}
 		}
@@ -248,6 +241,15 @@ func (checks *Checker) callExpr(x *operand, call *syntax.CallExpr) exprKind {
 	}
 	// x.typ may be generic
 
+	// goo: only a function may be called without parentheses, as in put 42
+	if call.NoParens && x.mode != invalid && x.mode != builtin {
+		u, _ := commonUnder(x.typ, nil)
+		if _, ok := u.(*Signature); x.mode == typexpr || !ok {
+			checks.errorf(x, InvalidCall, "%s is not a function: cannot call it without parentheses", x)
+			x.mode = invalid
+		}
+	}
+
 	switch x.mode {
 	case invalid:
 		checks.use(call.ArgList...)
@@ -364,7 +366,24 @@ func (checks *Checker) callExpr(x *operand, call *syntax.CallExpr) exprKind {
 	}
 
 	// evaluate arguments
-	args, atargs := checks.genericExprList(call.ArgList)
+	var args []*operand
+	var atargs [][]Type
+	checks.braceArgs(call, sig) // goo: brace literals take their parameter types
+	if fn := checks.declaredFunc(call.Fun); fn != nil && fn.defaults != nil || hasNamedArgs(call) {
+		var ok bool
+		args, atargs, ok = checks.namedArguments(call, fn, sig)
+		if !ok {
+			x.mode = invalid
+			x.expr = call
+			return statement
+		}
+	} else {
+		args, atargs = checks.genericExprList(call.ArgList)
+		// goo: slices.Sort and the like order elements by operator methods
+		if fsig := checks.orderByMethod(call, &args); fsig != nil {
+			sig = fsig
+		}
+	}
 	sig = checks.arguments(call, sig, targs, xlist, args, atargs)
 
 	if wasGeneric && sig.TypeParams().Len() == 0 {
@@ -399,6 +418,302 @@ func (checks *Checker) callExpr(x *operand, call *syntax.CallExpr) exprKind {
 	return statement
 }
 
+// declaredFunc returns the function or method that the callee expression f
+// denotes directly, or nil. Only calls of declared functions may use named
+// arguments and parameter defaults: a function value, including a method
+// value, may hold any function of its type.
+func (checks *Checker) declaredFunc(f syntax.Expr) *Func {
+	switch f := syntax.Unparen(f).(type) {
+	case *syntax.Name:
+		fn, _ := checks.lookup(f.Value).(*Func)
+		return fn
+	case *syntax.SelectorExpr:
+		if name, _ := f.X.(*syntax.Name); name != nil {
+			if pname, _ := checks.lookup(name.Value).(*PkgName); pname != nil {
+				fn, _ := pname.imported.scope.Lookup(f.Sel.Value).(*Func)
+				return fn
+			}
+		}
+		if fn := checks.methodVals[f]; fn != nil && !IsInterface(fn.Signature().Recv().Type()) {
+			return fn
+		}
+	}
+	return nil
+}
+
+// hasNamedArgs reports whether call has named arguments.
+func hasNamedArgs(call *syntax.CallExpr) bool {
+	for _, e := range call.ArgList {
+		if _, ok := e.(*syntax.KeyValueExpr); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// namedArguments evaluates the arguments of a call of the declared function
+// fn with signature sig. It moves named arguments to the position of their
+// parameter and fills in the defaults of omitted parameters, and updates
+// call.ArgList accordingly. If that changes the order of the arguments,
+// call.ArgOrder records the order in which they were written, which is
+// the order in which they are evaluated.
+// fn is nil if the callee is not a declared function.
+func (checks *Checker) namedArguments(call *syntax.CallExpr, fn *Func, sig *Signature) (args []*operand, atargs [][]Type, ok bool) {
+	fail := func(at poser, code Code, format string, args ...any) ([]*operand, [][]Type, bool) {
+		checks.errorf(at, code, format, args...)
+		checks.use(call.ArgList...)
+		return nil, nil, false
+	}
+
+	if fn == nil {
+		return fail(call, InvalidCall, "cannot use named arguments with %s (not a declared function or method)", call.Fun)
+	}
+	if sig.variadic {
+		return fail(call, InvalidCall, "cannot use named arguments with variadic function %s", fn.name)
+	}
+
+	// place each argument at the position of its parameter
+	n := sig.params.Len()
+	elist := make([]syntax.Expr, n)
+	var order []int // parameter indices of the arguments as written
+	named := false
+	for i, e := range call.ArgList {
+		kv, _ := e.(*syntax.KeyValueExpr)
+		if kv == nil {
+			if named {
+				return fail(e, InvalidCall, "positional argument %s follows named arguments", e)
+			}
+			if i >= n {
+				return fail(e, WrongArgCount, "too many arguments in call to %s", fn.name)
+			}
+			elist[i] = e
+			order = append(order, i)
+			continue
+		}
+		named = true
+		name := kv.Key.(*syntax.Name)
+		j := 0
+		for j < n && sig.params.At(j).name != name.Value {
+			j++
+		}
+		switch {
+		case j == n || name.Value == "_":
+			return fail(kv.Key, InvalidCall, "unknown parameter %s in call to %s", name.Value, fn.name)
+		case elist[j] != nil:
+			return fail(kv.Key, WrongArgCount, "duplicate argument for parameter %s in call to %s", name.Value, fn.name)
+		}
+		elist[j] = kv.Value
+		order = append(order, j)
+	}
+
+	// evaluate the explicit arguments
+	var xlist []syntax.Expr
+	for _, e := range elist {
+		if e != nil {
+			xlist = append(xlist, e)
+		}
+	}
+	xargs, xtargs := checks.genericExprList(xlist)
+	if len(xargs) != len(xlist) {
+		// f(g()) where g returns multiple values
+		if len(xargs) == n {
+			return xargs, xtargs, true
+		}
+		return fail(xlist[0], WrongArgCount, "cannot use multi-valued %s with default arguments", xlist[0])
+	}
+
+	// fill in the defaults
+	args = make([]*operand, n)
+	atargs = make([][]Type, n)
+	for i, k := 0, 0; i < n; i++ {
+		if elist[i] != nil {
+			args[i] = xargs[k]
+			if k < len(xtargs) {
+				atargs[i] = xtargs[k]
+			}
+			k++
+			continue
+		}
+		par := sig.params.At(i)
+		var d *paramDefault
+		if fn.defaults != nil {
+			d = fn.defaults[i]
+		}
+		if d == nil {
+			checks.errorf(call, WrongArgCount, "missing argument for parameter %s in call to %s", par.name, fn.name)
+			return nil, nil, false
+		}
+		x := checks.defaultArg(call, fn, par, d)
+		if x == nil {
+			return nil, nil, false
+		}
+		elist[i] = x.expr
+		args[i] = x
+	}
+	call.ArgList = elist
+	if !slices.IsSorted(order) {
+		call.ArgOrder = order
+	}
+	return args, atargs, true
+}
+
+// defaultArg returns the operand for the default value d of parameter par
+// of fn in call, or nil if d cannot be used. The default expression of a
+// function declared in this package was type-checked with the declaration;
+// an imported constant or nil default is rebuilt at the call site. Export
+// data carries no other defaults, so calls to functions of other packages
+// must pass the arguments of those parameters.
+func (checks *Checker) defaultArg(call *syntax.CallExpr, fn *Func, par *Var, d *paramDefault) *operand {
+	x := &operand{mode: value, typ: par.typ, val: d.val}
+	if d.val != nil {
+		x.mode = constant_
+	}
+	if d.expr != nil {
+		x.expr = checks.copyDefault(d.expr)
+		return x
+	}
+
+	pos := call.Pos()
+	switch {
+	case d.val != nil:
+		x.expr = syntax.NewName(pos, d.val.String())
+	case d.isNil:
+		name := syntax.NewName(pos, "nil")
+		checks.recordUse(name, Universe.Lookup("nil"))
+		x.expr = name
+	default:
+		checks.errorf(call, InvalidCall, "missing argument for parameter %s in call to %s (non-constant default values are not exported)", par.name, fn.FullName())
+		return nil
+	}
+	checks.recordTypeAndValue(x.expr, x.mode, x.typ, x.val)
+	return x
+}
+
+// copyDefault returns a copy of the default value expression e for a call
+// site, so that call sites do not share syntax nodes. Type expressions,
+// and expressions that declare variables like comprehensions, are shared.
+// The type information of the copies is recorded at the end, when that of
+// untyped operands of e is known.
+func (checks *Checker) copyDefault(e syntax.Expr) syntax.Expr {
+	if e == nil || e.GetTypeInfo().IsType() {
+		return e
+	}
+
+	var c syntax.Expr
+	switch e := e.(type) {
+	case *syntax.Name:
+		n := *e
+		c = &n
+	case *syntax.BasicLit:
+		n := *e
+		c = &n
+	case *syntax.CompositeLit:
+		n := *e
+		n.ElemList = checks.copyDefaults(e.ElemList)
+		c = &n
+	case *syntax.KeyValueExpr:
+		n := *e
+		n.Key = checks.copyDefault(e.Key)
+		n.Value = checks.copyDefault(e.Value)
+		c = &n
+	case *syntax.ParenExpr:
+		n := *e
+		n.X = checks.copyDefault(e.X)
+		c = &n
+	case *syntax.SelectorExpr:
+		n := *e
+		n.X = checks.copyDefault(e.X)
+		sel := *e.Sel
+		n.Sel = &sel
+		checks.defaultCopies = append(checks.defaultCopies, [2]syntax.Expr{e.Sel, n.Sel})
+		c = &n
+	case *syntax.IndexExpr:
+		n := *e
+		n.X = checks.copyDefault(e.X)
+		n.Index = checks.copyDefault(e.Index)
+		c = &n
+	case *syntax.SliceExpr:
+		n := *e
+		n.X = checks.copyDefault(e.X)
+		for i, x := range e.Index {
+			n.Index[i] = checks.copyDefault(x)
+		}
+		c = &n
+	case *syntax.AssertExpr:
+		n := *e
+		n.X = checks.copyDefault(e.X)
+		c = &n
+	case *syntax.Operation:
+		n := *e
+		n.X = checks.copyDefault(e.X)
+		n.Y = checks.copyDefault(e.Y)
+		c = &n
+	case *syntax.CallExpr:
+		n := *e
+		n.Fun = checks.copyDefault(e.Fun)
+		n.ArgList = checks.copyDefaults(e.ArgList)
+		c = &n
+	case *syntax.ListExpr:
+		n := *e
+		n.ElemList = checks.copyDefaults(e.ElemList)
+		c = &n
+	case *syntax.DotsType:
+		n := *e
+		n.Elem = checks.copyDefault(e.Elem)
+		c = &n
+	default:
+		return e
+	}
+	checks.defaultCopies = append(checks.defaultCopies, [2]syntax.Expr{e, c})
+	return c
+}
+
+// copyDefaults returns the copies of the expressions list made by copyDefault.
+func (checks *Checker) copyDefaults(list []syntax.Expr) []syntax.Expr {
+	if list == nil {
+		return nil
+	}
+	res := make([]syntax.Expr, len(list))
+	for i, e := range list {
+		res[i] = checks.copyDefault(e)
+	}
+	return res
+}
+
+// recordDefaultCopies records the type information of the default value
+// expressions copied by copyDefault for their copies.
+func (checks *Checker) recordDefaultCopies() {
+	for _, p := range checks.defaultCopies {
+		e, c := p[0], p[1]
+		if m := checks.Types; m != nil {
+			if tv, ok := m[e]; ok {
+				m[c] = tv
+			}
+		}
+		c.SetTypeInfo(e.GetTypeInfo())
+		if e, _ := e.(*syntax.Name); e != nil {
+			c := c.(*syntax.Name)
+			if obj := checks.Uses[e]; obj != nil {
+				checks.recordUse(c, obj)
+			}
+			if inst, ok := checks.Instances[e]; ok {
+				checks.Instances[c] = inst
+			}
+		}
+		if e, _ := e.(*syntax.SelectorExpr); e != nil {
+			if sel := checks.Selections[e]; sel != nil {
+				checks.Selections[c.(*syntax.SelectorExpr)] = sel
+			}
+		}
+		if obj := checks.Implicits[e]; obj != nil {
+			checks.recordImplicit(c, obj)
+		}
+		if fn := checks.Operators[e]; fn != nil {
+			checks.recordOperator(c, fn)
+		}
+	}
+}
+
 // exprList evaluates a list of expressions and returns the corresponding operands.
 // A single-element expression list may evaluate to multiple operands.
 func (checks *Checker) exprList(elist []syntax.Expr) (xlist []*operand) {
@@ -464,6 +779,9 @@ func (checks *Checker) genericExprList(elist []syntax.Expr) (resList []*operand,
 				checks.record(&x)
 			}
 			resList = []*operand{&x}
+		} else if isLambda(e) {
+			// x is typed by arguments once the parameter types are known
+			resList = []*operand{{mode: lambda, expr: e}}
 		} else {
 			// x is not a function instantiation (it may still be a generic function).
 			checks.rawExpr(nil, &x, e, nil, true)
@@ -498,6 +816,9 @@ func (checks *Checker) genericExprList(elist []syntax.Expr) (resList []*operand,
 					// use the usual expression evaluators.
 					checks.record(&x)
 				}
+			} else if isLambda(e) {
+				// x is typed by arguments once the parameter types are known
+				x = operand{mode: lambda, expr: e}
 			} else {
 				// x is exactly one value (possibly invalid or uninstantiated generic function).
 				checks.genericExpr(&x, e)
@@ -714,6 +1035,9 @@ func (checks *Checker) arguments(call *syntax.CallExpr, sig *Signature, targs []
 	if len(args) > 0 {
 		context := checks.sprintf("argument to %s", call.Fun)
 		for i, a := range args {
+			if a.mode == lambda {
+				checks.typeLambda(a, sigParams.vars[i].typ)
+			}
 			checks.assignment(a, sigParams.vars[i].typ, context)
 		}
 	}
@@ -721,6 +1045,20 @@ func (checks *Checker) arguments(call *syntax.CallExpr, sig *Signature, targs []
 	return
 }
 
+// isLambda reports whether e is a (possibly parenthesized) lambda.
+func isLambda(e syntax.Expr) bool {
+	f, _ := syntax.Unparen(e).(*syntax.FuncLit)
+	return f != nil && f.Lambda
+}
+
+// typeLambda type-checks the lambda argument x for a parameter of type
+// typ and records it.
+func (checks *Checker) typeLambda(x *operand, typ Type) {
+	sig, _ := under(typ).(*Signature)
+	checks.lambda(x, syntax.Unparen(x.expr).(*syntax.FuncLit), sig, false)
+	checks.record(x)
+}
+
 var cgoPrefixes = [...]string{
 	"_Ciconst_",
 	"_Cfconst_",
@@ -747,6 +1085,14 @@ func (checks *Checker) selector(x *operand, e *syntax.SelectorExpr, defi *TypeNa
 	// selector expressions.
 	if ident, ok := e.X.(*syntax.Name); ok {
 		obj := checks.lookup(ident.Value)
+		// goo: an undeclared qualifier may name a package to import.
+		if obj == nil && checks.conf.AutoImports[ident.Value] != nil && strings.HasSuffix(ident.Pos().RelFilename(), ".goo") {
+			pname := checks.autoImport(ident)
+			if pname == nil {
+				goto Error
+			}
+			obj = pname
+		}
 		if pname, _ := obj.(*PkgName); pname != nil {
 			assert(pname.pkg == checks.pkg)
 			checks.recordUse(ident, pname)
@@ -885,6 +1231,12 @@ func (checks *Checker) selector(x *operand, e *syntax.SelectorExpr, defi *TypeNa
 			goto Error
 		}
 
+		// goo: slices and maps have collection methods unless they have
+		// a real method of the same name.
+		if x.mode != typexpr && checks.collectionMethod(x, e) {
+			return
+		}
+
 		if indirect {
 			if x.mode == typexpr {
 				checks.errorf(e.Sel, InvalidMethodExpr, "invalid method expression %s.%s (needs pointer receiver (*%s).%s)", x.typ, sel, x.typ, sel)
@@ -973,6 +1325,10 @@ func (checks *Checker) selector(x *operand, e *syntax.SelectorExpr, defi *TypeNa
 			// TODO(gri) If we needed to take into account the receiver's
 			// addressability, should we report the type &(x.typ) instead?
 			checks.recordSelection(e, MethodVal, x.typ, obj, index, indirect)
+			if checks.methodVals == nil {
+				checks.methodVals = make(map[*syntax.SelectorExpr]*Func)
+			}
+			checks.methodVals[e] = obj
 
 			x.mode = value
 
@@ -1052,6 +1408,9 @@ func (checks *Checker) use1(e syntax.Expr, lhs bool) bool {
 		}
 	case *syntax.ListExpr:
 		return checks.useN(n.ElemList, lhs)
+	case *syntax.KeyValueExpr:
+		// named argument
+		return checks.use1(n.Value, lhs)
 	default:
 		checks.rawExpr(nil, &x, e, nil, true)
 	}
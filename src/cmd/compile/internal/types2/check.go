@@ -68,6 +68,12 @@ type environment struct {
 	isPanic       map[*syntax.CallExpr]bool // set of panic call expressions (used for termination check)
 	hasLabel      bool                      // set if a function makes use of labels (only ~1% of functions); unused outside functions
 	hasCallOrRecv bool                      // set if an expression contains a function call or channel receive operation
+
+	// goo: branch values of the innermost if or switch expression being checked
+	condValues map[*syntax.ExprStmt]*operand
+
+	// goo: set while checking an ensures clause, outside of old calls
+	inEnsures bool
 }
 
 // lookupScope looks up name in the current environment and if an object
@@ -170,6 +176,21 @@ type Checker struct {
 	usedPkgNames  map[*PkgName]bool          // set of used package names
 	mono          monoGraph                  // graph for detecting non-monomorphizable instantiation loops
 
+	// goo: methods selected by method values, for calls with named arguments
+	methodVals map[*syntax.SelectorExpr]*Func
+
+	// goo: nodes of default parameter values and their copies at call
+	// sites, whose type information is copied by recordDefaultCopies
+	defaultCopies [][2]syntax.Expr
+
+	// goo: types expected of brace literals {k: v} and list literals
+	// [...a, 4] by their context
+	braceHints map[*syntax.CompositeLit]Type
+
+	// goo: spreads starting brace literals and the first spreads of list
+	// literals, evaluated to find their types
+	spreads map[*syntax.DotsType]*operand
+
 	firstErr error                    // first error encountered
 	methods  map[*TypeName][]*Func    // maps package scope type names to associated non-blank (non-interface) methods
 	untyped  map[syntax.Expr]exprInfo // map of expressions without final type
@@ -319,6 +340,10 @@ func (checks *Checker) initFiles(files []*syntax.File) {
 	checks.delayed = nil
 	checks.objPath = nil
 	checks.cleaners = nil
+	checks.methodVals = nil
+	checks.defaultCopies = nil
+	checks.braceHints = nil
+	checks.spreads = nil
 
 	// We must initialize usedVars and usedPkgNames both here and in NewChecker,
 	// because initFiles is not called in the CheckExpr or Eval codepaths, yet we
@@ -512,6 +537,7 @@ func (checks *Checker) checkFiles(files []*syntax.File) {
 
 	print("== recordUntyped ==")
 	checks.recordUntyped()
+	checks.recordDefaultCopies() // goo
 
 	if checks.firstErr == nil {
 		// TODO(mdempsky): Ensure monomorph is safe when errors exist.
@@ -0,0 +1,236 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file implements typechecking of the goo collection methods of
+// slices, arrays and maps, such as xs.map(f).filter(g).sum().
+
+package types2
+
+import (
+	"cmd/compile/internal/syntax"
+	. "internal/types/errors"
+)
+
+// collectionMethod reports whether the selector e denotes a collection
+// method of x. If so, x becomes the built-in for the method; its type
+// remains the type of the collection.
+func (checks *Checker) collectionMethod(x *operand, e *syntax.SelectorExpr) bool {
+	id := collectionId(e.Sel.Value)
+	if id < 0 {
+		return false
+	}
+	switch CoreType(x.typ).(type) {
+	case *Slice, *Array:
+		if id == _CollKeys || id == _CollValues {
+			return false
+		}
+	case *Map:
+		switch id {
+		case _CollMap, _CollFilter, _CollKeys, _CollValues, _CollEach, _CollAny, _CollAll:
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+	checks.recordUse(e.Sel, newBuiltin(id))
+	x.mode = builtin
+	x.id = id
+	return true
+}
+
+// collection type-checks a call of the collection method id of the
+// collection of type x.typ and reports whether the call is valid, with
+// *x holding the result. Calls of each leave x.id unchanged.
+func (checks *Checker) collection(x *operand, call *syntax.CallExpr, id builtinId) (_ bool) {
+	bin := predeclaredFuncs[id]
+	if nargs := len(call.ArgList); nargs != bin.nargs {
+		msg := "not enough"
+		if nargs > bin.nargs {
+			msg = "too many"
+		}
+		checks.errorf(argErrPos(call), WrongArgCount, invalidOp+"%s arguments for %v (expected %d, found %d)", msg, call, bin.nargs, nargs)
+		checks.use(call.ArgList...)
+		return
+	}
+
+	// The elements are passed to function arguments as params; methods
+	// that keep the elements return a collection of type coll.
+	var elem, coll Type
+	var params []Type
+	switch u := CoreType(x.typ).(type) {
+	case *Slice:
+		elem, coll = u.elem, x.typ
+	case *Array:
+		elem, coll = u.elem, NewSlice(u.elem)
+	case *Map:
+		coll = x.typ
+		params = []Type{u.key, u.elem}
+	}
+	if params == nil {
+		params = []Type{elem}
+	}
+
+	var res Type    // result type, nil for each
+	var args []Type // argument types
+	switch id {
+	case _CollMap, _CollSortBy, _CollGroupBy:
+		f := checks.collectionFunc(call.ArgList[0], bin.name, params, nil)
+		if f == nil {
+			return
+		}
+		if f.results.Len() != 1 {
+			checks.errorf(call.ArgList[0], InvalidCall, invalidArg+"%s must have one result", call.ArgList[0])
+			return
+		}
+		args = []Type{f}
+		key := f.results.vars[0].typ
+		switch id {
+		case _CollMap:
+			res = NewSlice(key)
+		case _CollSortBy:
+			if !allOrdered(key) {
+				checks.errorf(call.ArgList[0], InvalidCall, invalidArg+"sort key of type %s cannot be ordered", key)
+				return
+			}
+			res = coll
+		case _CollGroupBy:
+			if !Comparable(key) {
+				checks.errorf(call.ArgList[0], IncomparableMapKey, invalidArg+"group key of type %s is not comparable", key)
+				return
+			}
+			res = NewMap(key, coll)
+		}
+
+	case _CollFilter, _CollAny, _CollAll:
+		f := checks.collectionFunc(call.ArgList[0], bin.name, params, Typ[Bool])
+		if f == nil {
+			return
+		}
+		args = []Type{f}
+		res = Typ[Bool]
+		if id == _CollFilter {
+			res = coll
+		}
+
+	case _CollEach:
+		f := checks.collectionFunc(call.ArgList[0], bin.name, params, nil)
+		if f == nil {
+			return
+		}
+		if f.results.Len() != 0 {
+			checks.errorf(call.ArgList[0], InvalidCall, invalidArg+"%s must not have results", call.ArgList[0])
+			return
+		}
+		args = []Type{f}
+
+	case _CollReduce:
+		// reduce(init, f) accumulates with f(acc, e) starting at init.
+		var init operand
+		checks.expr(nil, &init, call.ArgList[0])
+		if init.mode == invalid {
+			checks.use(call.ArgList[1])
+			return
+		}
+		checks.assignment(&init, nil, "argument to reduce")
+		if init.mode == invalid {
+			return
+		}
+		res = init.typ
+		f := checks.collectionFunc(call.ArgList[1], bin.name, []Type{res, elem}, res)
+		if f == nil {
+			return
+		}
+		args = []Type{res, f}
+
+	case _CollSum:
+		if !allNumericOrString(elem) {
+			checks.errorf(call, InvalidCall, invalidOp+"cannot sum elements of type %s", elem)
+			return
+		}
+		res = elem
+
+	case _CollMin, _CollMax, _CollSort:
+		if !allOrdered(elem) {
+			checks.errorf(call, InvalidMinMaxOperand, invalidOp+"elements of type %s cannot be ordered", elem)
+			return
+		}
+		res = elem
+		if id == _CollSort {
+			res = coll
+		}
+
+	case _CollKeys:
+		res = NewSlice(params[0])
+
+	case _CollValues:
+		res = NewSlice(params[1])
+
+	case _CollJoin:
+		if !allString(elem) {
+			checks.errorf(call, InvalidCall, invalidOp+"cannot join elements of type %s", elem)
+			checks.use(call.ArgList[0])
+			return
+		}
+		var sep operand
+		checks.expr(nil, &sep, call.ArgList[0])
+		if sep.mode == invalid {
+			return
+		}
+		checks.assignment(&sep, Typ[String], "argument to join")
+		if sep.mode == invalid {
+			return
+		}
+		args = []Type{Typ[String]}
+		res = Typ[String]
+
+	default:
+		panic("unreachable")
+	}
+
+	if res == nil {
+		x.mode = novalue
+		x.typ = nil
+	} else {
+		x.mode = value
+		x.typ = res
+	}
+	checks.recordBuiltinType(call.Fun, makeSig(res, args...))
+	return true
+}
+
+// collectionFunc checks the function argument arg of the collection
+// method name, whose parameters are of the given types, and returns its
+// signature. The result must be of type result, or is inferred if
+// result is nil.
+func (checks *Checker) collectionFunc(arg syntax.Expr, name string, params []Type, result Type) *Signature {
+	want := makeSig(result, params...)
+	var x operand
+	if isLambda(arg) {
+		x.expr = arg
+		checks.lambda(&x, syntax.Unparen(arg).(*syntax.FuncLit), want, result == nil)
+		if x.mode == invalid {
+			return nil
+		}
+		checks.record(&x)
+	} else {
+		checks.expr(nil, &x, arg)
+		if x.mode == invalid {
+			return nil
+		}
+	}
+	if result == nil {
+		sig, _ := under(x.typ).(*Signature)
+		if sig == nil || sig.TypeParams().Len() != 0 || sig.results.Len() > 1 {
+			checks.errorf(arg, InvalidCall, invalidArg+"cannot use %s as function argument to %s", arg, name)
+			return nil
+		}
+		want.results = sig.results
+	}
+	checks.assignment(&x, want, "argument to "+name)
+	if x.mode == invalid {
+		return nil
+	}
+	return want
+}
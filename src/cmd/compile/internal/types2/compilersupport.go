@@ -7,6 +7,8 @@
 
 package types2
 
+import "go/constant"
+
 // If t is a pointer, AsPointer returns that type, otherwise it returns nil.
 func AsPointer(t Type) *Pointer {
 	u, _ := t.Underlying().(*Pointer)
@@ -37,3 +39,40 @@ func RangeKeyVal(typ Type) (Type, Type) {
 	assert(ok)
 	return key, val
 }
+
+// ParamDefault reports whether parameter i of fn has a default value.
+// If so, val is its value if the default is constant, and isNil reports
+// whether it is nil. Other defaults cannot be used outside fn's package.
+func ParamDefault(fn *Func, i int) (ok bool, val constant.Value, isNil bool) {
+	if fn.defaults == nil || fn.defaults[i] == nil {
+		return false, nil, false
+	}
+	d := fn.defaults[i]
+	return true, d.val, d.isNil
+}
+
+// Contracts returns the source texts of the requires and ensures
+// clauses of fn, in source order.
+func Contracts(fn *Func) (requires, ensures []string) {
+	if c := fn.Origin().contracts; c != nil {
+		return c.requires, c.ensures
+	}
+	return nil, nil
+}
+
+// SetContracts records the imported requires and ensures clauses of fn,
+// as reported by Contracts.
+func SetContracts(fn *Func, requires, ensures []string) {
+	if len(requires) > 0 || len(ensures) > 0 {
+		fn.contracts = &funcContracts{requires, ensures}
+	}
+}
+
+// SetParamDefault records an imported default value for parameter i of fn,
+// as reported by ParamDefault.
+func SetParamDefault(fn *Func, i int, val constant.Value, isNil bool) {
+	if fn.defaults == nil {
+		fn.defaults = make([]*paramDefault, fn.Signature().Params().Len())
+	}
+	fn.defaults[i] = &paramDefault{val: val, isNil: isNil}
+}
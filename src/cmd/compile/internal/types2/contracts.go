@@ -0,0 +1,59 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file implements typechecking of goo's requires and ensures
+// clauses on function declarations.
+
+package types2
+
+import "cmd/compile/internal/syntax"
+
+// funcContracts holds the source texts of the requires and ensures
+// clauses of a function, for export data and documentation.
+type funcContracts struct {
+	requires, ensures []string
+}
+
+// setContracts records the source texts of the contract clauses of fn.
+func (fn *Func) setContracts(list []*syntax.Contract) {
+	if len(list) == 0 {
+		return
+	}
+	c := new(funcContracts)
+	for _, clause := range list {
+		if clause.Ensures {
+			c.ensures = append(c.ensures, clause.Text)
+		} else {
+			c.requires = append(c.requires, clause.Text)
+		}
+	}
+	fn.contracts = c
+}
+
+// contracts type-checks the contract clauses of a function with
+// signature sig in the environment of its body. As in check statements,
+// conditions may be of any type. An ensures clause is checked in a scope
+// of its own, in which result denotes the single result of the function
+// unless a parameter or result is named result, and in which old(x) is
+// the value of x on entry to the function.
+func (checks *Checker) contracts(list []*syntax.Contract, sig *Signature) {
+	var x operand
+	for _, c := range list {
+		if !c.Ensures {
+			checks.expr(nil, &x, c.Cond)
+			continue
+		}
+
+		scope := NewScope(checks.scope, c.Pos(), syntax.EndPos(c), "ensures")
+		checks.recordScope(c, scope)
+		if sig.results.Len() == 1 && sig.scope.Lookup("result") == nil {
+			scope.insert("result", sig.results.vars[0])
+		}
+		checks.scope = scope
+		checks.inEnsures = true
+		checks.expr(nil, &x, c.Cond)
+		checks.inEnsures = false
+		checks.scope = scope.parent
+	}
+}
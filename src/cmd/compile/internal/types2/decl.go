@@ -439,6 +439,7 @@ func (checks *Checker) varDecl(obj *Var, lhs []*Var, typ, init syntax.Expr) {
 	if lhs == nil || len(lhs) == 1 {
 		assert(lhs == nil || lhs[0] == obj)
 		var x operand
+		checks.braceHint(init, obj.typ) // goo
 		checks.expr(newTarget(obj.typ, obj.name), &x, init)
 		checks.initVar(obj, &x, "variable declaration")
 		return
@@ -769,6 +770,8 @@ func (checks *Checker) funcDecl(obj *Func, decl *declInfo) {
 	fdecl := decl.fdecl
 	checks.funcType(sig, fdecl.Recv, fdecl.TParamList, fdecl.Type)
 	obj.color_ = saved
+	checks.paramDefaults(obj, fdecl)
+	obj.setContracts(fdecl.Contracts)
 
 	// Set the scope's extent to the complete "func (...) { ... }"
 	// so that Scope.Innermost works correctly.
@@ -783,7 +786,7 @@ func (checks *Checker) funcDecl(obj *Func, decl *declInfo) {
 	// (functions implemented elsewhere have no body)
 	if !checks.conf.IgnoreFuncBodies && fdecl.Body != nil {
 		checks.later(func() {
-			checks.funcBody(decl, obj.name, sig, fdecl.Body, nil)
+			checks.funcBody(decl, obj.name, sig, fdecl.Contracts, fdecl.Body, nil)
 		}).describef(obj, "func %s", obj.name)
 	}
 }
@@ -0,0 +1,219 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file implements typechecking of goo's destructuring assignments.
+
+package types2
+
+import (
+	"cmd/compile/internal/syntax"
+	"go/constant"
+	. "internal/types/errors"
+)
+
+// destructure type-checks the assignment s whose left side is the
+// destructuring pattern pat, possibly followed by an ok variable:
+//
+//	{a, b} := x         fields a and b of the struct x
+//	{a, b}, ok := m     values for the keys "a" and "b" of the map m
+//	[a, b, ...rest] = s elements of the slice or array s
+//
+// A slice must have exactly as many elements as the pattern has names,
+// or at least as many if the pattern has a rest; the noder checks this
+// when the assignment is executed.
+func (checks *Checker) destructure(s *syntax.AssignStmt, pat *syntax.PatternExpr) {
+	names := patternNames(pat)
+	lhs := append(names, syntax.UnpackListExpr(s.Lhs)[1:]...)
+	extra := len(lhs) - len(names)
+
+	if s.Op != 0 && s.Op != syntax.Def || s.Rhs == nil {
+		checks.errorf(s, MultiValAssignOp, "assignment operation %s cannot destructure", s.Op)
+		checks.useLHS(lhs...)
+		return
+	}
+	rhs := syntax.UnpackListExpr(s.Rhs)
+	if len(rhs) > 1 {
+		checks.errorf(rhs[1], WrongAssignCount, "cannot destructure more than one value")
+		checks.use(rhs[1:]...)
+	}
+
+	if s.Op == syntax.Def {
+		checks.shortVarDeclFunc(s.Pos(), lhs, endPos(rhs[len(rhs)-1]), func(lhsVars []*Var) {
+			x, types := checks.patternTypes(pat, extra, rhs[0])
+			for i, v := range lhsVars {
+				x.mode, x.typ = value, types[i]
+				checks.initVar(v, x, "assignment")
+			}
+		})
+		return
+	}
+
+	x, types := checks.patternTypes(pat, extra, rhs[0])
+	for i, lhs := range lhs {
+		x.mode, x.typ = value, types[i]
+		checks.assignVar(lhs, nil, x, "assignment")
+	}
+}
+
+// patternNames returns the names of the variables bound by the pattern
+// pat, its rest last.
+func patternNames(pat *syntax.PatternExpr) []syntax.Expr {
+	var names []syntax.Expr
+	for _, name := range pat.Names {
+		names = append(names, name)
+	}
+	if pat.Rest != nil {
+		names = append(names, pat.Rest)
+	}
+	return names
+}
+
+// patternTypes evaluates the value x destructured by the pattern pat and
+// returns it with the types of the variables bound by pat, followed by
+// the types of the extra variables after pat. The types are invalid if
+// the pattern does not match x.
+func (checks *Checker) patternTypes(pat *syntax.PatternExpr, extra int, rhs syntax.Expr) (*operand, []Type) {
+	x := new(operand)
+	checks.expr(nil, x, rhs)
+
+	n := len(pat.Names)
+	if pat.Rest != nil {
+		n++
+	}
+	types := make([]Type, n+extra)
+	for i := range types {
+		types[i] = Typ[Invalid]
+	}
+	if x.mode == invalid {
+		return x, types
+	}
+
+	u := under(x.typ)
+	if _, ok := u.(*Map); extra > 1 {
+		checks.errorf(rhs, WrongAssignCount, "assignment mismatch: %d variables after destructuring pattern, want at most one ok variable", extra)
+		return x, types
+	} else if extra == 1 && !ok {
+		checks.errorf(rhs, WrongAssignCount, "cannot destructure %s with an ok variable: not a map", x)
+		return x, types
+	}
+
+	switch u := u.(type) {
+	case *Pointer:
+		if _, ok := under(u.base).(*Struct); ok && pat.Brace {
+			checks.fieldTypes(pat, x, types)
+			return x, types
+		}
+	case *Struct:
+		if pat.Brace {
+			checks.fieldTypes(pat, x, types)
+			return x, types
+		}
+	case *Map:
+		if pat.Brace {
+			checks.keyTypes(pat, x, u, types)
+			return x, types
+		}
+	case *Slice:
+		if !pat.Brace {
+			for i := range pat.Names {
+				types[i] = u.elem
+			}
+			if pat.Rest != nil {
+				types[len(pat.Names)] = x.typ
+			}
+			return x, types
+		}
+	case *Array:
+		if !pat.Brace {
+			n := int64(len(pat.Names))
+			switch {
+			case pat.Rest == nil && n != u.len:
+				checks.errorf(pat, WrongAssignCount, "cannot destructure %s of %d elements into %d names", x, u.len, n)
+				return x, types
+			case pat.Rest != nil && n > u.len:
+				checks.errorf(pat, WrongAssignCount, "cannot destructure %s of %d elements into %d or more names", x, u.len, n)
+				return x, types
+			}
+			for i := range pat.Names {
+				types[i] = u.elem
+			}
+			if pat.Rest != nil {
+				types[len(pat.Names)] = NewSlice(u.elem)
+			}
+			return x, types
+		}
+	}
+
+	if pat.Brace {
+		checks.errorf(x, UnassignableOperand, "cannot destructure %s with {...}: not a struct or map", x)
+	} else {
+		checks.errorf(x, UnassignableOperand, "cannot destructure %s with [...]: not a slice or array", x)
+	}
+	return x, types
+}
+
+// fieldTypes sets the types of the names of the pattern pat to those of
+// the fields of the struct (or pointer to struct) x that they name.
+func (checks *Checker) fieldTypes(pat *syntax.PatternExpr, x *operand, types []Type) {
+	for i, name := range pat.Names {
+		obj, _, _ := lookupFieldOrMethod(x.typ, false, checks.pkg, name.Value, false)
+		f, _ := obj.(*Var)
+		if f == nil {
+			checks.errorf(name, MissingFieldOrMethod, "%s has no field %s", x, name.Value)
+			continue
+		}
+		types[i] = f.typ
+	}
+}
+
+// keyTypes sets the types of the names of the pattern pat to the element
+// type of the map m, which they index by their names, and the type of
+// the ok variable, if any, to bool.
+func (checks *Checker) keyTypes(pat *syntax.PatternExpr, x *operand, m *Map, types []Type) {
+	if !isString(under(m.key)) {
+		checks.errorf(x, UnassignableOperand, "cannot destructure %s with {...}: key type %s is not a string type", x, m.key)
+		return
+	}
+	for i, name := range pat.Names {
+		key := operand{mode: constant_, expr: name, typ: Typ[UntypedString], val: constant.MakeString(name.Value)}
+		checks.assignment(&key, m.key, "map key")
+		if key.mode == invalid {
+			return
+		}
+		types[i] = m.elem
+	}
+	if len(types) > len(pat.Names) {
+		types[len(pat.Names)] = universeBool
+	}
+}
+
+// rangePattern rewrites the range clause of a for statement
+//
+//	for pattern in xs { ... }
+//
+// to
+//
+//	for .pattern in xs { pattern := .pattern; ... }
+//
+// in place, for rangeStmt and the noder.
+func (checks *Checker) rangePattern(s *syntax.ForStmt, rclause *syntax.RangeClause) {
+	lhs := &rclause.Lhs
+	if list, _ := rclause.Lhs.(*syntax.ListExpr); list != nil && len(list.ElemList) == 2 {
+		lhs = &list.ElemList[1]
+	}
+	pat, _ := (*lhs).(*syntax.PatternExpr)
+	if pat == nil {
+		return
+	}
+
+	v := syntax.NewName(pat.Pos(), ".pattern")
+	*lhs = v
+
+	a := new(syntax.AssignStmt)
+	a.SetPos(pat.Pos())
+	a.Op = syntax.Def
+	a.Lhs = pat
+	a.Rhs = syntax.NewName(pat.Pos(), v.Value)
+	s.Body.List = append([]syntax.Stmt{a}, s.Body.List...)
+}
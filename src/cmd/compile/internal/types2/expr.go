@@ -12,6 +12,7 @@ import (
 	"go/constant"
 	"go/token"
 	. "internal/types/errors"
+	"strings"
 )
 
 /*
@@ -249,6 +250,10 @@ func isComparison(op syntax.Operator) bool {
 	return false
 }
 
+func isMembership(op syntax.Operator) bool {
+	return op == syntax.In || op == syntax.NotIn
+}
+
 // updateExprType updates the type of x to typ and invokes itself
 // recursively for the operands of x, depending on expression kind.
 // If typ is still an untyped and not the final type, updateExprType
@@ -303,6 +308,12 @@ func (checks *Checker) updateExprType(x syntax.Expr, typ Type, final bool) {
 	case *syntax.ParenExpr:
 		checks.updateExprType(x.X, typ, final)
 
+	case *syntax.CondExpr:
+		// The branch values have the type of the expression.
+		for _, s := range syntax.CondValues(x) {
+			checks.updateExprType(s.X, typ, final)
+		}
+
 	// case *syntax.UnaryExpr:
 	// 	// If x is a constant, the operands were constants.
 	// 	// The operands don't need to be updated since they
@@ -341,7 +352,7 @@ func (checks *Checker) updateExprType(x syntax.Expr, typ Type, final bool) {
 		if old.val != nil {
 			break // see comment for unary expressions
 		}
-		if isComparison(x.Op) {
+		if isComparison(x.Op) || isMembership(x.Op) {
 			// The result type is independent of operand types
 			// and the operand types must have final types.
 		} else if isShift(x.Op) {
@@ -613,6 +624,141 @@ Error:
 	x.mode = invalid
 }
 
+// membershipValue returns the constant result of a membership test
+// whose element was found (or not), taking negation by ∉ into account.
+func membershipValue(found bool, op syntax.Operator) constant.Value {
+	return constant.MakeBool(found != (op == syntax.NotIn))
+}
+
+// membership checks the membership test x in y (or x ∉ y).
+// The type of y determines what x is tested against:
+//
+//	slice, array: x must be assignable to the element type
+//	map:          x must be assignable to the key type
+//	string:       x must be a string (substring) or a rune or byte
+//	integer:      x must be an integer (range test 0 <= x < y)
+//
+// Like a comparison, membership yields an untyped boolean value.
+func (checks *Checker) membership(x, y *operand, op syntax.Operator) {
+	// Avoid spurious errors if any of the operands has an invalid type.
+	if !isValid(x.typ) || !isValid(y.typ) {
+		x.mode = invalid
+		return
+	}
+
+	errOp := y  // operand for which error is reported
+	cause := "" // specific error cause, if any
+	var elem Type
+	if isTypeParam(y.typ) {
+		cause = checks.sprintf("type parameter %s cannot use operator %s", y.typ, op)
+		goto Error
+	}
+
+	switch u := under(y.typ).(type) {
+	case *Slice:
+		elem = u.elem
+	case *Array:
+		elem = u.elem
+	case *Map:
+		elem = u.key
+	case *Basic:
+		switch {
+		case isString(u) && allString(x.typ):
+			// substring test
+			if x.mode == constant_ && y.mode == constant_ {
+				x.val = membershipValue(strings.Contains(constant.StringVal(y.val), constant.StringVal(x.val)), op)
+				x.typ = Typ[UntypedBool]
+				return
+			}
+			checks.convertUntyped(x, Typ[String])
+			checks.convertUntyped(y, Typ[String])
+
+		case isString(u) && allInteger(x.typ):
+			// rune test
+			if isUntyped(x.typ) {
+				checks.convertUntyped(x, universeRune)
+			} else if t := under(x.typ); !Identical(t, Typ[Int32]) && !Identical(t, Typ[Uint8]) {
+				errOp = x
+				cause = checks.sprintf("%s is not a rune or byte", x.typ)
+				goto Error
+			}
+			if x.mode == invalid {
+				return
+			}
+			if x.mode == constant_ && y.mode == constant_ {
+				r, _ := constant.Int64Val(x.val)
+				found := strings.ContainsRune(constant.StringVal(y.val), rune(r))
+				if Identical(under(x.typ), Typ[Uint8]) {
+					found = strings.IndexByte(constant.StringVal(y.val), byte(r)) >= 0
+				}
+				x.val = membershipValue(found, op)
+				x.typ = Typ[UntypedBool]
+				return
+			}
+			checks.convertUntyped(y, Typ[String])
+
+		case isInteger(u):
+			// range test
+			checks.matchTypes(x, y)
+			if x.mode == invalid {
+				return
+			}
+			if !allInteger(x.typ) {
+				errOp = x
+				cause = checks.sprintf("%s is not an integer", x.typ)
+				goto Error
+			}
+			if x.mode == constant_ && y.mode == constant_ {
+				x.val = membershipValue(constant.Sign(x.val) >= 0 && constant.Compare(x.val, token.LSS, y.val), op)
+				x.typ = Typ[UntypedBool]
+				return
+			}
+			checks.updateExprType(x.expr, Default(x.typ), true)
+			checks.updateExprType(y.expr, Default(y.typ), true)
+
+		default:
+			goto Error
+		}
+		if x.mode == invalid || y.mode == invalid {
+			x.mode = invalid
+			return
+		}
+		x.mode = value
+		x.typ = Typ[UntypedBool]
+		return
+	default:
+		goto Error
+	}
+
+	// element or key test
+	checks.assignment(x, elem, "membership test")
+	if x.mode == invalid {
+		return
+	}
+	if !Comparable(elem) {
+		errOp = x
+		cause = checks.incomparableCause(elem)
+		if cause == "" {
+			cause = checks.sprintf("%s is not comparable", elem)
+		}
+		goto Error
+	}
+	x.mode = value
+	x.typ = Typ[UntypedBool]
+	return
+
+Error:
+	if cause == "" {
+		what := compositeKind(y.typ)
+		if what == "" {
+			what = checks.sprintf("%s", y.typ)
+		}
+		cause = checks.sprintf("operator %s not defined on %s", op, what)
+	}
+	checks.errorf(errOp, UndefinedOp, invalidOp+"%s %s %s (%s)", x.expr, op, y.expr, cause)
+	x.mode = invalid
+}
+
 // incomparableCause returns a more specific cause why typ is not comparable.
 // If there is no more specific cause, the result is "".
 func (checks *Checker) incomparableCause(typ Type) string {
@@ -807,6 +953,19 @@ func (checks *Checker) binary(x *operand, e syntax.Expr, lhs, rhs syntax.Expr, o
 		return
 	}
 
+	if isMembership(op) {
+		checks.membership(x, &y, op)
+		return
+	}
+
+	if checks.operator(x, &y, e, lhs, rhs, op) {
+		return
+	}
+
+	if checks.vector(x, &y, e, lhs, rhs, op) {
+		return
+	}
+
 	checks.matchTypes(x, &y)
 	if x.mode == invalid {
 		return
@@ -1105,8 +1264,31 @@ func (checks *Checker) exprInternal(T *target, x *operand, e syntax.Expr, hint T
 			goto Error
 		}
 
+	case *syntax.CondExpr:
+		checks.condExpr(x, e)
+		if x.mode == invalid {
+			goto Error
+		}
+
+	case *syntax.CompExpr:
+		checks.compExpr(x, e)
+		if x.mode == invalid {
+			goto Error
+		}
+
 	case *syntax.FuncLit:
-		checks.funcLit(x, e)
+		if e.Lambda {
+			// the target type or composite literal element type provides the signature
+			var sig *Signature
+			if T != nil {
+				sig = T.sig
+			} else if hint != nil {
+				sig, _ = under(hint).(*Signature)
+			}
+			checks.lambda(x, e, sig, false)
+		} else {
+			checks.funcLit(x, e)
+		}
 		if x.mode == invalid {
 			goto Error
 		}
@@ -10,12 +10,18 @@ import (
 	"cmd/compile/internal/syntax"
 	"go/constant"
 	. "internal/types/errors"
+	"unicode/utf8"
 )
 
 // If e is a valid function instantiation, indexExpr returns true.
 // In that case x represents the uninstantiated function value and
 // it is the caller's responsibility to instantiate the function.
 func (checks *Checker) indexExpr(x *operand, e *syntax.IndexExpr) (isFuncInst bool) {
+	if e.OneBased {
+		checks.hashIndexExpr(x, e)
+		return false
+	}
+
 	checks.exprOrType(x, e.X, true)
 	// x may be generic
 
@@ -207,6 +213,11 @@ func (checks *Checker) indexExpr(x *operand, e *syntax.IndexExpr) (isFuncInst bo
 }
 
 func (checks *Checker) sliceExpr(x *operand, e *syntax.SliceExpr) {
+	if e.OneBased {
+		checks.hashSliceExpr(x, e)
+		return
+	}
+
 	checks.expr(nil, x, e.X)
 	if x.mode == invalid {
 		checks.use(e.Index[:]...)
@@ -364,6 +375,133 @@ L:
 	}
 }
 
+// hashIndexExpr checks the goo index expression x#i. The index counts
+// from 1 and negative indices count from the end, so x#-1 is the last
+// element. Indexing a string yields its i'th rune rather than a byte.
+// A map with integer keys is indexed by the key i-1, as x#i always was.
+func (checks *Checker) hashIndexExpr(x *operand, e *syntax.IndexExpr) {
+	length := checks.hashOperand(x, e.X, "index")
+	if x.mode == invalid {
+		checks.use(e.Index)
+		return
+	}
+
+	switch typ := under(x.typ).(type) {
+	case *Map:
+		var key operand
+		checks.expr(nil, &key, e.Index)
+		checks.assignment(&key, typ.key, "map index")
+		x.mode = mapindex
+		x.typ = typ.elem
+		x.expr = e
+		return
+	case *Basic: // string
+		x.mode = value
+		x.typ = universeRune
+	case *Array:
+		if x.mode != variable {
+			x.mode = value
+		}
+		x.typ = typ.elem
+	case *Pointer:
+		x.mode = variable
+		x.typ = under(typ.base).(*Array).elem
+	case *Slice:
+		x.mode = variable
+		x.typ = typ.elem
+	}
+
+	checks.hashIndex(e.Index, length)
+}
+
+// hashSliceExpr checks the goo slice expression x#i:j, which selects
+// the elements i through j inclusive. Strings are sliced by runes.
+func (checks *Checker) hashSliceExpr(x *operand, e *syntax.SliceExpr) {
+	length := checks.hashOperand(x, e.X, "slice")
+	if x.mode == invalid {
+		checks.use(e.Index[:]...)
+		return
+	}
+
+	switch typ := under(x.typ).(type) {
+	case *Array:
+		if x.mode != variable {
+			checks.errorf(x, NonSliceableOperand, "cannot slice unaddressable value %s", x)
+			x.mode = invalid
+			return
+		}
+		x.typ = &Slice{elem: typ.elem}
+	case *Pointer:
+		x.typ = &Slice{elem: under(typ.base).(*Array).elem}
+	}
+	x.mode = value
+
+	checks.hashIndex(e.Index[0], length)
+	checks.hashIndex(e.Index[1], length)
+}
+
+// hashOperand evaluates the operand of a # index or slice expression and
+// returns its length if it is known statically, or -1. Strings, arrays,
+// pointers to arrays and slices are accepted, and maps with integer keys
+// for an index; untyped strings become strings. If the operand cannot be
+// used with #, x.mode is set to invalid.
+func (checks *Checker) hashOperand(x *operand, e syntax.Expr, what string) int64 {
+	checks.expr(nil, x, e)
+	if x.mode == invalid {
+		return -1
+	}
+
+	switch typ := under(x.typ).(type) {
+	case *Basic:
+		if isString(typ) {
+			length := int64(-1)
+			if x.mode == constant_ {
+				length = int64(utf8.RuneCountInString(constant.StringVal(x.val)))
+			}
+			checks.convertUntyped(x, Typ[String])
+			return length
+		}
+	case *Array:
+		return typ.len
+	case *Pointer:
+		if typ, _ := under(typ.base).(*Array); typ != nil {
+			return typ.len
+		}
+	case *Slice:
+		return -1
+	case *Map:
+		if what == "index" && allInteger(typ.key) {
+			return -1
+		}
+	}
+
+	checks.errorf(x, NonSliceableOperand, "cannot %s %s with #", what, x)
+	x.mode = invalid
+	return -1
+}
+
+// hashIndex checks a # index, which must be an integer. Constant indices
+// must not be zero and, if length >= 0, must lie within -length..length.
+func (checks *Checker) hashIndex(index syntax.Expr, length int64) {
+	var x operand
+	checks.expr(nil, &x, index)
+	if !checks.isValidIndex(&x, InvalidIndex, "index", true) {
+		return
+	}
+
+	if x.mode != constant_ || x.val.Kind() == constant.Unknown {
+		return
+	}
+
+	v, _ := constant.Int64Val(x.val)
+	switch {
+	case v == 0:
+		checks.errorf(&x, InvalidIndex, invalidArg+"index #0 is invalid (# indices start at 1)")
+	case length >= 0 && (v > length || -v > length):
+		checks.errorf(&x, InvalidIndex, invalidArg+"index #%s out of bounds [1:%d]", x.val.String(), length)
+	}
+}
+
 // singleIndex returns the (single) index from the index expression e.
 // If the index is missing, or if there are multiple indices, an error
 // is reported and the result is nil.
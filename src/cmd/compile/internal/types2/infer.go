@@ -153,6 +153,9 @@ func (checks *Checker) infer(pos syntax.Pos, tparams []*TypeParam, targs []Type,
 	// indices of generic parameters with untyped arguments, for later use
 	var untyped []int
 
+	// indices of lambda arguments, which are typed once their parameter types are known
+	var lambdas []int
+
 	// --- 1 ---
 	// use information from function arguments
 
@@ -169,6 +172,10 @@ func (checks *Checker) infer(pos syntax.Pos, tparams []*TypeParam, targs []Type,
 			// TODO(gri) determine if we still need this check
 			continue
 		}
+		if arg.mode == lambda {
+			lambdas = append(lambdas, i)
+			continue
+		}
 		par := params.At(i)
 		if isParameterized(tparams, par.typ) || isParameterized(tparams, arg.typ) {
 			// Function parameters are always typed. Arguments may be untyped.
@@ -307,6 +314,36 @@ func (checks *Checker) infer(pos syntax.Pos, tparams []*TypeParam, targs []Type,
 			}
 		}
 
+		// Lambda arguments are typed as soon as the types of their parameters
+		// are known; their result types may provide further type arguments.
+		if len(lambdas) > 0 {
+			smap := makeSubstMap(tparams, u.inferred(tparams))
+			n := 0
+			for _, index := range lambdas {
+				arg := args[index]
+				par := params.At(index)
+				sig, _ := under(checks.subst(nopos, par.typ, smap, nil, checks.context())).(*Signature)
+				if sig == nil || isParameterized(tparams, sig.params) {
+					lambdas[n] = index
+					n++
+					continue
+				}
+				if traceInference {
+					u.tracef("-> type lambda argument %s with %s", arg.expr, sig)
+				}
+				checks.lambda(arg, syntax.Unparen(arg.expr).(*syntax.FuncLit), sig, isParameterized(tparams, sig.results))
+				if arg.mode == invalid {
+					return nil
+				}
+				checks.record(arg)
+				if !u.unify(par.typ, arg.typ, assign) {
+					errorf(par.typ, arg.typ, arg)
+					return nil
+				}
+			}
+			lambdas = lambdas[:n]
+		}
+
 		if u.unknowns() == nn {
 			break // no progress
 		}
@@ -251,6 +251,9 @@ func (checks *Checker) blockBranches(all *Scope, parent *block, lstmt *syntax.La
 
 		case *syntax.ForStmt:
 			stmtBranches(lstmt, s.Body)
+
+		case *syntax.WithStmt:
+			stmtBranches(lstmt, s.Body)
 		}
 	}
 
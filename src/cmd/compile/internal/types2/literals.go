@@ -93,7 +93,7 @@ func (checks *Checker) funcLit(x *operand, e *syntax.FuncLit) {
 			// body refers. Instead, type-check as soon as possible,
 			// but before the enclosing scope contents changes (go.dev/issue/22992).
 			checks.later(func() {
-				checks.funcBody(decl, "<function literal>", sig, e.Body, iota)
+				checks.funcBody(decl, "<function literal>", sig, nil, e.Body, iota)
 			}).describef(e, "func literal")
 		}
 		x.mode = value
@@ -104,11 +104,155 @@ func (checks *Checker) funcLit(x *operand, e *syntax.FuncLit) {
 	}
 }
 
+// lambda type-checks the compact function literal e (x => e). Its
+// signature comes from the function type T the lambda is assigned or
+// passed to: the parameter types must be known, and so are the result
+// types unless inferResult is set, in which case T's results are still
+// to be inferred and an expression body determines them instead. A
+// lambda without target must not have parameters; its result is the
+// type of its body.
+func (checks *Checker) lambda(x *operand, e *syntax.FuncLit, T *Signature, inferResult bool) {
+	params := e.Type.ParamList
+	switch {
+	case T == nil:
+		if len(params) > 0 {
+			checks.errorf(e, CannotInferTypeArgs, "cannot infer lambda parameter types (lambda not assigned to a function type)")
+			x.mode = invalid
+			return
+		}
+		inferResult = true
+	case T.params.Len() != len(params):
+		checks.errorf(e, WrongArgCount, "lambda has %d parameters but %s has %d", len(params), T, T.params.Len())
+		x.mode = invalid
+		return
+	}
+
+	sig := checks.lambdaType(e, T, inferResult)
+	x.mode = value
+	x.typ = sig
+	x.expr = e
+
+	// An expression body determines the result type if it is not known,
+	// and a call used as body of a lambda without results is not returned.
+	var ret *syntax.ReturnStmt
+	if list := e.Body.List; len(list) == 1 {
+		ret, _ = list[0].(*syntax.ReturnStmt)
+	}
+	if ret != nil && ret.Results != nil {
+		if inferResult {
+			checks.lambdaResult(sig, e.Body)
+			return
+		}
+		if _, ok := syntax.Unparen(ret.Results).(*syntax.CallExpr); ok && sig.results.Len() == 0 {
+			lambdaExprStmt(e.Body)
+		}
+	}
+
+	if !checks.conf.IgnoreFuncBodies {
+		decl := checks.decl
+		iota := checks.iota
+		checks.later(func() {
+			checks.funcBody(decl, "<lambda>", sig, nil, e.Body, iota)
+		}).describef(e, "lambda")
+	}
+}
+
+// lambdaType returns the signature of lambda e with the parameter types
+// of T, and its result types if inferResult is not set. The parameters
+// are declared in the lambda's function scope.
+func (checks *Checker) lambdaType(e *syntax.FuncLit, T *Signature, inferResult bool) *Signature {
+	sig := new(Signature)
+	checks.openScope(e.Type, "function")
+	checks.scope.isFunc = true
+	checks.recordScope(e.Type, checks.scope)
+	sig.scope = checks.scope
+	defer checks.closeScope()
+	// see funcLit
+	sig.scope.pos = e.Pos()
+	sig.scope.end = endPos(e)
+
+	var params, results []*Var
+	for i, f := range e.Type.ParamList {
+		par := newVar(ParamVar, f.Name.Pos(), checks.pkg, f.Name.Value, T.params.vars[i].typ)
+		checks.declare(checks.scope, f.Name, par, e.Body.Pos())
+		params = append(params, par)
+	}
+	if T != nil {
+		sig.variadic = T.variadic
+		if !inferResult {
+			for i := 0; i < T.results.Len(); i++ {
+				results = append(results, newVar(ResultVar, e.Pos(), checks.pkg, "", T.results.At(i).typ))
+			}
+		}
+	}
+	sig.params = NewTuple(params...)
+	sig.results = NewTuple(results...)
+	return sig
+}
+
+// lambdaResult checks the expression body of a lambda right away and
+// sets the lambda's result type to the (default) type of the expression.
+// A call without result makes a lambda without result.
+func (checks *Checker) lambdaResult(sig *Signature, body *syntax.BlockStmt) {
+	// set up the function environment as funcBody does
+	defer func(env environment, indent int) {
+		checks.environment = env
+		checks.indent = indent
+	}(checks.environment, checks.indent)
+	checks.environment = environment{
+		decl:    checks.decl,
+		scope:   sig.scope,
+		version: checks.version,
+		iota:    checks.iota,
+		sig:     sig,
+	}
+
+	ret := body.List[0].(*syntax.ReturnStmt)
+	var y operand
+	checks.rawExpr(nil, &y, ret.Results, nil, false)
+	if y.mode == novalue {
+		// lambda without result
+		lambdaExprStmt(body)
+		checks.usage(sig.scope)
+		return
+	}
+	checks.exclude(&y, 1<<builtin|1<<typexpr)
+	checks.singleValue(&y)
+	if y.mode != invalid {
+		typ := Default(y.typ)
+		sig.results = NewTuple(newVar(ResultVar, ret.Pos(), checks.pkg, "", typ))
+		checks.assignment(&y, typ, "return statement")
+	}
+
+	checks.usage(sig.scope)
+}
+
+// lambdaExprStmt turns the lambda body { return f(...) } into { f(...) }
+// for lambdas without result.
+func lambdaExprStmt(body *syntax.BlockStmt) {
+	ret := body.List[0].(*syntax.ReturnStmt)
+	s := new(syntax.ExprStmt)
+	s.SetPos(ret.Pos())
+	s.X = ret.Results
+	body.List[0] = s
+}
+
 func (checks *Checker) compositeLit(x *operand, e *syntax.CompositeLit, hint Type) {
 	var typ, base Type
 	var isElem bool // true if composite literal is an element of an enclosing composite literal
 
+	checks.spreadHint(e) // goo
 	switch {
+	case e.Brace && checks.braceType(e, checks.braceHints[e]):
+		// goo: the brace literal takes the type expected by its context
+		typ = checks.braceHints[e]
+		base = typ
+
+	case e.List && e.Type == nil:
+		// goo: the list literal takes the type found by spreadHint
+		typ = checks.braceHints[e]
+		base = typ
+
 	case e.Type != nil:
 		// composite literal type present - use it
 		// [...]T array types may only appear with composite literals.
@@ -159,24 +303,46 @@ func (checks *Checker) compositeLit(x *operand, e *syntax.CompositeLit, hint Typ
 		// we mention the struct type only if it clarifies the error
 		// (e.g., a duplicate field error doesn't need the struct type).
 		fields := utyp.fields
-		if _, ok := e.ElemList[0].(*syntax.KeyValueExpr); ok {
+		if keyedElts(e.ElemList) {
 			// all elements must have keys
 			visited := make([]bool, len(fields))
 			for _, e := range e.ElemList {
+				// goo: the fields of {...x, f: v} start out as those of x
+				if d, _ := e.(*syntax.DotsType); d != nil {
+					checks.spreadStruct(x, d, base)
+					continue
+				}
 				kv, _ := e.(*syntax.KeyValueExpr)
 				if kv == nil {
 					checks.error(e, MixedStructLit, "mixture of field:value and value elements in struct literal")
 					continue
 				}
 				key, _ := kv.Key.(*syntax.Name)
+				i := -1
+				if key != nil {
+					i = fieldIndex(fields, checks.pkg, key.Value, false)
+					// goo: like brace literals, elided element literals
+					// match fields regardless of case
+					if i < 0 && isElem {
+						i = fieldIndex(fields, checks.pkg, key.Value, true)
+					}
+				}
 				// do all possible checks early (before exiting due to errors)
 				// so we don't drop information on the floor
-				checks.expr(nil, x, kv.Value)
+				if i >= 0 {
+					// goo: brace literal values take the types of their fields
+					checks.braceHint(kv.Value, fields[i].typ)
+				}
+				if i >= 0 && isElem {
+					// goo: so do elided literals inside elided literals
+					checks.exprWithHint(x, kv.Value, fields[i].typ)
+				} else {
+					checks.expr(nil, x, kv.Value)
+				}
 				if key == nil {
 					checks.errorf(kv, InvalidLitField, "invalid field name %s in struct literal", kv.Key)
 					continue
 				}
-				i := fieldIndex(fields, checks.pkg, key.Value, false)
 				if i < 0 {
 					var alt Object
 					if j := fieldIndex(fields, checks.pkg, key.Value, true); j >= 0 {
@@ -204,6 +370,11 @@ func (checks *Checker) compositeLit(x *operand, e *syntax.CompositeLit, hint Typ
 					checks.error(kv, MixedStructLit, "mixture of field:value and value elements in struct literal")
 					continue
 				}
+				if d, _ := e.(*syntax.DotsType); d != nil {
+					checks.error(d, MixedStructLit, "mixture of spread and value elements in struct literal")
+					checks.use(d.Elem)
+					continue
+				}
 				checks.expr(nil, x, e)
 				if i >= len(fields) {
 					checks.errorf(x, InvalidStructLit, "too many values in struct literal of type %s", base)
@@ -261,6 +432,10 @@ func (checks *Checker) compositeLit(x *operand, e *syntax.CompositeLit, hint Typ
 			x.mode = invalid
 			return
 		}
+		if hasSpread(e.ElemList) {
+			checks.spreadElts(e.ElemList, utyp.elem) // goo: [...a, 4]
+			break
+		}
 		checks.indexedElts(e.ElemList, utyp.elem, -1)
 
 	case *Map:
@@ -277,6 +452,11 @@ func (checks *Checker) compositeLit(x *operand, e *syntax.CompositeLit, hint Typ
 		keyIsInterface := isNonTypeParamInterface(utyp.key)
 		visited := make(map[any][]Type, len(e.ElemList))
 		for _, e := range e.ElemList {
+			// goo: the entries of {...m, k: v} start out as those of m
+			if d, _ := e.(*syntax.DotsType); d != nil {
+				checks.spreadMap(x, d, utyp)
+				continue
+			}
 			kv, _ := e.(*syntax.KeyValueExpr)
 			if kv == nil {
 				checks.error(e, MissingLitKey, "missing key in map literal")
@@ -307,6 +487,7 @@ func (checks *Checker) compositeLit(x *operand, e *syntax.CompositeLit, hint Typ
 					continue
 				}
 			}
+			checks.braceHint(kv.Value, utyp.elem) // goo: map[string]Config{"a": {port: 1}}
 			checks.exprWithHint(x, kv.Value, utyp.elem)
 			checks.assignment(x, utyp.elem, "map literal")
 		}
@@ -321,6 +502,9 @@ func (checks *Checker) compositeLit(x *operand, e *syntax.CompositeLit, hint Typ
 				// forward anyway can lead to other errors. Give up instead.
 				e = kv.Value
 			}
+			if d, _ := e.(*syntax.DotsType); d != nil {
+				e = d.Elem
+			}
 			checks.use(e)
 		}
 		// if utyp is invalid, an error was reported before
@@ -384,6 +568,7 @@ func (checks *Checker) indexedElts(elts []syntax.Expr, typ Type, length int64) i
 
 		// check element against composite literal element type
 		var x operand
+		checks.braceHint(eval, typ) // goo: []Config{{port: 1}}
 		checks.exprWithHint(&x, eval, typ)
 		checks.assignment(&x, typ, "array or slice literal")
 	}
@@ -435,8 +435,18 @@ func (*Var) isDependency() {} // a variable may be a dependency of an initializa
 // An abstract method may belong to many interfaces due to embedding.
 type Func struct {
 	object
-	hasPtrRecv_ bool  // only valid for methods that don't have a type yet; use hasPtrRecv() to read
-	origin      *Func // if non-nil, the Func from which this one was instantiated
+	hasPtrRecv_ bool            // only valid for methods that don't have a type yet; use hasPtrRecv() to read
+	origin      *Func           // if non-nil, the Func from which this one was instantiated
+	defaults    []*paramDefault // goo: default parameter values indexed by parameter, or nil
+	contracts   *funcContracts  // goo: requires and ensures clauses, or nil
+}
+
+// A paramDefault is the default value of a function parameter.
+// Defaults are evaluated at each call site that omits the argument.
+type paramDefault struct {
+	expr  syntax.Expr    // default value; nil if imported
+	val   constant.Value // constant value, or nil
+	isNil bool           // the default is nil
 }
 
 // NewFunc returns a new function with the given signature, representing
@@ -451,7 +461,7 @@ func NewFunc(pos syntax.Pos, pkg *Package, name string, sig *Signature) *Func {
 		// as this would violate object.{Type,color} invariants.
 		// TODO(adonovan): propose to disallow NewFunc with nil *Signature.
 	}
-	return &Func{object{nil, pos, pkg, name, typ, 0, colorFor(typ), nopos}, false, nil}
+	return &Func{object{nil, pos, pkg, name, typ, 0, colorFor(typ), nopos}, false, nil, nil, nil}
 }
 
 // Signature returns the signature (type) of the function or method.
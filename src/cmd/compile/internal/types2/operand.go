@@ -30,6 +30,7 @@ const (
 	commaok                      // like value, but operand may be used in a comma,ok expression
 	commaerr                     // like commaok, but second value is error, not boolean
 	cgofunc                      // operand is a cgo function
+	lambda                       // operand is a lambda argument whose type depends on the called function
 )
 
 var operandModeString = [...]string{
@@ -45,6 +46,7 @@ var operandModeString = [...]string{
 	commaok:   "comma, ok expression",
 	commaerr:  "comma, error expression",
 	cgofunc:   "cgo function",
+	lambda:    "lambda",
 }
 
 // An operand represents an intermediate value during type checking.
@@ -0,0 +1,242 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file implements typechecking of goo's operator methods: on named
+// types other than numbers, strings and interfaces, the arithmetic and
+// comparison operators call methods of fixed names, so that a + b*c
+// means a.Add(b.Mul(c)).
+
+package types2
+
+import (
+	"cmd/compile/internal/syntax"
+	. "internal/types/errors"
+	"strings"
+)
+
+// operatorMethods lists the names of the methods an operator calls, in
+// order of preference. For a type T, an arithmetic method M has one of
+// the signatures
+//
+//	func (T) M(T) T     // x op y is x.M(y)
+//	func (T) M(T, T) T  // x op y is new(U).M(x, y), for T = *U as in math/big
+//
+// Equal has the signature func (T) Equal(T) bool, and Compare and Cmp
+// have the signature func (T) Compare(T) int. x < y is x.Compare(y) < 0.
+var operatorMethods = map[syntax.Operator][]string{
+	syntax.Add: {"Add"},
+	syntax.Sub: {"Sub"},
+	syntax.Mul: {"Mul"},
+	syntax.Div: {"Div"},
+	syntax.Rem: {"Rem", "Mod"},
+	syntax.Eql: {"Equal", "Compare", "Cmp"},
+	syntax.Neq: {"Equal", "Compare", "Cmp"},
+	syntax.Lss: {"Compare", "Cmp"},
+	syntax.Leq: {"Compare", "Cmp"},
+	syntax.Gtr: {"Compare", "Cmp"},
+	syntax.Geq: {"Compare", "Cmp"},
+}
+
+// hasOperatorMethods reports whether the operators on values of type t
+// call operator methods: t is a named type, or a pointer to one, that is
+// not a basic type or an interface.
+func hasOperatorMethods(t Type) bool {
+	if p, _ := Unalias(t).(*Pointer); p != nil {
+		t = p.base
+	}
+	if asNamed(t) == nil {
+		return false
+	}
+	switch under(t).(type) {
+	case *Basic, *Interface:
+		return false
+	}
+	return true
+}
+
+// operatorMethod looks up the method name of type t. It returns the
+// method expression selection for the method if it has the signature
+// operatorMethods requires, and otherwise the method, if any, that has
+// the name but not the signature.
+func (checks *Checker) operatorMethod(t Type, name string) (*Selection, *Func) {
+	obj, index, indirect := LookupFieldOrMethod(t, false, checks.pkg, name)
+	m, _ := obj.(*Func)
+	if m == nil {
+		return nil, nil
+	}
+	sig := m.Signature()
+	params := sig.params.Len()
+	if sig.variadic || sig.results.Len() != 1 {
+		return nil, m
+	}
+	for i := range params {
+		if !Identical(sig.params.vars[i].typ, t) {
+			return nil, m
+		}
+	}
+	var ok bool
+	res := sig.results.vars[0].typ
+	switch name {
+	case "Equal":
+		ok = params == 1 && Identical(res, Typ[Bool])
+	case "Compare", "Cmp":
+		ok = params == 1 && Identical(res, Typ[Int])
+	default:
+		ok = Identical(res, t) && (params == 1 || params == 2 && isPointer(t))
+	}
+	if !ok {
+		return nil, m
+	}
+	return &Selection{MethodExpr, t, m, index, indirect}, nil
+}
+
+// operatorSignature describes the method signature op requires of type t
+// for use in error messages.
+func (checks *Checker) operatorSignature(t Type, op syntax.Operator) string {
+	switch op {
+	case syntax.Eql, syntax.Neq:
+		return checks.sprintf("Equal(%s) bool or Compare(%s) int", t, t)
+	case syntax.Lss, syntax.Leq, syntax.Gtr, syntax.Geq:
+		return checks.sprintf("Compare(%s) int", t)
+	}
+	return checks.sprintf("%s(%s) %s", operatorMethods[op][0], t, t)
+}
+
+// operator type-checks the binary operation x op y if x is of a type
+// with operator methods, and reports whether it did. y must have the
+// same type. The method called is recorded in Info.Operators. == and !=
+// without a method keep their meaning, and so do they in .go files for
+// comparable types so that Go code behaves as before; .goo files
+// prefer the methods.
+func (checks *Checker) operator(x, y *operand, e, lhs, rhs syntax.Expr, op syntax.Operator) bool {
+	names := operatorMethods[op]
+	if names == nil || !hasOperatorMethods(x.typ) {
+		return false
+	}
+	eq := op == syntax.Eql || op == syntax.Neq
+	if eq && (!Identical(x.typ, y.typ) || Comparable(x.typ) && !strings.HasSuffix(lhs.Pos().RelFilename(), ".goo")) {
+		return false // comparison with nil, or a Go comparison
+	}
+
+	var sel *Selection
+	var wrong *Func
+	for _, name := range names {
+		s, m := checks.operatorMethod(x.typ, name)
+		if s != nil {
+			sel = s
+			break
+		}
+		if wrong == nil {
+			wrong = m
+		}
+	}
+	switch {
+	case sel == nil && eq:
+		return false
+	case sel == nil && wrong != nil:
+		checks.errorf(x, UndefinedOp, invalidOp+"%s %s %s (operator %s not defined on %s; method %s has signature %s, want %s)", x.expr, op, y.expr, op, compositeKind(x.typ), wrong.name, wrong.typ, checks.operatorSignature(x.typ, op))
+		x.mode = invalid
+		return true
+	case sel == nil && (vectorElem(x.typ) != nil || !strings.HasSuffix(lhs.Pos().RelFilename(), ".goo")):
+		return false // elementwise arithmetic, or the Go error
+	case sel == nil:
+		checks.errorf(x, UndefinedOp, invalidOp+"%s %s %s (operator %s not defined on %s; missing method %s)", x.expr, op, y.expr, op, compositeKind(x.typ), checks.operatorSignature(x.typ, op))
+		x.mode = invalid
+		return true
+	}
+
+	if !Identical(x.typ, y.typ) {
+		if e != nil {
+			checks.errorf(x, MismatchedTypes, invalidOp+"%s (mismatched types %s and %s)", e, x.typ, y.typ)
+		} else {
+			checks.errorf(x, MismatchedTypes, invalidOp+"%s %s= %s (mismatched types %s and %s)", lhs, op, rhs, x.typ, y.typ)
+		}
+		x.mode = invalid
+		return true
+	}
+
+	// An assignment operation is recorded by its left-hand side.
+	key := e
+	if key == nil {
+		key = lhs
+	}
+	fn, _ := checks.methodExprOf(lhs.Pos(), sel)
+	checks.recordOperator(key, fn)
+	x.mode = value
+	if isComparison(op) {
+		x.typ = Typ[UntypedBool]
+	}
+	return true
+}
+
+// methodExprOf returns a new method expression for sel and its type,
+// with both recorded. The expression stands for code that goo derives
+// from operators and calls; its receiver type is not spelled out.
+func (checks *Checker) methodExprOf(pos syntax.Pos, sel *Selection) (*syntax.SelectorExpr, Type) {
+	m := sel.obj.(*Func)
+	e := &syntax.SelectorExpr{X: syntax.NewName(pos, "_"), Sel: syntax.NewName(pos, m.name)}
+	e.SetPos(pos)
+	checks.recordTypeAndValue(e.X, typexpr, sel.recv, nil)
+	checks.recordSelection(e, MethodExpr, sel.recv, m, sel.index, sel.indirect)
+
+	sig := m.Signature()
+	params := []*Var{NewParam(pos, nil, "", sel.recv)}
+	for _, p := range sig.params.vars {
+		params = append(params, NewParam(pos, nil, "", p.typ))
+	}
+	typ := &Signature{params: NewTuple(params...), results: sig.results}
+	checks.recordTypeAndValue(e, value, typ, nil)
+	return e, typ
+}
+
+// orderedFuncs maps the functions of package slices that order elements
+// with < to their variants that take a comparison function.
+var orderedFuncs = map[string]string{
+	"Sort":         "SortFunc",
+	"IsSorted":     "IsSortedFunc",
+	"Min":          "MinFunc",
+	"Max":          "MaxFunc",
+	"BinarySearch": "BinarySearchFunc",
+}
+
+// orderByMethod rewrites a call of one of the orderedFuncs, with a slice
+// of elements of type T ordered by an operator method as first argument,
+// to a call of the variant taking the method: slices.Sort(xs) becomes
+// slices.SortFunc(xs, T.Compare). It returns the signature of the new
+// function, and appends the method expression to args and the call, or
+// returns nil if the call is not one of these.
+func (checks *Checker) orderByMethod(call *syntax.CallExpr, args *[]*operand) *Signature {
+	fun, _ := syntax.Unparen(call.Fun).(*syntax.SelectorExpr)
+	if fun == nil || len(*args) == 0 || (*args)[0].mode == invalid {
+		return nil
+	}
+	pkg, _ := fun.X.(*syntax.Name)
+	name := orderedFuncs[fun.Sel.Value]
+	if pkg == nil || name == "" {
+		return nil
+	}
+	pkgName, _ := checks.lookup(pkg.Value).(*PkgName)
+	if pkgName == nil || pkgName.imported.path != "slices" {
+		return nil
+	}
+	s, _ := CoreType((*args)[0].typ).(*Slice)
+	if s == nil || allOrdered(s.elem) || !hasOperatorMethods(s.elem) {
+		return nil
+	}
+	sel, _ := checks.operatorMethod(s.elem, "Compare")
+	if sel == nil {
+		sel, _ = checks.operatorMethod(s.elem, "Cmp")
+	}
+	fn, _ := pkgName.imported.scope.Lookup(name).(*Func)
+	if sel == nil || fn == nil {
+		return nil
+	}
+
+	fun.Sel.Value = name
+	checks.recordUse(fun.Sel, fn)
+	cmp, typ := checks.methodExprOf(call.Pos(), sel)
+	call.ArgList = append(call.ArgList, cmp)
+	*args = append(*args, &operand{mode: value, expr: cmp, typ: typ})
+	return fn.Signature()
+}
@@ -35,6 +35,26 @@ func (checks *Checker) rangeStmt(inner stmtContext, rangeStmt *syntax.ForStmt, n
 	checks.hasCallOrRecv = false
 	checks.expr(nil, &x, rangeVar)
 
+	// goo: the single variable of "for x in xs" ranges over the elements,
+	// not the indices, of slices, arrays and strings. Rewrite the clause
+	// to "for _, x := range xs" in place, for the noder.
+	if rclause, _ := rangeStmt.Init.(*syntax.RangeClause); rclause != nil && rclause.In && sValue == nil && x.mode != invalid {
+		elems := false
+		switch t := arrayPtrDeref(under(x.typ)).(type) {
+		case *Basic:
+			elems = isString(t)
+		case *Slice, *Array:
+			elems = true
+		}
+		if elems {
+			blank := syntax.NewName(sKey.Pos(), "_")
+			list := &syntax.ListExpr{ElemList: []syntax.Expr{blank, sKey}}
+			list.SetPos(sKey.Pos())
+			rclause.Lhs = list
+			sKey, sValue = blank, sKey
+		}
+	}
+
 	if isTypes2 && x.mode != invalid && sValue == nil && !checks.hasCallOrRecv {
 		if t, ok := arrayPtrDeref(under(x.typ)).(*Array); ok {
 			for {
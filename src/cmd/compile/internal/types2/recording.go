@@ -166,6 +166,13 @@ func (checks *Checker) recordSelection(x *syntax.SelectorExpr, kind SelectionKin
 	}
 }
 
+func (checks *Checker) recordOperator(x syntax.Node, fn *syntax.SelectorExpr) {
+	assert(x != nil && fn != nil)
+	if m := checks.Operators; m != nil {
+		m[x] = fn
+	}
+}
+
 func (checks *Checker) recordScope(node syntax.Node, scope *Scope) {
 	assert(node != nil)
 	assert(scope != nil)
@@ -220,6 +220,8 @@ func (checks *Checker) collectObjects() {
 	}
 	var methods []methodInfo // collected methods with valid receivers and non-blank _ names
 
+	checks.topLevelMains()
+
 	fileScopes := make([]*Scope, len(checks.files)) // fileScopes[i] corresponds to check.files[i]
 	for fileNo, file := range checks.files {
 		checks.version = asGoVersion(checks.versions[file.Pos().FileBase()])
@@ -237,11 +239,6 @@ func (checks *Checker) collectObjects() {
 		// we get "." as the directory which is what we would want.
 		fileDir := dir(file.PkgName.Pos().RelFilename()) // TODO(gri) should this be filename?
 
-		// Auto-inject import "fmt" for .goo files if file uses printf but doesn't import fmt
-		if strings.HasSuffix(file.PkgName.Pos().RelFilename(), ".goo") {
-			injectFmtImportIfNeeded(file, fileDir, checks, fileScope)
-		}
-
 		first := -1                // index of first ConstDecl in the current group, or -1
 		var last *syntax.ConstDecl // last ConstDecl with init expressions, or nil
 		for index, decl := range file.DeclList {
@@ -513,6 +510,41 @@ func (checks *Checker) collectObjects() {
 	}
 }
 
+// goo: topLevelMains turns the implicit main functions, which hold the
+// top-level statements of files of package main, into init functions
+// if the package declares main, so that the statements run in file
+// order before main. Without a declared main, only one file may have
+// top-level statements.
+func (checks *Checker) topLevelMains() {
+	if checks.pkg.name != "main" {
+		return
+	}
+	var implicit []*syntax.FuncDecl
+	hasMain := false
+	for _, file := range checks.files {
+		for _, decl := range file.DeclList {
+			if d, _ := decl.(*syntax.FuncDecl); d != nil && d.Recv == nil && d.Name.Value == "main" {
+				if d.TopLevel {
+					implicit = append(implicit, d)
+				} else {
+					hasMain = true
+				}
+			}
+		}
+	}
+	for i, d := range implicit {
+		if i > 0 && !hasMain {
+			err := checks.newError(InvalidMainDecl)
+			err.addf(d.Body.List[0], "top-level statements in more than one file of package main without func main")
+			err.addf(implicit[0].Body.List[0], "other top-level statements")
+			err.report()
+		}
+		if hasMain || i > 0 {
+			d.Name.Value = "init"
+		}
+	}
+}
+
 // unpackRecv unpacks a receiver type expression and returns its components: ptr indicates
 // whether rtyp is a pointer receiver, base is the receiver base type expression stripped
 // of its type parameters (if any), and tparams are its type parameter names, if any. The
@@ -755,85 +787,42 @@ func dir(path string) string {
 	return "."
 }
 
-// injectFmtImportIfNeeded scans the file for put or printf usage and automatically
-// injects import "fmt" if put or printf is used but fmt is not already imported.
-func injectFmtImportIfNeeded(file *syntax.File, fileDir string, checks *Checker, fileScope *Scope) {
-	needsFmtImport := false
-	hasFmtImport := false
-
-	// First check if fmt is already imported
-	for _, decl := range file.DeclList {
-		if imp, ok := decl.(*syntax.ImportDecl); ok && imp.Path != nil {
-			if path, _ := strconv.Unquote(imp.Path.Value); path == "fmt" {
-				hasFmtImport = true
-				break
-			}
-		}
+// autoImport imports the package named by the undeclared qualifier ident
+// of a .goo file, per Config.AutoImports, and declares it in the scope of
+// the file. It reports an error and returns nil if ident names several
+// packages or the import fails.
+func (checks *Checker) autoImport(ident *syntax.Name) *PkgName {
+	paths := checks.conf.AutoImports[ident.Value]
+	if len(paths) > 1 {
+		checks.errorf(ident, UndeclaredName, "ambiguous package name %s: import one of %s explicitly", ident.Value, strings.Join(paths, ", "))
+		return nil
 	}
-
-	// If fmt not imported, scan for printf usage
-	if !hasFmtImport {
-		syntax.Inspect(file, func(n syntax.Node) bool {
-			if call, ok := n.(*syntax.CallExpr); ok {
-				if name, ok := call.Fun.(*syntax.Name); ok &&
-					(name.Value == "printf" || name.Value == "put" || name.Value == "prints") {
-					needsFmtImport = true
-					return false // found put or printf, stop searching
-				}
-			}
-			return true
-		})
+	fileScope := checks.scope
+	for fileScope.parent != checks.pkg.scope {
+		fileScope = fileScope.parent
 	}
+	imp := checks.importPackage(ident.Pos(), paths[0], dir(ident.Pos().RelFilename()))
+	if imp == nil {
+		return nil
+	}
+	pkgName := NewPkgName(ident.Pos(), checks.pkg, imp.name, imp)
+	checks.declare(fileScope, nil, pkgName, nopos)
+	if !slices.Contains(checks.pkg.imports, imp) {
+		checks.pkg.imports = append(checks.pkg.imports, imp)
+	}
+	checks.imports = append(checks.imports, pkgName)
+	return pkgName
+}
 
-	// Inject import "fmt" by processing it exactly like a regular import
-	if needsFmtImport {
-		// Create synthetic import declaration
-		importPath := &syntax.BasicLit{
-			Value: `"fmt"`,
-			Kind:  syntax.StringLit,
-		}
-		importPath.SetPos(file.PkgName.Pos())
-
-		fmtImport := &syntax.ImportDecl{
-			Path: importPath,
-		}
-		fmtImport.SetPos(importPath.Pos())
-
-		// Process this import using the exact same logic as regular imports
-		path, err := validatedImportPath(importPath.Value)
-		if err != nil {
-			checks.errorf(importPath, BadImportPath, "auto-import failed: invalid path (%s)", err)
-			return
-		}
-
-		imp := checks.importPackage(importPath.Pos(), path, fileDir)
-		if imp == nil {
-			// Import failed - don't inject the import declaration
-			return
-		}
-
-		// Create package name - use the imported package name
-		pkgName := NewPkgName(fmtImport.Pos(), checks.pkg, imp.name, imp)
-
-		// Declare the imported package in file scope
-		checks.declare(fileScope, nil, pkgName, nopos)
-
-		// Add to package imports list (avoid duplicates)
-		found := false
-		for _, existing := range checks.pkg.imports {
-			if existing == imp {
-				found = true
-				break
-			}
-		}
-		if !found {
-			checks.pkg.imports = append(checks.pkg.imports, imp)
-		}
-
-		// Add to checks.imports for consistency
-		checks.imports = append(checks.imports, pkgName)
-
-		// NOTE: Don't add to file.DeclList - just handle the import programmatically
-		// The AST transformation in call.go will handle the printf -> fmt.Printf conversion
+// autoImportFmt makes fmt available to the call of fmt that the goo print
+// builtin name is rewritten into, importing it per Config.AutoImports if
+// it is not declared. It reports whether fmt is available.
+func (checks *Checker) autoImportFmt(name *syntax.Name) bool {
+	if checks.lookup("fmt") != nil {
+		return true
+	}
+	if checks.conf.AutoImports["fmt"] == nil || !strings.HasSuffix(name.Pos().RelFilename(), ".goo") {
+		return false
 	}
+	return checks.autoImport(syntax.NewName(name.Pos(), "fmt")) != nil
 }
@@ -52,6 +52,9 @@ func (checks *Checker) isTerminating(s syntax.Stmt, label string) bool {
 	case *syntax.SwitchStmt:
 		return checks.isTerminatingSwitch(s.Body, label)
 
+	case *syntax.WithStmt:
+		return checks.isTerminating(s.Body, "")
+
 	case *syntax.SelectStmt:
 		for _, cc := range s.Body {
 			if !checks.isTerminatingList(cc.Body, "") || hasBreakList(cc.Body, label, true) {
@@ -142,6 +145,9 @@ func hasBreak(s syntax.Stmt, label string, implicit bool) bool {
 			return true
 		}
 
+	case *syntax.WithStmt:
+		return hasBreak(s.Body, label, implicit)
+
 	case *syntax.SelectStmt:
 		if label != "" && hasBreakCommList(s.Body, label, false) {
 			return true
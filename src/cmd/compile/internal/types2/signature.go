@@ -139,6 +139,10 @@ func (checks *Checker) funcType(sig *Signature, recvPar *syntax.Field, tparams [
 	// collect ordinary and result parameters
 	pnames, params, variadic := checks.collectParams(ParamVar, ftyp.ParamList)
 	rnames, results, _ := checks.collectParams(ResultVar, ftyp.ResultList)
+	if recvPar != nil {
+		checks.noParamDefaults([]*syntax.Field{recvPar})
+	}
+	checks.noParamDefaults(ftyp.ResultList)
 
 	// declare named receiver, ordinary, and result parameters
 	scopePos := syntax.EndPos(ftyp) // all parameter's scopes start after the signature
@@ -155,6 +159,99 @@ func (checks *Checker) funcType(sig *Signature, recvPar *syntax.Field, tparams [
 	sig.variadic = variadic
 }
 
+// noParamDefaults reports an error for each default value in list.
+// Only the parameters of function and method declarations may have
+// defaults; they are checked by paramDefaults.
+func (checks *Checker) noParamDefaults(list []*syntax.Field) {
+	var prev syntax.Expr
+	for _, f := range list {
+		if f.Default != nil && f.Default != prev {
+			checks.error(f.Default, BadDecl, "default values are only permitted for parameters of function declarations")
+			checks.use(f.Default)
+		}
+		prev = f.Default
+	}
+}
+
+// paramDefaults type-checks the default parameter values of the function
+// or method declaration fdecl and records them with obj. Since a default
+// is evaluated anew at every call site that omits its argument, it must be
+// constant or free of side effects. A parameter with a default may only be
+// followed by parameters with defaults.
+func (checks *Checker) paramDefaults(obj *Func, fdecl *syntax.FuncDecl) {
+	list := fdecl.Type.ParamList
+	first := -1 // index of the first parameter with a default
+	for i, f := range list {
+		if f.Default != nil {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return
+	}
+
+	sig := obj.typ.(*Signature)
+	switch {
+	case len(fdecl.TParamList) > 0 || sig.RecvTypeParams().Len() > 0:
+		checks.errorf(list[first].Default, BadDecl, "generic function %s cannot have default parameter values", obj.name)
+		return
+	case sig.variadic:
+		checks.errorf(list[first].Default, BadDecl, "variadic function %s cannot have default parameter values", obj.name)
+		return
+	}
+
+	defaults := make([]*paramDefault, len(list))
+	for i, f := range list[first:] {
+		i += first
+		if f.Default == nil {
+			checks.errorf(f, BadDecl, "parameter %s without default value follows parameters with defaults", paramName(f, i))
+			return
+		}
+
+		var x operand
+		hasCallOrRecv := checks.hasCallOrRecv
+		checks.hasCallOrRecv = false
+		checks.expr(nil, &x, f.Default)
+		impure := checks.hasCallOrRecv || hasFuncLit(f.Default)
+		checks.hasCallOrRecv = hasCallOrRecv
+		if x.mode == invalid {
+			return
+		}
+		if x.mode != constant_ && impure {
+			checks.errorf(f.Default, BadDecl, "default value %s for parameter %s must be constant or free of side effects", f.Default, paramName(f, i))
+			return
+		}
+		isNil := x.isNil()
+		checks.assignment(&x, sig.params.vars[i].typ, "default value")
+		if x.mode == invalid {
+			return
+		}
+		defaults[i] = &paramDefault{expr: f.Default, val: x.val, isNil: isNil}
+	}
+	obj.defaults = defaults
+}
+
+// paramName returns the name of the i'th parameter declared by f for use
+// in error messages.
+func paramName(f *syntax.Field, i int) string {
+	if f.Name != nil && f.Name.Value != "_" {
+		return f.Name.Value
+	}
+	return fmt.Sprintf("#%d", i+1)
+}
+
+// hasFuncLit reports whether the expression e contains a function literal.
+func hasFuncLit(e syntax.Expr) (found bool) {
+	syntax.Inspect(e, func(n syntax.Node) bool {
+		if _, ok := n.(*syntax.FuncLit); ok {
+			found = true
+		}
+		return !found
+	})
+	return
+}
+
 // collectRecv extracts the method receiver and its type parameters (if any) from rparam.
 // It declares the type parameters (but not the receiver) in the current scope, and
 // returns the receiver variable and its type parameter list (if any).
@@ -0,0 +1,203 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file implements typechecking of goo's spread elements ...x in
+// slice, map and struct literals, as in [...a, 4, ...b] and
+// {...defaults, port: 8080}.
+
+package types2
+
+import (
+	"cmd/compile/internal/syntax"
+	. "internal/types/errors"
+)
+
+// hasSpread reports whether the list of composite literal elements has a
+// spread element.
+func hasSpread(elems []syntax.Expr) bool {
+	for _, e := range elems {
+		if _, ok := e.(*syntax.DotsType); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// keyedElts reports whether the elements of a struct literal, which may
+// have spread elements, are field:value pairs. They are if the first
+// element that is not a spread is one, or if all of them are spreads.
+func keyedElts(elems []syntax.Expr) bool {
+	for _, e := range elems {
+		switch e.(type) {
+		case *syntax.DotsType:
+			continue
+		case *syntax.KeyValueExpr:
+			return true
+		}
+		return false
+	}
+	return true
+}
+
+// spreadHint records the type of the spread ...x that starts the brace
+// literal e as the type expected of e if its context expects none, so
+// that {...defaults, port: 8080} takes the type of defaults if that is a
+// struct or map type. For a list literal e, see listHint.
+func (checks *Checker) spreadHint(e *syntax.CompositeLit) {
+	if e.List {
+		checks.listHint(e)
+		return
+	}
+	if !e.Brace || checks.braceHints[e] != nil || len(e.ElemList) == 0 {
+		return
+	}
+	d, _ := e.ElemList[0].(*syntax.DotsType)
+	if d == nil {
+		return
+	}
+	if x := checks.spreadOperand(d); x.mode != invalid {
+		checks.braceHint(e, x.typ)
+	}
+}
+
+// listHint gives the list literal e with spreads the slice type its
+// context expects, as in var xs []int = [...a, 4], or else the type of
+// its first spread, so that [...a, 4, ...b] is an []int if a is; an
+// array spread gives a slice of its element type. Otherwise e remains
+// an []any.
+func (checks *Checker) listHint(e *syntax.CompositeLit) {
+	if e.Type == nil || !hasSpread(e.ElemList) {
+		return
+	}
+	typ := checks.braceHints[e]
+	if typ != nil {
+		if _, ok := CoreType(typ).(*Slice); !ok {
+			typ = nil
+		}
+	}
+	if typ == nil {
+		typ = checks.firstSpreadType(e.ElemList)
+	}
+	if typ != nil {
+		checks.braceHint(e, typ)
+		e.Type = nil
+	}
+}
+
+// firstSpreadType returns the type of the first spread among elems if
+// that is a slice type, a slice of its element type if it is an array
+// type, and nil otherwise.
+func (checks *Checker) firstSpreadType(elems []syntax.Expr) Type {
+	for _, e := range elems {
+		d, _ := e.(*syntax.DotsType)
+		if d == nil {
+			continue
+		}
+		if d.Elem == nil {
+			return nil
+		}
+		x := checks.spreadOperand(d)
+		if x.mode == invalid {
+			return nil
+		}
+		switch u := CoreType(x.typ).(type) {
+		case *Slice:
+			return x.typ
+		case *Array:
+			return NewSlice(u.elem)
+		}
+		return nil
+	}
+	return nil
+}
+
+// spreadOperand evaluates the spread element d for spreadExpr to find.
+func (checks *Checker) spreadOperand(d *syntax.DotsType) *operand {
+	x := new(operand)
+	checks.expr(nil, x, d.Elem)
+	if checks.spreads == nil {
+		checks.spreads = make(map[*syntax.DotsType]*operand)
+	}
+	checks.spreads[d] = x
+	return x
+}
+
+// spreadExpr evaluates the spread element e into x, unless spreadHint
+// or listHint did so already.
+func (checks *Checker) spreadExpr(x *operand, e *syntax.DotsType) {
+	if y := checks.spreads[e]; y != nil {
+		*x = *y
+		return
+	}
+	checks.expr(nil, x, e.Elem)
+}
+
+// spreadElts checks the elements of a slice literal with spread
+// elements. A spread must be a slice or array whose elements are
+// assignable to the element type elem of the literal; the other elements
+// must be assignable to elem themselves and cannot have indices.
+func (checks *Checker) spreadElts(elems []syntax.Expr, elem Type) {
+	for _, e := range elems {
+		var x operand
+		switch e := e.(type) {
+		case *syntax.DotsType:
+			checks.spreadExpr(&x, e)
+			if x.mode == invalid {
+				continue
+			}
+			var from Type
+			switch u := CoreType(x.typ).(type) {
+			case *Slice:
+				from = u.elem
+			case *Array:
+				from = u.elem
+			}
+			if from == nil {
+				checks.errorf(&x, InvalidLit, "cannot spread %s in slice literal: not a slice or array", &x)
+				continue
+			}
+			checks.spreadAssignable(&x, "element", from, elem, "slice literal")
+		case *syntax.KeyValueExpr:
+			checks.error(e, InvalidLitIndex, "index in slice literal with spread elements")
+			checks.use(e.Value)
+		default:
+			checks.braceHint(e, elem)
+			checks.exprWithHint(&x, e, elem)
+			checks.assignment(&x, elem, "slice literal")
+		}
+	}
+}
+
+// spreadMap checks the spread element e of a literal of the map type m.
+// It must be a map whose keys and values are assignable to those of m.
+func (checks *Checker) spreadMap(x *operand, e *syntax.DotsType, m *Map) {
+	checks.spreadExpr(x, e)
+	if x.mode == invalid {
+		return
+	}
+	u, _ := CoreType(x.typ).(*Map)
+	if u == nil {
+		checks.errorf(x, InvalidLit, "cannot spread %s in map literal: not a map", x)
+		return
+	}
+	checks.spreadAssignable(x, "key", u.key, m.key, "map literal")
+	checks.spreadAssignable(x, "value", u.elem, m.elem, "map literal")
+}
+
+// spreadStruct checks the spread element e of a literal of the struct
+// type typ. It must be assignable to typ; its fields are copied.
+func (checks *Checker) spreadStruct(x *operand, e *syntax.DotsType, typ Type) {
+	checks.spreadExpr(x, e)
+	checks.assignment(x, typ, "spread in struct literal")
+}
+
+// spreadAssignable reports an error unless the values of type from, the
+// elements, keys or values (as described by what) of the spread operand
+// x, are assignable to the type to of the literal's.
+func (checks *Checker) spreadAssignable(x *operand, what string, from, to Type, context string) {
+	v := operand{mode: value, expr: x.expr, typ: from}
+	if ok, _ := v.assignableTo(checks, to, nil); !ok {
+		checks.errorf(x, IncompatibleAssign, "cannot spread %s in %s: %s type %s is not assignable to %s", x, context, what, from, to)
+	}
+}
@@ -14,7 +14,7 @@ import (
 )
 
 // decl may be nil
-func (checks *Checker) funcBody(decl *declInfo, name string, sig *Signature, body *syntax.BlockStmt, iota constant.Value) {
+func (checks *Checker) funcBody(decl *declInfo, name string, sig *Signature, contracts []*syntax.Contract, body *syntax.BlockStmt, iota constant.Value) {
 	if checks.conf.IgnoreFuncBodies {
 		panic("function body not ignored")
 	}
@@ -38,6 +38,7 @@ func (checks *Checker) funcBody(decl *declInfo, name string, sig *Signature, bod
 	}
 	checks.indent = 0
 
+	checks.contracts(contracts, sig)
 	checks.stmtList(0, body.List)
 
 	if checks.hasLabel && !checks.conf.IgnoreBranchErrors {
@@ -184,7 +185,11 @@ func (checks *Checker) suspendedCall(keyword string, call syntax.Expr) {
 		msg = "discards result of"
 		code = UnusedResults
 	case statement:
-		return
+		if x.mode != novalue || x.id != _CollEach {
+			return
+		}
+		// goo: each is a loop, not a call
+		msg = "requires function call, not collection method"
 	default:
 		panic("unreachable")
 	}
@@ -437,6 +442,14 @@ func (checks *Checker) stmt(ctxt stmtContext, s syntax.Stmt) {
 		checks.stmt(ctxt, s.Stmt)
 
 	case *syntax.ExprStmt:
+		if _, ok := checks.condValues[s]; ok {
+			// value of an if or switch expression branch
+			var x operand
+			checks.expr(nil, &x, s.X)
+			checks.condValues[s] = &x
+			return
+		}
+
 		// spec: "With the exception of specific built-in functions,
 		// function and method calls and receive operations can appear
 		// in statement context. Such statements may be parenthesized."
@@ -489,6 +502,10 @@ func (checks *Checker) stmt(ctxt stmtContext, s syntax.Stmt) {
 		}
 
 		lhs := syntax.UnpackListExpr(s.Lhs)
+		if pat, _ := lhs[0].(*syntax.PatternExpr); pat != nil {
+			checks.destructure(s, pat) // goo
+			return
+		}
 		rhs := syntax.UnpackListExpr(s.Rhs)
 		switch s.Op {
 		case 0:
@@ -596,6 +613,7 @@ func (checks *Checker) stmt(ctxt stmtContext, s syntax.Stmt) {
 		if x.mode == invalid {
 			return
 		}
+		checks.maskCond(&x, s.Cond)
 		checks.stmt(inner, s.Then)
 		// The parser produces a correct AST but if it was modified
 		// elsewhere the else branch may be invalid. Check again.
@@ -621,6 +639,9 @@ func (checks *Checker) stmt(ctxt stmtContext, s syntax.Stmt) {
 			checks.switchStmt(inner, s)
 		}
 
+	case *syntax.WithStmt:
+		checks.withStmt(inner, s)
+
 	case *syntax.SelectStmt:
 		inner |= breakOk
 
@@ -668,6 +689,7 @@ func (checks *Checker) stmt(ctxt stmtContext, s syntax.Stmt) {
 		inner |= breakOk | continueOk
 
 		if rclause, _ := s.Init.(*syntax.RangeClause); rclause != nil {
+			checks.rangePattern(s, rclause) // goo: for {k, v} in pairs
 			// extract sKey, sValue, s.Extra from the range clause
 			sKey := rclause.Lhs            // possibly nil
 			var sValue, sExtra syntax.Expr // possibly nil
@@ -699,6 +721,7 @@ func (checks *Checker) stmt(ctxt stmtContext, s syntax.Stmt) {
 			if x.mode == invalid {
 				return
 			}
+			checks.maskCond(&x, s.Cond)
 		}
 		checks.simpleStmt(s.Post)
 		// spec: "The init statement may be a short variable
@@ -713,6 +736,13 @@ func (checks *Checker) stmt(ctxt stmtContext, s syntax.Stmt) {
 		var x operand
 		checks.expr(nil, &x, s.Cond)
 		// Allow any type in check conditions - truthy conversion handled in typecheck
+		if x.mode != invalid {
+			checks.maskCond(&x, s.Cond)
+		}
+		if s.Msg != nil {
+			checks.expr(nil, &x, s.Msg)
+			checks.assignment(&x, Typ[String], "check message")
+		}
 
 	default:
 		checks.error(s, InvalidSyntaxTree, "invalid statement")
@@ -847,3 +877,188 @@ func (checks *Checker) typeSwitchStmt(inner stmtContext, s *syntax.SwitchStmt, g
 		}
 	}
 }
+
+// condExpr type-checks the if or switch expression e. The statement is
+// checked like any other, except that the last statement of each branch
+// is an expression providing the value of the branch. The values must
+// all be assignable to the type of one of them, which is the type of e.
+// If the values are all untyped, e is untyped as well, and its eventual
+// type is passed on to the branches. Since e needs a value in every case,
+// an if expression must have an else branch and a switch expression must
+// have a default case.
+func (checks *Checker) condExpr(x *operand, e *syntax.CondExpr) {
+	x.mode = invalid
+	x.expr = e
+
+	kind := "if"
+	switch s := e.Stmt.(type) {
+	case *syntax.IfStmt:
+		for ; s != nil && s.Else != nil; s, _ = s.Else.(*syntax.IfStmt) {
+		}
+		if s != nil {
+			checks.errorf(s, NotAnExpr, "if expression is missing an else branch")
+			return
+		}
+	case *syntax.SwitchStmt:
+		kind = "switch"
+		if _, ok := s.Tag.(*syntax.TypeSwitchGuard); ok {
+			checks.error(s, NotAnExpr, "type switch cannot be used as an expression")
+			return
+		}
+		hasDefault := false
+		for _, c := range s.Body {
+			hasDefault = hasDefault || c.Cases == nil
+		}
+		if !hasDefault {
+			checks.error(s, NotAnExpr, "switch expression is missing a default case")
+			return
+		}
+	}
+
+	results := syntax.CondValues(e)
+	values := make(map[*syntax.ExprStmt]*operand, len(results))
+	for _, r := range results {
+		if r == nil {
+			checks.errorf(e, NotAnExpr, "every branch of the %s expression must end in a value", kind)
+			return
+		}
+		values[r] = nil
+	}
+	if checks.sig == nil && !isSimpleCond(e) {
+		// outside a function there is nowhere to declare locals
+		checks.errorf(e, UnsupportedFeature, "%s expression outside a function must not contain statements", kind)
+		return
+	}
+
+	saved := checks.condValues
+	checks.condValues = values
+	checks.stmt(0, e.Stmt)
+	checks.condValues = saved
+
+	list := make([]*operand, len(results))
+	for i, r := range results {
+		list[i] = values[r]
+		if list[i] == nil || list[i].mode == invalid {
+			return
+		}
+	}
+
+	// determine the type of e
+	var T Type
+	for _, y := range list {
+		if isUntyped(y.typ) || T != nil {
+			continue
+		}
+		T = y.typ
+		for _, z := range list {
+			if ok, _ := z.assignableTo(checks, y.typ, nil); !ok {
+				T = nil
+				break
+			}
+		}
+	}
+	if T == nil {
+		// no typed value that all others are assignable to;
+		// if they are all untyped, they must have matching kinds
+		T = list[0].typ
+		for _, y := range list[1:] {
+			if T != nil && isUntyped(T) && isUntyped(y.typ) {
+				T = maxType(T, y.typ)
+			} else {
+				T = nil
+			}
+			if T == nil {
+				checks.errorf(y, MismatchedTypes, "mismatched types %s and %s in %s expression", list[0].typ, y.typ, kind)
+				return
+			}
+		}
+	} else {
+		for _, y := range list {
+			checks.assignment(y, T, kind+" expression")
+			if y.mode == invalid {
+				return
+			}
+		}
+	}
+
+	x.mode = value
+	x.typ = T
+}
+
+// compExpr type-checks the comprehension e. Its loop is checked like any
+// other, except that the statements providing the elements (and keys)
+// are expressions, whose default types are the element (and key) types
+// of the resulting slice (or map).
+func (checks *Checker) compExpr(x *operand, e *syntax.CompExpr) {
+	x.mode = invalid
+	x.expr = e
+
+	if checks.sig == nil {
+		// outside a function there is nowhere to declare locals
+		checks.error(e, UnsupportedFeature, "comprehension outside a function")
+		return
+	}
+
+	_, _, results := syntax.CompClauses(e)
+	values := make(map[*syntax.ExprStmt]*operand, len(results))
+	for _, r := range results {
+		values[r] = nil
+	}
+
+	saved := checks.condValues
+	checks.condValues = values
+	checks.stmt(0, e.Loop)
+	checks.condValues = saved
+
+	typs := make([]Type, len(results))
+	for i, r := range results {
+		y := values[r]
+		if y == nil || y.mode == invalid {
+			return
+		}
+		typs[i] = Default(y.typ)
+		checks.assignment(y, typs[i], "comprehension")
+		if y.mode == invalid {
+			return
+		}
+	}
+
+	if e.Map {
+		if !Comparable(typs[0]) {
+			checks.errorf(results[0], IncomparableMapKey, "invalid map key type %s", typs[0])
+			return
+		}
+		x.typ = NewMap(typs[0], typs[1])
+	} else {
+		x.typ = NewSlice(typs[0])
+	}
+	x.mode = value
+}
+
+// isSimpleCond reports whether the if or switch expression e declares
+// no variables: it has no init statements and each branch consists of
+// its value only.
+func isSimpleCond(e *syntax.CondExpr) bool {
+	switch s := e.Stmt.(type) {
+	case *syntax.IfStmt:
+		for s != nil {
+			if s.Init != nil || len(s.Then.List) > 1 {
+				return false
+			}
+			if b, ok := s.Else.(*syntax.BlockStmt); ok && len(b.List) > 1 {
+				return false
+			}
+			s, _ = s.Else.(*syntax.IfStmt)
+		}
+	case *syntax.SwitchStmt:
+		if s.Init != nil {
+			return false
+		}
+		for _, c := range s.Body {
+			if len(c.Body) > 1 {
+				return false
+			}
+		}
+	}
+	return true
+}
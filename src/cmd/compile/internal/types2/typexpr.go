@@ -48,6 +48,14 @@ func (checks *Checker) ident(x *operand, e *syntax.Name, defi *TypeName, wantTyp
 
 	checks.recordUse(e, obj)
 
+	// goo: top-level statements run in order, like a script, so they
+	// cannot use the package-level variables declared after them.
+	if v, _ := obj.(*Var); v != nil && scope == checks.pkg.scope && checks.decl != nil && checks.decl.fdecl != nil && checks.decl.fdecl.TopLevel {
+		if pos := v.Pos(); pos.Base() == e.Pos().Base() && pos.Cmp(e.Pos()) > 0 {
+			checks.softErrorf(e, UndeclaredName, "cannot use %s before its declaration at %s in top-level statements", e.Value, pos)
+		}
+	}
+
 	// If we want a type but don't have one, stop right here and avoid potential problems
 	// with missing underlying types. This also gives better error messages in some cases
 	// (see go.dev/issue/65344).
@@ -355,6 +363,7 @@ func (checks *Checker) typInternal(e0 syntax.Expr, defi *TypeName) (T Type) {
 		typ := new(Signature)
 		setDefType(defi, typ)
 		checks.funcType(typ, nil, nil, e)
+		checks.noParamDefaults(e.ParamList)
 		return typ
 
 	case *syntax.InterfaceType:
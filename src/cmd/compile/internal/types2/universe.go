@@ -190,6 +190,10 @@ const (
 	_Real
 	_Recover
 	_Typeof
+	_Sum // goo
+	_Dot // goo
+	_Old // goo
+	_Dbg // goo
 
 	// package unsafe
 	_Add
@@ -204,6 +208,23 @@ const (
 	// testing support
 	_Assert
 	_Trace
+
+	// goo: collection methods of slices and maps
+	_CollMap
+	_CollFilter
+	_CollReduce
+	_CollSum
+	_CollMin
+	_CollMax
+	_CollSort
+	_CollSortBy
+	_CollGroupBy
+	_CollKeys
+	_CollValues
+	_CollJoin
+	_CollEach
+	_CollAny
+	_CollAll
 )
 
 var predeclaredFuncs = [...]struct {
@@ -232,6 +253,10 @@ var predeclaredFuncs = [...]struct {
 	_Real:    {"real", 1, false, expression},
 	_Recover: {"recover", 0, false, statement},
 	_Typeof:  {"typeof", 1, false, expression},
+	_Sum:     {"sum", 1, false, expression},
+	_Dot:     {"dot", 2, false, expression},
+	_Old:     {"old", 1, false, expression},
+	_Dbg:     {"dbg", 1, false, statement},
 
 	_Add:        {"Add", 2, false, expression},
 	_Alignof:    {"Alignof", 1, false, expression},
@@ -244,6 +269,33 @@ var predeclaredFuncs = [...]struct {
 
 	_Assert: {"assert", 1, false, statement},
 	_Trace:  {"trace", 0, true, statement},
+
+	_CollMap:     {"map", 1, false, expression},
+	_CollFilter:  {"filter", 1, false, expression},
+	_CollReduce:  {"reduce", 2, false, expression},
+	_CollSum:     {"sum", 0, false, expression},
+	_CollMin:     {"min", 0, false, expression},
+	_CollMax:     {"max", 0, false, expression},
+	_CollSort:    {"sort", 0, false, expression},
+	_CollSortBy:  {"sortBy", 1, false, expression},
+	_CollGroupBy: {"groupBy", 1, false, expression},
+	_CollKeys:    {"keys", 0, false, expression},
+	_CollValues:  {"values", 0, false, expression},
+	_CollJoin:    {"join", 1, false, expression},
+	_CollEach:    {"each", 1, false, statement},
+	_CollAny:     {"any", 1, false, expression},
+	_CollAll:     {"all", 1, false, expression},
+}
+
+// collectionId returns the id of the collection method with the given
+// name, or -1.
+func collectionId(name string) builtinId {
+	for id := _CollMap; id <= _CollAll; id++ {
+		if predeclaredFuncs[id].name == name {
+			return id
+		}
+	}
+	return -1
 }
 
 func defPredeclaredFuncs() {
@@ -252,6 +304,9 @@ func defPredeclaredFuncs() {
 		if id == _Trace {
 			continue // only define these in testing environment
 		}
+		if id >= _CollMap {
+			break // goo: collection methods are only found by selectors
+		}
 		defi(newBuiltin(id))
 	}
 }
@@ -0,0 +1,123 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file implements typechecking of goo's elementwise operations on
+// slices of numbers, such as a + b, a * 2.0 and a < b.
+
+package types2
+
+import (
+	"cmd/compile/internal/syntax"
+	"go/constant"
+	. "internal/types/errors"
+	"strings"
+)
+
+// vectorElem returns the element type of t if t is a slice of integers
+// or floats, and nil otherwise.
+func vectorElem(t Type) Type {
+	if s, _ := under(t).(*Slice); s != nil {
+		if b, _ := under(s.elem).(*Basic); b != nil && b.info&(IsInteger|IsFloat) != 0 {
+			return s.elem
+		}
+	}
+	return nil
+}
+
+// isVectorOp reports whether op applies elementwise to slices of
+// numbers.
+func isVectorOp(op syntax.Operator) bool {
+	switch op {
+	case syntax.Add, syntax.Sub, syntax.Mul, syntax.Div, syntax.Eql, syntax.Neq, syntax.Lss, syntax.Leq, syntax.Gtr, syntax.Geq:
+		return true
+	}
+	return false
+}
+
+// vector type-checks the binary operation x op y if either operand is
+// a slice of numbers and the other a slice or a number, and reports
+// whether it did. The other operand is a slice of the same type or a
+// number of the element type, which is combined with each element.
+// Arithmetic yields a slice of the type of the slice operand, and
+// comparisons yield a []bool mask. In .go files comparisons keep their
+// meaning. The lengths of two slices are checked when the operation
+// runs.
+func (checks *Checker) vector(x, y *operand, e, lhs, rhs syntax.Expr, op syntax.Operator) bool {
+	xelem, yelem := vectorElem(x.typ), vectorElem(y.typ)
+	if xelem == nil && yelem == nil || !isVectorOp(op) {
+		return false
+	}
+	if xelem == nil && !allNumeric(x.typ) || yelem == nil && !allNumeric(y.typ) {
+		return false // comparison with nil or an interface
+	}
+	if isComparison(op) && !strings.HasSuffix(lhs.Pos().RelFilename(), ".goo") {
+		return false
+	}
+
+	mismatch := func(at *operand) {
+		if e != nil {
+			checks.errorf(at, MismatchedTypes, invalidOp+"%s (mismatched types %s and %s)", e, x.typ, y.typ)
+		} else {
+			checks.errorf(at, MismatchedTypes, invalidOp+"%s %s= %s (mismatched types %s and %s)", lhs, op, rhs, x.typ, y.typ)
+		}
+		at.mode = invalid
+	}
+
+	// The other operand is a slice of the same type, or an element.
+	typ, elem := x.typ, xelem
+	switch {
+	case xelem != nil && yelem != nil:
+		if !Identical(x.typ, y.typ) {
+			mismatch(x)
+		}
+	case xelem != nil:
+		if isUntyped(y.typ) {
+			checks.convertUntyped(y, elem)
+		} else if !Identical(y.typ, elem) {
+			mismatch(y)
+		}
+	default:
+		typ, elem = y.typ, yelem
+		if isUntyped(x.typ) {
+			checks.convertUntyped(x, elem)
+		} else if !Identical(x.typ, elem) {
+			mismatch(x)
+		}
+	}
+	if x.mode == invalid || y.mode == invalid {
+		x.mode = invalid
+		return true
+	}
+
+	if op == syntax.Div && allInteger(elem) && y.mode == constant_ && constant.Sign(y.val) == 0 {
+		checks.error(y, DivByZero, invalidOp+"division by zero")
+		x.mode = invalid
+		return true
+	}
+
+	x.mode = value
+	x.typ = typ
+	if isComparison(op) {
+		x.typ = NewSlice(Typ[Bool])
+	}
+	return true
+}
+
+// maskCond reports an error if cond, the condition of an if, for or
+// check statement with operand x, is an elementwise comparison, since
+// its []bool mask would count as true whenever it is not empty.
+func (checks *Checker) maskCond(x *operand, cond syntax.Expr) {
+	op, _ := syntax.Unparen(cond).(*syntax.Operation)
+	if op == nil || op.Y == nil || !isComparison(op.Op) {
+		return
+	}
+	if s, _ := under(x.typ).(*Slice); s == nil || !Identical(s.elem, Typ[Bool]) {
+		return
+	}
+	if op.Op == syntax.Eql || op.Op == syntax.Neq {
+		checks.errorf(cond, InvalidCond, "invalid condition: %s compares slices elementwise (use slices.Equal)", cond)
+	} else {
+		checks.errorf(cond, InvalidCond, "invalid condition: %s compares slices elementwise", cond)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file implements typechecking of goo's with statements.
+
+package types2
+
+import (
+	"cmd/compile/internal/syntax"
+	. "internal/types/errors"
+)
+
+// withStmt type-checks the with statement s. As the init statement of an
+// if statement, its bindings declare variables in a scope of their own
+// that encloses the block.
+func (checks *Checker) withStmt(inner stmtContext, s *syntax.WithStmt) {
+	checks.openScope(s, "with")
+	defer checks.closeScope()
+
+	for _, b := range s.Bindings {
+		checks.withBinding(b)
+	}
+	checks.stmt(inner, s.Body)
+}
+
+// withBinding type-checks the binding b of a with statement. It declares
+// the variables of b like a short variable declaration. If b ends in ?,
+// its expression has an additional error result which is not assigned.
+// The first variable is the resource closed at the end of the statement.
+func (checks *Checker) withBinding(b *syntax.WithBinding) {
+	top := len(checks.delayed)
+
+	names := syntax.UnpackListExpr(b.Lhs)
+	lhs := make([]*Var, len(names))
+	for i, name := range names {
+		lhs[i] = newVar(LocalVar, name.Pos(), checks.pkg, name.(*syntax.Name).Value, nil)
+	}
+
+	rhs, _ := checks.multiExpr(b.Rhs, false)
+	want := len(lhs)
+	if b.Try {
+		want++
+	}
+	switch {
+	case len(rhs) == 1 && rhs[0].mode == invalid:
+		// error reported before
+		for _, v := range lhs {
+			v.typ = Typ[Invalid]
+		}
+	case len(rhs) != want:
+		checks.assignError([]syntax.Expr{b.Rhs}, want, len(rhs))
+		for _, v := range lhs {
+			v.typ = Typ[Invalid]
+		}
+	default:
+		if b.Try && !Identical(rhs[want-1].typ, universeError) {
+			checks.errorf(b.Rhs, MismatchedTypes, "cannot use ? with %s: last result is of type %s, not error", b.Rhs, rhs[want-1].typ)
+		}
+		for i, v := range lhs {
+			checks.initVar(v, rhs[i], "with statement")
+		}
+	}
+
+	checks.processDelayed(top)
+
+	scopePos := endPos(b)
+	for i, v := range lhs {
+		checks.declare(checks.scope, names[i].(*syntax.Name), v, scopePos)
+	}
+	checks.withResource(lhs[0])
+}
+
+// withResource reports an error unless the resource v of a with statement
+// has one of the methods
+//
+//	Close() error
+//	Close()
+//	Stop() error
+//	Stop() bool
+//	Stop()
+//
+// which the statement calls, in this order of preference.
+func (checks *Checker) withResource(v *Var) {
+	if !isValid(v.typ) {
+		return
+	}
+	if v.name == "_" {
+		checks.error(v, BadDecl, "cannot use _ as resource of with statement")
+		return
+	}
+	checks.usedVars[v] = true // closed at the end of the statement
+	for _, name := range [...]string{"Close", "Stop"} {
+		obj, _, _ := lookupFieldOrMethod(v.typ, false, checks.pkg, name, false)
+		m, _ := obj.(*Func)
+		if m == nil {
+			continue
+		}
+		sig := m.Signature()
+		if sig.params.Len() > 0 || sig.variadic {
+			continue
+		}
+		switch sig.results.Len() {
+		case 0:
+			return
+		case 1:
+			res := sig.results.vars[0].typ
+			if Identical(res, universeError) || name == "Stop" && Identical(res, Typ[Bool]) {
+				return
+			}
+		}
+	}
+	checks.errorf(v, MissingFieldOrMethod, "%s (variable of type %s) cannot be closed by with: missing method Close() error, Close(), Stop() error, Stop() bool or Stop()", v.name, v.typ)
+}
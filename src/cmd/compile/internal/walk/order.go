@@ -842,6 +842,9 @@ func (o *orderState) stmt(n ir.Node) {
 		n := n.(*ir.CheckStmt)
 		t := o.markTemp()
 		n.Cond = o.expr(n.Cond, nil)
+		if n.Msg != nil {
+			n.Msg = o.exprInPlace(n.Msg)
+		}
 		o.out = append(o.out, n)
 		o.popTemp(t)
 
@@ -255,10 +255,25 @@ func walkCheck(n *ir.CheckStmt) ir.Node {
 	
 	// Create panic call
 	condStr := ir.NewBasicLit(n.Pos(), types.Types[types.TSTRING], constant.MakeString("check failed"))
-	panicCall := mkcall("gopanic", nil, &init, condStr)
+	var body []ir.Node
+	if n.Contract != "" {
+		// goo: a failed requires or ensures clause reports the clause
+		// and the caller.
+		clause := ir.NewBasicLit(n.Pos(), types.Types[types.TSTRING], constant.MakeString(n.Contract))
+		body = []ir.Node{mkcall("contractFailed", nil, &init, clause)}
+	} else if n.Msg != nil {
+		// goo: check cond, msg panics with "check failed: " + msg, which is
+		// evaluated only if the check fails.
+		body = ir.TakeInit(n.Msg)
+		condStr = ir.NewBasicLit(n.Pos(), types.Types[types.TSTRING], constant.MakeString("check failed: "))
+		msg := typecheck.Expr(ir.NewBinaryExpr(n.Pos(), ir.OADD, condStr, n.Msg))
+		body = append(body, typecheck.Stmt(ir.NewUnaryExpr(n.Pos(), ir.OPANIC, msg)))
+	} else {
+		body = []ir.Node{mkcall("gopanic", nil, &init, condStr)}
+	}
 	
 	// Create if statement: if !runtime.truthy(condition) { panic(...) }
-	ifStmt := ir.NewIfStmt(n.Pos(), notCond, []ir.Node{panicCall}, nil)
+	ifStmt := ir.NewIfStmt(n.Pos(), notCond, body, nil)
 	if len(init) > 0 {
 		ifStmt.PtrInit().Prepend(init...)
 	}
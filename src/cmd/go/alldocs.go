@@ -26,7 +26,9 @@
 //	list        list packages or modules
 //	mod         module maintenance
 //	work        workspace maintenance
+//	repl        run an interactive goo session
 //	run         compile and run Go program
+//	script      goo script maintenance
 //	telemetry   manage telemetry data and settings
 //	test        test packages
 //	tool        run specified go tool
@@ -174,6 +176,19 @@
 //		cannot be included due to a missing tool or ambiguous directory structure.
 //	-compiler name
 //		name of compiler to use, as in runtime.Compiler (gccgo or gc).
+//	-contracts mode
+//		checking of goo contracts, 'on' (the default) or 'off'.
+//		With -contracts=off, the requires and ensures clauses of functions
+//		are not checked at run time. They remain in the export data for
+//		documentation.
+//	-gc mode
+//		garbage collection mode, 'on' (the default) or 'off'.
+//		With -gc=off, packages are compiled without write barriers and
+//		the program links against a runtime that never collects garbage
+//		and allocates from a bump allocator instead, for short-lived
+//		programs. Such a program fails with an out of memory error when
+//		its memory use reaches GOMEMLIMIT (8 GiB if unset) and reports
+//		its peak memory on standard error at exit.
 //	-gccgoflags '[pattern=]arg list'
 //		arguments to pass on each gccgo compiler/linker invocation.
 //	-gcflags '[pattern=]arg list'
@@ -283,7 +298,7 @@
 //
 // Usage:
 //
-//	go clean [-i] [-r] [-cache] [-testcache] [-modcache] [-fuzzcache] [build flags] [packages]
+//	go clean [-i] [-r] [-cache] [-testcache] [-modcache] [-fuzzcache] [-scriptcache] [build flags] [packages]
 //
 // Clean removes object files from package source directories.
 // The go command builds most objects in a temporary directory,
@@ -338,6 +353,9 @@
 // distinct from those stored in testdata directory; clean does not remove
 // those files.
 //
+// The -scriptcache flag causes clean to remove the executables and
+// ephemeral modules that 'go run' keeps for goo scripts.
+//
 // For more about build flags, see 'go help build'.
 //
 // For more about specifying packages, see 'go help packages'.
@@ -498,7 +516,7 @@
 //
 // Usage:
 //
-//	go fix [-fix list] [packages]
+//	go fix [-fix list] [-goo [-reverse]] [packages]
 //
 // Fix runs the Go fix command on the packages named by the import paths.
 //
@@ -506,6 +524,10 @@
 // The default is all known fixes.
 // (Its value is passed to 'go tool fix -r'.)
 //
+// The -goo flag runs the goo rewrites of 'go tool goofix' instead, which
+// turn Go code into idiomatic goo; with -reverse, they turn goo code back
+// into Go. The -fix flag then selects among the goo rewrites.
+//
 // For more about fix, see 'go doc cmd/fix'.
 // For more about specifying packages, see 'go help packages'.
 //
@@ -1738,6 +1760,41 @@
 // named "vendor" within the module root directory, so this flag is
 // primarily useful for other tools.
 //
+// # Run an interactive goo session
+//
+// Usage:
+//
+//	go repl [file.goo ...]
+//
+// Repl reads goo declarations, imports and statements from the standard input
+// and runs them as they are entered. Running goo without arguments does the same.
+//
+// The session is kept by a single running program. Every input is compiled
+// into a plugin that the program loads and runs, so each statement runs once
+// and the variables declared at the top level of an input keep their values
+// for later inputs. Declarations and imports are compiled again into every
+// later input, reusing the build cache. The value of an input that is a bare
+// expression is printed as by put. An input that fails to compile is reported
+// and dropped; so are the declarations and variables of an input that panics.
+// Lines are joined until brackets are balanced.
+//
+// The program reads its standard input from the null device, as it does not
+// share the standard input of the session. If it exits, as by os.Exit, the
+// variables of the session are lost. Repl requires cgo and a platform that
+// supports -buildmode=plugin.
+//
+// Inputs starting with a colon are commands:
+//
+//	:type expr     print the static type of expr, without evaluating it
+//	:doc pkg.Sym   print the documentation of pkg.Sym, as go doc does
+//	:load file     load the declarations and statements of a goo file
+//	:history       list the inputs of the session; !n repeats input n
+//	:reset         forget all inputs and variables
+//	:quit          end the session (as does end of input)
+//
+// The files named on the command line are loaded before reading the standard
+// input.
+//
 // # Compile and run Go program
 //
 // Usage:
@@ -1776,6 +1833,14 @@
 // used by debuggers, to reduce build time. To include debugger information in
 // the binary, use 'go build'.
 //
+// When the package is a list of .goo scripts and no build flags are given,
+// 'go run' keeps the binary in a script cache, keyed by the content of the
+// scripts, the toolchain and the go environment, and later runs it without
+// loading any packages. Only scripts depending solely on the standard library
+// or on modules pinned by #require directives (see 'go help script') are
+// cached. 'go clean -scriptcache' empties the cache, which is needed after
+// editing standard library sources without reinstalling the toolchain.
+//
 // The exit status of Run is not the exit status of the compiled binary.
 //
 // For more about build flags, see 'go help build'.
@@ -1783,6 +1848,61 @@
 //
 // See also: go build.
 //
+// # Goo script maintenance
+//
+// Script provides access to operations on single-file goo scripts.
+//
+// A goo script declares the modules it depends on in its header, the run
+// of blank and comment lines at the top of the file, one per line:
+//
+//	#!/usr/bin/env goo
+//	#require rsc.io/quote v1.5.2
+//	//goo:require golang.org/x/text v0.14.0
+//
+//	import (
+//		"fmt"
+//
+//		"rsc.io/quote"
+//	)
+//
+//	fmt.Println(quote.Hello())
+//
+// When 'go run' or 'go build' is given such a script, the go command builds
+// it in an ephemeral module requiring exactly those versions. The module
+// and its go.sum are kept in the build cache, so only the first run
+// downloads and verifies the dependencies. Modules are fetched as usual,
+// honoring GOPROXY, GONOSUMDB and GOFLAGS; a file:// GOPROXY serves them
+// in offline environments.
+//
+// Usage:
+//
+//	go script <command> [arguments]
+//
+// The commands are:
+//
+//	lock        pin the dependencies of a goo script
+//
+// Use "go help script <command>" for more information about a command.
+//
+// # Pin the dependencies of a goo script
+//
+// Usage:
+//
+//	go script lock [-v] file.goo
+//
+// Lock resolves the dependencies of a goo script and pins them in its
+// header, much as 'go mod tidy' does for a module.
+//
+// A #require directive may name a version query such as v1.5 or latest,
+// or omit the version entirely, meaning latest. Lock replaces each query
+// with the version it selects, adds a directive for every other module
+// the script imports and removes directives for modules it no longer needs.
+//
+// The -v flag prints the output of the 'go get' and 'go mod tidy' commands
+// that lock runs in a scratch module.
+//
+// See 'go help script' for the format of the header.
+//
 // # Manage telemetry data and settings
 //
 // Usage:
@@ -1840,6 +1960,9 @@
 // the file pattern "*_test.go".
 // These additional files can contain test functions, benchmark functions, fuzz
 // tests and example functions. See 'go help testfunc' for more.
+// Goo packages may also have "*_test.goo" files, whose top-level blocks
+// test "name" { ... } run as tests of that name. Failing check statements
+// in such blocks fail the test.
 // Each listed package causes the execution of a separate test binary.
 // Files whose names begin with "_" (including "_test.go") or "." are ignored.
 //
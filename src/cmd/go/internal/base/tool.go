@@ -45,6 +45,7 @@ func ValidToolName(toolName string) bool {
 	for _, c := range toolName {
 		switch {
 		case 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '_':
+		case c == '-' && toolName[0] != '-': // goo: for goo-lsp
 		default:
 			return false
 		}
@@ -30,7 +30,7 @@ import (
 )
 
 var CmdClean = &base.Command{
-	UsageLine: "go clean [-i] [-r] [-cache] [-testcache] [-modcache] [-fuzzcache] [build flags] [packages]",
+	UsageLine: "go clean [-i] [-r] [-cache] [-testcache] [-modcache] [-fuzzcache] [-scriptcache] [build flags] [packages]",
 	Short:     "remove object files and cached files",
 	Long: `
 Clean removes object files from package source directories.
@@ -86,6 +86,9 @@ new inputs are found that provide the same coverage. These files are
 distinct from those stored in testdata directory; clean does not remove
 those files.
 
+The -scriptcache flag causes clean to remove the executables and
+ephemeral modules that 'go run' keeps for goo scripts.
+
 For more about build flags, see 'go help build'.
 
 For more about specifying packages, see 'go help packages'.
@@ -93,12 +96,13 @@ For more about specifying packages, see 'go help packages'.
 }
 
 var (
-	cleanI         bool // clean -i flag
-	cleanR         bool // clean -r flag
-	cleanCache     bool // clean -cache flag
-	cleanFuzzcache bool // clean -fuzzcache flag
-	cleanModcache  bool // clean -modcache flag
-	cleanTestcache bool // clean -testcache flag
+	cleanI           bool // clean -i flag
+	cleanR           bool // clean -r flag
+	cleanCache       bool // clean -cache flag
+	cleanFuzzcache   bool // clean -fuzzcache flag
+	cleanModcache    bool // clean -modcache flag
+	cleanScriptcache bool // clean -scriptcache flag
+	cleanTestcache   bool // clean -testcache flag
 )
 
 func init() {
@@ -110,6 +114,7 @@ func init() {
 	CmdClean.Flag.BoolVar(&cleanCache, "cache", false, "")
 	CmdClean.Flag.BoolVar(&cleanFuzzcache, "fuzzcache", false, "")
 	CmdClean.Flag.BoolVar(&cleanModcache, "modcache", false, "")
+	CmdClean.Flag.BoolVar(&cleanScriptcache, "scriptcache", false, "")
 	CmdClean.Flag.BoolVar(&cleanTestcache, "testcache", false, "")
 
 	// -n and -x are important enough to be
@@ -131,6 +136,8 @@ func runClean(ctx context.Context, cmd *base.Command, args []string) {
 			cacheFlag = "-fuzzcache"
 		case cleanModcache:
 			cacheFlag = "-modcache"
+		case cleanScriptcache:
+			cacheFlag = "-scriptcache"
 		}
 		if cacheFlag != "" {
 			base.Fatalf("go: clean %s cannot be used with package arguments", cacheFlag)
@@ -142,7 +149,7 @@ func runClean(ctx context.Context, cmd *base.Command, args []string) {
 	// or no other target (such as a cache) was requested to be cleaned.
 	cleanPkg := len(args) > 0 || cleanI || cleanR
 	if (!modload.Enabled() || modload.HasModRoot()) &&
-		!cleanCache && !cleanModcache && !cleanTestcache && !cleanFuzzcache {
+		!cleanCache && !cleanModcache && !cleanTestcache && !cleanFuzzcache && !cleanScriptcache {
 		cleanPkg = true
 	}
 
@@ -243,6 +250,16 @@ func runClean(ctx context.Context, cmd *base.Command, args []string) {
 			base.Error(err)
 		}
 	}
+
+	if cleanScriptcache {
+		scriptDir, err := load.ScriptCacheDir()
+		if err != nil {
+			base.Fatal(err)
+		}
+		if err := sh.RemoveAll(scriptDir); err != nil {
+			base.Error(err)
+		}
+	}
 }
 
 // logFilesInGOMODCACHE reports the file names and modes for the files in GOMODCACHE using base.Error.
@@ -20,7 +20,7 @@ import (
 )
 
 var CmdFix = &base.Command{
-	UsageLine: "go fix [-fix list] [packages]",
+	UsageLine: "go fix [-fix list] [-goo [-reverse]] [packages]",
 	Short:     "update packages to use new APIs",
 	Long: `
 Fix runs the Go fix command on the packages named by the import paths.
@@ -29,6 +29,10 @@ The -fix flag sets a comma-separated list of fixes to run.
 The default is all known fixes.
 (Its value is passed to 'go tool fix -r'.)
 
+The -goo flag runs the goo rewrites of 'go tool goofix' instead, which
+turn Go code into idiomatic goo; with -reverse, they turn goo code back
+into Go. The -fix flag then selects among the goo rewrites.
+
 For more about fix, see 'go doc cmd/fix'.
 For more about specifying packages, see 'go help packages'.
 
@@ -38,7 +42,11 @@ See also: go fmt, go vet.
 	`,
 }
 
-var fixes = CmdFix.Flag.String("fix", "", "comma-separated list of fixes to apply")
+var (
+	fixes   = CmdFix.Flag.String("fix", "", "comma-separated list of fixes to apply")
+	gooMode = CmdFix.Flag.Bool("goo", false, "")
+	reverse = CmdFix.Flag.Bool("reverse", false, "")
+)
 
 func init() {
 	work.AddBuildFlags(CmdFix, work.OmitBuildOnlyFlags)
@@ -81,6 +89,13 @@ func runFix(ctx context.Context, cmd *base.Command, args []string) {
 		if *fixes != "" {
 			fixArg = []string{"-r=" + *fixes}
 		}
+		if *gooMode {
+			if *reverse {
+				fixArg = append(fixArg, "-reverse")
+			}
+			base.Run(str.StringList(cfg.BuildToolexec, filepath.Join(cfg.GOROOTbin, "go"), "tool", "goofix", fixArg, files))
+			continue
+		}
 		base.Run(str.StringList(cfg.BuildToolexec, filepath.Join(cfg.GOROOTbin, "go"), "tool", "fix", "-go="+goVersion, fixArg, files))
 	}
 }
@@ -6,6 +6,8 @@ package imports
 
 import (
 	"fmt"
+	"go/parser"
+	"go/token"
 	"io/fs"
 	"path/filepath"
 	"sort"
@@ -62,6 +64,11 @@ Files:
 		if err != nil {
 			return nil, nil, fmt.Errorf("reading %s: %v", name, err)
 		}
+		if strings.HasSuffix(name, ".goo") {
+			// goo: the package clause is optional, which stops ReadImports
+			// at the first import, so leave .goo files to go/parser.
+			list = gooImports(name, data)
+		}
 
 		// import "C" is implicit requirement of cgo tag.
 		// When listing files on the command line (explicitFiles=true)
@@ -80,7 +87,7 @@ Files:
 		}
 		numFiles++
 		m := imports
-		if strings.HasSuffix(name, "_test.go") {
+		if strings.HasSuffix(name, "_test.go") || strings.HasSuffix(name, "_test.goo") {
 			m = testImports
 		}
 		for _, p := range list {
@@ -97,6 +104,22 @@ Files:
 	return keys(imports), keys(testImports), nil
 }
 
+// gooImports returns the quoted import paths of the .goo file data.
+func gooImports(name string, data []byte) []string {
+	f, _ := parser.ParseFile(token.NewFileSet(), name, data, parser.ImportsOnly)
+	if f == nil {
+		return nil
+	}
+	var list []string
+	for _, spec := range f.Imports {
+		list = append(list, spec.Path.Value)
+	}
+	if strings.HasSuffix(name, "_test.goo") {
+		list = append(list, `"testing"`)
+	}
+	return list
+}
+
 var ErrNoGo = fmt.Errorf("no Go source files")
 
 func keys(m map[string]bool) []string {
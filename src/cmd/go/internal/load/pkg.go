@@ -12,7 +12,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"go/ast"
 	"go/build"
+	"go/parser"
 	"go/scanner"
 	"go/token"
 	"internal/godebug"
@@ -239,6 +241,8 @@ type PackageInternal struct {
 	OrigImportPath    string              // original import path before adding '_test' suffix
 	PGOProfile        string              // path to PGO profile
 	ForMain           string              // the main package if this package is built specifically for it
+	AutoImports       map[string][]string // goo: standard library packages by name for the qualifiers of .goo files
+	UsesDbg           bool                // goo: .goo files call dbg, so the package imports runtime/dbg
 
 	Asmflags   []string // -asmflags for this package
 	Gcflags    []string // -gcflags for this package
@@ -1933,9 +1937,20 @@ func (p *Package) load(ctx context.Context, opts PackageOpts, path string, stk *
 			// %go_import directives to import other packages.
 		}
 
-		// Auto-inject fmt import for .goo files that use printf() or put() but don't import fmt
-		if needsFmtImport(p) {
-			addImport("fmt", true)
+		// goo: .goo files import fmt and the standard library packages
+		// they use as qualifiers implicitly.
+		for _, path := range gooImports(p, p.GoFiles) {
+			addImport(path, true)
+		}
+		for _, path := range gooImports(p, p.TestGoFiles) {
+			if !slices.Contains(p.TestImports, path) {
+				p.TestImports = append(p.TestImports, path)
+			}
+		}
+		for _, path := range gooImports(p, p.XTestGoFiles) {
+			if !slices.Contains(p.XTestImports, path) {
+				p.XTestImports = append(p.XTestImports, path)
+			}
 		}
 
 		// The linker loads implicit dependencies.
@@ -3621,45 +3636,251 @@ func SelectCoverPackages(roots []*Package, match []func(*Package) bool, op strin
 	return covered
 }
 
-// needsFmtImport checks if any .goo files in the package use printf but don't import fmt
-func needsFmtImport(p *Package) bool {
-	// Only check .goo files
-	for _, file := range p.GoFiles {
+// gooImports returns the standard library packages the .goo files
+// among files import implicitly: fmt if they call put, printf or prints,
+// runtime/dbg if they call dbg, and the packages named like a qualifier
+// they use, as in strings.ToUpper, if the name is not declared in the
+// file and only one package has it.
+// It records the packages of all those names in p.Internal.AutoImports
+// for the compiler, which imports them if the names are not declared
+// otherwise and reports the ambiguous ones.
+func gooImports(p *Package, files []string) []string {
+	var imports []string
+	add := func(path string) {
+		if !slices.Contains(imports, path) {
+			imports = append(imports, path)
+		}
+	}
+	autoImport := func(name string, paths []string) {
+		if p.Internal.AutoImports == nil {
+			p.Internal.AutoImports = make(map[string][]string)
+		}
+		p.Internal.AutoImports[name] = paths
+		if len(paths) == 1 {
+			add(paths[0])
+		}
+	}
+	for _, file := range files {
 		if !strings.HasSuffix(file, ".goo") {
 			continue
 		}
+		src, err := fsys.ReadFile(filepath.Join(p.Dir, file))
+		if err != nil {
+			continue // reported when compiling
+		}
+		qualifiers, usesPrint, usesDbg := gooQualifiers(src)
+		if usesPrint {
+			autoImport("fmt", []string{"fmt"})
+		}
+		if usesDbg {
+			p.Internal.UsesDbg = true
+			add("runtime/dbg")
+		}
+		for _, name := range qualifiers {
+			if paths := modindex.StdPackagesByName()[name]; len(paths) > 0 {
+				autoImport(name, paths)
+			}
+		}
+	}
+	return imports
+}
 
-		// Check if fmt is already imported
-		hasFmt := false
-		for _, imp := range p.Imports {
-			if imp == "fmt" {
-				hasFmt = true
-				break
+// gooQualifiers returns the names src uses as the qualifier of a
+// selector, as in strings.ToUpper, but does not declare, whether it
+// uses put, printf or prints, and whether it uses dbg.
+// If go/parser accepts src, the names are resolved through the scopes
+// of the parsed file. Otherwise src uses syntax only the compiler
+// parses, and gooScanQualifiers leaves out the names declared anywhere
+// in src, even if the declaration does not reach the qualifier.
+func gooQualifiers(src []byte) (names []string, usesPrint, usesDbg bool) {
+	file, err := parser.ParseFile(token.NewFileSet(), "x.goo", src, 0)
+	if err != nil {
+		return gooScanQualifiers(src)
+	}
+	ast.PreorderStack(file, nil, func(n ast.Node, stack []ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if !ok || id.Obj != nil || gooLoopVar(id.Name, stack) {
+			return true
+		}
+		if sel, ok := stack[len(stack)-1].(*ast.SelectorExpr); ok {
+			if sel.X == id && !slices.Contains(names, id.Name) {
+				names = append(names, id.Name)
 			}
+			return true
 		}
-		if hasFmt {
-			return false
+		switch id.Name {
+		case "put", "printf", "prints":
+			usesPrint = true
+		case "dbg":
+			usesDbg = true
+		}
+		return true
+	})
+	return names, usesPrint, usesDbg
+}
+
+// gooLoopVar reports whether name is the variable of a goo loop
+// for name in xs around the node at the top of stack. go/parser reads
+// the loop header as the condition name in xs, so it leaves the
+// variable unresolved in the loop body.
+func gooLoopVar(name string, stack []ast.Node) bool {
+	for _, n := range stack {
+		if loop, ok := n.(*ast.ForStmt); ok {
+			if cond, ok := loop.Cond.(*ast.BinaryExpr); ok && cond.Op == token.IN {
+				if x, ok := cond.X.(*ast.Ident); ok && x.Name == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// gooScanQualifiers is gooQualifiers for a .goo file go/parser does not
+// accept. It scans the tokens of src and leaves out the names gooDeclared
+// finds declared anywhere in src.
+func gooScanQualifiers(src []byte) (names []string, usesPrint, usesDbg bool) {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", -1, len(src))
+	var s scanner.Scanner
+	s.Init(file, src, nil, 0)
+	var toks []token.Token
+	var lits []string
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
 		}
+		toks = append(toks, tok)
+		lits = append(lits, lit)
+	}
 
-		// Check if file uses printf
-		fullPath := filepath.Join(p.Dir, file)
-		content, err := os.ReadFile(fullPath)
-		if err != nil {
+	declared := gooDeclared(toks, lits)
+	for i, tok := range toks {
+		if tok != token.IDENT {
 			continue
 		}
+		if i == 0 || toks[i-1] != token.PERIOD {
+			switch lits[i] {
+			case "put", "printf", "prints":
+				usesPrint = true
+			case "dbg":
+				usesDbg = true
+			}
+		}
+		// X.Sel, but not the middle of a chain like a.X.Sel.
+		if i+2 < len(toks) && toks[i+1] == token.PERIOD && toks[i+2] == token.IDENT &&
+			(i == 0 || toks[i-1] != token.PERIOD) &&
+			!declared[lits[i]] && !slices.Contains(names, lits[i]) {
+			names = append(names, lits[i])
+		}
+	}
+	return names, usesPrint, usesDbg
+}
+
+// gooDeclared returns the names the tokens of a .goo file declare: the
+// names of declarations, of parameters and results, those on the left
+// of := (including destructuring patterns), the variables of for-in
+// loops and comprehensions, and the parameters of lambdas.
+func gooDeclared(toks []token.Token, lits []string) map[string]bool {
+	declared := make(map[string]bool)
+	// list declares the identifier list starting at toks[i].
+	list := func(i int) {
+		for ; i < len(toks) && toks[i] == token.IDENT; i += 2 {
+			declared[lits[i]] = true
+			if i+1 == len(toks) || toks[i+1] != token.COMMA {
+				break
+			}
+		}
+	}
+	// back declares the identifiers before toks[i] back to the start of
+	// the statement, skipping the punctuation of destructuring patterns.
+	back := func(i int) {
+		for i--; i >= 0; i-- {
+			switch toks[i] {
+			case token.IDENT:
+				declared[lits[i]] = true
+			case token.COMMA, token.ELLIPSIS, token.LBRACE, token.RBRACE, token.LBRACK, token.RBRACK:
+			default:
+				return
+			}
+		}
+	}
 
-		lines := strings.Split(string(content), "\n")
-		for _, line := range lines {
-			trimmed := strings.TrimSpace(line)
-			// Skip comment lines starting with #
-			if strings.HasPrefix(trimmed, "#") {
+	for i := 0; i < len(toks); i++ {
+		switch tok := toks[i]; {
+		case tok == token.DEFINE:
+			back(i)
+		case tok == token.VAR || tok == token.CONST || tok == token.TYPE:
+			if i+1 < len(toks) && toks[i+1] == token.LPAREN {
+				// var ( a, b int; c = 1 )
+				for j := i + 2; j < len(toks) && toks[j] != token.RPAREN; j++ {
+					if toks[j-1] == token.LPAREN || toks[j-1] == token.SEMICOLON {
+						list(j)
+					}
+				}
+				continue
+			}
+			list(i + 1)
+		case tok == token.FOR:
+			// for k, v in m, as in loops and comprehensions
+			j := i + 1
+			for j+1 < len(toks) && toks[j] == token.IDENT && toks[j+1] == token.COMMA {
+				j += 2
+			}
+			if j+1 < len(toks) && toks[j] == token.IDENT && toks[j+1] == token.IDENT && lits[j+1] == "in" {
+				list(i + 1)
+			}
+		case tok == token.ASSIGN && i+1 < len(toks) && toks[i+1] == token.GTR:
+			// x => e and (x, y) => e
+			if i > 0 && toks[i-1] == token.IDENT {
+				declared[lits[i-1]] = true
+			} else if i > 0 && toks[i-1] == token.RPAREN {
+				j := i - 2
+				for j >= 0 && (toks[j] == token.IDENT || toks[j] == token.COMMA) {
+					j--
+				}
+				list(j + 1)
+			}
+		case tok == token.FUNC || tok == token.IDENT && lits[i] == "def":
+			i = gooSignature(toks, lits, i+1, declared)
+		}
+	}
+	return declared
+}
+
+// gooSignature declares the name, receiver, type parameters, parameters
+// and results of the function whose signature starts at toks[i], and
+// returns the index of the last token of the signature.
+func gooSignature(toks []token.Token, lits []string, i int, declared map[string]bool) int {
+	depth := 0
+	for ; i < len(toks); i++ {
+		switch toks[i] {
+		case token.LPAREN, token.LBRACK:
+			depth++
+		case token.RPAREN, token.RBRACK:
+			depth--
+		case token.LBRACE, token.SEMICOLON, token.ASSIGN:
+			if depth == 0 {
+				return i - 1
+			}
+		case token.IDENT:
+			if depth == 0 {
+				// the function name is followed by its (type) parameters
+				if i+1 < len(toks) && (toks[i+1] == token.LPAREN || toks[i+1] == token.LBRACK) {
+					declared[lits[i]] = true
+				}
 				continue
 			}
-			// Look for printf function calls
-			if strings.Contains(line, "printf(") {
-				return true
+			// a parameter name is followed by its type or by the next name
+			if i+1 < len(toks) {
+				switch toks[i+1] {
+				case token.IDENT, token.COMMA, token.MUL, token.LBRACK, token.ELLIPSIS,
+					token.FUNC, token.MAP, token.CHAN, token.STRUCT, token.INTERFACE, token.ARROW:
+					declared[lits[i]] = true
+				}
 			}
 		}
 	}
-	return false
+	return i
 }
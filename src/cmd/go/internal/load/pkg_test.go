@@ -6,6 +6,7 @@ package load
 
 import (
 	"cmd/go/internal/cfg"
+	"slices"
 	"testing"
 )
 
@@ -80,3 +81,37 @@ func TestIsVersionElement(t *testing.T) {
 		}
 	}
 }
+
+func TestGooQualifiers(t *testing.T) {
+	for _, tt := range []struct {
+		src       string
+		want      []string
+		usesPrint bool
+		usesDbg   bool
+	}{
+		{"put strings.ToUpper(s)", []string{"strings"}, true, false},
+		{"x := a.b.c", []string{"a"}, false, false},
+		{"user := U{3}\nput user.age", nil, true, false},
+		{"user, n := U{3}, 1\nuser.age++", nil, false, false},
+		{"{user, n} := pair\nuser.age++", nil, false, false},
+		{"var user U\nuser.age++", nil, false, false},
+		{"var (\n\tos = 1\n\tuser U\n)\nuser.age += os.Getpid()", nil, false, false},
+		{"def f(user U, b strings.Builder) (time time.Duration) {\n\tuser.age++\n}", []string{"strings"}, false, false},
+		{"func (user *U) Grow() { user.age++ }", nil, false, false},
+		{"func f() strings.Builder { return strings.Builder{} }", []string{"strings"}, false, false},
+		{"for user in users { user.age++ }", nil, false, false},
+		{"ages := [user.age for user in users]", nil, false, false},
+		{"xs.map(user => user.age)", nil, false, false},
+		{"xs.reduce((sum, user) => sum + user.age)", []string{"xs"}, false, false},
+		{"time := 3\nx.time.Now()", []string{"x"}, false, false},
+		{"n := dbg(user.age) + 1", []string{"user"}, false, true},
+		{"func f() {\n\tpath := U{}\n\tpath.x++\n}\n\nfunc g() string { return path.Base(s) }", []string{"path"}, false, false},
+		{"func f(users []U) {\n\tfor user in users {\n\t\tput user.age\n\t}\n}", nil, true, false},
+		{"func f(dbg D) { dbg.x++; put x.put }", []string{"x"}, true, false},
+	} {
+		got, usesPrint, usesDbg := gooQualifiers([]byte(tt.src))
+		if !slices.Equal(got, tt.want) || usesPrint != tt.usesPrint || usesDbg != tt.usesDbg {
+			t.Errorf("gooQualifiers(%q) = %q, %v, %v; want %q, %v, %v", tt.src, got, usesPrint, usesDbg, tt.want, tt.usesPrint, tt.usesDbg)
+		}
+	}
+}
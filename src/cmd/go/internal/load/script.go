@@ -0,0 +1,178 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package load
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cmd/go/internal/base"
+	"cmd/go/internal/cache"
+	"cmd/go/internal/gover"
+	"cmd/go/internal/modload"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// A ScriptRequire is a dependency pragma in the header of a goo script:
+//
+//	#require github.com/foo/bar v1.2.3
+//	//goo:require github.com/foo/bar v1.2.3
+//
+// The header is the run of blank and comment lines at the top of the file.
+type ScriptRequire struct {
+	Mod  module.Version
+	File string // file declaring the requirement
+	Line int    // 1-based line of the directive
+}
+
+// ScriptModulePath is the module path of the ephemeral module
+// built for a script with dependency pragmas.
+const ScriptModulePath = "goo.script"
+
+// ParseScriptHeader returns the dependency pragmas in the header of data.
+// The version of a pragma written without one is empty.
+func ParseScriptHeader(file string, data []byte) ([]ScriptRequire, error) {
+	var reqs []ScriptRequire
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		f, ok := scriptDirective(line)
+		if !ok {
+			if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+				continue
+			}
+			break
+		}
+		if len(f) < 1 || len(f) > 2 {
+			return nil, fmt.Errorf("%s:%d: usage: #require module/path [version]", base.ShortPath(file), n)
+		}
+		r := ScriptRequire{File: file, Line: n}
+		r.Mod.Path = f[0]
+		if len(f) == 2 {
+			r.Mod.Version = f[1]
+		}
+		if err := module.CheckPath(r.Mod.Path); err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", base.ShortPath(file), n, err)
+		}
+		reqs = append(reqs, r)
+	}
+	return reqs, nil
+}
+
+// scriptDirective reports whether line is a #require or //goo:require
+// directive and returns its arguments.
+func scriptDirective(line string) ([]string, bool) {
+	for _, prefix := range []string{"#require", "//goo:require"} {
+		if rest, ok := strings.CutPrefix(line, prefix); ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t') {
+			return strings.Fields(rest), true
+		}
+	}
+	return nil, false
+}
+
+// ScriptRequires returns the dependency pragmas of the named .goo files.
+func ScriptRequires(files []string) ([]ScriptRequire, error) {
+	var reqs []ScriptRequire
+	for _, file := range files {
+		if !strings.HasSuffix(file, ".goo") {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			// Reported when the package is loaded.
+			continue
+		}
+		r, err := ParseScriptHeader(file, data)
+		if err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, r...)
+	}
+	return reqs, nil
+}
+
+// ScriptGoMod returns the go.mod file of the ephemeral module for reqs.
+func ScriptGoMod(reqs []ScriptRequire) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "module %s\n\ngo %s\n", ScriptModulePath, gover.Local())
+	if len(reqs) > 0 {
+		buf.WriteString("\nrequire (\n")
+		for _, r := range reqs {
+			fmt.Fprintf(&buf, "\t%s %s\n", r.Mod.Path, r.Mod.Version)
+		}
+		buf.WriteString(")\n")
+	}
+	return buf.Bytes()
+}
+
+// ScriptCacheDir returns the directory holding the ephemeral modules
+// of goo scripts.
+func ScriptCacheDir() (string, error) {
+	dir, _, err := cache.DefaultDir()
+	if err != nil {
+		return "", err
+	}
+	if dir == "off" {
+		return "", fmt.Errorf("GOCACHE=off")
+	}
+	return filepath.Join(dir, "goo-script"), nil
+}
+
+// InitScriptModule makes the ephemeral module for the dependency pragmas
+// of the .goo files at the start of args the main module.
+// Scripts without pragmas are left alone.
+// It must be called before modload.Init.
+//
+// The module lives in the script cache, keyed by its go.mod,
+// so that the go.sum recorded by the first run is reused by later ones.
+// Modules are fetched as usual, honoring GOPROXY and GOFLAGS.
+func InitScriptModule(args []string) {
+	var files []string
+	for _, arg := range args {
+		if !strings.HasSuffix(arg, ".go") && !strings.HasSuffix(arg, ".goo") {
+			break
+		}
+		files = append(files, arg)
+	}
+	reqs, err := ScriptRequires(files)
+	if err != nil {
+		base.Fatalf("go: %v", err)
+	}
+	if len(reqs) == 0 {
+		return
+	}
+	for _, r := range reqs {
+		if !semver.IsValid(r.Mod.Version) || semver.Canonical(r.Mod.Version) != r.Mod.Version {
+			what := "missing version"
+			if r.Mod.Version != "" {
+				what = fmt.Sprintf("version %q is not a pinned version", r.Mod.Version)
+			}
+			base.Fatalf("go: %s:%d: #require %s: %s\n\tRun 'go script lock %s' to pin it.", base.ShortPath(r.File), r.Line, r.Mod.Path, what, base.ShortPath(r.File))
+		}
+	}
+
+	gomod := ScriptGoMod(reqs)
+	cacheDir, err := ScriptCacheDir()
+	if err != nil {
+		base.Fatalf("go: #require needs the build cache: %v", err)
+	}
+	dir := filepath.Join(cacheDir, fmt.Sprintf("%x", sha256.Sum256(gomod))[:32])
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err != nil {
+		if err := os.MkdirAll(dir, 0777); err != nil {
+			base.Fatalf("go: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "go.mod"), gomod, 0666); err != nil {
+			base.Fatalf("go: %v", err)
+		}
+	}
+	modload.EnterScriptModule(dir)
+}
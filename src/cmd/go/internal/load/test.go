@@ -13,12 +13,15 @@ import (
 	"go/build"
 	"go/doc"
 	"go/parser"
+	"go/scanner"
 	"go/token"
 	"internal/lazytemplate"
+	"io"
 	"maps"
 	"path/filepath"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -257,6 +260,7 @@ func TestPackagesAndErrors(ctx context.Context, done func(), opts PackageOpts, p
 				Embed:          xtestEmbed,
 				OrigImportPath: p.Internal.OrigImportPath,
 				PGOProfile:     p.Internal.PGOProfile,
+				AutoImports:    p.Internal.AutoImports,
 			},
 		}
 		if pxtestNeedsPtest {
@@ -686,6 +690,15 @@ type testFunc struct {
 	Name      string // function name
 	Output    string // output, for examples
 	Unordered bool   // output is allowed to be unordered.
+	Func      string // function name if different from Name, for goo test blocks
+}
+
+// Ident returns the name of the test function.
+func (f testFunc) Ident() string {
+	if f.Func != "" {
+		return f.Func
+	}
+	return f.Name
 }
 
 var testFileSet = token.NewFileSet()
@@ -697,6 +710,9 @@ func (t *testFuncs) load(filename, pkg string, doImport, seen *bool) error {
 		return err
 	}
 	defer src.Close()
+	if strings.HasSuffix(filename, ".goo") {
+		return t.loadGoo(filename, pkg, src, doImport, seen)
+	}
 	f, err := parser.ParseFile(testFileSet, filename, src, parser.ParseComments|parser.SkipObjectResolution)
 	if err != nil {
 		return err
@@ -713,7 +729,7 @@ func (t *testFuncs) load(filename, pkg string, doImport, seen *bool) error {
 		switch {
 		case name == "TestMain":
 			if isTestFunc(n, "T") {
-				t.Tests = append(t.Tests, testFunc{pkg, name, "", false})
+				t.Tests = append(t.Tests, testFunc{pkg, name, "", false, ""})
 				*doImport, *seen = true, true
 				continue
 			}
@@ -724,28 +740,28 @@ func (t *testFuncs) load(filename, pkg string, doImport, seen *bool) error {
 			if t.TestMain != nil {
 				return errors.New("multiple definitions of TestMain")
 			}
-			t.TestMain = &testFunc{pkg, name, "", false}
+			t.TestMain = &testFunc{pkg, name, "", false, ""}
 			*doImport, *seen = true, true
 		case isTest(name, "Test"):
 			err := checkTestFunc(n, "T")
 			if err != nil {
 				return err
 			}
-			t.Tests = append(t.Tests, testFunc{pkg, name, "", false})
+			t.Tests = append(t.Tests, testFunc{pkg, name, "", false, ""})
 			*doImport, *seen = true, true
 		case isTest(name, "Benchmark"):
 			err := checkTestFunc(n, "B")
 			if err != nil {
 				return err
 			}
-			t.Benchmarks = append(t.Benchmarks, testFunc{pkg, name, "", false})
+			t.Benchmarks = append(t.Benchmarks, testFunc{pkg, name, "", false, ""})
 			*doImport, *seen = true, true
 		case isTest(name, "Fuzz"):
 			err := checkTestFunc(n, "F")
 			if err != nil {
 				return err
 			}
-			t.FuzzTargets = append(t.FuzzTargets, testFunc{pkg, name, "", false})
+			t.FuzzTargets = append(t.FuzzTargets, testFunc{pkg, name, "", false, ""})
 			*doImport, *seen = true, true
 		}
 	}
@@ -757,12 +773,98 @@ func (t *testFuncs) load(filename, pkg string, doImport, seen *bool) error {
 			// Don't run examples with no output.
 			continue
 		}
-		t.Examples = append(t.Examples, testFunc{pkg, "Example" + e.Name, e.Output, e.Unordered})
+		t.Examples = append(t.Examples, testFunc{pkg, "Example" + e.Name, e.Output, e.Unordered, ""})
 		*seen = true
 	}
 	return nil
 }
 
+// loadGoo is like load for a .goo file, which go/parser cannot parse.
+// It scans the top level of the file for test functions and for the
+// goo test blocks
+//
+//	test "name" { ... }
+//
+// which run under the quoted name (see gooTestFuncName).
+// Test function signatures are left to the compiler to check,
+// and examples are not supported.
+func (t *testFuncs) loadGoo(filename, pkg string, r io.Reader, doImport, seen *bool) error {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	var s scanner.Scanner
+	s.Init(testFileSet.AddFile(filename, -1, len(src)), src, nil, 0)
+
+	depth := 0
+	prev, prevLit := token.SEMICOLON, ""
+	start := true // prev starts a top-level declaration or statement
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		switch tok {
+		case token.LPAREN, token.LBRACK, token.LBRACE:
+			depth++
+		case token.RPAREN, token.RBRACK, token.RBRACE:
+			depth--
+		}
+		if depth > 0 {
+			continue
+		}
+
+		switch {
+		case start && prev == token.FUNC && tok == token.IDENT:
+			f := testFunc{Package: pkg, Name: lit}
+			switch {
+			case lit == "TestMain":
+				if t.TestMain != nil {
+					return errors.New("multiple definitions of TestMain")
+				}
+				t.TestMain = &f
+			case isTest(lit, "Test"):
+				t.Tests = append(t.Tests, f)
+			case isTest(lit, "Benchmark"):
+				t.Benchmarks = append(t.Benchmarks, f)
+			case isTest(lit, "Fuzz"):
+				t.FuzzTargets = append(t.FuzzTargets, f)
+			}
+			if isTest(lit, "Test") || isTest(lit, "Benchmark") || isTest(lit, "Fuzz") {
+				*doImport, *seen = true, true
+			}
+		case start && prev == token.IDENT && prevLit == "test" && tok == token.STRING:
+			name, err := strconv.Unquote(lit)
+			if err != nil {
+				return err
+			}
+			fn := gooTestFuncName(name)
+			for _, f := range t.Tests {
+				if f.Ident() == fn {
+					return fmt.Errorf("%s: test %q and test %q both compile to function %s", filename, name, f.Name, fn)
+				}
+			}
+			t.Tests = append(t.Tests, testFunc{Package: pkg, Name: name, Func: fn})
+			*doImport, *seen = true, true
+		}
+		start = prev == token.SEMICOLON
+		prev, prevLit = tok, lit
+	}
+	return nil
+}
+
+// gooTestFuncName returns the name of the function the compiler
+// generates for the goo test block with the given name.
+// It must match cmd/compile/internal/syntax.testFuncName.
+func gooTestFuncName(name string) string {
+	return "Test_" + strings.Map(func(r rune) rune {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
 func checkTestFunc(fn *ast.FuncDecl, arg string) error {
 	var why string
 	if !isTestFunc(fn, arg) {
@@ -804,7 +906,7 @@ import (
 
 var tests = []testing.InternalTest{
 {{range .Tests}}
-	{"{{.Name}}", {{.Package}}.{{.Name}}},
+	{ {{- .Name | printf "%q"}}, {{.Package}}.{{.Ident}}},
 {{end}}
 }
 
@@ -449,7 +449,7 @@ func getFileInfo(dir, name string, fset *token.FileSet) (*fileInfo, error) {
 	var ignoreBinaryOnly bool
 	if strings.HasSuffix(name, ".go") || strings.HasSuffix(name, ".goo") {
 		err = readGoInfo(f, info)
-		if strings.HasSuffix(name, "_test.go") {
+		if strings.HasSuffix(name, "_test.go") || strings.HasSuffix(name, "_test.goo") {
 			ignoreBinaryOnly = true // ignore //go:binary-only-package comments in _test.go files
 		}
 	} else {
@@ -14,6 +14,7 @@ import (
 	"go/token"
 	"internal/godebug"
 	"internal/goroot"
+	"io/fs"
 	"path"
 	"path/filepath"
 	"runtime"
@@ -549,7 +550,7 @@ func (rp *IndexPackage) Import(bctxt build.Context, mode build.ImportMode) (p *b
 			p.IgnoredGoFiles = append(p.IgnoredGoFiles, name)
 			continue
 		}
-		isTest := strings.HasSuffix(name, "_test.go")
+		isTest := strings.HasSuffix(name, "_test.go") || strings.HasSuffix(name, "_test.goo")
 		isXTest := false
 		if isTest && strings.HasSuffix(tf.pkgName(), "_test") && p.Name != tf.pkgName() {
 			isXTest = true
@@ -700,6 +701,58 @@ func IsStandardPackage(goroot_, compiler, path string) bool {
 	return false
 }
 
+// StdPackagesByName returns the import paths of the importable
+// standard library packages, keyed by package name, using the
+// module index if possible. goo: .goo files import the standard
+// library packages they use as qualifiers by these names.
+var StdPackagesByName = sync.OnceValue(func() map[string][]string {
+	byName := make(map[string][]string)
+	modroot := cfg.GOROOTsrc
+	fsys.WalkDir(modroot, func(dir string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		rel := str.TrimFilePathPrefix(dir, modroot)
+		if rel == "" {
+			return nil
+		}
+		switch elem := d.Name(); {
+		case rel == "cmd" || rel == "builtin", elem == "internal", elem == "vendor", elem == "testdata",
+			strings.HasPrefix(elem, "_"), strings.HasPrefix(elem, "."):
+			return filepath.SkipDir
+		}
+		rp, err := GetPackage(modroot, dir)
+		if errors.Is(err, ErrNotIndexed) {
+			rp, err = packageFromBytes(modroot, indexPackage(modroot, dir))
+		}
+		if err != nil {
+			return nil
+		}
+		if name := rp.packageName(); name != "" && name != "main" {
+			path := filepath.ToSlash(rel)
+			byName[name] = append(byName[name], path)
+		}
+		return nil
+	})
+	return byName
+})
+
+// packageName returns the package name of the non-test Go files of rp,
+// or "" if it has none.
+func (rp *IndexPackage) packageName() string {
+	defer unprotect(protect(), nil)
+	for _, sf := range rp.sourceFiles {
+		file := sf.name()
+		if sf.ignoreFile() || !strings.HasSuffix(file, ".go") || strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		if name := sf.pkgName(); name != "" && name != "documentation" {
+			return name
+		}
+	}
+	return ""
+}
+
 // IsGoDir is the equivalent of fsys.IsGoDir using the information in the index.
 func (rp *IndexPackage) IsGoDir() (_ bool, err error) {
 	defer func() {
@@ -761,7 +814,7 @@ Files:
 		}
 		numFiles++
 		m := imports_
-		if strings.HasSuffix(name, "_test.go") {
+		if strings.HasSuffix(name, "_test.go") || strings.HasSuffix(name, "_test.goo") {
 			m = testImports
 		}
 		for _, p := range imps {
@@ -69,8 +69,29 @@ var (
 	// modRoots != nil implies len(modRoots) > 0
 	modRoots []string
 	gopath   string
+
+	// scriptModule reports whether the main module is the ephemeral
+	// module of a goo script, set by EnterScriptModule.
+	scriptModule bool
 )
 
+// EnterScriptModule makes dir, which must contain a go.mod file, the main
+// module for a goo script that declares its own requirements.
+// Missing go.sum entries are recorded in dir rather than reported.
+// It must be called before Init.
+func EnterScriptModule(dir string) {
+	ForceUseModules = true
+	workFilePath = ""
+	modRoots = []string{dir}
+	scriptModule = true
+}
+
+// InScriptModule reports whether the main module is the ephemeral
+// module of a goo script.
+func InScriptModule() bool {
+	return scriptModule
+}
+
 // EnterModule resets MainModules and requirements to refer to just this one module.
 func EnterModule(ctx context.Context, enterModroot string) {
 	MainModules = nil // reset MainModules
@@ -1500,6 +1521,11 @@ func setDefaultBuildMod() {
 		// Don't override an explicit '-mod=' argument.
 		return
 	}
+	if scriptModule {
+		// goo: the script module is ours to update.
+		cfg.BuildMod = "mod"
+		return
+	}
 
 	// TODO(#40775): commands should pass in the module mode as an option
 	// to modload functions instead of relying on an implicit setting
@@ -0,0 +1,312 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package repl
+
+// hostSource is the source of the program that keeps the state of a
+// session. It reads requests from file descriptor 3, one per line,
+// loads the plugin compiled for an input, copies the session variables
+// the input uses into the plugin, runs it and copies them back. After
+// each request it writes marker to its output and a reply to file
+// descriptor 4. Requests and replies are JSON-encoded.
+const hostSource = `package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"os"
+	"plugin"
+	"reflect"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// marker is the marker of package cmd/go/internal/repl.
+const marker = "\x00goo-repl\x00"
+
+// A request asks to run the plugin compiled for an input.
+type request struct {
+	Plugin string
+	Old    []string // the session variables the input uses, as in GooReplOld
+	New    []string // the variables it declares, as in GooReplNew
+}
+
+// A reply reports the outcome of a request.
+type reply struct {
+	Err     string              // the input was not run
+	Panic   bool                // the input panicked
+	Dropped []string            // variables dropped because their types changed
+	Vars    map[string]variable // the variables declared by the input
+}
+
+// A variable describes a variable of the session.
+type variable struct {
+	Type    string            // its type as written in a plugin, or "" if it can't be
+	Imports map[string]string // the paths of the packages Type refers to, by name
+}
+
+// vars holds pointers to the variables of the session.
+var vars = map[string]reflect.Value{}
+
+func main() {
+	requests := bufio.NewScanner(os.NewFile(3, "requests"))
+	replies := json.NewEncoder(os.NewFile(4, "replies"))
+	for requests.Scan() {
+		var req request
+		rep := reply{Vars: map[string]variable{}}
+		if err := json.Unmarshal(requests.Bytes(), &req); err != nil {
+			rep.Err = err.Error()
+		} else {
+			run(&req, &rep)
+		}
+		os.Stdout.WriteString(marker)
+		replies.Encode(&rep)
+	}
+}
+
+// run runs the plugin of req.
+func run(req *request, rep *reply) {
+	p, err := plugin.Open(req.Plugin)
+	if err != nil {
+		rep.Err = err.Error()
+		return
+	}
+	var syms [3]plugin.Symbol
+	for i, name := range []string{"GooReplOld", "GooReplNew", "GooReplRun"} {
+		if syms[i], err = p.Lookup(name); err != nil {
+			rep.Err = err.Error()
+			return
+		}
+	}
+	old, new, f := *syms[0].(*[]any), syms[1].(*[]any), syms[2].(func())
+
+	for i, name := range req.Old {
+		v, ok := vars[name]
+		if !ok || !assign(reflect.ValueOf(old[i]), v) {
+			delete(vars, name)
+			rep.Dropped = append(rep.Dropped, name)
+		}
+	}
+	if rep.Dropped != nil {
+		rep.Err = "the types of " + strings.Join(rep.Dropped, ", ") + " have changed"
+		return
+	}
+	rep.Panic = call(f)
+	for i, name := range req.Old {
+		assign(vars[name], reflect.ValueOf(old[i]))
+	}
+	if rep.Panic {
+		return
+	}
+	for i, name := range req.New {
+		v := reflect.ValueOf((*new)[i])
+		vars[name] = v
+		imports := map[string]string{}
+		rep.Vars[name] = variable{spell(v.Type().Elem(), imports), imports}
+	}
+}
+
+// call calls f and reports whether it panicked.
+func call(f func()) (panicked bool) {
+	defer func() {
+		if e := recover(); e != nil {
+			fmt.Fprintf(os.Stderr, "panic: %v\n\n%s", e, debug.Stack())
+			panicked = true
+		}
+	}()
+	f()
+	return false
+}
+
+// assign copies the variable src points to into the one dst points to.
+// Every plugin declares the types of the session again, so the types of
+// the variables need only be alike.
+func assign(dst, src reflect.Value) bool {
+	t := dst.Type().Elem()
+	if !alike(t, src.Type().Elem(), map[[2]reflect.Type]bool{}) {
+		return false
+	}
+	reflect.NewAt(t, dst.UnsafePointer()).Elem().Set(reflect.NewAt(t, src.UnsafePointer()).Elem())
+	return true
+}
+
+// alike reports whether t and u are written the same way and have the
+// same layout.
+func alike(t, u reflect.Type, seen map[[2]reflect.Type]bool) bool {
+	if t == u || seen[[2]reflect.Type{t, u}] {
+		return true
+	}
+	if t.String() != u.String() || t.Kind() != u.Kind() || t.Size() != u.Size() {
+		return false
+	}
+	seen[[2]reflect.Type{t, u}] = true
+	switch t.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Pointer, reflect.Slice:
+		return alike(t.Elem(), u.Elem(), seen)
+	case reflect.Map:
+		return alike(t.Key(), u.Key(), seen) && alike(t.Elem(), u.Elem(), seen)
+	case reflect.Func:
+		if t.NumIn() != u.NumIn() || t.NumOut() != u.NumOut() {
+			return false
+		}
+		for i := range t.NumIn() {
+			if !alike(t.In(i), u.In(i), seen) {
+				return false
+			}
+		}
+		for i := range t.NumOut() {
+			if !alike(t.Out(i), u.Out(i), seen) {
+				return false
+			}
+		}
+	case reflect.Interface:
+		if t.NumMethod() != u.NumMethod() {
+			return false
+		}
+		for i := range t.NumMethod() {
+			if t.Method(i).Name != u.Method(i).Name || !alike(t.Method(i).Type, u.Method(i).Type, seen) {
+				return false
+			}
+		}
+	case reflect.Struct:
+		if t.NumField() != u.NumField() {
+			return false
+		}
+		for i := range t.NumField() {
+			f, g := t.Field(i), u.Field(i)
+			if f.Name != g.Name || f.Offset != g.Offset || !alike(f.Type, g.Type, seen) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// spell returns the type t as written in a plugin, adding the packages
+// it refers to to imports, or "" if it cannot be written.
+func spell(t reflect.Type, imports map[string]string) string {
+	if name := t.Name(); name != "" {
+		path := t.PkgPath()
+		switch {
+		case path == "":
+			return name
+		case strings.ContainsAny(name, "./"):
+			return "" // instantiated with qualified type arguments
+		case session(path):
+			return name
+		case !token.IsExported(name):
+			return ""
+		}
+		pkg := "_goo_" + strings.Map(func(r rune) rune {
+			if r == '_' || '0' <= r && r <= '9' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' {
+				return r
+			}
+			return '_'
+		}, path)
+		imports[pkg] = path
+		return pkg + "." + name
+	}
+
+	switch t.Kind() {
+	case reflect.Array:
+		return prefix("["+strconv.Itoa(t.Len())+"]", spell(t.Elem(), imports))
+	case reflect.Chan:
+		elem := spell(t.Elem(), imports)
+		if elem != "" && t.Elem().Kind() == reflect.Chan && t.Elem().ChanDir() == reflect.RecvDir {
+			elem = "(" + elem + ")"
+		}
+		return prefix(t.ChanDir().String()+" ", elem)
+	case reflect.Map:
+		key, elem := spell(t.Key(), imports), spell(t.Elem(), imports)
+		if key == "" {
+			return ""
+		}
+		return prefix("map["+key+"]", elem)
+	case reflect.Pointer:
+		return prefix("*", spell(t.Elem(), imports))
+	case reflect.Slice:
+		return prefix("[]", spell(t.Elem(), imports))
+	case reflect.Func:
+		return prefix("func", signature(t, imports))
+	case reflect.Interface:
+		var methods []string
+		for i := range t.NumMethod() {
+			m := t.Method(i)
+			sig := signature(m.Type, imports)
+			if sig == "" || !m.IsExported() && !session(m.PkgPath) {
+				return ""
+			}
+			methods = append(methods, m.Name+sig)
+		}
+		return "interface{" + strings.Join(methods, "; ") + "}"
+	case reflect.Struct:
+		var fields []string
+		for i := range t.NumField() {
+			f := t.Field(i)
+			field := spell(f.Type, imports)
+			if field == "" || !f.IsExported() && !session(f.PkgPath) {
+				return ""
+			}
+			if !f.Anonymous {
+				field = f.Name + " " + field
+			}
+			if f.Tag != "" {
+				field += " " + strconv.Quote(string(f.Tag))
+			}
+			fields = append(fields, field)
+		}
+		return "struct{" + strings.Join(fields, "; ") + "}"
+	}
+	return ""
+}
+
+// signature returns the parameters and results of the function type t
+// as written in a plugin, or "" if they cannot be written.
+func signature(t reflect.Type, imports map[string]string) string {
+	var in, out []string
+	for i := range t.NumIn() {
+		param := spell(t.In(i), imports)
+		if t.IsVariadic() && i == t.NumIn()-1 {
+			param = prefix("...", spell(t.In(i).Elem(), imports))
+		}
+		if param == "" {
+			return ""
+		}
+		in = append(in, param)
+	}
+	for i := range t.NumOut() {
+		result := spell(t.Out(i), imports)
+		if result == "" {
+			return ""
+		}
+		out = append(out, result)
+	}
+	sig := "(" + strings.Join(in, ", ") + ")"
+	switch len(out) {
+	case 0:
+	case 1:
+		sig += " " + out[0]
+	default:
+		sig += " (" + strings.Join(out, ", ") + ")"
+	}
+	return sig
+}
+
+// prefix returns p+s, or "" if s is.
+func prefix(p, s string) string {
+	if s == "" {
+		return ""
+	}
+	return p + s
+}
+
+// session reports whether path is the path of a package declared by the
+// session, which is the main package of a plugin.
+func session(path string) bool {
+	return strings.HasPrefix(path, "plugin/unnamed-")
+}
+`
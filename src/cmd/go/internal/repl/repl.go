@@ -0,0 +1,774 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package repl implements the “go repl” command.
+package repl
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"internal/platform"
+	"io"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"cmd/go/internal/base"
+	"cmd/go/internal/cfg"
+)
+
+var CmdRepl = &base.Command{
+	UsageLine: "go repl [file.goo ...]",
+	Short:     "run an interactive goo session",
+	Long: `
+Repl reads goo declarations, imports and statements from the standard input
+and runs them as they are entered. Running goo without arguments does the same.
+
+The session is kept by a single running program. Every input is compiled
+into a plugin that the program loads and runs, so each statement runs once
+and the variables declared at the top level of an input keep their values
+for later inputs. Declarations and imports are compiled again into every
+later input, reusing the build cache. The value of an input that is a bare
+expression is printed as by put. An input that fails to compile is reported
+and dropped; so are the declarations and variables of an input that panics.
+Lines are joined until brackets are balanced.
+
+The program reads its standard input from the null device, as it does not
+share the standard input of the session. If it exits, as by os.Exit, the
+variables of the session are lost. Repl requires cgo and a platform that
+supports -buildmode=plugin.
+
+Inputs starting with a colon are commands:
+
+	:type expr     print the static type of expr, without evaluating it
+	:doc pkg.Sym   print the documentation of pkg.Sym, as go doc does
+	:load file     load the declarations and statements of a goo file
+	:history       list the inputs of the session; !n repeats input n
+	:reset         forget all inputs and variables
+	:quit          end the session (as does end of input)
+
+The files named on the command line are loaded before reading the standard
+input.
+	`,
+}
+
+func init() {
+	CmdRepl.Run = runRepl // break init loop
+}
+
+func runRepl(ctx context.Context, cmd *base.Command, args []string) {
+	if !platform.BuildModeSupported(cfg.BuildToolchainName, "plugin", cfg.Goos, cfg.Goarch) {
+		base.Fatalf("go: repl requires -buildmode=plugin, which is not supported on %s/%s", cfg.Goos, cfg.Goarch)
+	}
+	if !cfg.BuildContext.CgoEnabled {
+		base.Fatalf("go: repl requires cgo")
+	}
+
+	dir, err := os.MkdirTemp("", "goo-repl-")
+	if err != nil {
+		base.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	gocmd, err := os.Executable()
+	if err != nil {
+		base.Fatal(err)
+	}
+	s := &session{gocmd: gocmd, dir: dir, out: os.Stdout}
+	for _, file := range args {
+		s.load(file)
+	}
+	s.run(os.Stdin)
+}
+
+// A session is the state of a repl: the inputs that make up the plugins
+// compiled for new inputs, and the program that runs them.
+type session struct {
+	gocmd string    // the go command, to build the plugins
+	dir   string    // temporary directory of the plugins
+	out   io.Writer // where results and errors are printed
+
+	imports []string            // import declarations
+	decls   []decl              // other top-level declarations
+	vars    map[string]variable // variables, kept by the host
+	host    *host               // the program running the inputs, if started
+	n       int                 // number of plugins built
+
+	history []string // all inputs, for :history
+}
+
+// A decl is a top-level declaration of a session.
+type decl struct {
+	name string // declared name, if a single one; a redeclaration replaces it
+	text string
+}
+
+// A variable describes a variable of the session.
+type variable struct {
+	Type    string            // its type as written in a plugin, or "" if it can't be
+	Imports map[string]string // the paths of the packages Type refers to, by name
+}
+
+// An input is the part of a plugin that is not taken from the session.
+type input struct {
+	imports []string
+	decls   []decl
+	vars    []string // package-level variable declarations, of loaded files
+	stmts   []string // statements run by the plugin
+
+	file string   // the plugin, once built
+	old  []string // the variables of the session the plugin uses
+	new  []string // the variables declared by vars and stmts
+}
+
+// marker separates the output of the inputs run by the host.
+const marker = "\x00goo-repl\x00"
+
+// pluginPath matches the qualifier of the types declared by the session,
+// which are in the main package of a plugin.
+var pluginPath = regexp.MustCompile(`plugin/unnamed-[0-9a-f]+\.`)
+
+// run reads inputs from r until its end or :quit.
+func (s *session) run(r io.Reader) {
+	defer s.stop()
+	in := bufio.NewScanner(r)
+	for {
+		fmt.Fprint(s.out, "goo> ")
+		if !in.Scan() {
+			fmt.Fprintln(s.out)
+			return
+		}
+		input := in.Text()
+		for depth(input) > 0 {
+			fmt.Fprint(s.out, "...  ")
+			if !in.Scan() {
+				break
+			}
+			input += "\n" + in.Text()
+		}
+		input = strings.TrimSpace(input)
+		if input == "" {
+			continue
+		}
+
+		if n, err := strconv.Atoi(strings.TrimPrefix(input, "!")); err == nil && input[0] == '!' {
+			if n < 1 || n > len(s.history) {
+				fmt.Fprintf(s.out, "no input %d in history\n", n)
+				continue
+			}
+			input = s.history[n-1]
+			fmt.Fprintln(s.out, input)
+		}
+		if input == ":quit" || input == ":q" {
+			return
+		}
+		s.history = append(s.history, input)
+		s.eval(input)
+	}
+}
+
+// eval evaluates a single input.
+func (s *session) eval(text string) {
+	if strings.HasPrefix(text, ":") {
+		cmd, arg, _ := strings.Cut(text[1:], " ")
+		arg = strings.TrimSpace(arg)
+		switch cmd {
+		case "type", "t":
+			// typeof is a constant computed by the type checker:
+			// arg is not evaluated.
+			out := s.out
+			var buf bytes.Buffer
+			s.out = &buf
+			s.exec(&input{stmts: []string{fmt.Sprintf("put(typeof(/*line repl%d.goo:1:7*/%s))", len(s.history), arg)}}, false)
+			s.out = out
+			out.Write(pluginPath.ReplaceAll(buf.Bytes(), []byte("main.")))
+		case "doc", "d":
+			s.doc(arg)
+		case "load", "l":
+			s.load(arg)
+		case "history", "h":
+			for i, h := range s.history[:len(s.history)-1] {
+				fmt.Fprintf(s.out, "%4d  %s\n", i+1, strings.ReplaceAll(h, "\n", "\n      "))
+			}
+		case "reset":
+			s.stop()
+			s.imports, s.decls, s.vars = nil, nil, nil
+		default:
+			fmt.Fprintf(s.out, "unknown command :%s\n", cmd)
+		}
+		return
+	}
+
+	line := fmt.Sprintf("//line repl%d.goo:1:1\n%s", len(s.history), text)
+	switch kind(text) {
+	case "import":
+		s.exec(&input{imports: []string{line}}, true)
+	case "decl":
+		s.exec(&input{decls: []decl{{declName(text), line}}}, true)
+	default:
+		var names []string
+		for _, c := range chunks(text) {
+			names = append(names, declared(c.text)...)
+		}
+		if _, err := parser.ParseExpr(text); err == nil {
+			// Print the value of a bare expression, if it has one.
+			in := &input{stmts: []string{fmt.Sprintf("put(/*line repl%d.goo:1:1*/%s)", len(s.history), text)}}
+			errs := s.build(in)
+			if in.file == "" {
+				in = &input{stmts: []string{line}, new: names}
+				s.build(in)
+			}
+			if in.file == "" {
+				fmt.Fprint(s.out, errs)
+				return
+			}
+			s.start(in, true)
+			return
+		}
+		s.exec(&input{stmts: []string{line}, new: names}, true)
+	}
+}
+
+// load adds the contents of a goo file to the session.
+func (s *session) load(file string) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		fmt.Fprintln(s.out, err)
+		return
+	}
+
+	in := new(input)
+	for _, c := range chunks(string(data)) {
+		text := fmt.Sprintf("//line %s:%d:1\n%s", file, c.line, c.text)
+		switch kind(c.text) {
+		case "package":
+		case "import":
+			in.imports = append(in.imports, text)
+		case "decl":
+			in.decls = append(in.decls, decl{declName(c.text), text})
+		case "var":
+			in.vars = append(in.vars, text)
+			in.new = append(in.new, declared(c.text)...)
+		default:
+			in.stmts = append(in.stmts, text)
+			in.new = append(in.new, declared(c.text)...)
+		}
+	}
+	s.exec(in, true)
+}
+
+// doc prints the documentation of sym.
+func (s *session) doc(sym string) {
+	cmd := exec.Command(s.gocmd, "doc", sym)
+	cmd.Stdout = s.out
+	cmd.Stderr = s.out
+	cmd.Run()
+}
+
+// exec builds the plugin of in and runs it if it declares variables or
+// has statements. If keep is set and this succeeds, in is added to the
+// session.
+func (s *session) exec(in *input, keep bool) {
+	if errs := s.build(in); in.file == "" {
+		fmt.Fprint(s.out, errs)
+		return
+	}
+	s.start(in, keep)
+}
+
+// start runs the plugin of in built by exec, if needed, and adds in to
+// the session if keep is set.
+func (s *session) start(in *input, keep bool) {
+	var vars map[string]variable
+	if len(in.stmts) > 0 || len(in.new) > 0 {
+		rep, err := s.call(in)
+		if err != nil {
+			fmt.Fprintln(s.out, err)
+			return
+		}
+		for _, name := range rep.Dropped {
+			delete(s.vars, name)
+		}
+		if rep.Err != "" {
+			fmt.Fprintln(s.out, rep.Err)
+			return
+		}
+		if rep.Panic {
+			return
+		}
+		vars = rep.Vars
+	}
+	if !keep {
+		return
+	}
+	s.imports = append(s.imports, in.imports...)
+	s.decls = redeclare(s.decls, in.decls)
+	for _, d := range in.decls {
+		delete(s.vars, d.name)
+	}
+	if s.vars == nil {
+		s.vars = make(map[string]variable)
+	}
+	for _, name := range in.new {
+		v := vars[name]
+		s.decls = slices.DeleteFunc(s.decls, func(d decl) bool { return d.name == name })
+		s.vars[name] = v
+		if v.Type == "" {
+			fmt.Fprintf(s.out, "%s cannot be used by later inputs: its type cannot be named\n", name)
+		}
+	}
+}
+
+// build compiles the plugin of in, setting in.file, or returns the
+// compiler errors.
+func (s *session) build(in *input) (errs string) {
+	s.n++
+	file := filepath.Join(s.dir, fmt.Sprintf("in%d.goo", s.n))
+	if err := os.WriteFile(file, []byte(s.program(in)), 0666); err != nil {
+		return err.Error() + "\n"
+	}
+	lib := strings.TrimSuffix(file, ".goo") + ".so"
+	if out, err := s.goBuild("-buildmode=plugin", "-o", lib, file); err != nil {
+		var b strings.Builder
+		for _, line := range strings.SplitAfter(string(out), "\n") {
+			if !strings.HasPrefix(line, "# ") {
+				b.WriteString(line)
+			}
+		}
+		return b.String()
+	}
+	in.file = lib
+	return ""
+}
+
+// goBuild runs go build with args in the directory of the session.
+func (s *session) goBuild(args ...string) ([]byte, error) {
+	cmd := exec.Command(s.gocmd, append([]string{"build"}, args...)...)
+	cmd.Dir = s.dir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=")
+	return cmd.CombinedOutput()
+}
+
+// program returns the source of the plugin of in, with the imports and
+// declarations of the session, and sets in.old. The variables of the
+// session that the plugin uses are declared again; the host copies their
+// values in before running the plugin and back out after.
+func (s *session) program(in *input) string {
+	slices.Sort(in.new)
+	in.new = slices.Compact(in.new)
+	decls := redeclare(s.decls[:len(s.decls):len(s.decls)], in.decls)
+	used := make(map[string]bool)
+	for _, d := range decls {
+		idents(used, d.text)
+	}
+	for _, text := range slices.Concat(in.vars, in.stmts) {
+		idents(used, text)
+	}
+	for _, d := range decls {
+		delete(used, d.name)
+	}
+	for _, text := range in.vars {
+		for _, name := range declared(text) {
+			delete(used, name)
+		}
+	}
+	in.old = nil
+	for _, name := range slices.Sorted(maps.Keys(s.vars)) {
+		if used[name] && s.vars[name].Type != "" {
+			in.old = append(in.old, name)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("package main\n\n")
+	imports := append(s.imports[:len(s.imports):len(s.imports)], in.imports...)
+	if !slices.ContainsFunc(imports, func(text string) bool { return strings.Contains(text, `"fmt"`) }) {
+		// put needs fmt, which is only imported implicitly by files
+		// without imports
+		b.WriteString("import \"fmt\"\n\n")
+	}
+	for _, text := range imports {
+		b.WriteString(text)
+		b.WriteString("\n\n")
+	}
+	for _, name := range in.old {
+		for _, pkg := range slices.Sorted(maps.Keys(s.vars[name].Imports)) {
+			fmt.Fprintf(&b, "import %s %q\n", pkg, s.vars[name].Imports[pkg])
+		}
+	}
+	for _, d := range decls {
+		b.WriteString("\n")
+		b.WriteString(d.text)
+		b.WriteString("\n")
+	}
+	for _, text := range in.vars {
+		b.WriteString("\n")
+		b.WriteString(text)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	for _, name := range in.old {
+		fmt.Fprintf(&b, "var %s %s\n", name, s.vars[name].Type)
+	}
+	fmt.Fprintf(&b, "\nvar GooReplOld = []any{%s}\n\n", addrs(in.old))
+	b.WriteString("var GooReplNew []any\n\n")
+	b.WriteString("func GooReplRun() {\n")
+	for _, text := range in.stmts {
+		b.WriteString(text)
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "GooReplNew = []any{%s}\n}\n", addrs(in.new))
+	return b.String()
+}
+
+// addrs returns the list of the addresses of the variables names.
+func addrs(names []string) string {
+	var list []string
+	for _, name := range names {
+		list = append(list, "&"+name)
+	}
+	return strings.Join(list, ", ")
+}
+
+// redeclare appends the decls to list, dropping the earlier declarations
+// of the same names.
+func redeclare(list, decls []decl) []decl {
+	for _, d := range decls {
+		if d.name != "" {
+			list = slices.DeleteFunc(list, func(e decl) bool { return e.name == d.name })
+		}
+		list = append(list, d)
+	}
+	return list
+}
+
+// kind classifies an input as "package", "import", "decl", "var" or "stmt".
+func kind(input string) string {
+	fields := strings.Fields(input)
+	if len(fields) == 0 {
+		return "stmt"
+	}
+	switch word := fields[0]; {
+	case word == "package" || word == "import" || word == "var":
+		return word
+	case word == "func" && !strings.HasPrefix(input, "func("):
+		return "decl"
+	case word == "type" || word == "const":
+		return "decl"
+	case (word == "def" || word == "enum") && len(fields) > 1:
+		return "decl"
+	}
+	return "stmt"
+}
+
+// declName returns the name declared by the declaration input, or ""
+// if it declares a method or several names.
+func declName(input string) string {
+	fields := strings.Fields(input)
+	if len(fields) < 2 || fields[0] == "import" {
+		return ""
+	}
+	name, _, _ := strings.Cut(fields[1], "(")
+	name, _, _ = strings.Cut(name, "[")
+	if !token.IsIdentifier(name) {
+		return ""
+	}
+	return name
+}
+
+// declared returns the names of the variables declared by the top-level
+// statement stmt, if it is a short variable or var declaration.
+func declared(stmt string) []string {
+	var toks []token.Token
+	var lits []string
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	s.Init(fset.AddFile("", -1, len(stmt)), []byte(stmt), nil, 0)
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		toks = append(toks, tok)
+		lits = append(lits, lit)
+	}
+
+	// list adds the names of the identifier list starting at toks[i]
+	// and returns the index of the token after it.
+	var names []string
+	list := func(i int) int {
+		for i < len(toks) && toks[i] == token.IDENT {
+			if lits[i] != "_" {
+				names = append(names, lits[i])
+			}
+			if i++; i == len(toks) || toks[i] != token.COMMA {
+				break
+			}
+			i++
+		}
+		return i
+	}
+
+	switch {
+	case len(toks) > 1 && toks[0] == token.VAR && toks[1] == token.LPAREN:
+		depth, spec := 0, true
+		for i := 2; i < len(toks) && depth >= 0; i++ {
+			if spec {
+				if i, spec = list(i), false; i == len(toks) {
+					break
+				}
+			}
+			switch toks[i] {
+			case token.LPAREN, token.LBRACK, token.LBRACE:
+				depth++
+			case token.RPAREN, token.RBRACK, token.RBRACE:
+				depth--
+			case token.SEMICOLON:
+				spec = depth == 0
+			}
+		}
+	case len(toks) > 0 && toks[0] == token.VAR:
+		list(1)
+	default:
+		if i := list(0); i == len(toks) || toks[i] != token.DEFINE {
+			names = nil
+		}
+	}
+	return names
+}
+
+// idents adds the identifiers of src to set.
+func idents(set map[string]bool, src string) {
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	s.Init(fset.AddFile("", -1, len(src)), []byte(src), nil, 0)
+	for {
+		_, tok, lit := s.Scan()
+		switch tok {
+		case token.EOF:
+			return
+		case token.IDENT:
+			set[lit] = true
+		}
+	}
+}
+
+// A host is the running program that keeps the variables of a session
+// and runs its inputs. Its source is hostSource.
+type host struct {
+	cmd      *exec.Cmd
+	requests *os.File // read by the host as file descriptor 3
+	replies  *os.File // written by the host to file descriptor 4
+	out      *os.File // its standard output and error
+	rest     []byte   // output read past the last marker
+	dec      *json.Decoder
+}
+
+// A request asks the host to run a plugin.
+type request struct {
+	Plugin string
+	Old    []string // the variables of the session the plugin uses
+	New    []string // the variables it declares
+}
+
+// A reply reports the outcome of a request.
+type reply struct {
+	Err     string              // the plugin was not run
+	Panic   bool                // the plugin panicked
+	Dropped []string            // variables dropped because their types changed
+	Vars    map[string]variable // the variables declared by the plugin
+}
+
+// call runs the plugin of in in the host, starting it if needed, and
+// copies its output to s.out.
+func (s *session) call(in *input) (*reply, error) {
+	if s.host == nil {
+		if err := s.startHost(); err != nil {
+			return nil, err
+		}
+	}
+	h := s.host
+	req, err := json.Marshal(request{in.file, in.old, in.new})
+	if err != nil {
+		return nil, err
+	}
+	h.requests.Write(append(req, '\n'))
+
+	var rep reply
+	if !h.copyOutput(s.out) || h.dec.Decode(&rep) != nil {
+		err := h.cmd.Wait()
+		h.close()
+		s.host, s.vars = nil, nil
+		if err == nil {
+			err = errors.New("exit status 0")
+		}
+		return nil, fmt.Errorf("program exited (%v): the variables of the session are lost", err)
+	}
+	return &rep, nil
+}
+
+// startHost builds and starts the host.
+func (s *session) startHost() error {
+	file := filepath.Join(s.dir, "host.go")
+	exe := filepath.Join(s.dir, "host")
+	if err := os.WriteFile(file, []byte(hostSource), 0666); err != nil {
+		return err
+	}
+	if out, err := s.goBuild("-o", exe, file); err != nil {
+		return fmt.Errorf("building the program of the session: %v\n%s", err, out)
+	}
+
+	var files [6]*os.File // read and write ends of requests, replies and output
+	for i := 0; i < len(files); i += 2 {
+		r, w, err := os.Pipe()
+		if err != nil {
+			for _, f := range files[:i] {
+				f.Close()
+			}
+			return err
+		}
+		files[i], files[i+1] = r, w
+	}
+	cmd := exec.Command(exe)
+	cmd.Dir = s.dir
+	cmd.Stdout = files[5]
+	cmd.Stderr = files[5]
+	cmd.ExtraFiles = []*os.File{files[0], files[3]}
+	err := cmd.Start()
+	files[0].Close()
+	files[3].Close()
+	files[5].Close()
+	if err != nil {
+		files[1].Close()
+		files[2].Close()
+		files[4].Close()
+		return err
+	}
+	s.host = &host{cmd: cmd, requests: files[1], replies: files[2], out: files[4], dec: json.NewDecoder(files[2])}
+	return nil
+}
+
+// stop stops the host, if it is running. The variables of the session
+// are lost.
+func (s *session) stop() {
+	h := s.host
+	if h == nil {
+		return
+	}
+	h.requests.Close() // the host exits at the end of its requests
+	s.out.Write(h.rest)
+	io.Copy(s.out, h.out)
+	h.cmd.Wait()
+	h.close()
+	s.host, s.vars = nil, nil
+}
+
+// close closes the files of h.
+func (h *host) close() {
+	h.requests.Close()
+	h.replies.Close()
+	h.out.Close()
+}
+
+// copyOutput copies the output of h to w up to the next marker and
+// reports whether it found one.
+func (h *host) copyOutput(w io.Writer) bool {
+	out, buf := h.rest, make([]byte, 32<<10)
+	for {
+		if i := bytes.Index(out, []byte(marker)); i >= 0 {
+			w.Write(out[:i])
+			h.rest = out[i+len(marker):]
+			return true
+		}
+		// Hold back what may be the start of the marker.
+		n := len(out)
+		for k := min(len(marker)-1, n); k > 0; k-- {
+			if bytes.HasSuffix(out, []byte(marker[:k])) {
+				n -= k
+				break
+			}
+		}
+		w.Write(out[:n])
+		out = slices.Clone(out[n:])
+
+		m, err := h.out.Read(buf)
+		out = append(out, buf[:m]...)
+		if err != nil && m == 0 {
+			w.Write(out)
+			h.rest = nil
+			return false
+		}
+	}
+}
+
+// depth returns the nesting depth of brackets at the end of src.
+func depth(src string) int {
+	n := 0
+	for _, c := range chunks(src) {
+		n = c.depth
+	}
+	return n
+}
+
+// A chunk is a top-level declaration or statement of a source file.
+type chunk struct {
+	text  string
+	line  int // line of the start of the chunk
+	depth int // bracket nesting depth at its end, if unterminated
+}
+
+// chunks splits the goo source src into its top-level declarations and
+// statements. Lines starting with # are comments.
+func chunks(src string) []chunk {
+	lines := strings.SplitAfter(src, "\n")
+	for i, l := range lines {
+		if strings.HasPrefix(strings.TrimSpace(l), "#") {
+			lines[i] = "\n"
+		}
+	}
+	src = strings.Join(lines, "")
+
+	var list []chunk
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	file := fset.AddFile("", -1, len(src))
+	s.Init(file, []byte(src), nil, 0)
+	start, depth := -1, 0
+	for {
+		pos, tok, _ := s.Scan()
+		off := file.Offset(pos)
+		switch tok {
+		case token.EOF:
+			if start >= 0 {
+				list = append(list, chunk{strings.TrimSpace(src[start:]), file.Line(file.Pos(start)), depth})
+			}
+			return list
+		case token.LPAREN, token.LBRACK, token.LBRACE:
+			depth++
+		case token.RPAREN, token.RBRACK, token.RBRACE:
+			depth--
+		case token.SEMICOLON:
+			if depth == 0 && start >= 0 {
+				list = append(list, chunk{strings.TrimSpace(src[start:off]), file.Line(file.Pos(start)), 0})
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = off
+		}
+	}
+}
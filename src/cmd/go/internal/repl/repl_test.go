@@ -0,0 +1,106 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package repl
+
+import (
+	"internal/testenv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const inputs = `x := 40
+x + 2
+import "strings"
+strings.ToUpper("abc")
+def sq(n int) int {
+	return n * n
+}
+sq(x)
+:type sq
+y := undefinedThing
+x := "shadow"
+x
+def sq(n int) int { return -n }
+sq(3)
+panic("boom")
+x
+:history
+:load lib.goo
+seven
+inc()
+inc()
+count
+type point struct{ x, y int }
+p := point{1, 2}
+p.x++
+p
+:type p
+n, err := fmt.Sscan("12", &count)
+count + n
+var line string
+fmt.Scanln(&line)
+`
+
+const lib = `#!/usr/bin/env goo
+import "fmt"
+
+const seven = 7
+
+var count int
+
+def inc() { count++ }
+
+fmt.Println("loaded", seven)
+`
+
+func TestSession(t *testing.T) {
+	testenv.MustHaveGoBuild(t)
+	testenv.MustHaveCGO(t)
+	testenv.MustHaveBuildMode(t, "plugin")
+	if testing.Short() {
+		t.Skip("builds a program for every input")
+	}
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.WriteFile(filepath.Join(dir, "lib.goo"), []byte(lib), 0666); err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	s := &session{gocmd: testenv.GoToolPath(t), dir: t.TempDir(), out: &out}
+	s.run(strings.NewReader(inputs))
+
+	got := out.String()
+	for _, want := range []string{
+		"goo> 42\n",
+		"goo> ABC\n",
+		"goo> ...  ...  goo> 1600\n",
+		"goo> func(n int) int\n",
+		"repl8.goo:1:6: undefined: undefinedThing\n",
+		"goo> shadow\n",
+		"goo> -3\n",
+		"panic: boom\n",
+		"   5  def sq(n int) int {\n",
+		"goo> loaded 7\n",
+		"goo> 7\n",
+		"goo> goo> goo> 2\n",
+		"goo> goo> goo> {2 2}\n",
+		"goo> main.point\n",
+		"goo> goo> 13\n",
+		"goo> goo> 0 EOF\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output does not contain %q", want)
+		}
+	}
+	if strings.Count(got, "ABC") != 1 {
+		t.Errorf("earlier inputs are run again")
+	}
+	if t.Failed() {
+		t.Logf("output:\n%s", got)
+	}
+}
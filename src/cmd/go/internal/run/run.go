@@ -53,6 +53,14 @@ By default, 'go run' compiles the binary without generating the information
 used by debuggers, to reduce build time. To include debugger information in
 the binary, use 'go build'.
 
+When the package is a list of .goo scripts and no build flags are given,
+'go run' keeps the binary in a script cache, keyed by the content of the
+scripts, the toolchain and the go environment, and later runs it without
+loading any packages. Only scripts depending solely on the standard library
+or on modules pinned by #require directives (see 'go help script') are
+cached. 'go clean -scriptcache' empties the cache, which is needed after
+editing standard library sources without reinstalling the toolchain.
+
 The exit status of Run is not the exit status of the compiled binary.
 
 For more about build flags, see 'go help build'.
@@ -71,6 +79,11 @@ func init() {
 }
 
 func runRun(ctx context.Context, cmd *base.Command, args []string) {
+	key := scriptKey(args)
+	if key != "" && runCachedScript(key, args) {
+		return
+	}
+
 	if shouldUseOutsideModuleMode(args) {
 		// Set global module flags for 'go run cmd@version'.
 		// This must be done before modload.Init, but we need to call work.BuildInit
@@ -82,6 +95,7 @@ func runRun(ctx context.Context, cmd *base.Command, args []string) {
 		modload.Init()
 	} else {
 		modload.InitWorkfile()
+		load.InitScriptModule(args)
 	}
 
 	work.BuildInit()
@@ -168,7 +182,14 @@ func runRun(ctx context.Context, cmd *base.Command, args []string) {
 
 	a1 := b.LinkAction(work.ModeBuild, work.ModeBuild, p)
 	a1.CacheExecutable = true
-	a := &work.Action{Mode: "go run", Actor: work.ActorFunc(buildRunProgram), Args: cmdArgs, Deps: []*work.Action{a1}}
+	run := buildRunProgram
+	if key != "" && scriptCacheable(p) {
+		run = func(b *work.Builder, ctx context.Context, a *work.Action) error {
+			cacheScript(key, args[0], a.Deps[0].BuiltTarget())
+			return buildRunProgram(b, ctx, a)
+		}
+	}
+	a := &work.Action{Mode: "go run", Actor: work.ActorFunc(run), Args: cmdArgs, Deps: []*work.Action{a1}}
 	b.Do(ctx, a)
 }
 
@@ -0,0 +1,170 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package run
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"go/build"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cmd/go/internal/base"
+	"cmd/go/internal/cfg"
+	"cmd/go/internal/load"
+	"cmd/go/internal/modload"
+	"cmd/go/internal/str"
+	"cmd/go/internal/work"
+)
+
+// goo: 'go run' keeps the executables of .goo scripts in the script
+// cache, keyed by the content of the scripts, the toolchain, the
+// environment and the go.mod and go.work files in effect, and runs an
+// unchanged script without loading a single package. Only scripts
+// whose dependencies are all in the standard library or pinned by
+// #require directives are cached, since nothing else that could change
+// them is part of the key.
+
+// scriptKey returns the script cache key for running the .goo files
+// at the start of args, or "" if the run cannot use the script cache.
+func scriptKey(args []string) string {
+	if len(args) == 0 || !strings.HasSuffix(args[0], ".goo") {
+		return ""
+	}
+	for _, arg := range os.Args[1:] {
+		if arg == args[0] {
+			break
+		}
+		if strings.HasPrefix(arg, "-") {
+			// Build flags change the executable.
+			// Those set by GOFLAGS are part of the environment.
+			return ""
+		}
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "goo script v1 %s/%s\n", cfg.Goos, cfg.Goarch)
+	exe, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	for _, tool := range []string{exe, filepath.Join(build.ToolDir, "compile"+cfg.ToolExeSuffix()), filepath.Join(build.ToolDir, "link"+cfg.ToolExeSuffix())} {
+		fi, err := os.Stat(tool)
+		if err != nil {
+			return ""
+		}
+		fmt.Fprintf(h, "tool %s %d %d\n", tool, fi.Size(), fi.ModTime().UnixNano())
+	}
+	for _, e := range cfg.CmdEnv {
+		fmt.Fprintf(h, "env %s=%q\n", e.Name, e.Value)
+	}
+	// The go.mod and go.work files around the script set its language
+	// version and GODEBUG defaults, even if it imports only the standard
+	// library.
+	wd := base.Cwd()
+	for _, file := range []string{modload.FindGoMod(wd), modload.FindGoWork(wd)} {
+		if file == "" {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return ""
+		}
+		fmt.Fprintf(h, "modfile %s\n", file)
+		h.Write(data)
+	}
+	for _, file := range args {
+		if !strings.HasSuffix(file, ".go") && !strings.HasSuffix(file, ".goo") {
+			break
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return ""
+		}
+		fmt.Fprintf(h, "file %s\n", filepath.Base(file))
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))[:32]
+}
+
+// scriptExe returns the name of the cached executable for key.
+func scriptExe(key, src string) (string, error) {
+	dir, err := load.ScriptCacheDir()
+	if err != nil {
+		return "", err
+	}
+	name := strings.TrimSuffix(filepath.Base(src), ".goo") + cfg.ExeSuffix
+	return filepath.Join(dir, "bin", key, name), nil
+}
+
+// runCachedScript runs the cached executable for key, if there is one,
+// and reports whether it did.
+func runCachedScript(key string, args []string) bool {
+	exe, err := scriptExe(key, args[0])
+	if err != nil {
+		return false
+	}
+	if _, err := os.Stat(exe); err != nil {
+		return false
+	}
+	i := 0
+	for i < len(args) && (strings.HasSuffix(args[i], ".go") || strings.HasSuffix(args[i], ".goo")) {
+		i++
+	}
+	base.RunStdin(str.StringList(work.FindExecCmd(), exe, args[i:]))
+	return true
+}
+
+// scriptCacheable reports whether the executable of p depends only on
+// what scriptKey hashes.
+func scriptCacheable(p *load.Package) bool {
+	for _, dep := range load.PackageList([]*load.Package{p}) {
+		if dep == p || dep.Standard {
+			continue
+		}
+		if dep.Module == nil || dep.Module.Main || !modload.InScriptModule() {
+			return false
+		}
+	}
+	return true
+}
+
+// cacheScript copies the executable built for the script into the
+// script cache under key. Failures only cost the next run a build.
+func cacheScript(key, src, built string) {
+	exe, err := scriptExe(key, src)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(exe), 0777); err != nil {
+		return
+	}
+	data, err := os.ReadFile(built)
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(exe), "tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0777)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), exe)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
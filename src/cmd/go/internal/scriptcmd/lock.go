@@ -0,0 +1,174 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// go script lock
+
+package scriptcmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"cmd/go/internal/base"
+	"cmd/go/internal/cfg"
+	"cmd/go/internal/load"
+
+	"golang.org/x/mod/modfile"
+)
+
+var cmdLock = &base.Command{
+	UsageLine: "go script lock [-v] file.goo",
+	Short:     "pin the dependencies of a goo script",
+	Long: `Lock resolves the dependencies of a goo script and pins them in its
+header, much as 'go mod tidy' does for a module.
+
+A #require directive may name a version query such as v1.5 or latest,
+or omit the version entirely, meaning latest. Lock replaces each query
+with the version it selects, adds a directive for every other module
+the script imports and removes directives for modules it no longer needs.
+
+The -v flag prints the output of the 'go get' and 'go mod tidy' commands
+that lock runs in a scratch module.
+
+See 'go help script' for the format of the header.
+`,
+}
+
+var lockV = cmdLock.Flag.Bool("v", false, "")
+
+func init() {
+	cmdLock.Run = runLock // break init cycle
+}
+
+func runLock(ctx context.Context, cmd *base.Command, args []string) {
+	if len(args) != 1 || !strings.HasSuffix(args[0], ".goo") {
+		base.Fatalf("usage: %s", cmd.UsageLine)
+	}
+	file := args[0]
+	data, err := os.ReadFile(file)
+	if err != nil {
+		base.Fatal(err)
+	}
+	reqs, err := load.ParseScriptHeader(file, data)
+	if err != nil {
+		base.Fatalf("go: %v", err)
+	}
+
+	dir, err := os.MkdirTemp("", "goo-lock-")
+	if err != nil {
+		base.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), load.ScriptGoMod(nil), 0666); err != nil {
+		base.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, filepath.Base(file)), data, 0666); err != nil {
+		base.Fatal(err)
+	}
+
+	if len(reqs) > 0 {
+		get := []string{"get"}
+		for _, r := range reqs {
+			query := r.Mod.Version
+			if query == "" {
+				query = "latest"
+			}
+			get = append(get, r.Mod.Path+"@"+query)
+		}
+		goCmd(dir, get...)
+	}
+	goCmd(dir, "mod", "tidy")
+
+	gomod, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		base.Fatal(err)
+	}
+	f, err := modfile.ParseLax("go.mod", gomod, nil)
+	if err != nil {
+		base.Fatal(err)
+	}
+	var pinned []*modfile.Require
+	for _, r := range f.Require {
+		if !r.Indirect {
+			pinned = append(pinned, r)
+		}
+	}
+
+	if out := lockHeader(data, reqs, pinned); !bytes.Equal(out, data) {
+		if err := os.WriteFile(file, out, 0666); err != nil {
+			base.Fatal(err)
+		}
+	}
+}
+
+// goCmd runs the go command in dir.
+func goCmd(dir string, args ...string) {
+	exe, err := os.Executable()
+	if err != nil {
+		base.Fatal(err)
+	}
+	cmd := exec.Command(exe, args...)
+	cmd.Dir = dir
+	cmd.Env = append(cfg.OrigEnv, "GOWORK=off")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err = cmd.Run()
+	if *lockV || err != nil {
+		os.Stderr.Write(out.Bytes())
+	}
+	if err != nil {
+		base.Fatalf("go: go %s: %v", strings.Join(args, " "), err)
+	}
+}
+
+// lockHeader rewrites the dependency pragmas reqs in the header of data
+// to require exactly the modules in pinned.
+// New pragmas follow the last existing one, in the style it uses.
+func lockHeader(data []byte, reqs []load.ScriptRequire, pinned []*modfile.Require) []byte {
+	versions := make(map[string]string)
+	for _, r := range pinned {
+		versions[r.Mod.Path] = r.Mod.Version
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+	prefix := "#require"
+	insert := 0
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "#!") {
+		insert = 1
+	}
+	for _, r := range reqs {
+		i := r.Line - 1
+		line := lines[i]
+		if strings.HasPrefix(strings.TrimSpace(line), "//") {
+			prefix = "//goo:require"
+		} else {
+			prefix = "#require"
+		}
+		insert = i + 1
+		v, ok := versions[r.Mod.Path]
+		if !ok {
+			lines[i] = ""
+			continue
+		}
+		delete(versions, r.Mod.Path)
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		lines[i] = fmt.Sprintf("%s%s %s %s\n", indent, prefix, r.Mod.Path, v)
+	}
+	var added []string
+	for _, r := range pinned {
+		if v, ok := versions[r.Mod.Path]; ok {
+			added = append(added, fmt.Sprintf("%s %s %s\n", prefix, r.Mod.Path, v))
+		}
+	}
+	if insert == 0 && len(reqs) == 0 && len(added) > 0 {
+		added = append(added, "\n")
+	}
+	lines = append(lines[:insert], append(added, lines[insert:]...)...)
+	return []byte(strings.Join(lines, ""))
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package scriptcmd implements the “go script” command.
+package scriptcmd
+
+import (
+	"cmd/go/internal/base"
+)
+
+var CmdScript = &base.Command{
+	UsageLine: "go script",
+	Short:     "goo script maintenance",
+	Long: `Script provides access to operations on single-file goo scripts.
+
+A goo script declares the modules it depends on in its header, the run
+of blank and comment lines at the top of the file, one per line:
+
+	#!/usr/bin/env goo
+	#require rsc.io/quote v1.5.2
+	//goo:require golang.org/x/text v0.14.0
+
+	import (
+		"fmt"
+
+		"rsc.io/quote"
+	)
+
+	fmt.Println(quote.Hello())
+
+When 'go run' or 'go build' is given such a script, the go command builds
+it in an ephemeral module requiring exactly those versions. The module
+and its go.sum are kept in the build cache, so only the first run
+downloads and verifies the dependencies. Modules are fetched as usual,
+honoring GOPROXY, GONOSUMDB and GOFLAGS; a file:// GOPROXY serves them
+in offline environments.
+`,
+
+	Commands: []*base.Command{
+		cmdLock,
+	},
+}
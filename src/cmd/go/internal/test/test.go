@@ -65,6 +65,9 @@ followed by detailed output for each failed package.
 the file pattern "*_test.go".
 These additional files can contain test functions, benchmark functions, fuzz
 tests and example functions. See 'go help testfunc' for more.
+Goo packages may also have "*_test.goo" files, whose top-level blocks
+test "name" { ... } run as tests of that name. Failing check statements
+in such blocks fail the test.
 Each listed package causes the execution of a separate test binary.
 Files whose names begin with "_" (including "_test.go") or "." are ignored.
 
@@ -1336,6 +1339,12 @@ func addTestVet(b *work.Builder, p *load.Package, runAction, installAction *work
 	if testVet.off {
 		return
 	}
+	if hasGooFiles(p) {
+		// vet parses and type-checks with go/parser and go/types, which
+		// don't understand goo. Go packages importing goo packages, like
+		// external tests written in Go, are vetted as usual.
+		return
+	}
 
 	vet := b.VetAction(work.ModeBuild, work.ModeBuild, p)
 	runAction.Deps = append(runAction.Deps, vet)
@@ -1348,6 +1357,13 @@ func addTestVet(b *work.Builder, p *load.Package, runAction, installAction *work
 	}
 }
 
+// hasGooFiles reports whether p has any .goo source files.
+func hasGooFiles(p *load.Package) bool {
+	return slices.ContainsFunc(p.GoFiles, func(name string) bool {
+		return strings.HasSuffix(name, ".goo")
+	})
+}
+
 var noTestsToRun = []byte("\ntesting: warning: no tests to run\n")
 var noFuzzTestsToFuzz = []byte("\ntesting: warning: no fuzz tests to fuzz\n")
 var tooManyFuzzTestsToFuzz = []byte("\ntesting: warning: -fuzz matches more than one fuzz test, won't fuzz\n")
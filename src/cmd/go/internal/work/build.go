@@ -130,6 +130,19 @@ and test commands:
 		cannot be included due to a missing tool or ambiguous directory structure.
 	-compiler name
 		name of compiler to use, as in runtime.Compiler (gccgo or gc).
+	-contracts mode
+		checking of goo contracts, 'on' (the default) or 'off'.
+		With -contracts=off, the requires and ensures clauses of functions
+		are not checked at run time. They remain in the export data for
+		documentation.
+	-gc mode
+		garbage collection mode, 'on' (the default) or 'off'.
+		With -gc=off, packages are compiled without write barriers and
+		the program links against a runtime that never collects garbage
+		and allocates from a bump allocator instead, for short-lived
+		programs. Such a program fails with an out of memory error when
+		its memory use reaches GOMEMLIMIT (8 GiB if unset) and reports
+		its peak memory on standard error at exit.
 	-gccgoflags '[pattern=]arg list'
 		arguments to pass on each gccgo compiler/linker invocation.
 	-gcflags '[pattern=]arg list'
@@ -320,8 +333,10 @@ func AddBuildFlags(cmd *base.Command, mask BuildFlagMask) {
 	cmd.Flag.BoolVar(&cfg.BuildASan, "asan", false, "")
 	cmd.Flag.Var(&load.BuildAsmflags, "asmflags", "")
 	cmd.Flag.Var(buildCompiler{}, "compiler", "")
+	cmd.Flag.StringVar(&cfg.BuildContracts, "contracts", "on", "")
 	cmd.Flag.StringVar(&cfg.BuildBuildmode, "buildmode", "default", "")
 	cmd.Flag.Var((*buildvcsFlag)(&cfg.BuildBuildvcs), "buildvcs", "")
+	cmd.Flag.StringVar(&cfg.BuildGC, "gc", "on", "")
 	cmd.Flag.Var(&load.BuildGcflags, "gcflags", "")
 	cmd.Flag.Var(&load.BuildGccgoflags, "gccgoflags", "")
 	if mask&OmitModFlag == 0 {
@@ -459,6 +474,7 @@ var pkgsFilter = func(pkgs []*load.Package) []*load.Package { return pkgs }
 
 func runBuild(ctx context.Context, cmd *base.Command, args []string) {
 	modload.InitWorkfile()
+	load.InitScriptModule(args)
 	BuildInit()
 	b := NewBuilder("")
 	defer func() {
@@ -20,6 +20,7 @@ import (
 	"io"
 	"io/fs"
 	"log"
+	"maps"
 	"math/rand"
 	"os"
 	"os/exec"
@@ -830,6 +831,12 @@ OverlayLoop:
 		}
 		fmt.Fprintf(&icfg, "packagefile %s=%s\n", p1.ImportPath, a1.built)
 	}
+	// goo: the packages the .goo files may import by their names alone.
+	for _, name := range slices.Sorted(maps.Keys(p.Internal.AutoImports)) {
+		for _, path := range p.Internal.AutoImports[name] {
+			fmt.Fprintf(&icfg, "autoimport %s=%s\n", name, path)
+		}
+	}
 
 	// Prepare Go embed config if needed.
 	// Unlike the import config, it's okay for the embed config to be empty.
@@ -1241,6 +1248,11 @@ func (b *Builder) vet(ctx context.Context, a *Action) error {
 		// Vet config should only be missing if the build failed.
 		return fmt.Errorf("vet config not found")
 	}
+	if slices.ContainsFunc(vcfg.NonGoFiles, func(name string) bool { return strings.HasSuffix(name, ".goo") }) {
+		// goo: vet cannot parse .goo files. Packages importing this one
+		// are vetted without its facts.
+		return nil
+	}
 
 	sh := b.Shell(a)
 
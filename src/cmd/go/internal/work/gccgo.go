@@ -173,6 +173,8 @@ func buildImportcfgSymlinks(sh *Shell, root string, importcfg []byte) error {
 			if err := sh.Symlink(after, archive); err != nil {
 				return err
 			}
+		case "autoimport":
+			// goo: gccgo does not import packages implicitly.
 		case "importmap":
 			if before == "" || after == "" {
 				return fmt.Errorf(`importcfg:%d: invalid importmap: syntax is "importmap old=new": %s`, lineNum, line)
@@ -59,6 +59,8 @@ func BuildInit() {
 
 	modload.Init()
 	instrumentInit()
+	gcModeInit()
+	contractsInit()
 	buildModeInit()
 	cfgChangedEnv = makeCfgChangedEnv()
 
@@ -128,6 +130,40 @@ func fuzzInstrumentFlags() []string {
 	return []string{"-d=libfuzzer"}
 }
 
+// gcModeInit applies the -gc flag.
+func gcModeInit() {
+	switch cfg.BuildGC {
+	case "", "on":
+	case "off":
+		// goo: see runtime/nogc.go.
+		if cfg.BuildRace || cfg.BuildMSan || cfg.BuildASan {
+			base.Fatalf("go: -gc=off cannot be used with -race, -msan or -asan")
+		}
+		if cfg.BuildContext.Compiler == "gccgo" {
+			base.Fatalf("go: -gc=off is not supported by gccgo")
+		}
+		forcedGcflags = append(forcedGcflags, "-wb=false")
+		forcedLdflags = append(forcedLdflags, "-X=runtime.gooGC=off")
+	default:
+		base.Fatalf("go: -gc must be on or off, not %q", cfg.BuildGC)
+	}
+}
+
+// contractsInit applies the -contracts flag.
+func contractsInit() {
+	switch cfg.BuildContracts {
+	case "", "on":
+	case "off":
+		// goo: the compiler drops requires and ensures clauses.
+		if cfg.BuildContext.Compiler == "gccgo" {
+			base.Fatalf("go: -contracts=off is not supported by gccgo")
+		}
+		forcedGcflags = append(forcedGcflags, "-contracts=false")
+	default:
+		base.Fatalf("go: -contracts must be on or off, not %q", cfg.BuildContracts)
+	}
+}
+
 func instrumentInit() {
 	if !cfg.BuildRace && !cfg.BuildMSan && !cfg.BuildASan {
 		return
@@ -33,7 +33,9 @@ import (
 	"cmd/go/internal/modfetch"
 	"cmd/go/internal/modget"
 	"cmd/go/internal/modload"
+	"cmd/go/internal/repl"
 	"cmd/go/internal/run"
+	"cmd/go/internal/scriptcmd"
 	"cmd/go/internal/telemetrycmd"
 	"cmd/go/internal/telemetrystats"
 	"cmd/go/internal/test"
@@ -63,7 +65,9 @@ func init() {
 		list.CmdList,
 		modcmd.CmdMod,
 		workcmd.CmdWork,
+		repl.CmdRepl,
 		run.CmdRun,
+		scriptcmd.CmdScript,
 		telemetrycmd.CmdTelemetry,
 		test.CmdTest,
 		tool.CmdTool,
@@ -116,7 +120,11 @@ func main() {
 
 	args := flag.Args()
 	if len(args) < 1 {
-		base.Usage()
+		if strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe") != "goo" {
+			base.Usage()
+		}
+		// A bare goo starts an interactive session.
+		args = []string{"repl"}
 	}
 
 	cfg.CmdName = args[0] // for error messages
@@ -394,6 +394,7 @@ type (
 		Args     []Expr    // function arguments; or nil
 		Ellipsis token.Pos // position of "..." (token.NoPos if there is no "...")
 		Rparen   token.Pos // position of ")"
+		NoParens bool      // goo: call statement without parentheses, as in put 42
 	}
 
 	// A StarExpr node represents an expression of the form "*" Expression.
@@ -544,13 +545,18 @@ func (x *IndexExpr) End() token.Pos      { return x.Rbrack + 1 }
 func (x *IndexListExpr) End() token.Pos  { return x.Rbrack + 1 }
 func (x *SliceExpr) End() token.Pos      { return x.Rbrack + 1 }
 func (x *TypeAssertExpr) End() token.Pos { return x.Rparen + 1 }
-func (x *CallExpr) End() token.Pos       { return x.Rparen + 1 }
-func (x *StarExpr) End() token.Pos       { return x.X.End() }
-func (x *UnaryExpr) End() token.Pos      { return x.X.End() }
-func (x *BinaryExpr) End() token.Pos     { return x.Y.End() }
-func (x *KeyValueExpr) End() token.Pos   { return x.Value.End() }
-func (x *ArrayType) End() token.Pos      { return x.Elt.End() }
-func (x *StructType) End() token.Pos     { return x.Fields.End() }
+func (x *CallExpr) End() token.Pos {
+	if x.NoParens && len(x.Args) > 0 {
+		return x.Args[len(x.Args)-1].End()
+	}
+	return x.Rparen + 1
+}
+func (x *StarExpr) End() token.Pos     { return x.X.End() }
+func (x *UnaryExpr) End() token.Pos    { return x.X.End() }
+func (x *BinaryExpr) End() token.Pos   { return x.Y.End() }
+func (x *KeyValueExpr) End() token.Pos { return x.Value.End() }
+func (x *ArrayType) End() token.Pos    { return x.Elt.End() }
+func (x *StructType) End() token.Pos   { return x.Fields.End() }
 func (x *FuncType) End() token.Pos {
 	if x.Results != nil {
 		return x.Results.End()
@@ -1001,6 +1007,16 @@ type (
 		Name *Ident        // function/method name
 		Type *FuncType     // function signature: type and value parameters, results, and position of "func" keyword
 		Body *BlockStmt    // function body; or nil for external (non-Go) function
+
+		Contracts []*Contract // goo: requires and ensures clauses, in source order; or nil
+	}
+
+	// A Contract node represents a goo contract clause of a function
+	// declaration: requires Cond or ensures Cond.
+	Contract struct {
+		Keyword token.Pos // position of "requires" or "ensures"
+		Ensures bool      // ensures rather than requires
+		Cond    Expr      // condition
 	}
 )
 
@@ -1009,6 +1025,7 @@ type (
 func (d *BadDecl) Pos() token.Pos  { return d.From }
 func (d *GenDecl) Pos() token.Pos  { return d.TokPos }
 func (d *FuncDecl) Pos() token.Pos { return d.Type.Pos() }
+func (c *Contract) Pos() token.Pos { return c.Keyword }
 
 func (d *BadDecl) End() token.Pos { return d.To }
 func (d *GenDecl) End() token.Pos {
@@ -1021,8 +1038,12 @@ func (d *FuncDecl) End() token.Pos {
 	if d.Body != nil {
 		return d.Body.End()
 	}
+	if n := len(d.Contracts); n > 0 {
+		return d.Contracts[n-1].End()
+	}
 	return d.Type.End()
 }
+func (c *Contract) End() token.Pos { return c.Cond.End() }
 
 // declNode() ensures that only declaration nodes can be
 // assigned to a Decl.
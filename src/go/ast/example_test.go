@@ -76,7 +76,7 @@ func main() {
 	ast.Print(fset, f)
 
 	// Output:
-	//      0  *ast.File {
+	// 0  *ast.File {
 	//      1  .  Package: 2:1
 	//      2  .  Name: *ast.Ident {
 	//      3  .  .  NamePos: 2:9
@@ -119,25 +119,26 @@ func main() {
 	//     40  .  .  .  .  .  .  .  }
 	//     41  .  .  .  .  .  .  .  Ellipsis: -
 	//     42  .  .  .  .  .  .  .  Rparen: 4:25
-	//     43  .  .  .  .  .  .  }
-	//     44  .  .  .  .  .  }
-	//     45  .  .  .  .  }
-	//     46  .  .  .  .  Rbrace: 5:1
-	//     47  .  .  .  }
-	//     48  .  .  }
-	//     49  .  }
-	//     50  .  FileStart: 1:1
-	//     51  .  FileEnd: 5:3
-	//     52  .  Scope: *ast.Scope {
-	//     53  .  .  Objects: map[string]*ast.Object (len = 1) {
-	//     54  .  .  .  "main": *(obj @ 11)
-	//     55  .  .  }
-	//     56  .  }
-	//     57  .  Unresolved: []*ast.Ident (len = 1) {
-	//     58  .  .  0: *(obj @ 29)
-	//     59  .  }
-	//     60  .  GoVersion: ""
-	//     61  }
+	//     43  .  .  .  .  .  .  .  NoParens: false
+	//     44  .  .  .  .  .  .  }
+	//     45  .  .  .  .  .  }
+	//     46  .  .  .  .  }
+	//     47  .  .  .  .  Rbrace: 5:1
+	//     48  .  .  .  }
+	//     49  .  .  }
+	//     50  .  }
+	//     51  .  FileStart: 1:1
+	//     52  .  FileEnd: 5:3
+	//     53  .  Scope: *ast.Scope {
+	//     54  .  .  Objects: map[string]*ast.Object (len = 1) {
+	//     55  .  .  .  "main": *(obj @ 11)
+	//     56  .  .  }
+	//     57  .  }
+	//     58  .  Unresolved: []*ast.Ident (len = 1) {
+	//     59  .  .  0: *(obj @ 29)
+	//     60  .  }
+	//     61  .  GoVersion: ""
+	//     62  }
 }
 
 func ExamplePreorder() {
@@ -328,10 +328,14 @@ func Walk(v Visitor, node Node) {
 		}
 		Walk(v, n.Name)
 		Walk(v, n.Type)
+		walkList(v, n.Contracts)
 		if n.Body != nil {
 			Walk(v, n.Body)
 		}
 
+	case *Contract:
+		Walk(v, n.Cond)
+
 	// Files and packages
 	case *File:
 		if n.Doc != nil {
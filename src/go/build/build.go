@@ -945,7 +945,7 @@ Found:
 			}
 		}
 
-		isTest := strings.HasSuffix(name, "_test.go")
+		isTest := strings.HasSuffix(name, "_test.go") || strings.HasSuffix(name, "_test.goo")
 		isXTest := false
 		if isTest && strings.HasSuffix(pkg, "_test") && p.Name != pkg {
 			isXTest = true
@@ -1493,7 +1493,7 @@ func (ctxt *Context) matchFile(dir, name string, allTags map[string]bool, binary
 
 	if strings.HasSuffix(name, ".go") || strings.HasSuffix(name, ".goo") {
 		err = readGoInfo(f, info)
-		if strings.HasSuffix(name, "_test.go") {
+		if strings.HasSuffix(name, "_test.go") || strings.HasSuffix(name, "_test.goo") {
 			binaryOnly = nil // ignore //go:binary-only-package comments in _test.go files
 		}
 	} else {
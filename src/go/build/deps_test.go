@@ -236,6 +236,7 @@ var depsRules = `
 	  mime/quotedprintable,
 	  net/internal/socktest,
 	  net/url,
+	  runtime/dbg,
 	  runtime/trace,
 	  text/scanner,
 	  text/tabwriter;
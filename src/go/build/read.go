@@ -490,6 +490,12 @@ func readGoInfo(f io.Reader, info *fileInfo) error {
 		}
 	}
 
+	// goo: test blocks in _test.goo files are compiled into test
+	// functions taking a *testing.T, so these files need testing.
+	if strings.HasSuffix(info.name, "_test.goo") {
+		info.imports = append(info.imports, fileImport{"testing", token.NoPos, nil})
+	}
+
 	// Extract directives.
 	for _, group := range info.parsed.Comments {
 		if group.Pos() >= info.parsed.Package {
@@ -1111,6 +1111,9 @@ func (p *parser) parseParameters(result bool) *ast.FieldList {
 		return &ast.FieldList{Opening: lparen, List: list, Closing: rparen}
 	}
 
+	if p.atContract() {
+		return nil
+	}
 	if typ := p.tryIdentOrType(); typ != nil {
 		list := make([]*ast.Field, 1)
 		list[0] = &ast.Field{Type: typ}
@@ -1747,6 +1750,10 @@ func (p *parser) parsePrimaryExpr(x ast.Expr) ast.Expr {
 			switch p.tok {
 			case token.IDENT:
 				x = p.parseSelector(x)
+			case token.MAP:
+				// goo: the collection method xs.map(f)
+				x = &ast.SelectorExpr{X: x, Sel: &ast.Ident{NamePos: p.pos, Name: "map"}}
+				p.next()
 			case token.LPAREN:
 				x = p.parseTypeAssertion(x)
 			default:
@@ -1875,6 +1882,10 @@ func (p *parser) tokPrec() (token.Token, int) {
 	if p.inRhs && tok == token.ASSIGN {
 		tok = token.EQL
 	}
+	if tok == token.IDENT && p.lit == "in" {
+		// the name "in" following an operand is the membership operator
+		tok = token.IN
+	}
 	return tok, tok.Precedence()
 }
 
@@ -1901,7 +1912,12 @@ func (p *parser) parseBinaryExpr(x ast.Expr, prec1 int) ast.Expr {
 		if oprec < prec1 {
 			return x
 		}
-		pos := p.expect(op)
+		pos := p.pos
+		if op == token.IN && p.tok == token.IDENT {
+			p.next() // contextual "in"
+		} else {
+			pos = p.expect(op)
+		}
 		y := p.parseBinaryExpr(nil, oprec+1)
 		x = &ast.BinaryExpr{X: x, OpPos: pos, Op: op, Y: y}
 	}
@@ -1943,7 +1959,12 @@ func (p *parser) parseSimpleStmt(mode int) (ast.Stmt, bool) {
 		defer un(trace(p, "SimpleStmt"))
 	}
 
-	x := p.parseList(false)
+	var x []ast.Expr
+	if mode == labelOk {
+		x = p.parseStmtHead()
+	} else {
+		x = p.parseList(false)
+	}
 
 	switch p.tok {
 	case
@@ -1972,6 +1993,10 @@ func (p *parser) parseSimpleStmt(mode int) (ast.Stmt, bool) {
 		// continue with first expression
 	}
 
+	if mode == labelOk && p.gooFile() && len(x) == 1 && isCallHead(x[0]) && p.startsParenFreeArg() {
+		return &ast.ExprStmt{X: p.parseParenFreeCall(x[0])}, false
+	}
+
 	switch p.tok {
 	case token.COLON:
 		// labeled statement
@@ -2011,6 +2036,76 @@ func (p *parser) parseSimpleStmt(mode int) (ast.Stmt, bool) {
 	return &ast.ExprStmt{X: x[0]}, false
 }
 
+// parseStmtHead parses the expression list that starts a statement.
+// In a goo file, a call head followed by the name in is left alone,
+// so that put in calls put with the variable in.
+func (p *parser) parseStmtHead() []ast.Expr {
+	old := p.inRhs
+	p.inRhs = false
+	defer func() { p.inRhs = old }()
+
+	x := p.parseUnaryExpr()
+	if p.gooFile() && isCallHead(x) && p.tok == token.IDENT && p.lit == "in" {
+		return []ast.Expr{x}
+	}
+	list := []ast.Expr{p.parseBinaryExpr(x, token.LowestPrec+1)}
+	for p.tok == token.COMMA {
+		p.next()
+		list = append(list, p.parseExpr())
+	}
+	return list
+}
+
+// gooFile reports whether the file being parsed is a goo source file,
+// where the statements of Go have a few more forms.
+func (p *parser) gooFile() bool {
+	return strings.HasSuffix(p.file.Name(), ".goo")
+}
+
+// isCallHead reports whether x, a (qualified) name, may be the
+// function of a goo call statement without parentheses.
+func isCallHead(x ast.Expr) bool {
+	switch x := x.(type) {
+	case *ast.Ident:
+		return x.Name != "_"
+	case *ast.SelectorExpr:
+		return isCallHead(x.X)
+	}
+	return false
+}
+
+// startsParenFreeArg reports whether the current token starts the
+// arguments of a goo call statement without parentheses. Since a
+// newline after a name ends the statement, they are on the same line.
+// Tokens which may continue an expression, like ( or -, never start
+// arguments: put -1 is put - 1, and put (1) is put(1).
+func (p *parser) startsParenFreeArg() bool {
+	switch p.tok {
+	case token.IDENT, token.INT, token.FLOAT, token.IMAG, token.CHAR, token.STRING,
+		token.FUNC, token.MAP, token.CHAN, token.STRUCT, token.INTERFACE, token.NOT:
+		return true
+	}
+	return false
+}
+
+// parseParenFreeCall parses the rest of the line after fun as the
+// arguments of the goo call statement fun x, y, which is fun(x, y).
+func (p *parser) parseParenFreeCall(fun ast.Expr) *ast.CallExpr {
+	if p.trace {
+		defer un(trace(p, "ParenFreeCall"))
+	}
+
+	call := &ast.CallExpr{Fun: fun, NoParens: true}
+	for {
+		call.Args = append(call.Args, p.parseRhs())
+		if p.tok != token.COMMA {
+			break
+		}
+		p.next()
+	}
+	return call
+}
+
 func (p *parser) parseCallExpr(callType string) *ast.CallExpr {
 	x := p.parseRhs() // could be a conversion: (some type)(x)
 	if t := ast.Unparen(x); t != x {
@@ -2817,6 +2912,11 @@ func (p *parser) parseFuncDecl() *ast.FuncDecl {
 	params := p.parseParameters(false)
 	results := p.parseParameters(true)
 
+	var contracts []*ast.Contract
+	for p.atContract() {
+		contracts = append(contracts, p.parseContract())
+	}
+
 	var body *ast.BlockStmt
 	switch p.tok {
 	case token.LBRACE:
@@ -2844,11 +2944,35 @@ func (p *parser) parseFuncDecl() *ast.FuncDecl {
 			Params:     params,
 			Results:    results,
 		},
-		Body: body,
+		Body:      body,
+		Contracts: contracts,
 	}
 	return decl
 }
 
+// goo: atContract reports whether the parser is at a requires or ensures
+// clause of a function declaration.
+func (p *parser) atContract() bool {
+	return p.tok == token.IDENT && (p.lit == "requires" || p.lit == "ensures")
+}
+
+// goo: parseContract parses a requires or ensures clause. As in the header
+// of an if statement, a composite literal in the condition must be
+// parenthesized.
+func (p *parser) parseContract() *ast.Contract {
+	if p.trace {
+		defer un(trace(p, "Contract"))
+	}
+
+	c := &ast.Contract{Keyword: p.pos, Ensures: p.lit == "ensures"}
+	p.next()
+	prevLev := p.exprLev
+	p.exprLev = -1
+	c.Cond = p.parseExpr()
+	p.exprLev = prevLev
+	return c
+}
+
 func (p *parser) parseDecl(sync map[token.Token]bool) ast.Decl {
 	if p.trace {
 		defer un(trace(p, "Declaration"))
@@ -2930,7 +3054,7 @@ func (p *parser) parseFile() *ast.File {
 		}
 		
 		// Auto-inject fmt import if no imports found and it's a .goo file
-		if !hasImports && strings.HasSuffix(p.file.Name(), ".goo") {
+		if !hasImports && p.gooFile() {
 			fmtImport := &ast.GenDecl{
 				Tok: token.IMPORT,
 				Specs: []ast.Spec{
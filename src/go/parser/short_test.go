@@ -13,6 +13,7 @@ var valids = []string{
 	`package p;`,
 	`package p; import "fmt"; func f() { fmt.Println("Hello, World!") };`,
 	`package p; func f() { if f(T{}) {} };`,
+	`package p; func f() { _ = xs.map(f).filter(g).sum() };`,
 	`package p; func f() { _ = <-chan int(nil) };`,
 	`package p; func f() { _ = (<-chan int)(nil) };`,
 	`package p; func f() { _ = (<-chan <-chan int)(nil) };`,
@@ -116,10 +117,19 @@ var valids = []string{
 	`package p; type T[P any] = T0`,
 }
 
+// gooValids are valid only in goo files.
+var gooValids = []string{
+	`package p; func f() { put 42 + 3; fmt.Println "a", x };`,
+	`package p; func f() { in := 7; put in };`,
+}
+
 func TestValid(t *testing.T) {
 	for _, src := range valids {
 		checkErrors(t, src, src, DeclarationErrors|AllErrors, false)
 	}
+	for _, src := range gooValids {
+		checkErrors(t, "p.goo", src, DeclarationErrors|AllErrors, false)
+	}
 }
 
 // TestSingle is useful to track down a problem with a single short test program.
@@ -777,7 +777,7 @@ func (p *printer) binaryExpr(x *ast.BinaryExpr, prec1, cutoff, depth int) {
 		return
 	}
 
-	printBlank := prec < cutoff
+	printBlank := prec < cutoff || x.Op == token.IN // "in" is a word and needs blanks
 
 	ws := indent
 	p.expr1(x.X, prec, depth+diffPrec(x.X, prec))
@@ -990,6 +990,16 @@ func (p *printer) expr1(expr ast.Expr, prec1, depth int) {
 			p.print(token.RPAREN)
 		}
 
+		if p.Config.Mode&ParenFree != 0 && x.NoParens && len(x.Args) > 0 {
+			// goo: put 42
+			p.print(blank)
+			p.exprList(x.Fun.End(), x.Args, depth, 0, x.End(), false)
+			if wasIndented {
+				p.print(unindent)
+			}
+			break
+		}
+
 		p.setPos(x.Lparen)
 		p.print(token.LPAREN)
 		if x.Ellipsis.IsValid() {
@@ -1931,9 +1941,22 @@ func (p *printer) funcDecl(d *ast.FuncDecl) {
 	}
 	p.expr(d.Name)
 	p.signature(d.Type)
+	for _, c := range d.Contracts {
+		p.contract(c)
+	}
 	p.funcBody(p.distanceFrom(d.Pos(), startCol), vtab, d.Body)
 }
 
+// goo: contract prints a requires or ensures clause of a function declaration.
+func (p *printer) contract(c *ast.Contract) {
+	keyword := "requires"
+	if c.Ensures {
+		keyword = "ensures"
+	}
+	p.print(blank, &ast.Ident{NamePos: c.Keyword, Name: keyword}, blank)
+	p.expr(c.Cond)
+}
+
 func (p *printer) decl(decl ast.Decl) {
 	switch d := decl.(type) {
 	case *ast.BadDecl:
@@ -1295,6 +1295,7 @@ const (
 	TabIndent                  // use tabs for indentation independent of UseSpaces
 	UseSpaces                  // use spaces instead of tabs for alignment
 	SourcePos                  // emit //line directives to preserve original source positions
+	ParenFree                  // goo: print calls parsed without parentheses, like put 42, without them
 )
 
 // The mode below is not included in printer's public API because
@@ -531,6 +531,61 @@ func TestStmtLists(t *testing.T) {
 	}
 }
 
+// TestParenFree checks that goo calls without parentheses are printed
+// without them in ParenFree mode only.
+func TestParenFree(t *testing.T) {
+	const src = "put 42 + 3\nfmt.Println x, \"y\"\nput(1)"
+	file, err := parser.ParseFile(fset, "p.goo", "package p; func _() {"+src+"}", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		mode Mode
+		want string
+	}{
+		{ParenFree, src},
+		{0, "put(42 + 3)\nfmt.Println(x, \"y\")\nput(1)"},
+	} {
+		var buf bytes.Buffer
+		cfg := Config{Mode: test.mode, Tabwidth: 8}
+		// a goo file without imports gets its imports before the function
+		if err := cfg.Fprint(&buf, fset, file.Decls[len(file.Decls)-1].(*ast.FuncDecl).Body.List); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != test.want {
+			t.Errorf("mode %d:\ngot : %q\nwant: %q", test.mode, got, test.want)
+		}
+	}
+}
+
+// TestContracts checks that the goo requires and ensures clauses of a
+// function declaration are parsed and printed back.
+func TestContracts(t *testing.T) {
+	const src = `package p
+
+func withdraw(amt int) int requires amt > 0 ensures result >= 0 && result <= old(balance) {
+	return balance - amt
+}
+
+func (a *Account) Close() requires (a != Account{}) {
+}
+`
+	file, err := parser.ParseFile(fset, "", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(file.Decls[0].(*ast.FuncDecl).Contracts); n != 2 {
+		t.Fatalf("got %d contracts, want 2", n)
+	}
+	var buf bytes.Buffer
+	if err := Fprint(&buf, fset, file); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != src {
+		t.Errorf("\ngot : %q\nwant: %q", got, src)
+	}
+}
+
 func TestBaseIndent(t *testing.T) {
 	t.Parallel()
 	// The testfile must not contain multi-line raw strings since those
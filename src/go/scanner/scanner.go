@@ -317,7 +317,7 @@ func trailingDigits(text []byte) (int, int, bool) {
 }
 
 func isLetter(ch rune) bool {
-	return 'a' <= lower(ch) && lower(ch) <= 'z' || ch == '_' || ch == '≠' || ch == '¬' || ch >= utf8.RuneSelf && unicode.IsLetter(ch)
+	return 'a' <= lower(ch) && lower(ch) <= 'z' || ch == '_' || ch >= utf8.RuneSelf && unicode.IsLetter(ch)
 }
 
 func isDigit(ch rune) bool {
@@ -818,14 +818,8 @@ scanAgain:
 	case isLetter(ch):
 		lit = s.scanIdentifier()
 		
-		// special case for Unicode operators
-		if lit == "≠" {
-			tok = token.NEQ
-			lit = "!="
-		} else if lit == "¬" {
-			tok = token.NOT
-			lit = "!"
-		} else if lit == "and" {
+		// special case for word operators
+		if lit == "and" {
 			tok = token.LAND
 			lit = "&&"
 		} else if lit == "or" {
@@ -964,6 +958,15 @@ scanAgain:
 			tok = s.switch3(token.OR, token.OR_ASSIGN, '|', token.LOR)
 		case '~':
 			tok = token.TILDE
+		// Unicode operators: ≠ for !=, ¬ for !, ∈ for in and ∉ for not in
+		case '≠':
+			tok = token.NEQ
+		case '¬':
+			tok = token.NOT
+		case '∈':
+			tok = token.IN
+		case '∉':
+			tok = token.NOT_IN
 		case '#':
 			// line comment starting with #
 			offs := s.offset - 1 // position of initial '#'
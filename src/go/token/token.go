@@ -131,6 +131,8 @@ const (
 	additional_beg
 	// additional tokens, handled in an ad-hoc manner
 	TILDE
+	IN     // in, ∈
+	NOT_IN // ∉
 	additional_end
 )
 
@@ -234,7 +236,9 @@ var tokens = [...]string{
 	TYPE:   "type",
 	VAR:    "var",
 
-	TILDE: "~",
+	TILDE:  "~",
+	IN:     "in",
+	NOT_IN: "∉",
 }
 
 // String returns the string corresponding to the token tok.
@@ -273,7 +277,7 @@ func (op Token) Precedence() int {
 		return 1
 	case LAND:
 		return 2
-	case EQL, NEQ, LSS, LEQ, GTR, GEQ:
+	case EQL, NEQ, LSS, LEQ, GTR, GEQ, IN, NOT_IN:
 		return 3
 	case ADD, SUB, OR, XOR:
 		return 4
@@ -309,7 +313,7 @@ func (tok Token) IsLiteral() bool { return literal_beg < tok && tok < literal_en
 // IsOperator returns true for tokens corresponding to operators and
 // delimiters; it returns false otherwise.
 func (tok Token) IsOperator() bool {
-	return (operator_beg < tok && tok < operator_end) || tok == TILDE
+	return (operator_beg < tok && tok < operator_end) || tok == TILDE || tok == IN || tok == NOT_IN
 }
 
 // IsKeyword returns true for tokens corresponding to keywords;
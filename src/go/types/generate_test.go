@@ -42,7 +42,9 @@ func TestGenerate(t *testing.T) {
 		files = strings.Split(*filesToWrite, ",")
 	} else {
 		for file := range filemap {
-			files = append(files, file)
+			if !gooOnly[file] {
+				files = append(files, file)
+			}
 		}
 	}
 
@@ -97,6 +99,21 @@ func generate(t *testing.T, filename string, write bool) {
 	}
 }
 
+// gooOnly lists the types2 files with code for goo features that go/types
+// does not type-check, such as lambdas, the for x in xs clause and default
+// parameter values. Their go/types counterparts keep the Go subset and are
+// neither compared nor written unless named by -write.
+var gooOnly = map[string]bool{
+	"assignments.go":   true,
+	"builtins_test.go": true,
+	"infer.go":         true,
+	"literals.go":      true,
+	"object.go":        true,
+	"operand.go":       true,
+	"range.go":         true,
+	"recording.go":     true,
+}
+
 type action func(in *ast.File)
 
 var filemap = map[string]action{
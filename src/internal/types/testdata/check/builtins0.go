@@ -78,6 +78,8 @@ func append3() {
 	_ = append(f3())
 	_ = append(f5())
 	_ = append(ff /* ERROR "must be a slice" */ ()) // TODO(gri) better error message
+	_ = append([]int{0}, ff /* ERROR "multiple-value ff" */ ())
+	_ = append([]int{0}, 1, ff /* ERROR "multiple-value ff" */ ())
 }
 
 func cap1() {
@@ -0,0 +1,36 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+import (
+	"internal/bytealg"
+	"internal/stringslite"
+)
+
+// stringcontains implements the membership test substr in s.
+// This function is called by the compiler for goo's in operator
+// when the container is a string and the element is a string.
+func stringcontains(s, substr string) bool {
+	return stringslite.Index(s, substr) >= 0
+}
+
+// stringcontainsrune implements the membership test r in s.
+// This function is called by the compiler for goo's in operator
+// when the container is a string and the element is a rune.
+func stringcontainsrune(s string, r rune) bool {
+	for _, c := range s {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
+
+// stringcontainsbyte implements the membership test b in s.
+// This function is called by the compiler for goo's in operator
+// when the container is a string and the element is a byte.
+func stringcontainsbyte(s string, b byte) bool {
+	return bytealg.IndexByteString(s, b) >= 0
+}
@@ -0,0 +1,31 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+// contractFailed panics with a runtime error reporting a violated goo
+// requires or ensures clause, as in
+//
+//	contract violated: requires amt > 0 in main.withdraw (called from main.goo:12)
+//
+// The compiler calls it from the function whose contract failed.
+func contractFailed(clause string) {
+	msg := "contract violated: " + clause
+	if pc, _, _, ok := Caller(1); ok {
+		if f := FuncForPC(pc); f != nil {
+			msg += " in " + funcNameForPrint(f.Name())
+		}
+	}
+	if _, file, line, ok := Caller(2); ok {
+		for i := len(file) - 1; i >= 0; i-- {
+			if file[i] == '/' || file[i] == '\\' {
+				file = file[i+1:]
+				break
+			}
+		}
+		var buf [20]byte
+		msg += " (called from " + file + ":" + string(itoa(buf[:], uint64(line))) + ")"
+	}
+	panic(plainError(msg))
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package dbg implements goo's dbg builtin. Programs do not import it:
+// the compiler lowers dbg(x) to a call of Print with a pointer to a copy
+// of x, and the go command links this package into programs whose .goo
+// files use dbg, as it links runtime/race into programs built with -race.
+package dbg
+
+import (
+	"fmt"
+	"internal/fmtsort"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Print prints a line such as
+//
+//	main.goo:12: user.age = 42 (int)
+//
+// to standard error for dbg(x) at pos, where expr is the source text of
+// x and p points to the value of x. Structs, maps and the slices and
+// arrays of them are printed with one field or element per line and
+// indented by depth; map keys are sorted. Strings are quoted.
+func Print(pos, expr string, p any) {
+	os.Stderr.WriteString(line(pos, expr, reflect.ValueOf(p).Elem()))
+}
+
+// line returns the line Print prints for the value v of the
+// expression expr at pos.
+func line(pos, expr string, v reflect.Value) string {
+	d := &printer{seen: make(map[uintptr]bool)}
+	d.buf.WriteString(pos + ": " + expr + " = ")
+	d.value(v, 0)
+	d.buf.WriteString(" (" + v.Type().String() + ")\n")
+	return d.buf.String()
+}
+
+// A printer formats values for Print.
+type printer struct {
+	buf  strings.Builder
+	seen map[uintptr]bool // the pointers, maps and slices being printed, to stop at cycles
+}
+
+// value formats v, indented by depth if it spans several lines.
+func (d *printer) value(v reflect.Value, depth int) {
+	if !v.IsValid() {
+		d.buf.WriteString("<nil>")
+		return
+	}
+	if v.CanInterface() {
+		switch v.Interface().(type) {
+		case error, fmt.Stringer:
+			d.buf.WriteString(fmt.Sprint(v))
+			return
+		}
+	}
+	switch v.Kind() {
+	case reflect.String:
+		d.buf.WriteString(strconv.Quote(v.String()))
+	case reflect.Interface:
+		d.value(v.Elem(), depth)
+	case reflect.Pointer:
+		if v.IsNil() || !multiline(v.Type().Elem()) {
+			d.buf.WriteString(fmt.Sprint(v))
+			return
+		}
+		if !d.enter(v) {
+			d.buf.WriteString("&" + v.Elem().Type().String() + "{...}")
+			return
+		}
+		defer delete(d.seen, v.Pointer())
+		d.buf.WriteByte('&')
+		d.value(v.Elem(), depth)
+	case reflect.Struct:
+		d.buf.WriteByte('{')
+		for i := range v.NumField() {
+			d.indent(depth + 1)
+			d.buf.WriteString(v.Type().Field(i).Name + ": ")
+			d.value(v.Field(i), depth+1)
+		}
+		if v.NumField() > 0 {
+			d.indent(depth)
+		}
+		d.buf.WriteByte('}')
+	case reflect.Map:
+		if !d.enter(v) {
+			d.buf.WriteString("map[...]")
+			return
+		}
+		defer delete(d.seen, v.Pointer())
+		d.buf.WriteString("map[")
+		sorted := fmtsort.Sort(v)
+		for _, kv := range sorted {
+			d.indent(depth + 1)
+			d.value(kv.Key, depth+1)
+			d.buf.WriteString(": ")
+			d.value(kv.Value, depth+1)
+		}
+		if len(sorted) > 0 {
+			d.indent(depth)
+		}
+		d.buf.WriteByte(']')
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			d.buf.WriteString("[]")
+			return
+		}
+		if v.Kind() == reflect.Slice && v.Len() > 0 {
+			if !d.enter(v) {
+				d.buf.WriteString("[...]")
+				return
+			}
+			defer delete(d.seen, v.Pointer())
+		}
+		ml := multiline(v.Type().Elem())
+		d.buf.WriteByte('[')
+		for i := range v.Len() {
+			if ml {
+				d.indent(depth + 1)
+			} else if i > 0 {
+				d.buf.WriteByte(' ')
+			}
+			d.value(v.Index(i), depth+1)
+		}
+		if ml && v.Len() > 0 {
+			d.indent(depth)
+		}
+		d.buf.WriteByte(']')
+	default:
+		d.buf.WriteString(fmt.Sprint(v))
+	}
+}
+
+// enter records that the pointer, map or slice v is being printed and
+// reports whether it was not already, that is, whether v is not part
+// of a cycle. The caller deletes v.Pointer() from d.seen when done.
+func (d *printer) enter(v reflect.Value) bool {
+	if d.seen[v.Pointer()] {
+		return false
+	}
+	d.seen[v.Pointer()] = true
+	return true
+}
+
+// indent starts a new line indented by depth tabs.
+func (d *printer) indent(depth int) {
+	d.buf.WriteByte('\n')
+	for range depth {
+		d.buf.WriteByte('\t')
+	}
+}
+
+var (
+	errorType    = reflect.TypeFor[error]()
+	stringerType = reflect.TypeFor[fmt.Stringer]()
+)
+
+// multiline reports whether values of type t are printed with one
+// field or element per line.
+func multiline(t reflect.Type) bool {
+	if t.Implements(errorType) || t.Implements(stringerType) {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		return true
+	case reflect.Slice, reflect.Array, reflect.Pointer:
+		return t.Elem() != t && multiline(t.Elem())
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type dbgAddr struct {
+	City string
+	Zip  int
+}
+
+type dbgUser struct {
+	Name  string
+	Tags  []string
+	Addr  *dbgAddr
+	Prefs map[string]int
+	next  *dbgUser
+}
+
+func TestPrint(t *testing.T) {
+	u := dbgUser{Name: "Al", Tags: []string{"a"}, Addr: &dbgAddr{"Paris", 75000}, Prefs: map[string]int{"z": 1, "a": 2}}
+	u.next = &u
+	var err error
+	m := map[string]any{"n": 1}
+	m["m"] = m
+	s := []any{1, nil}
+	s[1] = s
+	for _, test := range []struct {
+		x    any
+		want string
+	}{
+		{42, `42 (int)`},
+		{"hi", `"hi" (string)`},
+		{[]int{1, 2}, `[1 2] ([]int)`},
+		{[]string(nil), `[] ([]string)`},
+		{&err, `<nil> (error)`},
+		{errors.New("oops"), `oops (*errors.errorString)`},
+		{struct{}{}, `{} (struct {})`},
+		{map[int]bool{}, `map[] (map[int]bool)`},
+		{[]any{1, "a"}, `[1 "a"] ([]interface {})`},
+		{[]dbgAddr{{"A", 1}}, `[
+	{
+		City: "A"
+		Zip: 1
+	}
+] ([]dbg.dbgAddr)`},
+		{m, `map[
+	"m": map[...]
+	"n": 1
+] (map[string]interface {})`},
+		{s, `[1 [...]] ([]interface {})`},
+		{u, `{
+	Name: "Al"
+	Tags: ["a"]
+	Addr: &{
+		City: "Paris"
+		Zip: 75000
+	}
+	Prefs: map[
+		"a": 2
+		"z": 1
+	]
+	next: &{
+		Name: "Al"
+		Tags: ["a"]
+		Addr: &{
+			City: "Paris"
+			Zip: 75000
+		}
+		Prefs: map[
+			"a": 2
+			"z": 1
+		]
+		next: &dbg.dbgUser{...}
+	}
+} (dbg.dbgUser)`},
+	} {
+		v := reflect.ValueOf(test.x)
+		if p, ok := test.x.(*error); ok {
+			v = reflect.ValueOf(p).Elem()
+		}
+		want := "x.goo:1: x = " + test.want + "\n"
+		if got := line("x.goo:1", "x", v); got != want {
+			t.Errorf("Print(%T):\ngot  %s\nwant %s", test.x, got, want)
+		}
+	}
+}
@@ -151,6 +151,19 @@ const (
 
 	boundsConvert // (*[x]T)(s), 0 <= x <= len(s) failed
 	// Note: in the above, len(s) and cap(s) are stored in y
+
+	// goo's 1-based # operator, see hashindex.go
+	boundsHashIndex  // s#x, 1 <= |x| <= len(s) failed
+	boundsHashSliceA // s#x:?, s#?:x, 1 <= |x| <= len(s) failed
+	boundsHashSliceB // s#x:y, x <= y+1 failed
+	boundsHashZero   // s#0
+
+	// goo's elementwise slice operations, see vec.go
+	boundsVecLen // x op y, len(x) == len(y) failed; len(x) is stored in x, len(y) in y
+
+	// goo's destructuring assignments, see pattern.go
+	boundsPattern     // [a, b] := s, len(s) == 2 failed; the number of names is stored in x, len(s) in y
+	boundsPatternRest // [a, b, ...rest] := s, len(s) >= 2 failed
 )
 
 // boundsErrorFmts provide error text for various out-of-bounds panics.
@@ -166,6 +179,14 @@ var boundsErrorFmts = [...]string{
 	boundsSlice3B:    "slice bounds out of range [:%x:%y]",
 	boundsSlice3C:    "slice bounds out of range [%x:%y:]",
 	boundsConvert:    "cannot convert slice with length %y to array or pointer to array with length %x",
+	boundsHashIndex:  "index out of range #%x with length %y",
+	boundsHashSliceA: "slice bounds out of range #%x with length %y",
+	boundsHashSliceB: "slice bounds out of range #%x:%y",
+	boundsHashZero:   "index #0 is invalid, # indices start at 1",
+	boundsVecLen:     "elementwise operation on slices of length %x and %y",
+
+	boundsPattern:     "cannot destructure slice of length %y into %x names",
+	boundsPatternRest: "cannot destructure slice of length %y into %x or more names",
 }
 
 // boundsNegErrorFmts are overriding formats if x is negative. In this case there's no need to report y.
@@ -178,6 +199,14 @@ var boundsNegErrorFmts = [...]string{
 	boundsSlice3Acap: "slice bounds out of range [::%x]",
 	boundsSlice3B:    "slice bounds out of range [:%x:]",
 	boundsSlice3C:    "slice bounds out of range [%x::]",
+	boundsHashIndex:  "index out of range #%x with length %y",
+	boundsHashSliceA: "slice bounds out of range #%x with length %y",
+	boundsHashSliceB: "slice bounds out of range #%x:%y",
+	boundsHashZero:   "index #0 is invalid, # indices start at 1",
+	boundsVecLen:     "elementwise operation on slices of length %x and %y",
+
+	boundsPattern:     "cannot destructure slice of length %y into %x names",
+	boundsPatternRest: "cannot destructure slice of length %y into %x or more names",
 }
 
 func (e boundsError) RuntimeError() {}
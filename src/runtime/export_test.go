@@ -1927,3 +1927,86 @@ func (t *TraceStackTable) Reset() {
 func TraceStack(gp *G, tab *TraceStackTable) {
 	traceStack(0, gp, (*traceStackTable)(tab))
 }
+
+// VecFuncs calls the runtime functions for goo's arithmetic on slices
+// of T as the compiler does, so that they are intrinsics on amd64 and
+// arm64. The functions the runtime lacks for T are nil.
+type VecFuncs[T any] struct {
+	Add, Sub, Mul, Div      func(dst, x, y []T)
+	Adds, Subs, Rsubs, Muls func(dst, x []T, s T)
+	Divs, Rdivs             func(dst, x []T, s T)
+	Sum                     func(x []T) T
+	Dot                     func(x, y []T) T
+}
+
+func vecPtr[T any](s []T) unsafe.Pointer { return unsafe.Pointer(unsafe.SliceData(s)) }
+
+var VecF32 = VecFuncs[float32]{
+	Add:   func(d, x, y []float32) { vaddF32(vecPtr(d), vecPtr(x), vecPtr(y), len(x)) },
+	Sub:   func(d, x, y []float32) { vsubF32(vecPtr(d), vecPtr(x), vecPtr(y), len(x)) },
+	Mul:   func(d, x, y []float32) { vmulF32(vecPtr(d), vecPtr(x), vecPtr(y), len(x)) },
+	Div:   func(d, x, y []float32) { vdivF32(vecPtr(d), vecPtr(x), vecPtr(y), len(x)) },
+	Adds:  func(d, x []float32, s float32) { vaddsF32(vecPtr(d), vecPtr(x), s, len(x)) },
+	Subs:  func(d, x []float32, s float32) { vsubsF32(vecPtr(d), vecPtr(x), s, len(x)) },
+	Rsubs: func(d, x []float32, s float32) { vrsubsF32(vecPtr(d), vecPtr(x), s, len(x)) },
+	Muls:  func(d, x []float32, s float32) { vmulsF32(vecPtr(d), vecPtr(x), s, len(x)) },
+	Divs:  func(d, x []float32, s float32) { vdivsF32(vecPtr(d), vecPtr(x), s, len(x)) },
+	Rdivs: func(d, x []float32, s float32) { vrdivsF32(vecPtr(d), vecPtr(x), s, len(x)) },
+	Sum:   func(x []float32) float32 { return vsumF32(vecPtr(x), len(x)) },
+	Dot:   func(x, y []float32) float32 { return vdotF32(vecPtr(x), vecPtr(y), len(x)) },
+}
+
+var VecF64 = VecFuncs[float64]{
+	Add:   func(d, x, y []float64) { vaddF64(vecPtr(d), vecPtr(x), vecPtr(y), len(x)) },
+	Sub:   func(d, x, y []float64) { vsubF64(vecPtr(d), vecPtr(x), vecPtr(y), len(x)) },
+	Mul:   func(d, x, y []float64) { vmulF64(vecPtr(d), vecPtr(x), vecPtr(y), len(x)) },
+	Div:   func(d, x, y []float64) { vdivF64(vecPtr(d), vecPtr(x), vecPtr(y), len(x)) },
+	Adds:  func(d, x []float64, s float64) { vaddsF64(vecPtr(d), vecPtr(x), s, len(x)) },
+	Subs:  func(d, x []float64, s float64) { vsubsF64(vecPtr(d), vecPtr(x), s, len(x)) },
+	Rsubs: func(d, x []float64, s float64) { vrsubsF64(vecPtr(d), vecPtr(x), s, len(x)) },
+	Muls:  func(d, x []float64, s float64) { vmulsF64(vecPtr(d), vecPtr(x), s, len(x)) },
+	Divs:  func(d, x []float64, s float64) { vdivsF64(vecPtr(d), vecPtr(x), s, len(x)) },
+	Rdivs: func(d, x []float64, s float64) { vrdivsF64(vecPtr(d), vecPtr(x), s, len(x)) },
+	Sum:   func(x []float64) float64 { return vsumF64(vecPtr(x), len(x)) },
+	Dot:   func(x, y []float64) float64 { return vdotF64(vecPtr(x), vecPtr(y), len(x)) },
+}
+
+var VecI8 = VecFuncs[int8]{
+	Add:   func(d, x, y []int8) { vaddI8(vecPtr(d), vecPtr(x), vecPtr(y), len(x)) },
+	Sub:   func(d, x, y []int8) { vsubI8(vecPtr(d), vecPtr(x), vecPtr(y), len(x)) },
+	Adds:  func(d, x []int8, s int8) { vaddsI8(vecPtr(d), vecPtr(x), s, len(x)) },
+	Subs:  func(d, x []int8, s int8) { vsubsI8(vecPtr(d), vecPtr(x), s, len(x)) },
+	Rsubs: func(d, x []int8, s int8) { vrsubsI8(vecPtr(d), vecPtr(x), s, len(x)) },
+	Sum:   func(x []int8) int8 { return vsumI8(vecPtr(x), len(x)) },
+}
+
+var VecI16 = VecFuncs[int16]{
+	Add:   func(d, x, y []int16) { vaddI16(vecPtr(d), vecPtr(x), vecPtr(y), len(x)) },
+	Sub:   func(d, x, y []int16) { vsubI16(vecPtr(d), vecPtr(x), vecPtr(y), len(x)) },
+	Mul:   func(d, x, y []int16) { vmulI16(vecPtr(d), vecPtr(x), vecPtr(y), len(x)) },
+	Adds:  func(d, x []int16, s int16) { vaddsI16(vecPtr(d), vecPtr(x), s, len(x)) },
+	Subs:  func(d, x []int16, s int16) { vsubsI16(vecPtr(d), vecPtr(x), s, len(x)) },
+	Rsubs: func(d, x []int16, s int16) { vrsubsI16(vecPtr(d), vecPtr(x), s, len(x)) },
+	Muls:  func(d, x []int16, s int16) { vmulsI16(vecPtr(d), vecPtr(x), s, len(x)) },
+	Sum:   func(x []int16) int16 { return vsumI16(vecPtr(x), len(x)) },
+}
+
+var VecI32 = VecFuncs[int32]{
+	Add:   func(d, x, y []int32) { vaddI32(vecPtr(d), vecPtr(x), vecPtr(y), len(x)) },
+	Sub:   func(d, x, y []int32) { vsubI32(vecPtr(d), vecPtr(x), vecPtr(y), len(x)) },
+	Mul:   func(d, x, y []int32) { vmulI32(vecPtr(d), vecPtr(x), vecPtr(y), len(x)) },
+	Adds:  func(d, x []int32, s int32) { vaddsI32(vecPtr(d), vecPtr(x), s, len(x)) },
+	Subs:  func(d, x []int32, s int32) { vsubsI32(vecPtr(d), vecPtr(x), s, len(x)) },
+	Rsubs: func(d, x []int32, s int32) { vrsubsI32(vecPtr(d), vecPtr(x), s, len(x)) },
+	Muls:  func(d, x []int32, s int32) { vmulsI32(vecPtr(d), vecPtr(x), s, len(x)) },
+	Sum:   func(x []int32) int32 { return vsumI32(vecPtr(x), len(x)) },
+}
+
+var VecI64 = VecFuncs[int64]{
+	Add:   func(d, x, y []int64) { vaddI64(vecPtr(d), vecPtr(x), vecPtr(y), len(x)) },
+	Sub:   func(d, x, y []int64) { vsubI64(vecPtr(d), vecPtr(x), vecPtr(y), len(x)) },
+	Adds:  func(d, x []int64, s int64) { vaddsI64(vecPtr(d), vecPtr(x), s, len(x)) },
+	Subs:  func(d, x []int64, s int64) { vsubsI64(vecPtr(d), vecPtr(x), s, len(x)) },
+	Rsubs: func(d, x []int64, s int64) { vrsubsI64(vecPtr(d), vecPtr(x), s, len(x)) },
+	Sum:   func(x []int64) int64 { return vsumI64(vecPtr(x), len(x)) },
+}
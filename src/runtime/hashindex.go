@@ -0,0 +1,82 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+// The functions in this file implement goo's 1-based # operator.
+// x#i is the i'th element of x counting from 1, and negative indices
+// count from the end, so x#-1 is the last element. x#i:j selects the
+// elements i through j inclusive. The compiler calls these helpers
+// instead of emitting ordinary bounds checks, so that a failing check
+// reports the index the user wrote rather than the shifted 0-based one.
+
+// hashpos converts the # index i into a 0-based index into a sequence
+// of length n. ok reports whether the result is in range. hashpos
+// panics if i is zero, which is never a valid # index.
+func hashpos(i, n int) (k int, ok bool) {
+	if i == 0 {
+		panic(boundsError{code: boundsHashZero})
+	}
+	k = i - 1
+	if i < 0 {
+		k = n + i
+	}
+	return k, 0 <= k && k < n
+}
+
+// hashindex returns the 0-based index for x#i, where n is len(x).
+func hashindex(i, n int) int {
+	k, ok := hashpos(i, n)
+	if !ok {
+		panic(boundsError{x: int64(i), signed: true, y: n, code: boundsHashIndex})
+	}
+	return k
+}
+
+// hashslice returns the 0-based bounds lo, hi such that x[lo:hi]
+// is x#i:j, where n is len(x). j may precede i by one element,
+// which yields an empty slice.
+func hashslice(i, j, n int) (lo, hi int) {
+	lo, ok := hashpos(i, n)
+	if !ok {
+		panic(boundsError{x: int64(i), signed: true, y: n, code: boundsHashSliceA})
+	}
+	hi, ok = hashpos(j, n)
+	if !ok {
+		panic(boundsError{x: int64(j), signed: true, y: n, code: boundsHashSliceA})
+	}
+	hi++ // j is inclusive
+	if hi < lo {
+		panic(boundsError{x: int64(i), signed: true, y: j, code: boundsHashSliceB})
+	}
+	return lo, hi
+}
+
+// hashrune returns s#i, the i'th rune of s.
+func hashrune(s string, i int) rune {
+	k := hashindex(i, countrunes(s))
+	for _, r := range s {
+		if k == 0 {
+			return r
+		}
+		k--
+	}
+	return runeError // not reached
+}
+
+// hashslicestring returns s#i:j, the runes i through j of s.
+func hashslicestring(s string, i, j int) string {
+	lo, hi := hashslice(i, j, countrunes(s))
+	k, start := 0, len(s)
+	for p := range s {
+		if k == lo {
+			start = p
+		}
+		if k == hi {
+			return s[start:p]
+		}
+		k++
+	}
+	return s[start:]
+}
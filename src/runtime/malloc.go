@@ -1656,6 +1656,9 @@ func preMallocgcDebug(size uintptr, typ *_type) unsafe.Pointer {
 				align = 1
 			}
 		}
+		if nogc.enabled {
+			return nogcAlloc(size, align)
+		}
 		return persistentalloc(size, align, &memstats.other_sys)
 	}
 	if inittrace.active && inittrace.id == getg().goid {
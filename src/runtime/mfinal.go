@@ -298,6 +298,11 @@ func isGoPointerWithoutSpan(p unsafe.Pointer) bool {
 		return true
 	}
 
+	// goo: the arena of the gc=off mode is never freed.
+	if nogc.enabled && nogc.base <= uintptr(p) && uintptr(p) < nogc.base+nogc.ceiling {
+		return true
+	}
+
 	// Global initializers might be linker-allocated.
 	//	var Foo = &Object{}
 	//	func main() {
@@ -207,6 +207,10 @@ func gcinit() {
 // It kicks off the background sweeper goroutine, the background
 // scavenger goroutine, and enables GC.
 func gcenable() {
+	if nogc.enabled {
+		// goo: nothing to sweep or scavenge, and GC stays disabled.
+		return
+	}
 	// Kick off sweeping and scavenging.
 	c := make(chan int, 2)
 	go bgsweep(c)
@@ -503,6 +507,11 @@ func GC() {
 	// GC may move ahead on its own. For example, when we block
 	// until mark termination N, we may wake up in cycle N+2.
 
+	if nogc.enabled {
+		// goo: there is no collector to run.
+		return
+	}
+
 	// Wait until the current sweep termination, mark, and mark
 	// termination complete.
 	n := work.cycles.Load()
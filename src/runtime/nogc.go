@@ -0,0 +1,143 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// goo: a runtime mode without garbage collection for short-lived
+// programs, selected at link time by 'go build -gc=off'.
+//
+// In this mode the runtime never starts the background sweeper,
+// scavenger or mark workers, and never triggers a GC cycle.
+// Every allocation comes from a bump allocator carving per-P chunks
+// out of one huge arena reserved up front, in the manner of
+// GODEBUG=sbrk=1, whose handling of finalizers, cleanups and weak
+// pointers it reuses. The arena is as large as the memory ceiling,
+// GOMEMLIMIT or nogcDefaultCeiling, and exhausting it is a fatal
+// error rather than an OOM kill. Packages are compiled with -wb=false,
+// so there are no write barriers either.
+//
+// The peak memory is reported on standard error at exit.
+
+package runtime
+
+import (
+	"internal/goarch"
+	"internal/runtime/math"
+	"unsafe"
+)
+
+// gooGC is set by the linker, as in -X runtime.gooGC=off.
+var gooGC string
+
+const (
+	// nogcChunk is the size of the per-P bump allocation chunks.
+	nogcChunk = 1 << 20
+
+	// nogcDefaultCeiling is the memory ceiling without a GOMEMLIMIT:
+	// 8 GiB on 64-bit systems, 1 GiB on 32-bit ones.
+	nogcDefaultCeiling = 1<<30 + goarch.PtrSize/8*(7<<30)
+)
+
+var nogc struct {
+	enabled bool
+	ceiling uintptr
+	base    uintptr
+	arena   linearAlloc     // protected by globalAlloc.mutex
+	global  persistentAlloc // for allocations without a P, protected by globalAlloc.mutex
+}
+
+// nogcinit enables the no-GC mode if the program was linked for it.
+// It must run after gcinit, which reads GOMEMLIMIT.
+func nogcinit() {
+	if gooGC != "off" {
+		return
+	}
+	ceiling := uintptr(nogcDefaultCeiling)
+	if limit := gcController.memoryLimit.Load(); limit != math.MaxInt64 && limit > 0 {
+		ceiling = uintptr(limit)
+	}
+	ceiling = alignUp(ceiling, physPageSize)
+	base := sysReserve(nil, ceiling, "goo gc=off arena")
+	if base == nil {
+		print("runtime: gc=off cannot reserve ", ceiling>>20, " MiB; lower GOMEMLIMIT\n")
+		throw("out of memory")
+	}
+	nogc.ceiling = ceiling
+	nogc.base = uintptr(base)
+	nogc.arena.init(uintptr(base), ceiling, true)
+
+	// Nothing may start a GC cycle or scavenge: gcenable leaves
+	// memstats.enablegc unset, which stops all triggers.
+	gcController.gcPercent.Store(-1)
+	gcController.memoryLimit.Store(math.MaxInt64)
+
+	debug.sbrk = 1
+	debug.malloc = true
+	nogc.enabled = true
+}
+
+// nogcAlloc allocates size bytes from the arena.
+// The memory is zeroed, since it is never reused.
+func nogcAlloc(size, align uintptr) unsafe.Pointer {
+	if size >= nogcChunk/4 {
+		lock(&globalAlloc.mutex)
+		p := nogc.arena.alloc(size, align, &memstats.other_sys, "goo gc=off arena")
+		unlock(&globalAlloc.mutex)
+		if p == nil {
+			nogcExhausted(size)
+		}
+		return p
+	}
+
+	mp := acquirem()
+	global := mp == nil || mp.p == 0
+	var a *persistentAlloc
+	if global {
+		lock(&globalAlloc.mutex)
+		a = &nogc.global
+	} else {
+		a = &mp.p.ptr().nogcAlloc
+	}
+	a.off = alignUp(a.off, align)
+	if a.base == nil || a.off+size > nogcChunk {
+		if !global {
+			lock(&globalAlloc.mutex)
+		}
+		a.base = (*notInHeap)(nogc.arena.alloc(nogcChunk, physPageSize, &memstats.other_sys, "goo gc=off arena"))
+		if !global {
+			unlock(&globalAlloc.mutex)
+		}
+		a.off = 0
+		if a.base == nil {
+			if global {
+				unlock(&globalAlloc.mutex)
+			}
+			releasem(mp)
+			nogcExhausted(size)
+		}
+	}
+	p := a.base.add(a.off)
+	a.off += size
+	if global {
+		unlock(&globalAlloc.mutex)
+	}
+	releasem(mp)
+	return unsafe.Pointer(p)
+}
+
+// nogcExhausted reports that an allocation of size bytes hit the ceiling.
+func nogcExhausted(size uintptr) {
+	print("runtime: gc=off memory ceiling of ", nogc.ceiling>>20, " MiB exceeded allocating ", size, " bytes\n")
+	print("runtime: raise GOMEMLIMIT or build without -gc=off\n")
+	fatal("out of memory")
+}
+
+// nogcReport prints the peak memory of the program on standard error.
+// Nothing is ever freed, so that is the memory in use at exit.
+func nogcReport() {
+	lock(&globalAlloc.mutex)
+	used := nogc.arena.next - nogc.base
+	unlock(&globalAlloc.mutex)
+	used += uintptr(gcController.heapInUse.load())
+	var buf [24]byte
+	print("gc=off: peak memory ", string(itoaDiv(buf[:], uint64(used*10>>20), 1)), " MiB of ", nogc.ceiling>>20, " MiB\n")
+}
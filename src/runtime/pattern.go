@@ -0,0 +1,21 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+// panicpattern reports that the slice s of a goo destructuring
+// assignment
+//
+//	[a, b] := s
+//	[a, b, ...rest] := s
+//
+// has have elements; it panics unless the slice has exactly n elements,
+// or at least n if rest is set.
+func panicpattern(n, have int, rest bool) {
+	code := boundsPattern
+	if rest {
+		code = boundsPatternRest
+	}
+	panic(boundsError{x: int64(n), signed: true, y: have, code: code})
+}
@@ -353,6 +353,9 @@ func init() {
 }
 
 func runExitHooks(code int) {
+	if nogc.enabled {
+		nogcReport()
+	}
 	exithook.Run(code)
 }
 
@@ -882,6 +885,7 @@ func schedinit() {
 	checkfds()
 	parsedebugvars()
 	gcinit()
+	nogcinit()
 
 	// Allocate stack space that can be used when crashing due to bad stack
 	// conditions, e.g. morestack on g0.
@@ -38,10 +38,10 @@ func RunStealOrderTest() {
 		// We want at least procs*len(ord.coprimes) different pos+inc values
 		// before we start repeating.
 		for i := 0; i < procs*len(ord.coprimes); i++ {
-			enum := ord.start(uint32(i))
-			j := enum.pos*uint32(procs) + enum.inc
+			e := ord.start(uint32(i))
+			j := e.pos*uint32(procs) + e.inc
 			if checked[j] {
-				println("procs:", procs, "pos:", enum.pos, "inc:", enum.inc)
+				println("procs:", procs, "pos:", e.pos, "inc:", e.inc)
 				panic("duplicate pos+inc during enumeration")
 			}
 			checked[j] = true
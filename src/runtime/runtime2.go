@@ -701,6 +701,8 @@ type p struct {
 
 	palloc persistentAlloc // per-P to avoid mutex
 
+	nogcAlloc persistentAlloc // goo: per-P bump chunk in gc=off mode
+
 	// Per-P GC state
 	gcAssistTime         int64 // Nanoseconds in assistAlloc
 	gcFractionalMarkTime int64 // Nanoseconds in fractional mark worker (atomic)
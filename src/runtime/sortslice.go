@@ -0,0 +1,107 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+import (
+	"internal/abi"
+	"unsafe"
+)
+
+// sortslice implements goo's sort and sortBy collection methods. It
+// sorts the n keys of type keyType at keys in ascending order, and
+// moves the n elements of type elemType at elems (unless nil) along
+// with their keys. The sort is stable. Keys are of an ordered kind;
+// NaNs order before all other floating-point values, as in cmp.Less.
+func sortslice(keyType *_type, keys unsafe.Pointer, elemType *_type, elems unsafe.Pointer, n int) {
+	if n < 2 {
+		return
+	}
+	key := func(i int) unsafe.Pointer {
+		return add(keys, uintptr(i)*keyType.Size_)
+	}
+	less := sortLess(keyType.Kind())
+
+	// Merge sort a permutation of the indices, then apply it.
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	tmp := make([]int, n)
+	for width := 1; width < n; width *= 2 {
+		for lo := 0; lo < n; lo += 2 * width {
+			mid := min(lo+width, n)
+			hi := min(lo+2*width, n)
+			i, j := lo, mid
+			for k := lo; k < hi; k++ {
+				if i < mid && (j == hi || !less(key(perm[j]), key(perm[i]))) {
+					tmp[k] = perm[i]
+					i++
+				} else {
+					tmp[k] = perm[j]
+					j++
+				}
+			}
+		}
+		perm, tmp = tmp, perm
+	}
+
+	permute(keyType, keys, perm)
+	if elems != nil {
+		permute(elemType, elems, perm)
+	}
+}
+
+// permute rearranges the len(perm) values of type typ at p such that
+// the i'th value is the perm[i]'th one before.
+func permute(typ *_type, p unsafe.Pointer, perm []int) {
+	n := len(perm)
+	buf := newarray(typ, n)
+	for i, j := range perm {
+		typedmemmove(typ, add(buf, uintptr(i)*typ.Size_), add(p, uintptr(j)*typ.Size_))
+	}
+	for i := 0; i < n; i++ {
+		typedmemmove(typ, add(p, uintptr(i)*typ.Size_), add(buf, uintptr(i)*typ.Size_))
+	}
+}
+
+// sortLess returns the less function for values of the ordered kind.
+func sortLess(kind abi.Kind) func(x, y unsafe.Pointer) bool {
+	switch kind {
+	case abi.Int:
+		return func(x, y unsafe.Pointer) bool { return *(*int)(x) < *(*int)(y) }
+	case abi.Int8:
+		return func(x, y unsafe.Pointer) bool { return *(*int8)(x) < *(*int8)(y) }
+	case abi.Int16:
+		return func(x, y unsafe.Pointer) bool { return *(*int16)(x) < *(*int16)(y) }
+	case abi.Int32:
+		return func(x, y unsafe.Pointer) bool { return *(*int32)(x) < *(*int32)(y) }
+	case abi.Int64:
+		return func(x, y unsafe.Pointer) bool { return *(*int64)(x) < *(*int64)(y) }
+	case abi.Uint:
+		return func(x, y unsafe.Pointer) bool { return *(*uint)(x) < *(*uint)(y) }
+	case abi.Uint8:
+		return func(x, y unsafe.Pointer) bool { return *(*uint8)(x) < *(*uint8)(y) }
+	case abi.Uint16:
+		return func(x, y unsafe.Pointer) bool { return *(*uint16)(x) < *(*uint16)(y) }
+	case abi.Uint32:
+		return func(x, y unsafe.Pointer) bool { return *(*uint32)(x) < *(*uint32)(y) }
+	case abi.Uint64:
+		return func(x, y unsafe.Pointer) bool { return *(*uint64)(x) < *(*uint64)(y) }
+	case abi.Uintptr:
+		return func(x, y unsafe.Pointer) bool { return *(*uintptr)(x) < *(*uintptr)(y) }
+	case abi.Float32:
+		return func(x, y unsafe.Pointer) bool { return floatLess(float64(*(*float32)(x)), float64(*(*float32)(y))) }
+	case abi.Float64:
+		return func(x, y unsafe.Pointer) bool { return floatLess(*(*float64)(x), *(*float64)(y)) }
+	case abi.String:
+		return func(x, y unsafe.Pointer) bool { return *(*string)(x) < *(*string)(y) }
+	}
+	throw("sortslice: unordered key type")
+	return nil
+}
+
+func floatLess(x, y float64) bool {
+	return x < y || (x != x && y == y)
+}
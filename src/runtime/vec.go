@@ -0,0 +1,187 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+import "unsafe"
+
+// The functions in this file implement goo's arithmetic on slices of
+// numbers: a + b, a * 2.0, 1 - a, and the sum and dot builtins. The
+// compiler calls them with the data pointers and the length of the
+// slices, after checking that the lengths match. On amd64 and arm64 it
+// replaces the calls with the VecOp, VecSum and VecDot SSA ops, which
+// run as SIMD loops, so these portable loops only run elsewhere.
+//
+// The integer functions take signed integers, which add, subtract and
+// multiply as the unsigned ones of the same size do. There are none
+// for division, dot, or multiplication of 8 and 64 bit integers, which
+// have no SIMD instructions to speak of; the compiler lowers those, and
+// comparisons, to plain loops.
+
+type vecNum interface {
+	float32 | float64 | int8 | int16 | int32 | int64
+}
+
+// panicveclen panics for an elementwise operation on slices of the
+// lengths x and y, which differ.
+func panicveclen(x, y int) {
+	panic(boundsError{x: int64(x), signed: true, y: y, code: boundsVecLen})
+}
+
+func vaddF32(dst, x, y unsafe.Pointer, n int) { vecAdd[float32](dst, x, y, n) }
+func vsubF32(dst, x, y unsafe.Pointer, n int) { vecSub[float32](dst, x, y, n) }
+func vmulF32(dst, x, y unsafe.Pointer, n int) { vecMul[float32](dst, x, y, n) }
+func vdivF32(dst, x, y unsafe.Pointer, n int) { vecDiv[float32](dst, x, y, n) }
+
+func vaddsF32(dst, x unsafe.Pointer, s float32, n int)  { vecAdds(dst, x, s, n) }
+func vsubsF32(dst, x unsafe.Pointer, s float32, n int)  { vecSubs(dst, x, s, n) }
+func vmulsF32(dst, x unsafe.Pointer, s float32, n int)  { vecMuls(dst, x, s, n) }
+func vdivsF32(dst, x unsafe.Pointer, s float32, n int)  { vecDivs(dst, x, s, n) }
+func vrsubsF32(dst, x unsafe.Pointer, s float32, n int) { vecRsubs(dst, x, s, n) }
+func vrdivsF32(dst, x unsafe.Pointer, s float32, n int) { vecRdivs(dst, x, s, n) }
+
+func vsumF32(x unsafe.Pointer, n int) float32    { return vecSum[float32](x, n) }
+func vdotF32(x, y unsafe.Pointer, n int) float32 { return vecDot[float32](x, y, n) }
+
+func vaddF64(dst, x, y unsafe.Pointer, n int) { vecAdd[float64](dst, x, y, n) }
+func vsubF64(dst, x, y unsafe.Pointer, n int) { vecSub[float64](dst, x, y, n) }
+func vmulF64(dst, x, y unsafe.Pointer, n int) { vecMul[float64](dst, x, y, n) }
+func vdivF64(dst, x, y unsafe.Pointer, n int) { vecDiv[float64](dst, x, y, n) }
+
+func vaddsF64(dst, x unsafe.Pointer, s float64, n int)  { vecAdds(dst, x, s, n) }
+func vsubsF64(dst, x unsafe.Pointer, s float64, n int)  { vecSubs(dst, x, s, n) }
+func vmulsF64(dst, x unsafe.Pointer, s float64, n int)  { vecMuls(dst, x, s, n) }
+func vdivsF64(dst, x unsafe.Pointer, s float64, n int)  { vecDivs(dst, x, s, n) }
+func vrsubsF64(dst, x unsafe.Pointer, s float64, n int) { vecRsubs(dst, x, s, n) }
+func vrdivsF64(dst, x unsafe.Pointer, s float64, n int) { vecRdivs(dst, x, s, n) }
+
+func vsumF64(x unsafe.Pointer, n int) float64    { return vecSum[float64](x, n) }
+func vdotF64(x, y unsafe.Pointer, n int) float64 { return vecDot[float64](x, y, n) }
+
+func vaddI8(dst, x, y unsafe.Pointer, n int) { vecAdd[int8](dst, x, y, n) }
+func vsubI8(dst, x, y unsafe.Pointer, n int) { vecSub[int8](dst, x, y, n) }
+
+func vaddsI8(dst, x unsafe.Pointer, s int8, n int)  { vecAdds(dst, x, s, n) }
+func vsubsI8(dst, x unsafe.Pointer, s int8, n int)  { vecSubs(dst, x, s, n) }
+func vrsubsI8(dst, x unsafe.Pointer, s int8, n int) { vecRsubs(dst, x, s, n) }
+
+func vsumI8(x unsafe.Pointer, n int) int8 { return vecSum[int8](x, n) }
+
+func vaddI16(dst, x, y unsafe.Pointer, n int) { vecAdd[int16](dst, x, y, n) }
+func vsubI16(dst, x, y unsafe.Pointer, n int) { vecSub[int16](dst, x, y, n) }
+func vmulI16(dst, x, y unsafe.Pointer, n int) { vecMul[int16](dst, x, y, n) }
+
+func vaddsI16(dst, x unsafe.Pointer, s int16, n int)  { vecAdds(dst, x, s, n) }
+func vsubsI16(dst, x unsafe.Pointer, s int16, n int)  { vecSubs(dst, x, s, n) }
+func vmulsI16(dst, x unsafe.Pointer, s int16, n int)  { vecMuls(dst, x, s, n) }
+func vrsubsI16(dst, x unsafe.Pointer, s int16, n int) { vecRsubs(dst, x, s, n) }
+
+func vsumI16(x unsafe.Pointer, n int) int16 { return vecSum[int16](x, n) }
+
+func vaddI32(dst, x, y unsafe.Pointer, n int) { vecAdd[int32](dst, x, y, n) }
+func vsubI32(dst, x, y unsafe.Pointer, n int) { vecSub[int32](dst, x, y, n) }
+func vmulI32(dst, x, y unsafe.Pointer, n int) { vecMul[int32](dst, x, y, n) }
+
+func vaddsI32(dst, x unsafe.Pointer, s int32, n int)  { vecAdds(dst, x, s, n) }
+func vsubsI32(dst, x unsafe.Pointer, s int32, n int)  { vecSubs(dst, x, s, n) }
+func vmulsI32(dst, x unsafe.Pointer, s int32, n int)  { vecMuls(dst, x, s, n) }
+func vrsubsI32(dst, x unsafe.Pointer, s int32, n int) { vecRsubs(dst, x, s, n) }
+
+func vsumI32(x unsafe.Pointer, n int) int32 { return vecSum[int32](x, n) }
+
+func vaddI64(dst, x, y unsafe.Pointer, n int) { vecAdd[int64](dst, x, y, n) }
+func vsubI64(dst, x, y unsafe.Pointer, n int) { vecSub[int64](dst, x, y, n) }
+
+func vaddsI64(dst, x unsafe.Pointer, s int64, n int)  { vecAdds(dst, x, s, n) }
+func vsubsI64(dst, x unsafe.Pointer, s int64, n int)  { vecSubs(dst, x, s, n) }
+func vrsubsI64(dst, x unsafe.Pointer, s int64, n int) { vecRsubs(dst, x, s, n) }
+
+func vsumI64(x unsafe.Pointer, n int) int64 { return vecSum[int64](x, n) }
+
+func vecAdd[T vecNum](dst, x, y unsafe.Pointer, n int) {
+	d, xs, ys := unsafe.Slice((*T)(dst), n), unsafe.Slice((*T)(x), n), unsafe.Slice((*T)(y), n)
+	for i, v := range xs {
+		d[i] = v + ys[i]
+	}
+}
+
+func vecSub[T vecNum](dst, x, y unsafe.Pointer, n int) {
+	d, xs, ys := unsafe.Slice((*T)(dst), n), unsafe.Slice((*T)(x), n), unsafe.Slice((*T)(y), n)
+	for i, v := range xs {
+		d[i] = v - ys[i]
+	}
+}
+
+func vecMul[T vecNum](dst, x, y unsafe.Pointer, n int) {
+	d, xs, ys := unsafe.Slice((*T)(dst), n), unsafe.Slice((*T)(x), n), unsafe.Slice((*T)(y), n)
+	for i, v := range xs {
+		d[i] = v * ys[i]
+	}
+}
+
+func vecDiv[T vecNum](dst, x, y unsafe.Pointer, n int) {
+	d, xs, ys := unsafe.Slice((*T)(dst), n), unsafe.Slice((*T)(x), n), unsafe.Slice((*T)(y), n)
+	for i, v := range xs {
+		d[i] = v / ys[i]
+	}
+}
+
+func vecAdds[T vecNum](dst, x unsafe.Pointer, s T, n int) {
+	d := unsafe.Slice((*T)(dst), n)
+	for i, v := range unsafe.Slice((*T)(x), n) {
+		d[i] = v + s
+	}
+}
+
+func vecSubs[T vecNum](dst, x unsafe.Pointer, s T, n int) {
+	d := unsafe.Slice((*T)(dst), n)
+	for i, v := range unsafe.Slice((*T)(x), n) {
+		d[i] = v - s
+	}
+}
+
+func vecMuls[T vecNum](dst, x unsafe.Pointer, s T, n int) {
+	d := unsafe.Slice((*T)(dst), n)
+	for i, v := range unsafe.Slice((*T)(x), n) {
+		d[i] = v * s
+	}
+}
+
+func vecDivs[T vecNum](dst, x unsafe.Pointer, s T, n int) {
+	d := unsafe.Slice((*T)(dst), n)
+	for i, v := range unsafe.Slice((*T)(x), n) {
+		d[i] = v / s
+	}
+}
+
+func vecRsubs[T vecNum](dst, x unsafe.Pointer, s T, n int) {
+	d := unsafe.Slice((*T)(dst), n)
+	for i, v := range unsafe.Slice((*T)(x), n) {
+		d[i] = s - v
+	}
+}
+
+func vecRdivs[T vecNum](dst, x unsafe.Pointer, s T, n int) {
+	d := unsafe.Slice((*T)(dst), n)
+	for i, v := range unsafe.Slice((*T)(x), n) {
+		d[i] = s / v
+	}
+}
+
+func vecSum[T vecNum](x unsafe.Pointer, n int) T {
+	var s T
+	for _, v := range unsafe.Slice((*T)(x), n) {
+		s += v
+	}
+	return s
+}
+
+func vecDot[T vecNum](x, y unsafe.Pointer, n int) T {
+	var s T
+	ys := unsafe.Slice((*T)(y), n)
+	for i, v := range unsafe.Slice((*T)(x), n) {
+		s += v * ys[i]
+	}
+	return s
+}
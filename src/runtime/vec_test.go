@@ -0,0 +1,158 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime_test
+
+import (
+	. "runtime"
+	"testing"
+	"unsafe"
+)
+
+type vecNum interface {
+	~int8 | ~int16 | ~int32 | ~int64 | ~float32 | ~float64
+}
+
+// testVec checks the functions of fs on slices of every length up to
+// 70, which covers empty slices, partial blocks and several whole
+// blocks. The values are small integers, so float sums are exact, and
+// integer products wrap around.
+func testVec[T vecNum](fs VecFuncs[T]) func(*testing.T) {
+	return func(t *testing.T) {
+		binary := []struct {
+			name string
+			f    func(dst, x, y []T)
+			ref  func(x, y T) T
+		}{
+			{"add", fs.Add, func(x, y T) T { return x + y }},
+			{"sub", fs.Sub, func(x, y T) T { return x - y }},
+			{"mul", fs.Mul, func(x, y T) T { return x * y }},
+			{"div", fs.Div, func(x, y T) T { return x / y }},
+			{"adds", func(d, x, y []T) { fs.Adds(d, x, 3) }, func(x, _ T) T { return x + 3 }},
+			{"subs", func(d, x, y []T) { fs.Subs(d, x, 3) }, func(x, _ T) T { return x - 3 }},
+			{"rsubs", func(d, x, y []T) { fs.Rsubs(d, x, 3) }, func(x, _ T) T { return 3 - x }},
+			{"muls", func(d, x, y []T) { fs.Muls(d, x, 3) }, func(x, _ T) T { return x * 3 }},
+			{"divs", func(d, x, y []T) { fs.Divs(d, x, 3) }, func(x, _ T) T { return x / 3 }},
+			{"rdivs", func(d, x, y []T) { fs.Rdivs(d, x, 3) }, func(x, _ T) T { return 3 / x }},
+		}
+		skip := map[string]bool{
+			"mul": fs.Mul == nil, "div": fs.Div == nil,
+			"muls": fs.Muls == nil, "divs": fs.Divs == nil, "rdivs": fs.Rdivs == nil,
+		}
+		for n := 0; n <= 70; n++ {
+			x, y := make([]T, n), make([]T, n)
+			var sum, dot T
+			for i := range n {
+				x[i] = T(i%7*29 + 1)
+				y[i] = T(i%5 + 1)
+				sum += x[i]
+				dot += x[i] * y[i]
+			}
+			for _, op := range binary {
+				if skip[op.name] {
+					continue
+				}
+				d := make([]T, n)
+				op.f(d, x, y)
+				for i := range n {
+					if want := op.ref(x[i], y[i]); d[i] != want {
+						t.Fatalf("n=%d: element %d of %s is %v, want %v", n, i, op.name, d[i], want)
+					}
+				}
+			}
+			if got := fs.Sum(x); got != sum {
+				t.Errorf("n=%d: sum is %v, want %v", n, got, sum)
+			}
+			if fs.Dot != nil {
+				if got := fs.Dot(x, y); got != dot {
+					t.Errorf("n=%d: dot is %v, want %v", n, got, dot)
+				}
+			}
+		}
+	}
+}
+
+func TestVec(t *testing.T) {
+	t.Run("float32", testVec(VecF32))
+	t.Run("float64", testVec(VecF64))
+	t.Run("int8", testVec(VecI8))
+	t.Run("int16", testVec(VecI16))
+	t.Run("int32", testVec(VecI32))
+	t.Run("int64", testVec(VecI64))
+}
+
+func BenchmarkVecAdd(b *testing.B) {
+	b.Run("float64", benchmarkVecAdd(VecF64))
+	b.Run("int32", benchmarkVecAdd(VecI32))
+	b.Run("int8", benchmarkVecAdd(VecI8))
+}
+
+func benchmarkVecAdd[T vecNum](fs VecFuncs[T]) func(*testing.B) {
+	return func(b *testing.B) {
+		x, y, d := make([]T, 1024), make([]T, 1024), make([]T, 1024)
+		size := int64(len(x)) * int64(unsafe.Sizeof(x[0]))
+		b.Run("kernel", func(b *testing.B) {
+			b.SetBytes(size)
+			for b.Loop() {
+				fs.Add(d, x, y)
+			}
+		})
+		b.Run("loop", func(b *testing.B) {
+			b.SetBytes(size)
+			for b.Loop() {
+				for i := range x {
+					d[i] = x[i] + y[i]
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkVecSum(b *testing.B) {
+	b.Run("float32", benchmarkVecSum(VecF32))
+	b.Run("int16", benchmarkVecSum(VecI16))
+}
+
+func benchmarkVecSum[T vecNum](fs VecFuncs[T]) func(*testing.B) {
+	return func(b *testing.B) {
+		x := make([]T, 1024)
+		size := int64(len(x)) * int64(unsafe.Sizeof(x[0]))
+		b.Run("kernel", func(b *testing.B) {
+			b.SetBytes(size)
+			for b.Loop() {
+				fs.Sum(x)
+			}
+		})
+		b.Run("loop", func(b *testing.B) {
+			b.SetBytes(size)
+			for b.Loop() {
+				var s T
+				for _, v := range x {
+					s += v
+				}
+				_ = s
+			}
+		})
+	}
+}
+
+func BenchmarkVecDot(b *testing.B) {
+	x, y := make([]float32, 1024), make([]float32, 1024)
+	b.Run("kernel", func(b *testing.B) {
+		b.SetBytes(4 * 1024)
+		for b.Loop() {
+			VecF32.Dot(x, y)
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		b.SetBytes(4 * 1024)
+		for b.Loop() {
+			var s float32
+			for i := range x {
+				s += x[i] * y[i]
+			}
+			_ = s
+		}
+	})
+}
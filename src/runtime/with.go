@@ -0,0 +1,92 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+import "unsafe"
+
+// The compiler lowers a goo with statement
+//
+//	with f := os.Open(name)? {
+//		...
+//	}
+//
+// to pushing f onto a stack of resources local to the function and a
+// call of withpop at the end of the block and before any branch
+// statement leaving it. A function with with statements defers a call
+// of withunwind on entry, which closes the resources that remain when
+// it returns or panics.
+
+// withpop pops the last n resources off the stack s and closes them in
+// reverse order, joining the errors from closing them into the error
+// *errs.
+func withpop(s *[]any, n int, errs unsafe.Pointer) {
+	for range n {
+		stack := *s
+		x := stack[len(stack)-1]
+		stack[len(stack)-1] = nil
+		*s = stack[:len(stack)-1] // before closing, so that a panicking x is not closed again
+		withjoin((*error)(errs), withclose(x))
+	}
+}
+
+// withunwind closes the resources that remain on the stack s. If res is
+// not nil, it joins the errors *errs from closing resources into the
+// error result *res of the function.
+func withunwind(s *[]any, errs, res unsafe.Pointer) {
+	withpop(s, len(*s), errs)
+	if res != nil {
+		withjoin((*error)(res), *(*error)(errs))
+	}
+}
+
+// withclose closes the resource x by calling its method
+//
+//	Close() error, Close(), Stop() error, Stop() bool or Stop()
+//
+// whichever x has first, and returns the error it returns, if any. A
+// nil pointer, map, channel or function is not closed.
+func withclose(x any) error {
+	if efaceOf(&x).data == nil {
+		return nil
+	}
+	switch x := x.(type) {
+	case interface{ Close() error }:
+		return x.Close()
+	case interface{ Close() }:
+		x.Close()
+	case interface{ Stop() error }:
+		return x.Stop()
+	case interface{ Stop() bool }:
+		x.Stop()
+	case interface{ Stop() }:
+		x.Stop()
+	}
+	return nil
+}
+
+// withjoin joins err into the error *dst.
+func withjoin(dst *error, err error) {
+	switch {
+	case err == nil:
+	case *dst == nil:
+		*dst = err
+	default:
+		*dst = &withError{[]error{*dst, err}}
+	}
+}
+
+// A withError joins two errors of a function with with statements, as
+// errors.Join does.
+type withError struct {
+	errs []error
+}
+
+func (e *withError) Error() string {
+	return e.errs[0].Error() + "\n" + e.errs[1].Error()
+}
+
+func (e *withError) Unwrap() []error {
+	return e.errs
+}
@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"unicode"
 )
 
 // matcher sanitizes, uniques, and filters names of subtests and subbenchmarks.
@@ -95,6 +96,11 @@ func (m *matcher) fullName(c *common, subname string) (name string, ok, partial
 
 	// We check the full array of paths each time to allow for the case that a pattern contains a '/'.
 	elem := strings.Split(name, "/")
+	if (c == nil || c.level == 0) && isGooTestName(elem[0]) {
+		// The names of goo test blocks may contain spaces, which are
+		// matched like those of subtests, as underscores.
+		elem[0] = rewrite(elem[0])
+	}
 
 	// filter must match.
 	// accept partial match that may produce full match later.
@@ -279,6 +285,15 @@ func parseSubtestNumber(s string) (prefix string, nn int32) {
 	return prefix, int32(n)
 }
 
+// isGooTestName reports whether the name of a top-level test is that of
+// a goo test block, which is a quoted string, rather than that of a test
+// function, which is an identifier.
+func isGooTestName(name string) bool {
+	return strings.ContainsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+}
+
 // rewrite rewrites a subname to having only printable characters and no white
 // space.
 func rewrite(s string) string {